@@ -11,8 +11,16 @@ type Application interface {
 	Query(pbabci.RequestQuery) pbabci.ResponseQuery
 	CheckTx(pbabci.RequestCheckTx) pbabci.ResponseCheckTx
 	DeliverTx(pbabci.RequestDeliverTx) pbabci.ResponseDeliverTx
+	PrepareProposal(pbabci.RequestPrepareProposal) pbabci.ResponsePrepareProposal // 主节点打包区块时决定区块里的交易数据及其顺序
+	ProcessProposal(pbabci.RequestProcessProposal) pbabci.ResponseProcessProposal // 在为区块投票之前检查区块里的交易数据，拒绝的区块不会被提交
 	BeginBlock(pbabci.RequestBeginBlock) pbabci.ResponseBeginBlock
 	EndBlock(pbabci.RequestEndBlock) pbabci.ResponseEndBlock
 	Commit(pbabci.RequestCommit) pbabci.ResponseCommit
 	Redact(pbabci.RequestRedact) pbabci.ResponseRedact
+
+	// 状态同步
+	ListSnapshots(pbabci.RequestListSnapshots) pbabci.ResponseListSnapshots                // 列出本地保存的快照
+	OfferSnapshot(pbabci.RequestOfferSnapshot) pbabci.ResponseOfferSnapshot                // 决定是否用其他节点提供的快照恢复状态
+	LoadSnapshotChunk(pbabci.RequestLoadSnapshotChunk) pbabci.ResponseLoadSnapshotChunk    // 加载本地快照的一个分块
+	ApplySnapshotChunk(pbabci.RequestApplySnapshotChunk) pbabci.ResponseApplySnapshotChunk // 用快照的一个分块恢复状态
 }
@@ -5,33 +5,71 @@ import (
 	"github.com/232425wxy/meta--/abci"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/232425wxy/meta--/database"
 	"github.com/232425wxy/meta--/proto/pbabci"
 	"github.com/cosmos/gogoproto/proto"
 	"sync"
 )
 
+// stateKey 保存最近一次提交的区块高度和提交后的应用状态哈希，节点重启后从它继续计算，并通过Info告诉节点。
+var stateKey = []byte("kvstore:state")
+
 type KVStoreApp struct {
 	mu         *sync.RWMutex
 	height     int64
 	validators map[crypto.ID]pbabci.ValidatorUpdate
 	db         database.DB
+	appHash    []byte   // 最近一次提交后的应用状态哈希
+	delivered  [][]byte // 当前区块里成功执行的交易数据
+	// retainBlocks 要求节点保留最近多少个区块，为0时节点保留所有区块
+	retainBlocks int64
+	// 快照相关的状态，节点在另一个连接上请求快照，所以用单独的锁保护
+	snapshotMu        sync.Mutex
+	snapshotInterval  int64 // 每提交多少个区块创建一次快照，为0时不创建快照
+	snapshotChunkSize int
+	snapshots         []*pbabci.Snapshot // 本地保存的快照，按照高度从低到高排列
+	restore           *restoration
 }
 
-func NewKVStoreApp(name, dir string, backend database.BackendType) abci.Application {
+func NewKVStoreApp(name, dir string, backend database.BackendType) *KVStoreApp {
 	db, err := database.NewDB(name, dir, backend)
 	if err != nil {
 		panic(err)
 	}
+	bz, err := db.Get(stateKey)
+	if err != nil {
+		panic(err)
+	}
+	var state pbabci.ResponseInfo
+	if err = proto.Unmarshal(bz, &state); err != nil {
+		panic(err)
+	}
+	if bz, err = db.Get(snapshotsKey); err != nil {
+		panic(err)
+	}
+	var snapshots pbabci.ResponseListSnapshots
+	if err = proto.Unmarshal(bz, &snapshots); err != nil {
+		panic(err)
+	}
 	return &KVStoreApp{
-		height:     0,
-		validators: make(map[crypto.ID]pbabci.ValidatorUpdate),
-		db:         db,
+		height:            state.LastBlockHeight,
+		validators:        make(map[crypto.ID]pbabci.ValidatorUpdate),
+		db:                db,
+		appHash:           state.LastBlockAppHash,
+		snapshotChunkSize: defaultSnapshotChunkSize,
+		snapshots:         snapshots.Snapshots,
 	}
 }
 
+// SetRetainBlocks 设置要求节点保留的最近的区块数量。
+func (k *KVStoreApp) SetRetainBlocks(blocks int64) {
+	k.retainBlocks = blocks
+}
+
+// Info 返回最近一次提交的区块高度和应用状态哈希，节点启动时据此判断需要重新执行哪些区块。
 func (k *KVStoreApp) Info(req pbabci.RequestInfo) pbabci.ResponseInfo {
-	return pbabci.ResponseInfo{Type: "kv-store"}
+	return pbabci.ResponseInfo{Type: "kv-store", LastBlockHeight: k.height, LastBlockAppHash: k.appHash}
 }
 
 func (k *KVStoreApp) Echo(req pbabci.RequestEcho) pbabci.ResponseEcho {
@@ -103,13 +141,45 @@ func (k *KVStoreApp) DeliverTx(req pbabci.RequestDeliverTx) pbabci.ResponseDeliv
 	if err := k.db.Set(key, value); err != nil {
 		res.OK = false
 	}
+	if res.OK {
+		k.delivered = append(k.delivered, req.Tx)
+	}
 	return res
 }
 
+// PrepareProposal 丢弃不是"key=value"形式的交易数据，并按照交易数据在交易池里的顺序打包，保证打包出的区块能够通过
+// ProcessProposal的检查。
+func (k *KVStoreApp) PrepareProposal(req pbabci.RequestPrepareProposal) pbabci.ResponsePrepareProposal {
+	txs := make([][]byte, 0, len(req.Txs))
+	var size int64
+	for _, tx := range req.Txs {
+		if len(bytes.Split(tx, []byte("="))) != 2 {
+			continue
+		}
+		if size+int64(len(tx)) > req.MaxTxBytes {
+			break
+		}
+		size += int64(len(tx))
+		txs = append(txs, tx)
+	}
+	return pbabci.ResponsePrepareProposal{Txs: txs}
+}
+
+// ProcessProposal 区块里的交易数据必须都是"key=value"形式的键值对，否则拒绝该区块。
+func (k *KVStoreApp) ProcessProposal(req pbabci.RequestProcessProposal) pbabci.ResponseProcessProposal {
+	for _, tx := range req.Txs {
+		if len(bytes.Split(tx, []byte("="))) != 2 {
+			return pbabci.ResponseProcessProposal{OK: false}
+		}
+	}
+	return pbabci.ResponseProcessProposal{OK: true}
+}
+
 // BeginBlock ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
 //
 // BeginBlock 对犯错的validator进行惩罚。
 func (k *KVStoreApp) BeginBlock(req pbabci.RequestBeginBlock) pbabci.ResponseBeginBlock {
+	k.height = req.Height
 	for _, evidence := range req.Evidences {
 		val := evidence.Validator
 		publicKey := bls12.PublicKeyFromProto(val.BLS12PublicKey)
@@ -129,8 +199,36 @@ func (k *KVStoreApp) EndBlock(req pbabci.RequestEndBlock) pbabci.ResponseEndBloc
 	return res
 }
 
+// Commit 把上一次的应用状态哈希与当前区块里成功执行的交易数据依次串起来计算新的应用状态哈希，区块里没有成功执行的
+// 交易数据时应用状态不变。提交的区块高度和应用状态哈希一起被保存下来。
 func (k *KVStoreApp) Commit(req pbabci.RequestCommit) pbabci.ResponseCommit {
-	return pbabci.ResponseCommit{OK: true}
+	if len(k.delivered) > 0 {
+		h := sha256.New()
+		h.Write(k.appHash)
+		for _, tx := range k.delivered {
+			h.Write(tx)
+		}
+		k.appHash = h.Sum(nil)
+		k.delivered = nil
+	}
+	k.saveState()
+	k.snapshot()
+	res := pbabci.ResponseCommit{OK: true, AppHash: k.appHash}
+	if k.retainBlocks > 0 && k.height >= k.retainBlocks {
+		res.RetainHeight = k.height - k.retainBlocks + 1
+	}
+	return res
+}
+
+// saveState 保存最近一次提交的区块高度和应用状态哈希。
+func (k *KVStoreApp) saveState() {
+	bz, err := proto.Marshal(&pbabci.ResponseInfo{LastBlockHeight: k.height, LastBlockAppHash: k.appHash})
+	if err != nil {
+		panic(err)
+	}
+	if err = k.db.SetSync(stateKey, bz); err != nil {
+		panic(err)
+	}
 }
 
 func (k *KVStoreApp) Redact(req pbabci.RequestRedact) pbabci.ResponseRedact {
@@ -0,0 +1,240 @@
+package apps
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+const (
+	// snapshotFormat 快照数据的格式：高度、应用状态哈希，然后依次是数据库里的每个键值对，每一项都以长度作为前缀。
+	snapshotFormat uint32 = 1
+	// defaultSnapshotChunkSize 快照分块的默认大小
+	defaultSnapshotChunkSize = 1 << 16
+	// snapshotKeepRecent 只保留最近的几个快照
+	snapshotKeepRecent = 2
+)
+
+var (
+	// snapshotsKey 保存本地所有快照的描述信息
+	snapshotsKey = []byte("kvstore:snapshots")
+	// internalKeyPrefix 应用自己使用的键都以它开头，它们不属于应用状态，不会被放进快照里
+	internalKeyPrefix = []byte("kvstore:")
+)
+
+func snapshotChunkKey(height int64, index uint32) []byte {
+	return []byte(fmt.Sprintf("kvstore:snapshot-chunk:%d:%d", height, index))
+}
+
+// restoration 正在用其他节点的快照恢复的状态。
+type restoration struct {
+	snapshot *pbabci.Snapshot
+	appHash  []byte
+	chunks   [][]byte
+}
+
+// SetSnapshotInterval 每提交interval个区块创建一次快照，分块的大小为chunkSize，interval为0时不创建快照，chunkSize不大于0
+// 时使用默认的分块大小。
+func (k *KVStoreApp) SetSnapshotInterval(interval int64, chunkSize int) {
+	if chunkSize <= 0 {
+		chunkSize = defaultSnapshotChunkSize
+	}
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	k.snapshotInterval = interval
+	k.snapshotChunkSize = chunkSize
+}
+
+func (k *KVStoreApp) ListSnapshots(req pbabci.RequestListSnapshots) pbabci.ResponseListSnapshots {
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	return pbabci.ResponseListSnapshots{Snapshots: append([]*pbabci.Snapshot(nil), k.snapshots...)}
+}
+
+func (k *KVStoreApp) LoadSnapshotChunk(req pbabci.RequestLoadSnapshotChunk) pbabci.ResponseLoadSnapshotChunk {
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	for _, snapshot := range k.snapshots {
+		if snapshot.Height == req.Height && snapshot.Format == req.Format && req.Chunk < snapshot.Chunks {
+			chunk, err := k.db.Get(snapshotChunkKey(req.Height, req.Chunk))
+			if err != nil {
+				panic(err)
+			}
+			return pbabci.ResponseLoadSnapshotChunk{Chunk: chunk}
+		}
+	}
+	return pbabci.ResponseLoadSnapshotChunk{}
+}
+
+// OfferSnapshot 接受格式正确的快照，快照的元数据是每个分块的哈希值，用来在收到分块时立即检查分块是否被篡改。
+func (k *KVStoreApp) OfferSnapshot(req pbabci.RequestOfferSnapshot) pbabci.ResponseOfferSnapshot {
+	snapshot := req.Snapshot
+	if snapshot == nil {
+		return pbabci.ResponseOfferSnapshot{Result: pbabci.ResponseOfferSnapshot_REJECT}
+	}
+	if snapshot.Format != snapshotFormat {
+		return pbabci.ResponseOfferSnapshot{Result: pbabci.ResponseOfferSnapshot_REJECT_FORMAT}
+	}
+	if snapshot.Chunks == 0 || len(snapshot.Metadata) != int(snapshot.Chunks)*sha256.Size32 || len(req.AppHash) == 0 {
+		return pbabci.ResponseOfferSnapshot{Result: pbabci.ResponseOfferSnapshot_REJECT}
+	}
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	k.restore = &restoration{snapshot: snapshot, appHash: req.AppHash, chunks: make([][]byte, 0, snapshot.Chunks)}
+	return pbabci.ResponseOfferSnapshot{Result: pbabci.ResponseOfferSnapshot_ACCEPT}
+}
+
+// ApplySnapshotChunk 按照顺序接收快照的分块，分块的哈希值与元数据里记录的不一致时要求从其他节点那里重新获取，收到
+// 所有分块后检查快照的哈希值以及快照里的应用状态哈希，然后把快照里的键值对写进数据库。
+func (k *KVStoreApp) ApplySnapshotChunk(req pbabci.RequestApplySnapshotChunk) pbabci.ResponseApplySnapshotChunk {
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	restore := k.restore
+	if restore == nil {
+		return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_ABORT}
+	}
+	if int(req.Index) != len(restore.chunks) {
+		// 分块必须按照顺序应用
+		return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT}
+	}
+	expected := restore.snapshot.Metadata[int(req.Index)*sha256.Size32 : int(req.Index+1)*sha256.Size32]
+	if sum := sha256.Sum(req.Chunk); !bytes.Equal(sum[:], expected) {
+		return pbabci.ResponseApplySnapshotChunk{
+			Result:        pbabci.ResponseApplySnapshotChunk_RETRY,
+			RefetchChunks: []uint32{req.Index},
+			RejectSenders: []string{req.Sender},
+		}
+	}
+	restore.chunks = append(restore.chunks, req.Chunk)
+	if len(restore.chunks) < int(restore.snapshot.Chunks) {
+		return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_ACCEPT}
+	}
+
+	k.restore = nil
+	data := bytes.Join(restore.chunks, nil)
+	if sum := sha256.Sum(data); !bytes.Equal(sum[:], restore.snapshot.Hash) {
+		return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+	height, appHash, pairs, err := decodeSnapshot(data)
+	if err != nil || height != restore.snapshot.Height || !bytes.Equal(appHash, restore.appHash) {
+		return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT}
+	}
+	batch := k.db.NewBatch()
+	defer batch.Close()
+	for i := 0; i < len(pairs); i += 2 {
+		if err = batch.Set(pairs[i], pairs[i+1]); err != nil {
+			panic(err)
+		}
+	}
+	if err = batch.WriteSync(); err != nil {
+		panic(err)
+	}
+	k.height, k.appHash = height, appHash
+	k.saveState()
+	return pbabci.ResponseApplySnapshotChunk{Result: pbabci.ResponseApplySnapshotChunk_ACCEPT}
+}
+
+// snapshot 在提交之后，按照设置的间隔为当前的应用状态创建快照。
+func (k *KVStoreApp) snapshot() {
+	k.snapshotMu.Lock()
+	defer k.snapshotMu.Unlock()
+	if k.snapshotInterval <= 0 || k.height%k.snapshotInterval != 0 {
+		return
+	}
+	data, err := k.encodeSnapshot()
+	if err != nil {
+		panic(err)
+	}
+	hash := sha256.Sum(data)
+	snapshot := &pbabci.Snapshot{Height: k.height, Format: snapshotFormat, Hash: hash[:]}
+	for start := 0; start < len(data); start += k.snapshotChunkSize {
+		end := start + k.snapshotChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		sum := sha256.Sum(data[start:end])
+		snapshot.Metadata = append(snapshot.Metadata, sum[:]...)
+		if err = k.db.Set(snapshotChunkKey(k.height, snapshot.Chunks), data[start:end]); err != nil {
+			panic(err)
+		}
+		snapshot.Chunks++
+	}
+	k.snapshots = append(k.snapshots, snapshot)
+	for len(k.snapshots) > snapshotKeepRecent {
+		old := k.snapshots[0]
+		for i := uint32(0); i < old.Chunks; i++ {
+			if err = k.db.Delete(snapshotChunkKey(old.Height, i)); err != nil {
+				panic(err)
+			}
+		}
+		k.snapshots = k.snapshots[1:]
+	}
+	bz, err := proto.Marshal(&pbabci.ResponseListSnapshots{Snapshots: k.snapshots})
+	if err != nil {
+		panic(err)
+	}
+	if err = k.db.SetSync(snapshotsKey, bz); err != nil {
+		panic(err)
+	}
+}
+
+// encodeSnapshot 把高度、应用状态哈希以及数据库里属于应用状态的所有键值对编码成快照数据。
+func (k *KVStoreApp) encodeSnapshot() ([]byte, error) {
+	data := binary.AppendUvarint(nil, uint64(k.height))
+	data = appendBytes(data, k.appHash)
+	iter, err := k.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if bytes.HasPrefix(iter.Key(), internalKeyPrefix) {
+			continue
+		}
+		data = appendBytes(data, iter.Key())
+		data = appendBytes(data, iter.Value())
+	}
+	return data, iter.Error()
+}
+
+// decodeSnapshot 解析快照数据，返回高度、应用状态哈希，以及依次排列的键和值。
+func decodeSnapshot(data []byte) (int64, []byte, [][]byte, error) {
+	height, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, nil, nil, errors.New("invalid snapshot height")
+	}
+	data = data[n:]
+	appHash, data, err := readBytes(data)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	var pairs [][]byte
+	for len(data) > 0 {
+		var item []byte
+		if item, data, err = readBytes(data); err != nil {
+			return 0, nil, nil, err
+		}
+		pairs = append(pairs, item)
+	}
+	if len(pairs)%2 != 0 {
+		return 0, nil, nil, errors.New("snapshot has a key without value")
+	}
+	return int64(height), appHash, pairs, nil
+}
+
+func appendBytes(data, bz []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(len(bz)))
+	return append(data, bz...)
+}
+
+func readBytes(data []byte) ([]byte, []byte, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return nil, nil, errors.New("truncated snapshot data")
+	}
+	return data[n : n+int(length)], data[n+int(length):], nil
+}
@@ -64,6 +64,7 @@ func dockernetFiles(cmd *cobra.Command, args []string) (err error) {
 		} else {
 			genesis.GenesisTime = time.Now()
 			genesis.InitialHeight = 1
+			genesis.ConsensusParams = types.DefaultConsensusParams()
 		}
 		if err = genesis.SaveAs(genesisFilePath); err != nil {
 			return err
@@ -0,0 +1,15 @@
+package commands
+
+import "github.com/spf13/cobra"
+
+// RootCmd meta-- 命令行工具的根命令，其他命令都作为它的子命令。
+var RootCmd = &cobra.Command{
+	Use:   "meta--",
+	Short: "Command line tools for meta--",
+}
+
+func init() {
+	RootCmd.AddCommand(DockerNetCmd)
+	RootCmd.AddCommand(WALCmd)
+	RootCmd.AddCommand(SignerCmd)
+}
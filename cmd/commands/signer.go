@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/privval"
+	"github.com/spf13/cobra"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+var SignerListenAddr string
+var SignerKeyFile string
+var SignerStateFile string
+var SignerConnKeyFile string
+var SignerNodePubKey string
+
+func init() {
+	SignerCmd.Flags().StringVar(&SignerListenAddr, "addr", "unix://signer.sock", "address to listen on for the node, unix://<path> or tcp://<host>:<port>")
+	SignerCmd.Flags().StringVar(&SignerKeyFile, "key", "node_key.json", "path to the node key file which holds the validator's private key")
+	SignerCmd.Flags().StringVar(&SignerStateFile, "state", "sign_state.json", "path to the file recording the last signed height, round and step, chained HotStuff views are recorded in <name>_chained<ext> next to it")
+	SignerCmd.Flags().StringVar(&SignerConnKeyFile, "conn-key", "signer_conn_key.json", "path to the ed25519 key authenticating the signer to the node, generated if it does not exist")
+	SignerCmd.Flags().StringVar(&SignerNodePubKey, "node-pub-key", "", "hex encoded ed25519 connection public key of the node allowed to request signatures")
+}
+
+var SignerCmd = &cobra.Command{
+	Use:   "signer",
+	Short: "Run a standalone signer process that holds the validator's private key and signs consensus messages for a node",
+	RunE:  runSigner,
+}
+
+func runSigner(cmd *cobra.Command, args []string) error {
+	nodeKey, err := p2p.LoadNodeKey(SignerKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load node key: %w", err)
+	}
+	if nodeKey.PrivateKey == nil {
+		return errors.New("node key file does not contain private key")
+	}
+	signer, err := privval.LoadLocalSigner(nodeKey.PrivateKey, SignerStateFile)
+	if err != nil {
+		return fmt.Errorf("failed to load sign state: %w", err)
+	}
+	connKey, err := privval.LoadOrGenConnKey(SignerConnKeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load connection key: %w", err)
+	}
+	nodePubKey, err := privval.ParseConnPublicKey(SignerNodePubKey)
+	if err != nil {
+		return fmt.Errorf("invalid --node-pub-key: %w", err)
+	}
+	server := privval.NewSignerServer(SignerListenAddr, signer, connKey, nodePubKey, log.New("module", "Signer"))
+	if err = server.Start(); err != nil {
+		return err
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	<-sigCh
+	return server.Stop()
+}
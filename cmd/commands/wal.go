@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/consensus"
+	"github.com/232425wxy/meta--/proto/pbconsensus"
+	"github.com/cosmos/gogoproto/jsonpb"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+)
+
+var WALCmd = &cobra.Command{
+	Use:   "wal [path to wal file]",
+	Short: "Dump the entries of a consensus WAL file as JSON, one entry per line",
+	Args:  cobra.ExactArgs(1),
+	RunE:  dumpWAL,
+}
+
+func dumpWAL(cmd *cobra.Command, args []string) error {
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to open WAL file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	marshaler := &jsonpb.Marshaler{OrigName: true}
+	dec := consensus.NewWALDecoder(f)
+	for {
+		msg, err := dec.Decode()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("failed to decode WAL entry: %w", err)
+		}
+		pb, err := consensus.WALToProto(msg.Msg)
+		if err != nil {
+			return err
+		}
+		js, err := marshaler.MarshalToString(&pbconsensus.TimedWALMessage{Time: msg.Time, Msg: pb})
+		if err != nil {
+			return fmt.Errorf("failed to marshal WAL entry to json: %w", err)
+		}
+		if _, err = fmt.Fprintln(cmd.OutOrStdout(), js); err != nil {
+			return err
+		}
+	}
+}
@@ -3,7 +3,7 @@ package main
 import "github.com/232425wxy/meta--/cmd/commands"
 
 func main() {
-	if err := commands.DockerNetCmd.Execute(); err != nil {
+	if err := commands.RootCmd.Execute(); err != nil {
 		panic(err)
 	}
 }
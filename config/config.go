@@ -2,8 +2,11 @@ package config
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	mos "github.com/232425wxy/meta--/common/os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -13,6 +16,8 @@ type Config struct {
 	P2PConfig       *P2PConfig       `mapstructure:"p2p"`
 	TxsPoolConfig   *TxsPoolConfig   `mapstructure:"txs_pool"`
 	ConsensusConfig *ConsensusConfig `mapstructure:"consensus"`
+	ByzantineConfig *ByzantineConfig `mapstructure:"byzantine"`
+	StateSyncConfig *StateSyncConfig `mapstructure:"state_sync"`
 }
 
 func DefaultConfig() *Config {
@@ -21,6 +26,8 @@ func DefaultConfig() *Config {
 		P2PConfig:       DefaultP2PConfig(),
 		TxsPoolConfig:   DefaultTxsPoolConfig(),
 		ConsensusConfig: DefaultConsensusConfig(),
+		ByzantineConfig: DefaultByzantineConfig(),
+		StateSyncConfig: DefaultStateSyncConfig(),
 	}
 }
 
@@ -53,6 +60,9 @@ type BasicConfig struct {
 	DBBackend        string `mapstructure:"db_backend"`
 	DBDir            string `mapstructure:"db_dir"`
 	App              string `mapstructure:"app"`
+	// MinRetainBlocks 至少保留最近多少个区块，应用通过RetainHeight要求删除更多的区块时以它为准，为0时不做限制。它应当
+	// 不小于证据的有效期，否则旧证据只能用当前的验证者集合来验证。
+	MinRetainBlocks int64 `mapstructure:"min_retain_blocks"`
 }
 
 func DefaultBasicConfig() *BasicConfig {
@@ -63,9 +73,18 @@ func DefaultBasicConfig() *BasicConfig {
 		DBBackend:        "goleveldb",
 		DBDir:            "data",
 		App:              "kvstore",
+		MinRetainBlocks:  0,
 	}
 }
 
+// ValidateBasic 检查最少保留的区块数量是否合法。
+func (bc *BasicConfig) ValidateBasic() error {
+	if bc.MinRetainBlocks < 0 {
+		return fmt.Errorf("min_retain_blocks can't be negative, got %d", bc.MinRetainBlocks)
+	}
+	return nil
+}
+
 func (bc *BasicConfig) KeyFilePath() string {
 	return filepath.Join(bc.Home, bc.KeyFile)
 }
@@ -168,16 +187,280 @@ type ConsensusConfig struct {
 	TimeoutCommit    time.Duration `mapstructure:"timeout_commit"`
 	TimeoutDecide    time.Duration `mapstructure:"timeout_decide"`
 	TimeoutConsensus time.Duration `mapstructure:"timeout_consensus"`
+	WalPath          string        `mapstructure:"wal_file"`
+	SignStatePath    string        `mapstructure:"sign_state_file"`
+	// RemoteSignerAddr 独立签名进程的地址，例如unix:///path/to/signer.sock或者tcp://10.0.0.2:26659，为空时使用本地的
+	// 节点私钥签名，此时SignStatePath记录本地签名的状态
+	RemoteSignerAddr    string        `mapstructure:"remote_signer_addr"`
+	RemoteSignerTimeout time.Duration `mapstructure:"remote_signer_timeout"`
+	// RemoteSignerConnKeyPath 节点与签名进程建立加密连接时使用的ed25519私钥文件，文件不存在时自动生成，签名进程需要
+	// 配置它对应的公钥
+	RemoteSignerConnKeyPath string `mapstructure:"remote_signer_conn_key_file"`
+	// RemoteSignerPubKey 签名进程的ed25519连接公钥，16进制编码，节点只与持有对应私钥的签名进程通信
+	RemoteSignerPubKey string `mapstructure:"remote_signer_pub_key"`
+	// Protocol 共识协议，取值见ProtocolHotStuff、ProtocolChained和ProtocolTwoPhase，为空时使用默认的四阶段共识
+	Protocol string `mapstructure:"protocol"`
+	// CompactBlocks 主节点的Prepare消息里只携带交易哈希，副本节点用自己交易池里的交易还原区块，缺少的交易再向主节点索要
+	CompactBlocks bool `mapstructure:"compact_blocks"`
+	// Mode 节点的运行模式，取值见ModeValidator和ModeObserver，为空时作为验证者运行
+	Mode string `mapstructure:"mode"`
+	// VoteTreeFanout 投票聚合树的分支数，验证者按照聚合树把投票逐层聚合后再交给主节点，为0时所有节点都直接把投票发送给主节点
+	VoteTreeFanout int `mapstructure:"vote_tree_fanout"`
+	// VoteTreeTimeout 聚合树里每一层等待子节点投票的时间，子树高度为h的节点最多等待h倍的该时间，超时后把已经收到的投票
+	// 聚合起来发送给父节点，自己的投票迟迟没有得到确认时再直接发送给主节点
+	VoteTreeTimeout time.Duration `mapstructure:"vote_tree_timeout"`
 }
 
+// 可以选择的共识协议。
+const (
+	// ProtocolHotStuff 默认的Prepare、PreCommit、Commit、Decide四阶段共识
+	ProtocolHotStuff = "hotstuff"
+	// ProtocolChained 链式（流水线）HotStuff：每个视图只有一轮投票，主节点的提案携带上一个提案的QC，连续三个视图的
+	// QC确认一个区块
+	ProtocolChained = "chained"
+	// ProtocolTwoPhase 两阶段共识：正常情况下两轮投票就能确认区块，主节点失效时用超时证书（TC）切换视图
+	ProtocolTwoPhase = "two-phase"
+
+	// ModeValidator 验证者节点：自己的公钥在验证者集合里时参与投票，轮到自己时提出区块
+	ModeValidator = "validator"
+	// ModeObserver 观察者（全节点）：只验证Decide消息里的QC并执行被确认的区块，从不为共识消息签名，可以用来对外提供
+	// RPC和区块同步服务
+	ModeObserver = "observer"
+)
+
 func DefaultConsensusConfig() *ConsensusConfig {
 	return &ConsensusConfig{
-		TimeoutPrepare:   3000 * time.Millisecond,
-		TimeoutPreCommit: 1000 * time.Millisecond,
-		TimeoutCommit:    1000 * time.Millisecond,
-		TimeoutDecide:    1000 * time.Millisecond,
-		TimeoutConsensus: 10 * time.Second,
+		TimeoutPrepare:          3000 * time.Millisecond,
+		TimeoutPreCommit:        1000 * time.Millisecond,
+		TimeoutCommit:           1000 * time.Millisecond,
+		TimeoutDecide:           1000 * time.Millisecond,
+		TimeoutConsensus:        10 * time.Second,
+		WalPath:                 filepath.Join("data", "cs.wal", "wal"),
+		SignStatePath:           filepath.Join("data", "sign_state.json"),
+		RemoteSignerAddr:        "",
+		RemoteSignerTimeout:     3 * time.Second,
+		RemoteSignerConnKeyPath: "signer_conn_key.json",
+		RemoteSignerPubKey:      "",
+		Protocol:                ProtocolHotStuff,
+		CompactBlocks:           false,
+		Mode:                    ModeValidator,
+		VoteTreeFanout:          0,
+		VoteTreeTimeout:         100 * time.Millisecond,
+	}
+}
+
+// ValidateBasic 检查共识协议和运行模式是否是可以选择的取值之一，以及投票聚合树的配置是否合法。
+func (cc *ConsensusConfig) ValidateBasic() error {
+	switch cc.Protocol {
+	case "", ProtocolHotStuff, ProtocolChained, ProtocolTwoPhase:
+	default:
+		return fmt.Errorf("unknown consensus protocol %q, want one of %q, %q and %q", cc.Protocol, ProtocolHotStuff, ProtocolChained, ProtocolTwoPhase)
+	}
+	switch cc.Mode {
+	case "", ModeValidator, ModeObserver:
+	default:
+		return fmt.Errorf("unknown node mode %q, want %q or %q", cc.Mode, ModeValidator, ModeObserver)
+	}
+	if cc.VoteTreeFanout < 0 {
+		return fmt.Errorf("negative vote tree fanout %d", cc.VoteTreeFanout)
+	}
+	if cc.VoteTreeFanout > 0 && cc.VoteTreeTimeout <= 0 {
+		return fmt.Errorf("vote tree timeout must be positive when vote tree is enabled, got %s", cc.VoteTreeTimeout)
+	}
+	if cc.RemoteSignerAddr != "" && (cc.RemoteSignerConnKeyPath == "" || cc.RemoteSignerPubKey == "") {
+		return errors.New("remote signer requires both remote_signer_conn_key_file and remote_signer_pub_key")
+	}
+	return nil
+}
+
+// IsObserver 判断节点是否以观察者模式运行。
+func (cc *ConsensusConfig) IsObserver() bool {
+	return cc.Mode == ModeObserver
+}
+
+// RemoteSignerConnKeyFile 返回节点与签名进程建立加密连接时使用的私钥文件路径。
+func (cc *ConsensusConfig) RemoteSignerConnKeyFile() string {
+	return filepath.Join(cc.Home, cc.RemoteSignerConnKeyPath)
+}
+
+// WalFile 返回共识模块预写日志的文件路径，如果没有配置WalPath，则返回空字符串，表示不启用WAL。
+func (cc *ConsensusConfig) WalFile() string {
+	if cc.WalPath == "" {
+		return ""
+	}
+	return filepath.Join(cc.Home, cc.WalPath)
+}
+
+// SignStateFile 返回记录最后一次签名状态的文件路径，如果没有配置SignStatePath，则返回空字符串，此时签名状态只保存在内存里。
+// 链式HotStuff的签名状态保存在同一目录下的另一个文件里，见privval.ChainedSignStateFile。
+func (cc *ConsensusConfig) SignStateFile() string {
+	if cc.SignStatePath == "" {
+		return ""
+	}
+	return filepath.Join(cc.Home, cc.SignStatePath)
+}
+
+// ByzantineConfig ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
+//
+//	---------------------------------------------------------
+//
+// ByzantineConfig 让测试网里的节点在指定的高度区间里故意作恶，用来观察诚实节点能否发现并容忍这些错误行为，
+// 生产环境里的节点绝对不能开启。每种行为的取值都是一个高度区间：
+//  1. ""：不作恶，这是默认值
+//  2. "5"：只在高度5作恶
+//  3. "5-10"：在高度5到10（包括10）之间作恶
+//  4. "5-"：从高度5开始一直作恶
+//
+// 可以配置的行为有：
+//  1. EquivocatePrepare：作为主节点时，为同一个视图提出两个不同的Prepare消息
+//  2. WithholdDecide：作为主节点时，自己提交区块，但是不把Decide消息发送给其他节点
+//  3. WrongVoteHash：作为副本节点时，为一个与提案不同的值哈希投票
+//  4. CorruptDKGShares：分布式密钥生成阶段，发送错误的FnX和PublicKeySeg
+//  5. CorruptRedactShares：修改区块时，发送错误的ReplicaSchnorrSig签名片段
+//
+// 分布式密钥生成发生在创世之后、第一个区块之前，CorruptDKGShares按照高度0（或者节点重启时所处的高度）来判断是否作恶。
+type ByzantineConfig struct {
+	EquivocatePrepare   string `mapstructure:"equivocate_prepare"`
+	WithholdDecide      string `mapstructure:"withhold_decide"`
+	WrongVoteHash       string `mapstructure:"wrong_vote_hash"`
+	CorruptDKGShares    string `mapstructure:"corrupt_dkg_shares"`
+	CorruptRedactShares string `mapstructure:"corrupt_redact_shares"`
+}
+
+// 各种拜占庭行为的名字
+const (
+	EquivocatePrepare   = "equivocate_prepare"
+	WithholdDecide      = "withhold_decide"
+	WrongVoteHash       = "wrong_vote_hash"
+	CorruptDKGShares    = "corrupt_dkg_shares"
+	CorruptRedactShares = "corrupt_redact_shares"
+)
+
+func DefaultByzantineConfig() *ByzantineConfig {
+	return &ByzantineConfig{}
+}
+
+func (bc *ByzantineConfig) ranges() map[string]string {
+	return map[string]string{
+		EquivocatePrepare:   bc.EquivocatePrepare,
+		WithholdDecide:      bc.WithholdDecide,
+		WrongVoteHash:       bc.WrongVoteHash,
+		CorruptDKGShares:    bc.CorruptDKGShares,
+		CorruptRedactShares: bc.CorruptRedactShares,
+	}
+}
+
+// ValidateBasic 检查每种行为配置的高度区间格式是否正确。
+func (bc *ByzantineConfig) ValidateBasic() error {
+	for behaviour, r := range bc.ranges() {
+		if _, _, err := ParseHeightRange(r); err != nil {
+			return fmt.Errorf("invalid byzantine config %s: %w", behaviour, err)
+		}
+	}
+	return nil
+}
+
+// Enabled 返回是否配置了任何一种拜占庭行为。
+func (bc *ByzantineConfig) Enabled() bool {
+	if bc == nil {
+		return false
+	}
+	for _, r := range bc.ranges() {
+		if strings.TrimSpace(r) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Misbehave 返回节点在给定的高度是否应该表现出behaviour这种拜占庭行为，bc为nil时总是返回false。
+func (bc *ByzantineConfig) Misbehave(behaviour string, height int64) bool {
+	if bc == nil {
+		return false
+	}
+	r, ok := bc.ranges()[behaviour]
+	if !ok {
+		return false
+	}
+	from, to, err := ParseHeightRange(r)
+	if err != nil || from < 0 {
+		return false
+	}
+	return height >= from && (to < 0 || height <= to)
+}
+
+// ParseHeightRange 解析"from-to"形式的高度区间，空字符串返回from = -1，表示区间为空；"from-"形式的区间返回to = -1，
+// 表示区间没有上界。
+func ParseHeightRange(r string) (from int64, to int64, err error) {
+	r = strings.TrimSpace(r)
+	if r == "" {
+		return -1, -1, nil
+	}
+	parts := strings.SplitN(r, "-", 2)
+	if from, err = strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64); err != nil {
+		return -1, -1, fmt.Errorf("malformed height range %q: %w", r, err)
+	}
+	if from < 0 {
+		return -1, -1, fmt.Errorf("malformed height range %q: negative height", r)
+	}
+	if len(parts) == 1 {
+		return from, from, nil
+	}
+	if strings.TrimSpace(parts[1]) == "" {
+		return from, -1, nil
+	}
+	if to, err = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64); err != nil {
+		return -1, -1, fmt.Errorf("malformed height range %q: %w", r, err)
+	}
+	if to < from {
+		return -1, -1, fmt.Errorf("malformed height range %q: end is lower than start", r)
+	}
+	return from, to, nil
+}
+
+// StateSyncConfig 状态同步的配置：
+//  1. Enable：新节点不再从初始高度开始执行每一个区块，而是从其他节点那里获取应用状态的快照来恢复状态，然后从快照
+//     的高度开始同步区块，只在节点没有任何区块时生效
+//  2. DiscoveryTime：向其他节点询问快照后，等待多长时间再从收到的快照里挑选一个
+//  3. ChunkFetchers：同时获取快照分块的协程数量
+//  4. ChunkRequestTimeout：等待一个分块的时间，超时后向其他节点重新索要
+//  5. SnapshotInterval：应用每提交多少个区块创建一次快照，提供给其他节点做状态同步，为0时不创建快照
+type StateSyncConfig struct {
+	Enable              bool          `mapstructure:"enable"`
+	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
+	ChunkFetchers       int           `mapstructure:"chunk_fetchers"`
+	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
+	SnapshotInterval    int64         `mapstructure:"snapshot_interval"`
+}
+
+func DefaultStateSyncConfig() *StateSyncConfig {
+	return &StateSyncConfig{
+		Enable:              false,
+		DiscoveryTime:       15 * time.Second,
+		ChunkFetchers:       4,
+		ChunkRequestTimeout: 10 * time.Second,
+		SnapshotInterval:    0,
+	}
+}
+
+// ValidateBasic 检查状态同步的各项配置是否合法。
+func (sc *StateSyncConfig) ValidateBasic() error {
+	if sc.SnapshotInterval < 0 {
+		return fmt.Errorf("snapshot_interval can't be negative, got %d", sc.SnapshotInterval)
+	}
+	if !sc.Enable {
+		return nil
+	}
+	if sc.DiscoveryTime <= 0 {
+		return fmt.Errorf("discovery_time must be positive, got %s", sc.DiscoveryTime)
+	}
+	if sc.ChunkFetchers <= 0 {
+		return fmt.Errorf("chunk_fetchers must be positive, got %d", sc.ChunkFetchers)
+	}
+	if sc.ChunkRequestTimeout <= 0 {
+		return fmt.Errorf("chunk_request_timeout must be positive, got %s", sc.ChunkRequestTimeout)
 	}
+	return nil
 }
 
 /*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
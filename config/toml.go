@@ -14,6 +14,7 @@ genesis_file = "{{ .BasicConfig.GenesisFile }}"
 db_backend = "{{ .BasicConfig.DBBackend }}"
 db_dir = "{{ .BasicConfig.DBDir }}"
 app = "{{ .BasicConfig.App }}"
+min_retain_blocks = {{ .BasicConfig.MinRetainBlocks }}
 
 [p2p]
 home = "{{ .P2PConfig.Home }}"
@@ -39,6 +40,38 @@ timeout_pre_commit = "{{ .ConsensusConfig.TimeoutPreCommit }}"
 timeout_commit = "{{ .ConsensusConfig.TimeoutCommit }}"
 timeout_decide = "{{ .ConsensusConfig.TimeoutDecide }}"
 timeout_consensus = "{{ .ConsensusConfig.TimeoutConsensus }}"
+wal_file = "{{ .ConsensusConfig.WalPath }}"
+sign_state_file = "{{ .ConsensusConfig.SignStatePath }}"
+remote_signer_addr = "{{ .ConsensusConfig.RemoteSignerAddr }}"
+remote_signer_timeout = "{{ .ConsensusConfig.RemoteSignerTimeout }}"
+remote_signer_conn_key_file = "{{ .ConsensusConfig.RemoteSignerConnKeyPath }}"
+remote_signer_pub_key = "{{ .ConsensusConfig.RemoteSignerPubKey }}"
+# 共识协议："hotstuff"为默认的四阶段共识，"chained"为链式（流水线）HotStuff，"two-phase"为两阶段共识
+protocol = "{{ .ConsensusConfig.Protocol }}"
+# 为true时主节点的Prepare消息里只携带交易哈希，副本节点用自己交易池里的交易还原区块，以节省主节点的带宽
+compact_blocks = {{ .ConsensusConfig.CompactBlocks }}
+# 节点的运行模式："validator"为验证者，"observer"为只跟随共识、从不投票的观察者（全节点）
+mode = "{{ .ConsensusConfig.Mode }}"
+# 投票聚合树的分支数，为0时所有节点都直接把投票发送给主节点，验证者很多时可以设置为大于1的值，由树的内部节点逐层聚合投票
+vote_tree_fanout = {{ .ConsensusConfig.VoteTreeFanout }}
+vote_tree_timeout = "{{ .ConsensusConfig.VoteTreeTimeout }}"
+
+# 拜占庭行为只用于测试网，取值为高度区间，例如"5"、"5-10"、"5-"，为空表示不作恶
+[byzantine]
+equivocate_prepare = "{{ .ByzantineConfig.EquivocatePrepare }}"
+withhold_decide = "{{ .ByzantineConfig.WithholdDecide }}"
+wrong_vote_hash = "{{ .ByzantineConfig.WrongVoteHash }}"
+corrupt_dkg_shares = "{{ .ByzantineConfig.CorruptDKGShares }}"
+corrupt_redact_shares = "{{ .ByzantineConfig.CorruptRedactShares }}"
+
+# 状态同步：新节点从其他节点的快照恢复应用状态，然后从快照的高度开始同步区块，只在节点没有任何区块时生效
+[state_sync]
+enable = {{ .StateSyncConfig.Enable }}
+discovery_time = "{{ .StateSyncConfig.DiscoveryTime }}"
+chunk_fetchers = {{ .StateSyncConfig.ChunkFetchers }}
+chunk_request_timeout = "{{ .StateSyncConfig.ChunkRequestTimeout }}"
+# 应用每提交多少个区块创建一次快照，为0时不创建快照
+snapshot_interval = {{ .StateSyncConfig.SnapshotInterval }}
 `
 
 var configTemplate *template.Template
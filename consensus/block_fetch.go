@@ -0,0 +1,128 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/types"
+)
+
+// blockFetch 副本节点错过了主节点的Prepare消息，却收到了认证该区块的PreCommit、Commit或者Decide消息，于是按照消息里的
+// 变色龙哈希向其他节点索要区块，这些消息先被保存起来，等区块到达后再按照收到的顺序重新处理。
+type blockFetch struct {
+	height int64
+	round  int16 // 认证该区块的消息里最大的轮次
+	hash   []byte
+	msgs   []Message
+}
+
+// fetchBlock 先确认msg的聚合签名确实认证了round轮次里变色龙哈希为blockHash的区块，其中expected是根据blockHash重新计算出的
+// 被签名的值，然后向其他节点索要该区块。每收到一条认证同一区块的消息都会重新索要一次，这样请求丢失时也不会一直等待下去。
+func (c *Core) fetchBlock(msg Message, height int64, round int16, blockHash, valueHash, expected []byte, agg *bls12.AggregateSignature) error {
+	if len(blockHash) == 0 || !bytes.Equal(valueHash, expected) {
+		return fmt.Errorf("%T message does not certify block %X", msg, blockHash)
+	}
+	if err := c.state.Validators.VerifyAggregateSignature(agg, valueHash); err != nil {
+		return fmt.Errorf("%T message certifying block %X has invalid aggregated signature: %w", msg, blockHash, err)
+	}
+	if f := c.stepInfo.fetch; f != nil && f.height == height && bytes.Equal(f.hash, blockHash) {
+		f.msgs = append(f.msgs, msg)
+		if round > f.round {
+			f.round = round
+		}
+	} else {
+		c.stepInfo.fetch = &blockFetch{height: height, round: round, hash: blockHash, msgs: []Message{msg}}
+	}
+	c.Logger.Info("missing certified block, request it from peers", "height", height, "block", fmt.Sprintf("%X", blockHash), "msg", fmt.Sprintf("%T", msg))
+	c.fireEvent(events.EventBlockRequest, &types.BlockRequest{Height: height, BlockHash: blockHash})
+	return nil
+}
+
+// handleBlockResponse 收到的区块就是正在索要的区块，并且通过了验证时，用它继续处理之前保存下来的消息。
+func (c *Core) handleBlockResponse(resp *types.BlockResponse) error {
+	f := c.stepInfo.fetch
+	if f == nil || f.height != c.stepInfo.height {
+		return nil
+	}
+	if err := resp.ValidateBasic(); err != nil {
+		return err
+	}
+	block := resp.Block
+	if block.Header.Height != f.height || !bytes.Equal(block.ChameleonHash.Hash, f.hash) {
+		// 其他节点回复的是之前索要的区块
+		return nil
+	}
+	if err := c.blockExec.ValidateBlock(c.state, block, c.proposerOf(block, f.round, f.round)); err != nil {
+		return fmt.Errorf("fetched an invalid block %X: %w", f.hash, err)
+	}
+	c.Logger.Info("fetched missing block", "height", f.height, "block", fmt.Sprintf("%X", f.hash))
+	c.stepInfo.fetch = nil
+	if c.stepInfo.block == nil {
+		c.stepInfo.block = block
+	}
+	for _, msg := range f.msgs {
+		var err error
+		switch msg := msg.(type) {
+		case *types.PreCommit:
+			if c.twoPhase {
+				err = c.handleTwoPhasePreCommit(msg)
+			} else {
+				err = c.handlePreCommit(msg)
+			}
+		case *types.Commit:
+			err = c.handleCommit(msg)
+		case *types.Decide:
+			// Decide消息确认的区块可能来自之前的轮次，已经被聚合签名证明是最终的区块，直接替换当前轮次的区块
+			c.stepInfo.block = block
+			err = c.handleDecide(msg)
+		}
+		if err != nil {
+			c.Logger.Error(fmt.Sprintf("failed to handle %T message with fetched block", msg), "err", err)
+		}
+	}
+	return nil
+}
+
+// lookupBlock 在内存里的区块和已经提交的区块里查找height高度变色龙哈希为hash的区块，用来回复其他节点的BlockRequest。
+func (c *Core) lookupBlock(height int64, hash []byte) *types.Block {
+	c.mu.RLock()
+	candidates := []*types.Block{c.stepInfo.block, c.stepInfo.highQCBlock, c.stepInfo.lockedBlock}
+	if c.stepInfo.proposal != nil {
+		candidates = append(candidates, c.stepInfo.proposal.Block)
+	}
+	c.mu.RUnlock()
+	candidates = append(candidates, c.blockExec.LoadBlock(height))
+	for _, block := range candidates {
+		if block != nil && block.Header != nil && block.ChameleonHash != nil && block.Header.Height == height && bytes.Equal(block.ChameleonHash.Hash, hash) {
+			return block
+		}
+	}
+	return nil
+}
+
+// broadcastBlockRequest 向所有节点索要区块，任何一个有该区块的节点都会回复。
+func (r *Reactor) broadcastBlockRequest(req *types.BlockRequest) {
+	r.Switch.Broadcast(p2p.BlockFetchChannel, MustEncode(req))
+}
+
+// receiveBlockFetch 回复其他节点的BlockRequest，收到的BlockResponse与其他共识消息一样交给Core，这样它也会被记录在WAL里。
+func (r *Reactor) receiveBlockFetch(src *p2p.Peer, msg Message) {
+	switch msg := msg.(type) {
+	case *types.BlockRequest:
+		if err := msg.ValidateBasic(); err != nil {
+			r.Logger.Warn("received invalid block request", "from", src.NodeID(), "err", err)
+			return
+		}
+		block := r.core.lookupBlock(msg.Height, msg.BlockHash)
+		if block == nil {
+			return
+		}
+		if ok := src.Send(p2p.BlockFetchChannel, MustEncode(&types.BlockResponse{Block: block})); !ok {
+			r.Logger.Error("failed to send block response", "to", src.NodeID())
+		}
+	case *types.BlockResponse:
+		r.core.sendExternalMessage(MessageInfo{Msg: msg, NodeID: src.NodeID()})
+	}
+}
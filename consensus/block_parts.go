@@ -0,0 +1,188 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/types"
+	"math/rand"
+	"time"
+)
+
+// blockPartsGossipSleep 没有分片可以发送给某个节点时，等待一段时间再检查。
+const blockPartsGossipSleep = 20 * time.Millisecond
+
+// proposalParts Prepare消息的分片集合。主节点把编码后的Prepare消息切分成分片，先把分片集合的头部发送给其他节点，
+// 然后每个节点都把自己有而对方没有的分片发送给对方，收集完整后再把拼接出来的Prepare消息交给Core，主节点的签名由Core验证。
+type proposalParts struct {
+	height    int64
+	round     int16
+	from      *p2p.Peer // 发来分片集合头部的节点，自己是主节点时为nil
+	parts     *types.PartSet
+	sent      []int // 每个分片被自己发送出去的次数，优先发送还没有被发送过的分片，分散主节点的上传压力
+	delivered bool
+}
+
+func newProposalParts(height int64, round int16, from *p2p.Peer, parts *types.PartSet) *proposalParts {
+	return &proposalParts{
+		height: height,
+		round:  round,
+		from:   from,
+		parts:  parts,
+		sent:   make([]int, parts.Total()),
+	}
+}
+
+// proposeBlockParts 主节点把自己提出的Prepare消息切分成分片，返回需要发送给其他节点的分片集合头部。
+func (r *Reactor) proposeBlockParts(prepare *types.Prepare) *types.BlockPartSetHeader {
+	parts := types.NewPartSetFromData(r.encodePrepare(prepare), types.BlockPartSizeBytes)
+	pp := newProposalParts(prepare.Height, prepare.Round, nil, parts)
+	pp.delivered = true
+	r.partsMu.Lock()
+	r.proposals[r.core.id] = pp
+	r.partsMu.Unlock()
+	return &types.BlockPartSetHeader{Height: prepare.Height, Round: prepare.Round, Header: parts.Header()}
+}
+
+// receiveBlockPartSetHeader 每个节点最多只保留它发来的最新的一个分片集合，不是主节点的节点发来的分片集合最终会因为
+// 签名不正确而被Core拒绝。
+func (r *Reactor) receiveBlockPartSetHeader(src *p2p.Peer, msg *types.BlockPartSetHeader) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	r.partsMu.Lock()
+	defer r.partsMu.Unlock()
+	if pp, ok := r.proposals[src.NodeID()]; ok && bytes.Equal(pp.parts.Hash(), msg.Header.Hash) {
+		return nil
+	}
+	r.proposals[src.NodeID()] = newProposalParts(msg.Height, msg.Round, src, types.NewPartSetFromHeader(msg.Header))
+	return nil
+}
+
+// receiveBlockPart 验证并保存收到的分片，新的分片会被告知其他节点，分片集合收集完整后，拼接出的Prepare消息被交给Core。
+func (r *Reactor) receiveBlockPart(src *p2p.Peer, ps *PeerState, msg *types.BlockPart) error {
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	ps.SetHasBlockPart(msg.Hash, msg.Part.Index)
+
+	r.partsMu.Lock()
+	pp := r.findProposalParts(msg.Height, msg.Round, msg.Hash)
+	if pp == nil {
+		// 还没有收到分片集合的头部，或者分片集合已经被替换掉了
+		r.partsMu.Unlock()
+		return nil
+	}
+	added, err := pp.parts.AddPart(msg.Part)
+	if err != nil || !added {
+		r.partsMu.Unlock()
+		return err
+	}
+	complete := pp.parts.IsComplete() && !pp.delivered
+	if complete {
+		pp.delivered = true
+	}
+	r.partsMu.Unlock()
+
+	r.Switch.Broadcast(p2p.BlockPartChannel, MustEncode(&types.HasBlockPart{Height: msg.Height, Round: msg.Round, Hash: msg.Hash, Index: msg.Part.Index}))
+	if complete {
+		return r.deliverProposalParts(pp)
+	}
+	return nil
+}
+
+// deliverProposalParts 把收集完整的分片拼接成Prepare消息，如果主节点发送的是紧凑提案，还需要先用交易池还原出区块。
+func (r *Reactor) deliverProposalParts(pp *proposalParts) error {
+	msg, err := decodeMsg(pp.parts.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to decode block parts from %s: %w", pp.from.NodeID(), err)
+	}
+	switch msg := msg.(type) {
+	case *types.Prepare:
+		if msg.Height != pp.height || msg.Round != pp.round {
+			return fmt.Errorf("block parts from %s are for %d/%d, but got Prepare message at %d/%d", pp.from.NodeID(), pp.height, pp.round, msg.Height, msg.Round)
+		}
+		r.core.sendExternalMessage(MessageInfo{Msg: msg, NodeID: pp.from.NodeID()})
+	case *types.CompactPrepare:
+		if msg.Height != pp.height || msg.Round != pp.round {
+			return fmt.Errorf("block parts from %s are for %d/%d, but got CompactPrepare message at %d/%d", pp.from.NodeID(), pp.height, pp.round, msg.Height, msg.Round)
+		}
+		r.receiveCompactPrepare(pp.from, msg)
+	default:
+		return fmt.Errorf("block parts from %s contain unexpected message %T", pp.from.NodeID(), msg)
+	}
+	return nil
+}
+
+func (r *Reactor) findProposalParts(height int64, round int16, hash []byte) *proposalParts {
+	for _, pp := range r.proposals {
+		if pp.height == height && pp.round == round && bytes.Equal(pp.parts.Hash(), hash) {
+			return pp
+		}
+	}
+	return nil
+}
+
+// pickBlockPart 从节点正在收集的分片集合里挑选一个自己有而节点没有的分片，优先挑选自己发送次数最少的分片。
+func (r *Reactor) pickBlockPart(ps *PeerState) *types.BlockPart {
+	r.partsMu.Lock()
+	defer r.partsMu.Unlock()
+	for _, pp := range r.proposals {
+		hash := pp.parts.Hash()
+		if !ps.CollectsBlockParts(hash) {
+			continue
+		}
+		candidates := make([]uint32, 0)
+		least := -1
+		for i, has := range pp.parts.HasParts() {
+			index := uint32(i)
+			if !has || ps.HasBlockPart(hash, index) {
+				continue
+			}
+			switch {
+			case least == -1 || pp.sent[index] < least:
+				least = pp.sent[index]
+				candidates = append(candidates[:0], index)
+			case pp.sent[index] == least:
+				candidates = append(candidates, index)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+		index := candidates[rand.Intn(len(candidates))]
+		pp.sent[index]++
+		return &types.BlockPart{Height: pp.height, Round: pp.round, Hash: hash, Part: pp.parts.GetPart(index)}
+	}
+	return nil
+}
+
+// gossipBlockPartsRoutine 不断地把节点缺少的分片发送给它。
+func (r *Reactor) gossipBlockPartsRoutine(peer *p2p.Peer) {
+	for {
+		if !r.IsRunning() || !peer.IsRunning() {
+			return
+		}
+		if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+			if part := r.pickBlockPart(ps); part != nil && peer.Send(p2p.BlockPartChannel, MustEncode(part)) {
+				ps.SetHasBlockPart(part.Hash, part.Part.Index)
+				continue
+			}
+		}
+		select {
+		case <-time.After(blockPartsGossipSleep):
+		case <-peer.WaitStop():
+			return
+		case <-r.WaitStop():
+			return
+		}
+	}
+}
+
+// removeProposalParts 节点断开连接后，丢弃它发来的分片集合。
+func (r *Reactor) removeProposalParts(id crypto.ID) {
+	r.partsMu.Lock()
+	defer r.partsMu.Unlock()
+	delete(r.proposals, id)
+}
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPickBlockPart(t *testing.T) {
+	data := make([]byte, 4*types.BlockPartSizeBytes)
+	parts := types.NewPartSetFromData(data, types.BlockPartSizeBytes)
+	r := &Reactor{proposals: map[crypto.ID]*proposalParts{"leader": newProposalParts(5, 1, nil, parts)}}
+
+	// 节点还没有开始收集这个分片集合时，不会给它发送分片
+	ps := NewPeerState()
+	assert.Nil(t, r.pickBlockPart(ps))
+
+	// 主节点优先发送还没有被发送过的分片，4个节点各自先收到不同的分片
+	picked := make(map[uint32]struct{})
+	for i := 0; i < 4; i++ {
+		ps := NewPeerState()
+		ps.SetBlockPartSetHeader(parts.Header())
+		part := r.pickBlockPart(ps)
+		assert.NotNil(t, part)
+		assert.Equal(t, parts.Hash(), part.Hash)
+		picked[part.Part.Index] = struct{}{}
+	}
+	assert.Equal(t, 4, len(picked))
+
+	// 节点已经有的分片不会再被发送给它
+	ps.SetBlockPartSetHeader(parts.Header())
+	for i := uint32(0); i < 3; i++ {
+		ps.SetHasBlockPart(parts.Hash(), i)
+	}
+	part := r.pickBlockPart(ps)
+	assert.Equal(t, uint32(3), part.Part.Index)
+	ps.SetHasBlockPart(parts.Hash(), 3)
+	assert.Nil(t, r.pickBlockPart(ps))
+
+	// 节点开始收集另一个分片集合后，之前的记录被清空
+	ps.SetHasBlockPart([]byte("other part set"), 0)
+	assert.False(t, ps.HasBlockPart(parts.Hash(), 1))
+	assert.Nil(t, r.pickBlockPart(ps))
+}
@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/types"
+)
+
+// SetByzantineConfig 让节点按照配置在指定的高度故意作恶，只能用于测试网，观察诚实节点能否发现并容忍这些错误行为。
+func (c *Core) SetByzantineConfig(cfg *config.ByzantineConfig) {
+	c.byzantine = cfg
+	if cfg.Enabled() {
+		c.Logger.Warn("byzantine mode is enabled, this node will misbehave on purpose", "config", fmt.Sprintf("%+v", *cfg))
+	}
+}
+
+// misbehave 返回节点在当前高度是否应该表现出behaviour这种拜占庭行为，重放WAL时总是诚实的。
+func (c *Core) misbehave(behaviour string) bool {
+	return !c.replayMode && c.byzantine.Misbehave(behaviour, c.stepInfo.height)
+}
+
+// equivocate 主节点在同一个视图里再提出一个与prepare相冲突的区块：在原有交易的基础上多打包一条交易，然后为新的区块
+// 签名，并绕过PeerState直接广播出去。收到两个Prepare消息的诚实节点会生成ConflictingPrepareEvidence。
+func (c *Core) equivocate(prepare *types.Prepare) {
+	block := prepare.Block
+	txs := make(types.Txs, 0, len(block.Body.Txs)+1)
+	txs = append(txs, block.Body.Txs...)
+	txs = append(txs, types.Tx(fmt.Sprintf("equivocation=%d", prepare.Round)))
+	conflicting := c.state.MakeBlock(block.Header.Height, txs, block.Evidence, block.Header.Proposer, block.Header.PreviousBlockHash, block.Header.AppHash, block.Header.AppHashHeight())
+	another, err := types.NewPrepare(prepare.Height, prepare.Round, conflicting, prepare.HighQC, c.signer)
+	if err != nil {
+		c.Logger.Error("byzantine: failed to sign conflicting Prepare message", "err", err)
+		return
+	}
+	c.Logger.Warn("byzantine: propose conflicting Prepare message", "height", prepare.Height, "round", prepare.Round,
+		"block", fmt.Sprintf("%X", block.ChameleonHash.Hash), "conflicting", fmt.Sprintf("%X", conflicting.ChameleonHash.Hash))
+	c.fireEvent(events.EventEquivocation, another)
+}
+
+// voteBlockHash 返回投票所针对的区块哈希值，拜占庭节点会为一个与提案不同的哈希值投票。
+func (c *Core) voteBlockHash() []byte {
+	hash := c.stepInfo.block.ChameleonHash.Hash
+	if c.misbehave(config.WrongVoteHash) {
+		wrong := sha256.Sum(append([]byte("byzantine"), hash...))
+		c.Logger.Warn("byzantine: vote for wrong block hash", "height", c.stepInfo.height, "round", c.stepInfo.round)
+		return wrong[:]
+	}
+	return hash
+}
@@ -0,0 +1,553 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	state2 "github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/types"
+	"sort"
+	"time"
+)
+
+// 链式（流水线）HotStuff，在ConsensusConfig.Protocol为ProtocolChained时代替四阶段共识。
+//
+// 共识按照单调递增的视图推进，每个视图只有一轮投票：view视图的主节点提出一个区块，区块携带父区块的QC（justify），
+// 验证者为区块投票，并把投票发送给view+1视图的主节点，后者收集到2/3以上的投票后组成QC，进入view+1视图并基于这个
+// QC提出下一个区块。验证者收到提案时沿着justify向前看：
+//  1. b'' = justify所认证的区块，justify成为新的highQC；
+//  2. b' = b''.justify所认证的区块，如果b'的视图比锁定的区块更新，则锁定在b'上；
+//  3. b = b'.justify所认证的区块，如果b、b'、b''是在连续的三个视图里被提出的，则提交b以及b之前所有尚未提交的区块。
+//
+// 验证者只为扩展了锁定区块的提案，或者justify比锁定的QC更新的提案投票。视图超时后，验证者向下一个视图的主节点发送
+// 携带highQC的ChainedNewView消息，主节点收集到2/3以上的ChainedNewView消息后，基于其中最高的QC提出区块。
+//
+// 主节点按照加权轮询算法轮流担任，投票权越大的验证者担任主节点的次数越多，每个主节点连续担任chainedLeaderViews个视图，
+// 这样即使它前后的主节点都宕机了，它自己的任期里也能形成连续三个视图的QC链并提交区块；视图超时后，节点直接进入下一个
+// 主节点任期的第一个视图。各节点提交区块的进度不完全相同，而状态里验证者的优先级每提交一个区块就累加一次，因此主节点
+// 的任期表不使用状态里的优先级，而是在优先级全部清零的验证者集合上逐个任期累加优先级排出来的，只由验证者和投票权决定。
+// 验证者集合取自各节点已经提交的状态，因此验证者集合的变更会在所有节点都提交了对应的区块之后才会对主节点的选择产生一致
+// 的影响。
+
+// chainedLeaderViews 每个主节点连续担任的视图数，提交一个区块至少需要一个主节点连续提出4个区块。
+const chainedLeaderViews = 4
+
+// chainedState 链式HotStuff的共识状态。
+type chainedState struct {
+	view      int64                                      // 当前所处的视图
+	lastVoted int64                                      // 最后一次投票的视图，每个视图最多投一票
+	proposed  int64                                      // 作为主节点最后一次提出区块的视图
+	timeouts  int64                                      // 连续超时的次数，超时次数越多，下一个视图的超时时间越长
+	highQC    *types.QuorumCert                          // 所知道的最高的QC
+	lockedQC  *types.QuorumCert                          // 锁定的QC，它认证的区块就是被锁定的区块
+	pending   map[string]*types.ChainedProposal          // 区块哈希 -> 尚未提交的区块的提案
+	votes     map[int64]map[crypto.ID]*types.ChainedVote // 视图 -> 为该视图的提案投出的票，由下一个视图的主节点收集
+	newViews  map[int64]map[crypto.ID]*types.ChainedNewView
+	schedule  *leaderSchedule // 主节点的任期表
+}
+
+// leaderSchedule 按照加权轮询算法排出的主节点任期表。所有验证者的优先级从零开始累加，经过总投票权除以各投票权的最大
+// 公约数次之后，每个验证者都恰好当选了与其投票权成正比的次数，优先级也全部回到零，所以任期表是周期性的，第term个任期
+// 的主节点是leaders[term%period]。这样任期表的长度有上限，计算很大的视图的主节点也不需要从头累加。
+type leaderSchedule struct {
+	validators string              // 排出任期表的验证者集合，验证者集合变化后需要重新排
+	set        *types.ValidatorSet // 优先级从零开始累加到最后一个任期的验证者集合
+	period     int64
+	leaders    []crypto.ID
+}
+
+func newLeaderSchedule(validators *types.ValidatorSet, key string) *leaderSchedule {
+	set := validators.Copy()
+	var divisor int64
+	for _, val := range set.Validators {
+		val.LeaderPriority = 0
+		divisor = gcd(divisor, val.VotingPower)
+	}
+	return &leaderSchedule{validators: key, set: set, period: set.TotalVotingPower / divisor}
+}
+
+// leader 返回第term个任期的主节点，任期表在需要时才逐个任期地排出来。
+func (s *leaderSchedule) leader(term int64) crypto.ID {
+	term %= s.period
+	for int64(len(s.leaders)) <= term {
+		s.set.IncrementLeaderPriority(1)
+		s.leaders = append(s.leaders, s.set.Leader.ID)
+	}
+	return s.leaders[term]
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// scheduleKey 用验证者和它们的投票权标识一个验证者集合。
+func scheduleKey(validators *types.ValidatorSet) string {
+	var key bytes.Buffer
+	for _, val := range validators.Validators {
+		key.WriteString(fmt.Sprintf("%s:%d;", val.ID, val.VotingPower))
+	}
+	return key.String()
+}
+
+func newChainedState() *chainedState {
+	return &chainedState{
+		view:     1,
+		pending:  make(map[string]*types.ChainedProposal),
+		votes:    make(map[int64]map[crypto.ID]*types.ChainedVote),
+		newViews: make(map[int64]map[crypto.ID]*types.ChainedNewView),
+	}
+}
+
+// prune 删除已经被提交的高度上的提案，包括与被提交的区块相冲突的提案。
+func (cs *chainedState) prune(committed int64) {
+	for hash, proposal := range cs.pending {
+		if proposal.Block.Header.Height <= committed {
+			delete(cs.pending, hash)
+		}
+	}
+}
+
+// chainedLeader 返回view视图的主节点。
+func (c *Core) chainedLeader(view int64) *types.Validator {
+	validators := c.state.Validators
+	key := scheduleKey(validators)
+	if c.chained.schedule == nil || c.chained.schedule.validators != key {
+		c.chained.schedule = newLeaderSchedule(validators, key)
+	}
+	return validators.GetValidatorByID(c.chained.schedule.leader(view / chainedLeaderViews))
+}
+
+// nextLeaderView 返回view视图之后，下一个主节点任期的第一个视图。
+func nextLeaderView(view int64) int64 {
+	return (view/chainedLeaderViews + 1) * chainedLeaderViews
+}
+
+// handleChainedProposal 处理view视图的主节点提出的区块，own为true表示是自己提出的区块，它已经被写入了WAL，需要
+// 广播给其他节点。
+func (c *Core) handleChainedProposal(proposal *types.ChainedProposal, own bool) error {
+	cs := c.chained
+	if err := proposal.ValidateBasic(); err != nil {
+		return fmt.Errorf("validator %s sent invalid ChainedProposal message to me: %w", proposal.ID, err)
+	}
+	block := proposal.Block
+	hash := block.ChameleonHash.Hash
+	if block.Header.Height <= c.state.LastBlockHeight {
+		return nil
+	}
+	if _, ok := cs.pending[string(hash)]; ok {
+		return nil
+	}
+	leader := c.chainedLeader(proposal.View)
+	if proposal.ID != leader.ID || proposal.Signature.Signer() != leader.ID ||
+		!leader.PublicKey.Verify(proposal.Signature, types.GenerateChainedProposalSignBytes(proposal.View, block.Header.Height, hash)) {
+		return fmt.Errorf("ChainedProposal at view %d is not signed by leader %s", proposal.View, leader.ID)
+	}
+	if proposal.Justify != nil {
+		if err := proposal.Justify.Verify(c.state.Validators); err != nil {
+			return fmt.Errorf("leader %s sent ChainedProposal with invalid justify QC: %w", leader.ID, err)
+		}
+	}
+	ancestors, err := c.chainedAncestors(block.Header.Height, block.Header.PreviousBlockHash)
+	if err != nil {
+		return fmt.Errorf("cannot validate block proposed by %s at view %d: %w", leader.ID, proposal.View, err)
+	}
+	if proposal.Justify == nil && len(ancestors) > 0 {
+		return fmt.Errorf("leader %s proposed a block on an uncommitted block without QC at view %d", leader.ID, proposal.View)
+	}
+	if err = c.blockExec.ValidateBlockOnAncestors(c.state, block, ancestors, leader.ID); err != nil {
+		c.Logger.Warn("reject proposal", "view", proposal.View, "height", block.Header.Height, "leader", leader.ID, "err", err)
+		return fmt.Errorf("leader %s proposed an invalid block at view %d: %w", leader.ID, proposal.View, err)
+	}
+	cs.pending[string(hash)] = proposal
+	if own {
+		c.fireEvent(events.EventChainedProposal, proposal)
+	}
+	c.Logger.Debug("receive a valid ChainedProposal", "view", proposal.View, "height", block.Header.Height, "block", fmt.Sprintf("%X", hash), "leader", leader.ID)
+
+	safe := c.checkSafeChainedProposal(proposal)
+	c.processJustify(proposal.Justify)
+	if proposal.View >= cs.view && proposal.View > cs.lastVoted && safe && c.isValidator() {
+		vote, err := types.NewChainedVote(proposal.View, block.Header.Height, hash, c.signer)
+		if err != nil {
+			c.Logger.Error("failed to sign ChainedVote", "view", proposal.View, "err", err)
+		} else {
+			cs.lastVoted = proposal.View
+			c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
+		}
+	}
+	c.enterChainedView(proposal.View + 1)
+	return nil
+}
+
+// checkSafeChainedProposal 实现链式HotStuff的safeNode规则：提案扩展了被锁定的区块，或者提案携带的QC比锁定的QC更新。
+func (c *Core) checkSafeChainedProposal(proposal *types.ChainedProposal) bool {
+	locked := c.chained.lockedQC
+	if locked == nil {
+		return true
+	}
+	if proposal.Justify != nil && proposal.Justify.View > locked.View {
+		return true
+	}
+	block := proposal.Block
+	for block.Header.Height > locked.Height {
+		parent, ok := c.chained.pending[string(block.Header.PreviousBlockHash)]
+		if !ok {
+			break
+		}
+		block = parent.Block
+	}
+	hash, height := block.ChameleonHash.Hash, block.Header.Height
+	if height == locked.Height && bytes.Equal(hash, locked.BlockHash) {
+		return true
+	}
+	c.Logger.Warn("unsafe ChainedProposal", "view", proposal.View, "locked_view", locked.View, "locked_block", fmt.Sprintf("%X", locked.BlockHash))
+	return false
+}
+
+// processJustify 根据提案携带的QC更新highQC和锁定的QC，并在形成了连续三个视图的QC链时提交区块。
+func (c *Core) processJustify(qc *types.QuorumCert) {
+	if qc == nil {
+		return
+	}
+	cs := c.chained
+	c.updateChainedHighQC(qc)
+	b2, ok := cs.pending[string(qc.BlockHash)]
+	if !ok || b2.Justify == nil {
+		return
+	}
+	qc1 := b2.Justify
+	if cs.lockedQC == nil || qc1.View > cs.lockedQC.View {
+		cs.lockedQC = qc1
+		c.Logger.Debug("lock on block", "view", qc1.View, "height", qc1.Height, "block", fmt.Sprintf("%X", qc1.BlockHash))
+	}
+	b1, ok := cs.pending[string(qc1.BlockHash)]
+	if !ok || b1.Justify == nil {
+		return
+	}
+	qc0 := b1.Justify
+	if qc.View == qc1.View+1 && qc1.View == qc0.View+1 {
+		c.commitChained(qc0)
+	}
+}
+
+// updateChainedHighQC 记录视图更高的QC，形成了新的QC说明共识在推进，连续超时的次数清零。
+func (c *Core) updateChainedHighQC(qc *types.QuorumCert) {
+	cs := c.chained
+	if cs.highQC == nil || qc.View > cs.highQC.View {
+		cs.highQC = qc
+		cs.timeouts = 0
+	}
+}
+
+// commitChained 提交qc所认证的区块，以及它之前所有尚未提交的区块，每个区块的提交证明是认证它的QC。
+func (c *Core) commitChained(qc *types.QuorumCert) {
+	cs := c.chained
+	type commitment struct {
+		block *types.Block
+		qc    *types.QuorumCert
+	}
+	chain := make([]commitment, 0)
+	for qc != nil && qc.Height > c.state.LastBlockHeight {
+		proposal, ok := cs.pending[string(qc.BlockHash)]
+		if !ok {
+			c.Logger.Error("cannot commit block, because it is unknown", "height", qc.Height, "block", fmt.Sprintf("%X", qc.BlockHash))
+			return
+		}
+		chain = append(chain, commitment{block: proposal.Block, qc: qc})
+		qc = proposal.Justify
+	}
+	if len(chain) == 0 {
+		return
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		block := chain[i].block
+		newState, err := c.blockExec.ApplyBlock(c.state, block, types.NewChainedCommitBlock(block, chain[i].qc))
+		if err != nil {
+			haltOnApplyFailure(err)
+			c.Logger.Error("failed to apply block", "height", block.Header.Height, "err", err)
+			return
+		}
+		c.state = newState
+		c.stepInfo.height = newState.LastBlockHeight + 1
+		c.stepInfo.previousBlock = block
+		// 重放WAL时提交的区块在崩溃之前没有被执行，同样需要写入结束标志
+		if err = c.wal.WriteSync(EndHeightMessage{Height: newState.LastBlockHeight}); err != nil {
+			panic(fmt.Sprintf("failed to write EndHeightMessage{%d} to consensus WAL due to %v; check your file system and restart the node", newState.LastBlockHeight, err))
+		}
+		c.Logger.Info("commit block", "height", block.Header.Height, "view", chain[i].qc.View, "block", fmt.Sprintf("%X", block.ChameleonHash.Hash))
+	}
+	c.hasTxs = false
+	cs.prune(c.state.LastBlockHeight)
+	// 尚未提交的提案是在最后一个结束标志之前收到的，把它们重新写进WAL，节点重启后从最后一个结束标志开始重放
+	// 时才能找回它们，以及它们携带的highQC和锁定的QC
+	remaining := make([]*types.ChainedProposal, 0, len(cs.pending))
+	for _, proposal := range cs.pending {
+		remaining = append(remaining, proposal)
+	}
+	sort.Slice(remaining, func(i, j int) bool { return remaining[i].View < remaining[j].View })
+	for _, proposal := range remaining {
+		_ = c.wal.Write(MessageInfo{Msg: proposal, NodeID: proposal.ID})
+	}
+}
+
+// handleChainedVote 下一个视图的主节点收集投票，收集到2/3以上的投票后组成QC，进入下一个视图并提出新的区块。
+func (c *Core) handleChainedVote(vote *types.ChainedVote) error {
+	cs := c.chained
+	if c.chainedLeader(vote.View+1).ID != c.id {
+		return nil
+	}
+	if err := vote.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid ChainedVote: %w", err)
+	}
+	if cs.highQC != nil && cs.highQC.View >= vote.View {
+		return nil
+	}
+	signer := vote.Signature.Signer()
+	validator := c.state.Validators.GetValidatorByID(signer)
+	if validator == nil {
+		return fmt.Errorf("cannot find this validator: %s", signer)
+	}
+	if !validator.PublicKey.Verify(vote.Signature, vote.ValueHash) {
+		return fmt.Errorf("validator %s sent invalid ChainedVote message to me", signer)
+	}
+	votes := cs.votes[vote.View]
+	if votes == nil {
+		votes = make(map[crypto.ID]*types.ChainedVote)
+		cs.votes[vote.View] = votes
+	}
+	if existing, ok := votes[signer]; ok {
+		if !bytes.Equal(existing.BlockHash, vote.BlockHash) {
+			return fmt.Errorf("validator %s voted for different blocks at view %d", signer, vote.View)
+		}
+		return nil
+	}
+	votes[signer] = vote
+	var power int64 = 0
+	sigs := make([]*bls12.Signature, 0, len(votes))
+	for id, v := range votes {
+		if bytes.Equal(v.BlockHash, vote.BlockHash) {
+			power += c.state.Validators.GetValidatorByID(id).VotingPower
+			sigs = append(sigs, v.Signature)
+		}
+	}
+	if power < c.state.Validators.PowerMajor23() {
+		return nil
+	}
+	agg, err := c.cryptoBLS12.CreateThresholdSignature(sigs)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate ChainedVote at view %d: %w", vote.View, err)
+	}
+	qc := types.NewQuorumCert(vote.View, vote.Height, vote.BlockHash, agg)
+	c.Logger.Debug("receive enough ChainedVote messages", "view", vote.View, "height", vote.Height)
+	c.updateChainedHighQC(qc)
+	c.enterChainedView(vote.View + 1)
+	c.tryProposeChained()
+	return nil
+}
+
+// handleChainedNewView 视图超时后，view视图的主节点收集ChainedNewView消息，收集到2/3以上的消息后基于其中最高的QC
+// 提出区块。
+func (c *Core) handleChainedNewView(view *types.ChainedNewView) error {
+	cs := c.chained
+	if err := view.ValidateBasic(); err != nil {
+		return fmt.Errorf("validator %s sent invalid ChainedNewView message to me: %w", view.ID, err)
+	}
+	if c.chainedLeader(view.View).ID != c.id || view.View < cs.view || view.View <= cs.proposed {
+		return nil
+	}
+	validator := c.state.Validators.GetValidatorByID(view.ID)
+	if validator == nil {
+		return fmt.Errorf("an unknown validator %s sent ChainedNewView message to me", view.ID)
+	}
+	if view.Signature.Signer() != view.ID || !validator.PublicKey.Verify(view.Signature, view.ValueHash()) {
+		return fmt.Errorf("validator %s sent ChainedNewView message with invalid signature", view.ID)
+	}
+	if view.HighQC != nil {
+		if err := view.HighQC.Verify(c.state.Validators); err != nil {
+			return fmt.Errorf("validator %s sent ChainedNewView message with invalid high QC: %w", view.ID, err)
+		}
+		c.updateChainedHighQC(view.HighQC)
+	}
+	views := cs.newViews[view.View]
+	if views == nil {
+		views = make(map[crypto.ID]*types.ChainedNewView)
+		cs.newViews[view.View] = views
+	}
+	views[view.ID] = view
+	if c.hasChainedNewViewQuorum(view.View) {
+		c.Logger.Debug("receive enough ChainedNewView messages", "view", view.View)
+		c.enterChainedView(view.View)
+		c.tryProposeChained()
+	}
+	return nil
+}
+
+func (c *Core) hasChainedNewViewQuorum(view int64) bool {
+	var power int64 = 0
+	for id := range c.chained.newViews[view] {
+		if validator := c.state.Validators.GetValidatorByID(id); validator != nil {
+			power += validator.VotingPower
+		}
+	}
+	return power >= c.state.Validators.PowerMajor23()
+}
+
+// sendChainedVote 将自己的投票发送给下一个视图的主节点，如果自己就是下一个视图的主节点，则直接处理。
+func (c *Core) sendChainedVote(vote *types.ChainedVote) {
+	if c.chainedLeader(vote.View+1).ID == c.id {
+		if err := c.handleChainedVote(vote); err != nil {
+			c.Logger.Error("failed to handle ChainedVote message", "err", err)
+		}
+		return
+	}
+	c.fireEvent(events.EventChainedVote, vote)
+}
+
+// sendChainedNewView 将自己的ChainedNewView消息发送给新视图的主节点，如果自己就是新视图的主节点，则直接处理。
+func (c *Core) sendChainedNewView(view *types.ChainedNewView) {
+	if c.chainedLeader(view.View).ID == c.id {
+		if err := c.handleChainedNewView(view); err != nil {
+			c.Logger.Error("failed to handle ChainedNewView message", "err", err)
+		}
+		return
+	}
+	c.fireEvent(events.EventChainedNewView, view)
+}
+
+// enterChainedView 进入更新的视图，并为新视图设置超时时间。
+func (c *Core) enterChainedView(view int64) {
+	cs := c.chained
+	if view <= cs.view {
+		return
+	}
+	cs.view = view
+	for v := range cs.votes {
+		if v+1 < view {
+			delete(cs.votes, v)
+		}
+	}
+	for v := range cs.newViews {
+		if v < view {
+			delete(cs.newViews, v)
+		}
+	}
+	c.Logger.Debug("=> NEW_VIEW", "view", view, "leader", c.chainedLeader(view).ID)
+	c.scheduleChainedPacemaker()
+}
+
+// scheduleChainedPacemaker 有需要达成共识的数据时，为当前视图设置超时时间，连续超时的次数越多，超时时间越长。
+func (c *Core) scheduleChainedPacemaker() {
+	cs := c.chained
+	if !c.hasChainedWork() {
+		return
+	}
+	duration := c.cfg.TimeoutConsensus * time.Duration(cs.timeouts+1)
+	c.viewTicker.ScheduleTimeout(TimeoutInfo{Duration: duration, Height: cs.view, Round: 1, Step: ConsensusTimeout})
+}
+
+// handleChainedTimeout 在规定时间内当前视图没有形成QC，说明当前的主节点可能已经宕机，向下一个主节点发送携带highQC的
+// ChainedNewView消息，并进入它任期的第一个视图。链式HotStuff里超时事件的Height字段记录的是视图。
+func (c *Core) handleChainedTimeout(info TimeoutInfo) {
+	cs := c.chained
+	if info.Height != cs.view {
+		return
+	}
+	if !c.replayMode && !c.hasChainedWork() {
+		return
+	}
+	next := nextLeaderView(cs.view)
+	c.Logger.Warn("consensus timeout, change view", "old_view", cs.view, "new_view", next, "timeout", info.Duration)
+	cs.timeouts++
+	view, err := types.NewChainedNewView(next, cs.highQC, c.signer)
+	c.enterChainedView(next)
+	if err != nil {
+		c.Logger.Error("failed to sign ChainedNewView message", "err", err)
+		return
+	}
+	c.sendInternalMessage(MessageInfo{Msg: view, NodeID: ""})
+}
+
+// handleChainedTxs 交易池里有了新的交易，自己是当前视图的主节点时提出区块，否则等待主节点提出区块，超时后切换视图。
+func (c *Core) handleChainedTxs() {
+	c.scheduleChainedPacemaker()
+	c.tryProposeChained()
+}
+
+// tryProposeChained 自己是当前视图的主节点，并且已经得到了上一个视图的QC，或者收集到了足够多的ChainedNewView消息
+// 时，基于highQC提出新的区块。
+func (c *Core) tryProposeChained() {
+	cs := c.chained
+	view := cs.view
+	if c.replayMode || cs.proposed >= view || !c.isValidator() || c.chainedLeader(view).ID != c.id {
+		return
+	}
+	ready := (cs.highQC == nil && view == 1) || (cs.highQC != nil && cs.highQC.View == view-1) || c.hasChainedNewViewQuorum(view)
+	if !ready || !c.hasChainedWork() {
+		return
+	}
+	height, previous := c.state.NextHeight(), c.state.PreviousBlockHash(c.state.NextHeight())
+	if cs.highQC != nil && cs.highQC.Height >= height {
+		height, previous = cs.highQC.Height+1, cs.highQC.BlockHash
+	}
+	ancestors, err := c.chainedAncestors(height, previous)
+	if err != nil {
+		c.Logger.Error("cannot propose block", "view", view, "err", err)
+		return
+	}
+	block, err := c.blockExec.CreateBlockOnAncestors(c.state, c.id, ancestors)
+	if err != nil {
+		c.Logger.Error("failed to create block", "view", view, "err", err)
+		return
+	}
+	proposal, err := types.NewChainedProposal(view, block, cs.highQC, c.signer)
+	if err != nil {
+		c.Logger.Error("failed to sign ChainedProposal", "view", view, "err", err)
+		return
+	}
+	cs.proposed = view
+	c.Logger.Info("propose block", "view", view, "height", block.Header.Height, "txs", len(block.Body.Txs))
+	c.sendInternalMessage(MessageInfo{Msg: proposal, NodeID: ""})
+}
+
+// hasChainedWork 交易池里还有交易，或者highQC所认证的尚未提交的区块里有交易时，才需要继续推进共识，后者需要更多的
+// QC才能被提交。交易在被提交之前会一直留在交易池里。
+func (c *Core) hasChainedWork() bool {
+	if c.txsPool.TxsNumInPool() > 0 {
+		return true
+	}
+	qc := c.chained.highQC
+	for qc != nil && qc.Height > c.state.LastBlockHeight {
+		proposal, ok := c.chained.pending[string(qc.BlockHash)]
+		if !ok {
+			return false
+		}
+		if len(proposal.Block.Body.Txs) > 0 {
+			return true
+		}
+		qc = proposal.Justify
+	}
+	return false
+}
+
+// chainedAncestors 返回height高度的区块接在哈希值为previous的区块之后时，它尚未提交的祖先区块，祖先区块从父区块开始
+// 依次向前排列，最后一个祖先区块必须接在已经提交的最后一个区块之后。
+func (c *Core) chainedAncestors(height int64, previous []byte) (state2.Ancestors, error) {
+	ancestors := state2.Ancestors{}
+	for height-1 > c.state.LastBlockHeight {
+		parent, ok := c.chained.pending[string(previous)]
+		if !ok {
+			return nil, fmt.Errorf("unknown parent block %X at height %d", previous, height-1)
+		}
+		ancestors = append(ancestors, parent.Block)
+		height, previous = parent.Block.Header.Height, parent.Block.Header.PreviousBlockHash
+	}
+	if next := c.state.NextHeight(); height != next || !bytes.Equal(previous, c.state.PreviousBlockHash(next)) {
+		return nil, fmt.Errorf("block does not extend the last committed block at height %d", c.state.LastBlockHeight)
+	}
+	return ancestors, nil
+}
@@ -0,0 +1,45 @@
+package consensus
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestLeaderSchedule(t *testing.T) {
+	validators := make([]*types.Validator, 0)
+	for _, power := range []int64{10, 20, 30} {
+		privateKey, err := bls12.GeneratePrivateKey()
+		require.Nil(t, err)
+		validators = append(validators, types.NewValidator(privateKey.PublicKey(), power))
+	}
+	set := types.NewValidatorSet(validators)
+	schedule := newLeaderSchedule(set, scheduleKey(set))
+	assert.EqualValues(t, 6, schedule.period)
+
+	// 一个周期里每个验证者担任主节点的次数与投票权成正比
+	counts := make(map[crypto.ID]int64)
+	for term := int64(0); term < schedule.period; term++ {
+		counts[schedule.leader(term)]++
+	}
+	for _, val := range set.Validators {
+		assert.Equal(t, val.VotingPower/10, counts[val.ID])
+	}
+
+	// 任期表是周期性的，很大的任期也能直接算出主节点
+	for term := int64(0); term < schedule.period; term++ {
+		assert.Equal(t, schedule.leader(term), schedule.leader(term+schedule.period))
+	}
+	assert.Equal(t, schedule.leader(0), schedule.leader(schedule.period<<40))
+
+	// 任期表与状态里验证者当前的优先级无关，提交进度不同的节点排出的任期表是一样的
+	advanced := set.Copy()
+	advanced.IncrementLeaderPriority(5)
+	other := newLeaderSchedule(advanced, scheduleKey(advanced))
+	for term := int64(0); term < 2*schedule.period; term++ {
+		assert.Equal(t, schedule.leader(term), other.leader(term))
+	}
+}
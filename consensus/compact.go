@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/types"
+	"sync"
+)
+
+// txsLookup 还原紧凑区块时在本地查找交易，*txspool.TxsPool实现了该接口。
+type txsLookup interface {
+	GetTx(hash []byte) (types.Tx, bool)
+}
+
+// compactRelay 在Reactor里实现紧凑区块转发：开启了compact_blocks的主节点把Prepare消息压缩成CompactPrepare再发送出去，
+// 并回复副本节点对缺少的交易的请求；副本节点用交易池里的交易还原出完整的Prepare消息后再把它交给Core，因此Core和WAL
+// 看到的始终是完整的Prepare消息。
+type compactRelay struct {
+	mu       sync.Mutex
+	pool     txsLookup
+	proposal *types.Prepare                // 自己作为主节点最近一次以紧凑形式发出的提案
+	pending  map[crypto.ID]*pendingCompact // 每个节点发来的最近一个还缺少交易的紧凑提案
+}
+
+// pendingCompact 等待主节点补全交易的紧凑提案。
+type pendingCompact struct {
+	prepare *types.CompactPrepare
+	txs     types.Txs // 已经找到的交易，缺少的交易为nil
+	missing []int
+}
+
+func newCompactRelay(pool txsLookup) *compactRelay {
+	return &compactRelay{
+		pool:    pool,
+		pending: make(map[crypto.ID]*pendingCompact),
+	}
+}
+
+// compact 主节点压缩自己提出的Prepare消息，并记住该提案，以便回复副本节点的BlockTxsRequest。
+func (cr *compactRelay) compact(prepare *types.Prepare) *types.CompactPrepare {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	cr.proposal = prepare
+	return types.NewCompactPrepare(prepare)
+}
+
+// restore 用交易池里的交易还原from发来的紧凑提案，交易齐全时返回完整的Prepare消息，否则返回需要发送给from的
+// BlockTxsRequest。
+func (cr *compactRelay) restore(from crypto.ID, cp *types.CompactPrepare) (*types.Prepare, *types.BlockTxsRequest, error) {
+	if err := cp.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	delete(cr.pending, from)
+
+	txs := make(types.Txs, len(cp.Block.TxHashes))
+	missing := make([]int, 0)
+	for i, hash := range cp.Block.TxHashes {
+		if tx, ok := cr.pool.GetTx(hash); ok {
+			txs[i] = tx
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) > 0 {
+		cr.pending[from] = &pendingCompact{prepare: cp, txs: txs, missing: missing}
+		return nil, &types.BlockTxsRequest{
+			Height:    cp.Height,
+			Round:     cp.Round,
+			BlockHash: cp.Block.ChameleonHash.Hash,
+			Indexes:   missing,
+		}, nil
+	}
+	block, err := cp.Block.Reconstruct(txs)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cp.ToPrepare(block), nil, nil
+}
+
+// serve 主节点从自己最近一次提出的区块里取出副本节点索要的交易。
+func (cr *compactRelay) serve(req *types.BlockTxsRequest) (*types.BlockTxsResponse, error) {
+	if err := req.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	proposal := cr.proposal
+	if proposal == nil || proposal.Height != req.Height || proposal.Round != req.Round || !bytes.Equal(proposal.Block.ChameleonHash.Hash, req.BlockHash) {
+		return nil, fmt.Errorf("no proposal for block %X at height %d round %d", req.BlockHash, req.Height, req.Round)
+	}
+	return types.NewBlockTxsResponse(proposal.Block, req)
+}
+
+// fill 用from回复的交易补全紧凑提案，还原出的区块与紧凑区块相符时返回完整的Prepare消息。
+func (cr *compactRelay) fill(from crypto.ID, resp *types.BlockTxsResponse) (*types.Prepare, error) {
+	if err := resp.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+	p, ok := cr.pending[from]
+	if !ok || p.prepare.Height != resp.Height || p.prepare.Round != resp.Round || !bytes.Equal(p.prepare.Block.ChameleonHash.Hash, resp.BlockHash) {
+		return nil, errors.New("unsolicited block txs response")
+	}
+	for i, index := range resp.Indexes {
+		if index >= len(p.txs) {
+			return nil, fmt.Errorf("tx index %d is out of range, block has %d txs", index, len(p.txs))
+		}
+		p.txs[index] = resp.Txs[i]
+	}
+	for _, index := range p.missing {
+		if p.txs[index] == nil {
+			return nil, fmt.Errorf("tx #%d is still missing", index)
+		}
+	}
+	delete(cr.pending, from)
+	block, err := p.prepare.Block.Reconstruct(p.txs)
+	if err != nil {
+		return nil, err
+	}
+	return p.prepare.ToPrepare(block), nil
+}
@@ -0,0 +1,126 @@
+package consensus
+
+import (
+	"encoding/hex"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+type mapTxsLookup map[string]types.Tx
+
+func (m mapTxsLookup) GetTx(hash []byte) (types.Tx, bool) {
+	tx, ok := m[hex.EncodeToString(hash)]
+	return tx, ok
+}
+
+func (m mapTxsLookup) add(tx types.Tx) {
+	m[hex.EncodeToString(tx.Hash())] = tx
+}
+
+func newCompactTestPrepare(t *testing.T, txs types.Txs) (*types.Prepare, *bls12.PrivateKey) {
+	privateKey, err := bls12.GeneratePrivateKey()
+	assert.Nil(t, err)
+	block := &types.Block{
+		Header: &types.Header{
+			PreviousBlockHash: []byte("previous block hash"),
+			Height:            3,
+			Timestamp:         time.Now(),
+			Proposer:          privateKey.PublicKey().ToID(),
+		},
+		Body: &types.Data{Txs: txs},
+		ChameleonHash: &types.ChameleonHash{
+			R1:    big.NewInt(1),
+			R2:    big.NewInt(2),
+			Alpha: big.NewInt(3),
+			Hash:  []byte("block hash"),
+		},
+	}
+	block.BlockDataHash()
+	prepare, err := types.NewPrepare(3, 1, block, nil, types.NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	return prepare, privateKey
+}
+
+func TestCompactRelay(t *testing.T) {
+	txs := make(types.Txs, 10)
+	for i := range txs {
+		txs[i] = []byte(fmt.Sprintf("key:%d=value:%d", i, i))
+	}
+	prepare, privateKey := newCompactTestPrepare(t, txs)
+
+	leader := newCompactRelay(mapTxsLookup{})
+	cp, ok := MustDecode(MustEncode(leader.compact(prepare))).(*types.CompactPrepare)
+	assert.True(t, ok)
+
+	// 交易池里有全部的交易，直接还原出Prepare消息
+	full := mapTxsLookup{}
+	for _, tx := range txs {
+		full.add(tx)
+	}
+	restored, req, err := newCompactRelay(full).restore(prepare.ID, cp)
+	assert.Nil(t, err)
+	assert.Nil(t, req)
+	assert.Nil(t, restored.Block.ValidateBasic())
+	assert.Equal(t, txs, restored.Block.Body.Txs)
+	assert.True(t, privateKey.PublicKey().Verify(restored.Signature, types.GeneratePrepareSignBytes(restored.Height, restored.Round, restored.Block.ChameleonHash.Hash)))
+
+	// 交易池里缺少第0笔和第7笔交易，需要向主节点索要
+	partial := mapTxsLookup{}
+	for i, tx := range txs {
+		if i != 0 && i != 7 {
+			partial.add(tx)
+		}
+	}
+	replica := newCompactRelay(partial)
+	restored, req, err = replica.restore(prepare.ID, cp)
+	assert.Nil(t, err)
+	assert.Nil(t, restored)
+	assert.Equal(t, []int{0, 7}, req.Indexes)
+
+	req = MustDecode(MustEncode(req)).(*types.BlockTxsRequest)
+	resp, err := leader.serve(req)
+	assert.Nil(t, err)
+	resp = MustDecode(MustEncode(resp)).(*types.BlockTxsResponse)
+	restored, err = replica.fill(prepare.ID, resp)
+	assert.Nil(t, err)
+	assert.Nil(t, restored.Block.ValidateBasic())
+	assert.Equal(t, prepare.Block.Header.BlockDataHash, restored.Block.Header.BlockDataHash)
+
+	// 回复只能使用一次
+	_, err = replica.fill(prepare.ID, resp)
+	assert.NotNil(t, err)
+
+	// 主节点回复了错误的交易，还原出的区块对不上默克尔根
+	_, req, err = replica.restore(prepare.ID, cp)
+	assert.Nil(t, err)
+	resp, err = leader.serve(req)
+	assert.Nil(t, err)
+	resp.Txs[1] = []byte("forged tx")
+	_, err = replica.fill(prepare.ID, resp)
+	assert.NotNil(t, err)
+
+	// 主节点不会回复不是自己提出的区块
+	req.BlockHash = []byte("other block")
+	_, err = leader.serve(req)
+	assert.NotNil(t, err)
+}
+
+func TestCompactRelayWrongRootHash(t *testing.T) {
+	txs := types.Txs{[]byte("a=1"), []byte("b=2"), []byte("c=3")}
+	prepare, _ := newCompactTestPrepare(t, txs)
+	cp := types.NewCompactPrepare(prepare)
+	cp.Block.RootHash = []byte("forged root hash")
+
+	pool := mapTxsLookup{}
+	for _, tx := range txs {
+		pool.add(tx)
+	}
+	restored, _, err := newCompactRelay(pool).restore(prepare.ID, cp)
+	assert.Nil(t, restored)
+	assert.NotNil(t, err)
+}
@@ -3,13 +3,13 @@ package consensus
 import "C"
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/common/service"
 	"github.com/232425wxy/meta--/config"
 	state2 "github.com/232425wxy/meta--/consensus/state"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12"
-	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/232425wxy/meta--/events"
 	"github.com/232425wxy/meta--/log"
 	"github.com/232425wxy/meta--/proto/pbtypes"
@@ -24,48 +24,70 @@ const msgQueueSize = 10000
 type Core struct {
 	service.BaseService
 	cfg                 *config.ConsensusConfig
-	privateKey          *bls12.PrivateKey // 为共识消息签名的私钥
+	signer              types.Signer // 为共识消息签名，私钥可能在本地，也可能在独立的签名进程里
 	publicKey           *bls12.PublicKey
 	id                  crypto.ID             // 自己的节点ID
 	blockExec           *state2.BlockExecutor // 创建区块和执行区块里的交易指令
 	state               *state2.State
+	evpool              state2.EvidencePool // 检测到的作恶证据会被放入证据池里
 	txsPool             *txspool.TxsPool
 	hasTxs              bool // hasTxs与交易池里的notifiedAvailable相互配合，保证主节点不会错失有交易数据到来的信号
 	eventBus            *events.EventBus
 	eventSwitch         *events.EventSwitch
 	stepInfo            *StepInfo
-	scheduledTicker     *TimeoutTicker
+	scheduledTicker     Ticker
+	viewTicker          Ticker // 共识超时定时器，在规定时间内没有确认当前高度的区块时，触发视图切换
 	internalMsgQueue    chan MessageInfo
 	externalMsgQueue    chan MessageInfo
 	prepareVotesQueue   chan *types.PrepareVote
 	preCommitVotesQueue chan *types.PreCommitVote
 	commitVotesQueue    chan *types.CommitVote
+	outboundReady       chan struct{} // 处理完一个事件后提醒Reactor取走Core产生的需要发送给其他节点的消息，见gossip.go
 	mu                  sync.RWMutex
 	cryptoBLS12         *bls12.CryptoBLS12
+	wal                 WAL
+	replayMode          bool                    // 正在重放WAL里的消息，此时除了结束标志不会再向WAL里写消息，也不会向内部通道发送消息或者通知Reactor
+	byzantine           *config.ByzantineConfig // 测试网里让节点在指定的高度故意作恶，为nil时节点总是诚实的
+	chained             *chainedState           // 链式HotStuff的共识状态，为nil时使用四阶段共识
+	twoPhase            bool                    // 使用两阶段共识，见twophase.go
+	observer            bool                    // 以观察者模式运行，只跟随共识执行区块，从不投票
 }
 
-func NewCore(cfg *config.ConsensusConfig, privateKey *bls12.PrivateKey, state *state2.State, blockExec *state2.BlockExecutor, txsPool *txspool.TxsPool, cryptoBLS12 *bls12.CryptoBLS12) *Core {
+func NewCore(cfg *config.ConsensusConfig, signer types.Signer, state *state2.State, blockExec *state2.BlockExecutor, txsPool *txspool.TxsPool, evpool state2.EvidencePool, cryptoBLS12 *bls12.CryptoBLS12) *Core {
+	if evpool == nil {
+		evpool = state2.EmptyEvidencePool{}
+	}
 	core := &Core{
 		BaseService:         *service.NewBaseService(nil, "Consensus_Core"),
 		cfg:                 cfg,
-		privateKey:          privateKey,
-		publicKey:           privateKey.PublicKey(),
-		id:                  privateKey.PublicKey().ToID(),
+		signer:              signer,
+		publicKey:           signer.PublicKey(),
+		id:                  signer.PublicKey().ToID(),
 		blockExec:           blockExec,
 		state:               state,
 		txsPool:             txsPool,
+		evpool:              evpool,
 		hasTxs:              false,
 		eventSwitch:         events.NewEventSwitch(),
 		stepInfo:            NewStepInfo(),
 		scheduledTicker:     NewTimeoutTicker(),
+		viewTicker:          NewTimeoutTicker(),
 		internalMsgQueue:    make(chan MessageInfo, msgQueueSize),
 		externalMsgQueue:    make(chan MessageInfo, msgQueueSize),
 		prepareVotesQueue:   make(chan *types.PrepareVote, msgQueueSize/100),
 		preCommitVotesQueue: make(chan *types.PreCommitVote, msgQueueSize/100),
 		commitVotesQueue:    make(chan *types.CommitVote, msgQueueSize/100),
+		outboundReady:       make(chan struct{}, 1),
 		cryptoBLS12:         cryptoBLS12,
+		wal:                 nilWAL{},
+	}
+	if cfg.Protocol == config.ProtocolChained {
+		core.chained = newChainedState()
 	}
+	core.twoPhase = cfg.Protocol == config.ProtocolTwoPhase
+	core.observer = cfg.IsObserver()
 	core.stepInfo.height = state.InitialHeight
+	core.updateToState(state)
 	return core
 }
 
@@ -83,9 +105,54 @@ func (c *Core) Start() error {
 	if err := c.scheduledTicker.Start(); err != nil {
 		return err
 	}
+	if err := c.viewTicker.Start(); err != nil {
+		return err
+	}
+	if err := c.loadWalFile(); err != nil {
+		return err
+	}
+	// 重放WAL里当前高度的消息，恢复到崩溃前的共识状态
+	if err := c.catchupReplay(c.stepInfo.height); err != nil {
+		c.Logger.Error("failed to catchup replay consensus WAL", "err", err, "height", c.stepInfo.height)
+		return err
+	}
 	go c.receiveRoutine()
 	//go c.testStatus()
 
+	return c.BaseService.Start()
+}
+
+func (c *Core) Stop() error {
+	_ = c.scheduledTicker.Stop()
+	_ = c.viewTicker.Stop()
+	if err := c.wal.Stop(); err != nil {
+		c.Logger.Error("failed to stop consensus WAL", "err", err)
+	}
+	return c.BaseService.Stop()
+}
+
+// loadWalFile 打开配置文件中指定的WAL文件，如果WAL是新建的，则写入上一个高度的结束标志，作为重放的起点。
+func (c *Core) loadWalFile() error {
+	walFile := c.cfg.WalFile()
+	if walFile == "" {
+		c.Logger.Info("consensus WAL is disabled")
+		return nil
+	}
+	wal, err := NewWAL(walFile)
+	if err != nil {
+		c.Logger.Error("failed to open consensus WAL", "wal", walFile, "err", err)
+		return err
+	}
+	wal.SetLogger(c.Logger.New("wal", walFile))
+	if err = wal.Start(); err != nil {
+		return err
+	}
+	if wal.IsEmpty() {
+		if err = wal.WriteSync(EndHeightMessage{Height: c.stepInfo.height - 1}); err != nil {
+			return err
+		}
+	}
+	c.wal = wal
 	return nil
 }
 
@@ -108,58 +175,92 @@ func (c *Core) receiveRoutine() {
 				c.hasTxs = true
 			}
 		case tock := <-c.scheduledTicker.TockChan():
+			_ = c.wal.Write(tock)
 			c.handleScheduled(tock, *c.stepInfo)
+		case tock := <-c.viewTicker.TockChan():
+			_ = c.wal.Write(tock)
+			c.handleViewTimeout(tock)
 		case mi := <-c.internalMsgQueue:
+			// 自己产生的消息必须在落盘之后才能被处理，否则节点崩溃重启后可能会对同一高度的不同区块进行投票
+			if err := c.wal.WriteSync(mi); err != nil {
+				panic(fmt.Sprintf("failed to write %T msg to consensus WAL due to %v; check your file system and restart the node", mi.Msg, err))
+			}
 			c.handleMsg(mi)
 		case mi := <-c.externalMsgQueue:
+			_ = c.wal.Write(mi)
 			c.handleMsg(mi)
 		case <-c.WaitStop():
 			return
 		}
+		c.notifyOutbound()
 	}
 }
 
 func (c *Core) handleAvailableTxs() {
+	defer c.notifyOutbound()
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.isLeader() {
-		switch c.stepInfo.step {
-		case NewHeightStep, NewRoundStep:
-			c.proposePrepareMsg(c.stepInfo.height, c.stepInfo.round)
-		case DecideStep:
-			// 当前高度的主节点收集齐其他节点发来的NextView消息后，本身的区块高度状态会自增1，
-			// 凭借isLeader方法可以判定自己就是主节点，此外，收集齐其他节点发来的NextView消
-			// 息后，主节点会进入1秒的超时等待状态，等待将状态从DecideStep切换为NewHeightStep，
-			// 如果在这个阶段获得了需要打包交易数据的提醒，则超前进入打包区块的超时等待阶段。
-			c.scheduleStep(time.Second, c.stepInfo.height, c.stepInfo.round, PrepareStep)
+	if c.chained != nil {
+		c.handleChainedTxs()
+		return
+	}
+	if c.stepInfo.step != c.decideStep() {
+		// 交易池里有交易数据了，如果主节点迟迟不提出区块，则需要进行视图切换
+		c.schedulePacemaker()
+	}
+	switch c.stepInfo.step {
+	case NewHeightStep, NewRoundStep:
+		if c.isLeader() {
+			c.propose(c.stepInfo.height, c.stepInfo.round)
+		}
+	case DecideStep, TwoPhaseDecideStep:
+		// 下一个高度的主节点收集齐其他节点发来的NextView消息后，本身的区块高度状态会自增1，
+		// 凭借isHeightLeader方法可以判定自己就是主节点，此外，收集齐其他节点发来的NextView消
+		// 息后，主节点会进入NewHeightDelay的超时等待状态，等待将状态从DecideStep切换为NewHeightStep，
+		// 如果在这个阶段获得了需要打包交易数据的提醒，则超前进入打包区块的超时等待阶段。
+		if c.isHeightLeader() && c.state.LastBlockHeight < c.stepInfo.height {
+			c.scheduleStep(c.state.ConsensusParams.Timeout.NewHeightDelay, c.stepInfo.height, c.stepInfo.round, c.proposeStep())
 		}
 	}
 }
 
-func (c *Core) handleScheduled(info timeoutInfo, stepInfo StepInfo) {
+func (c *Core) handleScheduled(info TimeoutInfo, stepInfo StepInfo) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if info.Height != c.stepInfo.height || (info.Step != NewHeightStep && info.Round != c.stepInfo.round) {
+		// 已经进入了新的高度或者新的一轮，忽略过期的超时事件
+		return
+	}
+
 	switch info.Step {
 	case NewHeightStep:
 		c.stepInfo.Reset()
+		if c.hasTxs {
+			c.schedulePacemaker()
+		}
 		if c.hasTxs && c.isLeader() {
 			c.mu.Unlock()
 			c.handleAvailableTxs()
 			c.mu.Lock()
 		}
-	case PrepareStep:
+	case PrepareStep, TwoPhaseProposeStep:
 		// 在从DecideStep状态转为NewHeightStep状态的过程中收到了交易池里有交易数据的信号，那么会
 		// 重新设置一个超时时间，从DecideStep状态直接进入到PrepareStep，提出新的区块数据。
 		c.stepInfo.Reset()
-		c.proposePrepareMsg(c.stepInfo.height, c.stepInfo.round)
+		c.schedulePacemaker()
+		c.propose(c.stepInfo.height, c.stepInfo.round)
 	case PreCommitStep:
 		c.proposePreCommitMsg(c.stepInfo.height, c.stepInfo.round)
 	case CommitStep:
 		c.proposeCommitMsg(c.stepInfo.height, c.stepInfo.round)
 	case DecideStep:
 		c.proposeDecideMsg(c.stepInfo.height, c.stepInfo.round)
+	case TwoPhaseLockStep:
+		c.proposeTwoPhaseLock(c.stepInfo.height, c.stepInfo.round)
+	case TwoPhaseDecideStep:
+		c.proposeTwoPhaseDecide(c.stepInfo.height, c.stepInfo.round)
 	}
 }
 
@@ -168,6 +269,9 @@ func (c *Core) handleMsg(mi MessageInfo) {
 	defer c.mu.Unlock()
 	var err error
 
+	if c.twoPhase && c.handleTwoPhaseMsg(mi) {
+		return
+	}
 	switch msg := mi.Msg.(type) {
 	case *types.NextView:
 		err = c.handleNextView(msg)
@@ -175,6 +279,12 @@ func (c *Core) handleMsg(mi MessageInfo) {
 			c.Logger.Error("failed to handle NextView message", "err", err)
 			err = nil
 		}
+	case *types.NewView:
+		err = c.handleNewView(msg)
+		if err != nil {
+			c.Logger.Error("failed to handle NewView message", "err", err)
+			err = nil
+		}
 	case *types.Prepare:
 		err = c.handlePrepare(msg)
 		if err != nil {
@@ -192,7 +302,7 @@ func (c *Core) handleMsg(mi MessageInfo) {
 			select {
 			case c.prepareVotesQueue <- msg:
 			default:
-				go func() { c.prepareVotesQueue <- msg }()
+				go func() { c.prepareVotesQueue <- msg; c.notifyOutbound() }()
 			}
 		}
 	case *types.PreCommit:
@@ -212,7 +322,7 @@ func (c *Core) handleMsg(mi MessageInfo) {
 			select {
 			case c.preCommitVotesQueue <- msg:
 			default:
-				go func() { c.preCommitVotesQueue <- msg }()
+				go func() { c.preCommitVotesQueue <- msg; c.notifyOutbound() }()
 			}
 		}
 	case *types.Commit:
@@ -232,15 +342,53 @@ func (c *Core) handleMsg(mi MessageInfo) {
 			select {
 			case c.commitVotesQueue <- msg:
 			default:
-				go func() { c.commitVotesQueue <- msg }()
+				go func() { c.commitVotesQueue <- msg; c.notifyOutbound() }()
 			}
 		}
+	case *types.AggregateVote:
+		err = c.handleAggregateVote(msg)
+		if err != nil {
+			c.Logger.Error("failed to handle AggregateVote message", "err", err)
+			err = nil
+		}
 	case *types.Decide:
 		err = c.handleDecide(msg)
 		if err != nil {
 			c.Logger.Error("failed to handle Decide message", "err", err)
 			err = nil
 		}
+	case *types.BlockResponse:
+		err = c.handleBlockResponse(msg)
+		if err != nil {
+			c.Logger.Error("failed to handle BlockResponse message", "err", err)
+			err = nil
+		}
+	case *types.ChainedProposal:
+		err = c.handleChainedProposal(msg, mi.NodeID == "")
+		if err != nil {
+			c.Logger.Error("failed to handle ChainedProposal message", "err", err)
+			err = nil
+		}
+	case *types.ChainedVote:
+		if mi.NodeID != "" { // 这表示自己是下一个视图的主节点，收到了其他副本节点发送来的投票
+			err = c.handleChainedVote(msg)
+			if err != nil {
+				c.Logger.Error("failed to handle ChainedVote message", "err", err)
+				err = nil
+			}
+		} else {
+			c.sendChainedVote(msg)
+		}
+	case *types.ChainedNewView:
+		if mi.NodeID != "" {
+			err = c.handleChainedNewView(msg)
+			if err != nil {
+				c.Logger.Error("failed to handle ChainedNewView message", "err", err)
+				err = nil
+			}
+		} else {
+			c.sendChainedNewView(msg)
+		}
 	default:
 		c.Logger.Error("unknown message type", "type", fmt.Sprintf("%T", msg))
 		return
@@ -248,10 +396,6 @@ func (c *Core) handleMsg(mi MessageInfo) {
 }
 
 func (c *Core) handleNextView(view *types.NextView) error {
-	if !c.isLeader() {
-		// 只有主节点才会处理其他节点发送过来的NextView消息
-		return nil
-	}
 	if view.Type != pbtypes.NextViewType {
 		return fmt.Errorf("want message type %s, but got %s", pbtypes.NextViewType.String(), view.Type.String())
 	}
@@ -262,30 +406,130 @@ func (c *Core) handleNextView(view *types.NextView) error {
 		return fmt.Errorf("validator %s sent invalid NextView message to me, because \"height\" is wrong", view.ID)
 	}
 	c.Logger.Trace("receive a valid NextView message", "from", view.ID)
+	// 主节点是轮换的，自己可能还没有执行完当前高度的区块，所以不知道自己是不是下一个高度的主节点，
+	// 因此先将NextView消息收集起来，等执行完区块后再做判断
 	c.stepInfo.AddNextView(view)
-	if c.stepInfo.CheckCollectNextViewIsComplete(c.state.Validators) {
+	c.tryEnterNextHeight()
+	return nil
+}
+
+// tryEnterNextHeight 下一个高度的主节点在执行完当前高度的区块，并且收集到足够多的NextView消息后，进入下一个高度。
+func (c *Core) tryEnterNextHeight() {
+	if c.state.LastBlockHeight != c.stepInfo.height || !c.isHeightLeader() {
+		return
+	}
+	if c.stepInfo.CheckCollectNextViewIsComplete(c.state.Validators, c.id) {
 		c.Logger.Debug("receive enough NextView messages", "height", c.stepInfo.height)
 		c.stepInfo.height += 1
 		c.scheduleNewHeight(c.stepInfo)
 	}
+}
+
+// handleNewView 处理视图切换消息，收集到足够多的NewView消息后，进入新的一轮，如果自己是新一轮的主节点，则基于收集到
+// 的最高QC继续提出区块。
+func (c *Core) handleNewView(view *types.NewView) error {
+	if view.Type != pbtypes.NewViewType {
+		return fmt.Errorf("want message type %s, but got %s", pbtypes.NewViewType.String(), view.Type.String())
+	}
+	if err := view.ValidateBasic(); err != nil {
+		return fmt.Errorf("validator %s sent invalid NewView message to me: %q", view.ID, err)
+	}
+	if view.Height != c.stepInfo.height || view.Round < c.stepInfo.round {
+		return nil
+	}
+	validator := c.state.Validators.GetValidatorByID(view.ID)
+	if validator == nil {
+		return fmt.Errorf("an unknown validator %s sent NewView message to me", view.ID)
+	}
+	if view.Signature.Signer() != view.ID || !validator.PublicKey.Verify(view.Signature, view.ValueHash()) {
+		return fmt.Errorf("validator %s sent NewView message with invalid signature to me", view.ID)
+	}
+	if view.HighQC != nil {
+		if view.HighQCBlock.ChameleonHash == nil || view.HighQC.AggregateSignature == nil {
+			return fmt.Errorf("validator %s sent NewView message with incomplete high QC to me", view.ID)
+		}
+		hash := types.GeneratePreCommitValueHash(view.Height, view.HighQCRound, view.HighQCBlock.ChameleonHash.Hash)
+		if !bytes.Equal(hash, view.HighQC.ValueHash) {
+			return fmt.Errorf("the high QC in NewView message from %s does not certify the carried block", view.ID)
+		}
+		if err := c.state.Validators.VerifyAggregateSignature(view.HighQC.AggregateSignature, view.HighQC.ValueHash); err != nil {
+			return fmt.Errorf("the high QC in NewView message from %s has invalid aggregated signature: %w", view.ID, err)
+		}
+		// QC只证明了区块的变色龙哈希，区块本身也要验证：主节点会重新提出highQC的区块，提交时也会执行它
+		if err := view.HighQCBlock.ValidateBasic(); err != nil {
+			return fmt.Errorf("the high QC block in NewView message from %s is invalid: %w", view.ID, err)
+		}
+		if err := c.state.Chameleon.VerifyHash(view.HighQCBlock); err != nil {
+			return fmt.Errorf("the high QC block in NewView message from %s does not match its chameleon hash: %w", view.ID, err)
+		}
+		c.stepInfo.UpdateHighQC(view.HighQCRound, view.HighQCBlock, view.HighQC)
+	}
+	c.Logger.Trace("receive a valid NewView message", "from", view.ID, "round", view.Round)
+	c.stepInfo.AddNewView(view)
+	if !c.stepInfo.CheckCollectNewViewIsComplete(view.Round, c.state.Validators) {
+		return nil
+	}
+	if view.Round > c.stepInfo.round {
+		// 大多数节点都已经进入了新的一轮，自己也跟着进入新的一轮
+		c.enterNewRound(c.stepInfo.height, view.Round)
+	}
+	if c.isLeader() && c.stepInfo.round == view.Round && c.stepInfo.step == NewRoundStep {
+		c.Logger.Info("receive enough NewView messages, resume consensus from the highest QC", "height", c.stepInfo.height, "round", c.stepInfo.round, "high_qc_round", c.stepInfo.highQCRound)
+		c.stepInfo.block = c.stepInfo.highQCBlock
+		c.proposePrepareMsg(c.stepInfo.height, c.stepInfo.round)
+	}
 	return nil
 }
 
 func (c *Core) handlePrepare(prepare *types.Prepare) error {
-	if prepare.Height != c.stepInfo.height {
+	if prepare.Height != c.stepInfo.height || prepare.Round < c.stepInfo.round {
 		return nil
 	}
-	hash := make([]byte, len(prepare.Block.ChameleonHash.Hash))
-	copy(hash[:], prepare.Block.ChameleonHash.Hash)
-	ok := c.state.Validators.GetLeader(c.stepInfo.round).PublicKey.Verify(prepare.Signature, hash)
+	if err := prepare.ValidateBasic(); err != nil {
+		return err
+	}
+	if prepare.Block == nil || prepare.Block.ChameleonHash == nil || prepare.Signature == nil {
+		return fmt.Errorf("leader %s sent an incomplete prepare message to me", prepare.ID)
+	}
+	leader := c.state.Validators.GetLeader(prepare.Round)
+	ok := prepare.Signature.Signer() == leader.ID && leader.PublicKey.Verify(prepare.Signature, types.GeneratePrepareSignBytes(prepare.Height, prepare.Round, prepare.Block.ChameleonHash.Hash))
 	if !ok {
-		if c.isLeader() {
-			panic(fmt.Sprintf("%s: \"why I created an invalid Prepare message?\" %d", c.state.Validators.GetLeader(c.stepInfo.round).PublicKey.ToID(), c.stepInfo.round))
+		if leader.ID == c.publicKey.ToID() {
+			panic(fmt.Sprintf("%s: \"why I created an invalid Prepare message?\" %d", leader.ID, prepare.Round))
+		}
+		return fmt.Errorf("leader %s sent an invalid prepare message to me", leader.ID)
+	}
+	if proposal := c.stepInfo.proposal; proposal != nil && proposal.Round == prepare.Round {
+		if !bytes.Equal(proposal.Block.ChameleonHash.Hash, prepare.Block.ChameleonHash.Hash) {
+			// 主节点在同一轮次提出了两个不同的区块
+			c.reportEvidence(types.NewConflictingPrepareEvidence(proposal, prepare, time.Now()))
+			return fmt.Errorf("leader %s proposed conflicting blocks at height %d round %d", leader.ID, prepare.Height, prepare.Round)
+		}
+		return nil
+	}
+	if err := c.blockExec.ValidateBlock(c.state, prepare.Block, c.prepareProposer(prepare)); err != nil {
+		// 拒绝为不合法的区块投票，并且不再等待超时，直接切换到下一个视图
+		c.Logger.Warn("reject proposal", "height", prepare.Height, "round", prepare.Round, "leader", leader.ID, "err", err)
+		if prepare.Round > c.stepInfo.round {
+			c.stepInfo.round = prepare.Round
 		}
-		return fmt.Errorf("leader %s sent an invalid prepare message to me", c.state.Validators.GetLeader(c.stepInfo.round).ID)
+		c.changeView()
+		return fmt.Errorf("leader %s proposed an invalid block at height %d round %d: %w", leader.ID, prepare.Height, prepare.Round, err)
+	}
+	if err := c.checkSafeProposal(prepare); err != nil {
+		return err
 	}
+	if prepare.HighQC != nil {
+		c.stepInfo.UpdateHighQC(prepare.HighQC.Round, prepare.Block, prepare.HighQC)
+	}
+	if prepare.Round > c.stepInfo.round {
+		// 主节点已经进入了新的一轮，而自己还没有进入，则跟随主节点进入新的一轮
+		c.enterNewRound(c.stepInfo.height, prepare.Round)
+	}
+	c.stepInfo.proposal = prepare
+	c.schedulePacemaker()
 	if c.isLeader() {
-		c.stepInfo.prepare <- prepare // reactor循环检测c.stepInfo.prepare是否有东西，有的话就发送给其他节点
+		c.stepInfo.prepare <- prepare // Reactor被提醒后从c.stepInfo.prepare里取走消息，再发送给其他节点
 	}
 	c.stepInfo.block = prepare.Block
 	c.enterPrepareVoteStep(c.stepInfo.height, c.stepInfo.round)
@@ -297,31 +541,21 @@ func (c *Core) handlePrepareVote(vote *types.PrepareVote) error {
 		// 不是主节点，直接忽略
 		return nil
 	}
-	if vote.Vote.Height != c.stepInfo.height {
-		return fmt.Errorf("invalid PrepareVote message, my height: %d, message's height: %d", c.stepInfo.height, vote.Vote.Height)
-	}
-	if vote.Vote.VoteType != pbtypes.PrepareVoteType {
-		return fmt.Errorf("invalid vote type, want %s, got %s", pbtypes.PrepareVoteType.String(), vote.Vote.VoteType.String())
+	if err := c.verifyVote(vote.Vote, pbtypes.PrepareVoteType); err != nil {
+		return err
 	}
-	validator := c.state.Validators.GetValidatorByID(vote.Vote.Signature.Signer())
-	if validator == nil {
-		return fmt.Errorf("cannot find this validator: %s", vote.Vote.Signature.Signer())
+	if vote.Vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
 	}
-	valueHash := types.GeneratePrepareVoteValueHash(c.stepInfo.block.ChameleonHash.Hash)
-	equal := bytes.Equal(valueHash[:], vote.Vote.ValueHash[:])
-	if !equal {
+	valueHash := types.GeneratePrepareVoteValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(valueHash, vote.Vote.ValueHash) {
 		return fmt.Errorf("validator %s vote for different block", vote.Vote.Signature.Signer())
 	}
-	ok := validator.PublicKey.Verify(vote.Vote.Signature, vote.Vote.ValueHash)
-	if !ok {
-		return fmt.Errorf("validator %s sent invalid PrepareVote message to me", vote.Vote.Signature.Signer())
-	}
 	c.stepInfo.voteSet.AddPrepareVote(c.stepInfo.round, vote)
-	ok = c.stepInfo.voteSet.CheckPrepareVoteIsComplete(c.stepInfo.round, c.state.Validators)
-	if ok { // TODO 这里需要搞一个超时机制，就是哪怕收到了足够数量的投票，也不要立即去组装门陷签名，防止接下来还会有投票过来
+	if c.stepInfo.voteSet.CheckPrepareVoteIsComplete(c.stepInfo.round, c.state.Validators) { // TODO 这里需要搞一个超时机制，就是哪怕收到了足够数量的投票，也不要立即去组装门陷签名，防止接下来还会有投票过来
 		// 收集齐了副本节点对Prepare消息的投票，那么开始构造PreCommit消息
 		//c.proposePreCommitMsg(c.stepInfo.height, c.stepInfo.round)
-		c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, PreCommitStep)
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, PreCommitStep)
 	}
 	return nil
 }
@@ -330,21 +564,25 @@ func (c *Core) handlePreCommit(preCommit *types.PreCommit) error {
 	if preCommit.Height != c.stepInfo.height {
 		return nil
 	}
-	if c.stepInfo.block == nil {
-		return nil
-	}
 	if preCommit.ID != c.state.Validators.GetLeader(c.stepInfo.round).ID {
 		return fmt.Errorf("PreCommit message is not from leader %s at height %d", c.state.Validators.GetLeader(c.stepInfo.round).ID, c.stepInfo.height)
 	}
-	hash := types.GeneratePreCommitValueHash(c.stepInfo.block.ChameleonHash.Hash)
+	if preCommit.Round != c.stepInfo.round {
+		return nil
+	}
+	if c.stepInfo.block == nil {
+		// 错过了Prepare消息，向其他节点索要QC所认证的区块
+		return c.fetchBlock(preCommit, preCommit.Height, preCommit.Round, preCommit.BlockHash, preCommit.ValueHash, types.GeneratePreCommitValueHash(preCommit.Height, preCommit.Round, preCommit.BlockHash), preCommit.AggregateSignature)
+	}
+	hash := types.GeneratePreCommitValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
 	equal := bytes.Equal(hash[:], preCommit.ValueHash[:])
 	if !equal {
 		return fmt.Errorf("leader %s sent invalid PreCommit message to me", preCommit.ID)
 	}
-	ok := c.cryptoBLS12.VerifyThresholdSignature(preCommit.AggregateSignature, preCommit.ValueHash)
-	if !ok {
-		return fmt.Errorf("leader %s sent invalid PreCommit message to me, aggregated signature is wrong", preCommit.ID)
+	if err := c.state.Validators.VerifyAggregateSignature(preCommit.AggregateSignature, preCommit.ValueHash); err != nil {
+		return fmt.Errorf("leader %s sent invalid PreCommit message to me: %w", preCommit.ID, err)
 	}
+	c.stepInfo.UpdateHighQC(preCommit.Round, c.stepInfo.block, preCommit)
 	if c.isLeader() {
 		c.stepInfo.preCommit <- preCommit
 	}
@@ -356,30 +594,20 @@ func (c *Core) handlePreCommitVote(vote *types.PreCommitVote) error {
 	if !c.isLeader() {
 		return nil
 	}
-	if vote.Vote.Height != c.stepInfo.height {
-		return fmt.Errorf("invalid PreCommitVote message, my height: %d, message's height: %d", c.stepInfo.height, vote.Vote.Height)
-	}
-	if vote.Vote.VoteType != pbtypes.PreCommitVoteType {
-		return fmt.Errorf("invalid vote type, want %s, got %s", pbtypes.PreCommitVoteType.String(), vote.Vote.VoteType.String())
+	if err := c.verifyVote(vote.Vote, pbtypes.PreCommitVoteType); err != nil {
+		return err
 	}
-	validator := c.state.Validators.GetValidatorByID(vote.Vote.Signature.Signer())
-	if validator == nil {
-		return fmt.Errorf("cannot find this validator: %s", vote.Vote.Signature.Signer())
+	if vote.Vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
 	}
-	valueHash := types.GeneratePreCommitVoteValueHash(c.stepInfo.block.ChameleonHash.Hash)
-	equal := bytes.Equal(valueHash[:], vote.Vote.ValueHash[:])
-	if !equal {
+	valueHash := types.GeneratePreCommitVoteValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(valueHash, vote.Vote.ValueHash) {
 		return fmt.Errorf("validator %s vote for different block", vote.Vote.Signature.Signer())
 	}
-	ok := validator.PublicKey.Verify(vote.Vote.Signature, vote.Vote.ValueHash)
-	if !ok {
-		return fmt.Errorf("validator %s sent invalid PreCommitVote message to me", vote.Vote.Signature.Signer())
-	}
 	c.stepInfo.voteSet.AddPreCommitVote(c.stepInfo.round, vote)
-	ok = c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(c.stepInfo.round, c.state.Validators)
-	if ok {
+	if c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(c.stepInfo.round, c.state.Validators) {
 		//c.proposeCommitMsg(c.stepInfo.height, c.stepInfo.round)
-		c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, CommitStep)
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, CommitStep)
 	}
 	return nil
 }
@@ -388,20 +616,25 @@ func (c *Core) handleCommit(commit *types.Commit) error {
 	if c.stepInfo.height != commit.Height {
 		return nil
 	}
-	if c.stepInfo.block == nil {
-		return nil
-	}
 	if commit.ID != c.state.Validators.GetLeader(c.stepInfo.round).ID {
 		return fmt.Errorf("Commit message is not from leader %s at height %d", c.state.Validators.GetLeader(c.stepInfo.round).ID, c.stepInfo.height)
 	}
-	hash := types.GenerateCommitValueHash(c.stepInfo.block.ChameleonHash.Hash)
+	if commit.Round != c.stepInfo.round {
+		return nil
+	}
+	if c.stepInfo.block == nil {
+		return c.fetchBlock(commit, commit.Height, commit.Round, commit.BlockHash, commit.ValueHash, types.GenerateCommitValueHash(commit.Height, commit.Round, commit.BlockHash), commit.AggregateSignature)
+	}
+	hash := types.GenerateCommitValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
 	equal := bytes.Equal(hash[:], commit.ValueHash[:])
 	if !equal {
 		return fmt.Errorf("leader %s sent invalid Commit message to me", commit.ID)
 	}
-	ok := c.cryptoBLS12.VerifyThresholdSignature(commit.AggregateSignature, hash)
-	if !ok {
-		return fmt.Errorf("leader %s sent invalid Commit message to me, aggregated signature is wrong", commit.ID)
+	if err := c.state.Validators.VerifyAggregateSignature(commit.AggregateSignature, hash); err != nil {
+		return fmt.Errorf("leader %s sent invalid Commit message to me: %w", commit.ID, err)
+	}
+	if c.stepInfo.Lock(commit.Round, c.stepInfo.block, commit) {
+		c.Logger.Debug("lock on block", "height", commit.Height, "round", commit.Round, "block", fmt.Sprintf("%X", c.stepInfo.block.ChameleonHash.Hash))
 	}
 	if c.isLeader() {
 		c.stepInfo.commit <- commit
@@ -414,80 +647,155 @@ func (c *Core) handleCommitVote(vote *types.CommitVote) error {
 	if !c.isLeader() {
 		return nil
 	}
-	if vote.Vote.Height != c.stepInfo.height {
-		return fmt.Errorf("invalid CommitVote message, my height: %d, message's height: %d", c.stepInfo.height, vote.Vote.Height)
-	}
-	if vote.Vote.VoteType != pbtypes.CommitVoteType {
-		return fmt.Errorf("invalid vote type, want %s, got %s", pbtypes.CommitVoteType.String(), vote.Vote.VoteType.String())
+	if err := c.verifyVote(vote.Vote, pbtypes.CommitVoteType); err != nil {
+		return err
 	}
-	validator := c.state.Validators.GetValidatorByID(vote.Vote.Signature.Signer())
-	if validator == nil {
-		return fmt.Errorf("cannot find this validator: %s", vote.Vote.Signature.Signer())
+	if vote.Vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
 	}
-	valueHash := types.GenerateCommitVoteValueHash(c.stepInfo.block.ChameleonHash.Hash)
-	equal := bytes.Equal(valueHash[:], vote.Vote.ValueHash[:])
-	if !equal {
+	valueHash := types.GenerateCommitVoteValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(valueHash, vote.Vote.ValueHash) {
 		return fmt.Errorf("validator %s vote for different block", vote.Vote.Signature.Signer())
 	}
-	ok := validator.PublicKey.Verify(vote.Vote.Signature, vote.Vote.ValueHash)
-	if !ok {
-		return fmt.Errorf("validator %s sent invalid CommitVote message to me", vote.Vote.Signature.Signer())
-	}
 	c.stepInfo.voteSet.AddCommitVote(c.stepInfo.round, vote)
-	ok = c.stepInfo.voteSet.CheckCommitVoteIsComplete(c.stepInfo.round, c.state.Validators)
-	if ok {
-		c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, DecideStep)
+	if c.stepInfo.voteSet.CheckCommitVoteIsComplete(c.stepInfo.round, c.state.Validators) {
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, DecideStep)
 	}
 	return nil
 }
 
 func (c *Core) handleDecide(decide *types.Decide) error {
-	if c.stepInfo.height != decide.Height {
+	if c.stepInfo.height != decide.Height || c.state.LastBlockHeight >= decide.Height {
+		// 下一个高度的主节点在收集齐NextView消息之前不会进入新的高度，它可能会再次收到其他节点转交的Decide消息
 		return nil
 	}
-	if c.stepInfo.block == nil {
-		return nil
+	if c.stepInfo.block == nil || !bytes.Equal(c.decideValueHash(decide.Height, decide.Round, c.stepInfo.block.ChameleonHash.Hash), decide.ValueHash) {
+		// 自己可能因为共识超时已经进入了新的一轮，丢掉了上一轮的区块，但是highQC所认证的区块依然可能是被确认的区块
+		if c.stepInfo.highQCBlock != nil && bytes.Equal(c.decideValueHash(decide.Height, decide.Round, c.stepInfo.highQCBlock.ChameleonHash.Hash), decide.ValueHash) {
+			c.stepInfo.block = c.stepInfo.highQCBlock
+		} else {
+			// 自己没有被确认的区块，向其他节点索要
+			return c.fetchBlock(decide, decide.Height, decide.Round, decide.BlockHash, decide.ValueHash, c.decideValueHash(decide.Height, decide.Round, decide.BlockHash), decide.AggregateSignature)
+		}
 	}
-	if decide.ID != c.state.Validators.GetLeader(c.stepInfo.round).ID {
-		return fmt.Errorf("Decide message is not from leader %s at height %d", c.state.Validators.GetLeader(c.stepInfo.round).ID, c.stepInfo.height)
+	if c.state.Validators.GetValidatorByID(decide.ID) == nil {
+		// Decide消息有可能来自上一轮的主节点，消息的合法性由聚合签名来保证
+		return fmt.Errorf("Decide message is from an unknown validator %s at height %d", decide.ID, c.stepInfo.height)
 	}
-	hash := types.GenerateDecideValueHash(c.stepInfo.block.ChameleonHash.Hash)
+	hash := c.decideValueHash(decide.Height, decide.Round, c.stepInfo.block.ChameleonHash.Hash)
 	equal := bytes.Equal(hash[:], decide.ValueHash[:])
 	if !equal {
 		return fmt.Errorf("leader %s sent invalid Decide message to me", decide.ID)
 	}
-	ok := c.cryptoBLS12.VerifyThresholdSignature(decide.AggregateSignature, hash)
-	if !ok {
-		return fmt.Errorf("leader %s sent invalid Decide message to me, aggregated signature is wrong", decide.ID)
+	if err := c.state.Validators.VerifyAggregateSignature(decide.AggregateSignature, hash); err != nil {
+		return fmt.Errorf("leader %s sent invalid Decide message to me: %w", decide.ID, err)
 	}
 	if c.isLeader() {
-		c.stepInfo.decide <- decide
+		if c.misbehave(config.WithholdDecide) {
+			c.Logger.Warn("byzantine: withhold Decide message", "height", decide.Height, "round", decide.Round)
+		} else {
+			c.stepInfo.decide <- decide
+		}
 	}
 	if !c.isLeader() {
-		c.stepInfo.step = DecideStep
+		// Decide消息就是区块的提交证明，把它转交给还没有收到它的节点，这样没有与主节点直接相连的节点（例如观察者）
+		// 也能确认区块
+		select {
+		case c.stepInfo.decide <- decide:
+		default:
+		}
+		c.stepInfo.step = c.decideStep()
 		c.newStep()
 	}
-	c.applyBlock()
+	c.applyBlock(types.NewCommitBlock(c.stepInfo.block, decide))
 	return nil
 }
 
+// enterNewRound 在共识超时或者得知其他节点已经进入新的一轮后，进入同一高度下的新一轮共识，新一轮的主节点由
+// ValidatorSet.GetLeader(round)决定。
 func (c *Core) enterNewRound(height int64, round int16) {
-	if c.stepInfo.height != height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step != NewHeightStep) {
-		//logger.Warn("entering NEW ROUND step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	if c.stepInfo.height != height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step != ConsensusTimeout) {
+		c.Logger.Warn("entering NEW_ROUND step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
 		return
 	}
-	c.stepInfo.round = round + 1
-	c.stepInfo.step = NewRoundStep
-	//c.Logger.Info(">>> NEW ROUND step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
-	if round != 1 {
-		c.stepInfo.block = nil
-		c.stepInfo.prepare = nil
-		c.stepInfo.preCommit = nil
-		c.stepInfo.commit = nil
-		c.stepInfo.decide = nil
+	c.stepInfo.EnterRound(round)
+	c.Logger.Info("=> NEW_ROUND step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step), "leader", c.state.Validators.GetLeader(round).ID)
+	c.newStep()
+	c.schedulePacemaker()
+}
+
+// handleViewTimeout 在规定时间内没有确认当前高度的区块，说明当前轮次的主节点可能已经宕机或者停滞，此时进入下一轮，
+// 并广播携带自己所知道的最高QC的NewView消息。
+func (c *Core) handleViewTimeout(info TimeoutInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.isValidator() {
+		// 不是验证者的节点不参与视图切换，它跟随主节点的消息进入新的一轮
+		return
+	}
+	if c.chained != nil {
+		c.handleChainedTimeout(info)
+		return
+	}
+	if info.Height != c.stepInfo.height || info.Round != c.stepInfo.round {
+		return
+	}
+	if c.state.LastBlockHeight >= info.Height || c.stepInfo.step == c.decideStep() {
+		// 当前高度的区块已经被确认了，正在等待进入下一个高度
+		return
 	}
-	//round += 1
-	//c.proposePrepareMsg(height, round)
+	if !c.replayMode && !c.hasTxs && c.stepInfo.block == nil && c.stepInfo.highQC == nil {
+		// 没有需要达成共识的数据，不需要切换视图
+		return
+	}
+	c.Logger.Warn("consensus timeout, change view", "height", c.stepInfo.height, "old_round", c.stepInfo.round, "new_round", c.stepInfo.round+1, "timeout", info.Duration)
+	if c.twoPhase {
+		c.timeoutTwoPhase()
+		return
+	}
+	c.changeView()
+}
+
+// prepareProposer 返回prepare消息里的区块应该由谁提出。主节点重新提出被highQC认证过的区块时，区块头里的提出者是最初提出该
+// 区块的那一轮的主节点，否则区块必须由prepare所在轮次的主节点提出。
+func (c *Core) prepareProposer(prepare *types.Prepare) crypto.ID {
+	var certified int16
+	if qc := prepare.HighQC; qc != nil && bytes.Equal(qc.ValueHash, types.GeneratePreCommitValueHash(prepare.Height, qc.Round, prepare.Block.ChameleonHash.Hash)) {
+		certified = qc.Round
+	}
+	return c.proposerOf(prepare.Block, prepare.Round, certified)
+}
+
+// proposerOf 被认证过的区块可能是在certified轮次及之前的任意一轮里提出的，区块的提出者是其中某一轮的主节点时返回它，
+// 否则返回round轮次的主节点。
+func (c *Core) proposerOf(block *types.Block, round, certified int16) crypto.ID {
+	for r := int16(1); r <= certified; r++ {
+		if leader := c.state.Validators.GetLeader(r); leader.ID == block.Header.Proposer {
+			return leader.ID
+		}
+	}
+	return c.state.Validators.GetLeader(round).ID
+}
+
+// changeView 放弃当前轮次，进入下一轮，并广播携带自己所知道的最高QC的NewView消息。
+func (c *Core) changeView() {
+	round := c.stepInfo.round + 1
+	c.stepInfo.round = round
+	c.stepInfo.step = ConsensusTimeout
+	c.newStep()
+	if !c.isValidator() {
+		c.enterNewRound(c.stepInfo.height, round)
+		return
+	}
+	view, err := types.NewNewView(c.stepInfo.height, round, c.stepInfo.highQCRound, c.stepInfo.highQCBlock, c.stepInfo.highQC, c.signer)
+	c.enterNewRound(c.stepInfo.height, round)
+	if err != nil {
+		c.Logger.Error("failed to sign NewView message", "err", err)
+		return
+	}
+	c.fireEvent(events.EventNewView, view)
+	c.sendInternalMessage(MessageInfo{Msg: view, NodeID: ""})
 }
 
 func (c *Core) proposePrepareMsg(height int64, round int16) {
@@ -518,9 +826,21 @@ func (c *Core) proposePrepareMsg(height int64, round int16) {
 				}
 			}
 		}
-		prepare := types.NewPrepare(height, block, c.publicKey.ToID(), c.privateKey)
+		var highQC *types.PreCommit
+		if c.stepInfo.highQCBlock != nil && bytes.Equal(c.stepInfo.highQCBlock.ChameleonHash.Hash, block.ChameleonHash.Hash) {
+			// 重新提出highQC所认证的区块，携带highQC，使锁定在旧区块上的副本节点可以解锁
+			highQC = c.stepInfo.highQC
+		}
+		prepare, err := types.NewPrepare(height, round, block, highQC, c.signer)
+		if err != nil {
+			c.Logger.Error("PREPARE step: failed to sign Prepare message", "err", err)
+			return
+		}
 		// 将Prepare消息发送到内部的消息通道里，这样在recvRoutine进程中可以捕获该消息，然后就会去处理该消息
 		c.sendInternalMessage(MessageInfo{Msg: prepare, NodeID: ""})
+		if c.misbehave(config.EquivocatePrepare) {
+			c.equivocate(prepare)
+		}
 		c.Logger.Info("=> PREPARE step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
 	}
 }
@@ -542,13 +862,23 @@ func (c *Core) enterPrepareVoteStep(height int64, round int16) {
 		c.Logger.Error("PREPARE_VOTE step: Prepare message is nil")
 		return
 	}
+	if !c.isValidator() {
+		// 不是验证者的节点只跟随共识的进度，不投票
+		c.stepInfo.prepare = nil
+		return
+	}
 	//logger.Debug("Prepare message is valid, decide to vote for it")
-	vote := types.NewPrepareVote(height, types.GeneratePrepareVoteValueHash(c.stepInfo.block.ChameleonHash.Hash), c.privateKey)
+	// 签名者会拒绝可能导致重复签名的请求
+	vote, err := types.NewPrepareVote(height, round, c.voteBlockHash(), c.signer)
+	if err != nil {
+		c.Logger.Error("PREPARE_VOTE step: failed to sign PrepareVote", "err", err)
+		return
+	}
 	if c.isLeader() {
 		c.stepInfo.voteSet.AddPrepareVote(c.stepInfo.round, vote)
 		ok := c.stepInfo.voteSet.CheckPrepareVoteIsComplete(c.stepInfo.round, c.state.Validators)
 		if ok {
-			c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, PreCommitStep)
+			c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, PreCommitStep)
 		}
 	} else {
 		c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
@@ -568,7 +898,7 @@ func (c *Core) proposePreCommitMsg(height int64, round int16) {
 		c.newStep()
 	}()
 	agg := c.stepInfo.voteSet.CreateThresholdSigForPrepareVote(round, c.cryptoBLS12)
-	preCommit := types.NewPreCommit(agg, types.GeneratePreCommitValueHash(c.stepInfo.block.ChameleonHash.Hash), c.publicKey.ToID(), height)
+	preCommit := types.NewPreCommit(agg, types.GeneratePreCommitValueHash(height, round, c.stepInfo.block.ChameleonHash.Hash), c.stepInfo.block.ChameleonHash.Hash, c.publicKey.ToID(), height, round)
 	c.sendInternalMessage(MessageInfo{Msg: preCommit, NodeID: ""})
 }
 
@@ -587,12 +917,21 @@ func (c *Core) enterPreCommitVoteStep(height int64, round int16) {
 		c.Logger.Error("PRE_COMMIT_VOTE step: PreCommit message is nil")
 		return
 	}
-	vote := types.NewPreCommitVote(height, types.GeneratePreCommitVoteValueHash(c.stepInfo.block.ChameleonHash.Hash), c.privateKey)
+	if !c.isValidator() {
+		c.stepInfo.preCommit = nil
+		return
+	}
+	// 签名者会拒绝可能导致重复签名的请求
+	vote, err := types.NewPreCommitVote(height, round, c.voteBlockHash(), c.signer)
+	if err != nil {
+		c.Logger.Error("PRE_COMMIT_VOTE step: failed to sign PreCommitVote", "err", err)
+		return
+	}
 	if c.isLeader() {
 		c.stepInfo.voteSet.AddPreCommitVote(c.stepInfo.round, vote)
 		ok := c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(c.stepInfo.round, c.state.Validators)
 		if ok {
-			c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, CommitStep)
+			c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, CommitStep)
 		}
 	} else {
 		c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
@@ -612,7 +951,7 @@ func (c *Core) proposeCommitMsg(height int64, round int16) {
 		c.newStep()
 	}()
 	agg := c.stepInfo.voteSet.CreateThresholdSigForPreCommitVote(round, c.cryptoBLS12)
-	commit := types.NewCommit(agg, types.GenerateCommitValueHash(c.stepInfo.block.ChameleonHash.Hash), c.publicKey.ToID(), height)
+	commit := types.NewCommit(agg, types.GenerateCommitValueHash(height, round, c.stepInfo.block.ChameleonHash.Hash), c.stepInfo.block.ChameleonHash.Hash, c.publicKey.ToID(), height, round)
 	c.sendInternalMessage(MessageInfo{Msg: commit, NodeID: ""})
 }
 
@@ -631,12 +970,21 @@ func (c *Core) enterCommitVoteStep(height int64, round int16) {
 		c.Logger.Error("COMMIT_VOTE step: Commit message is nil")
 		return
 	}
-	vote := types.NewCommitVote(height, types.GenerateCommitVoteValueHash(c.stepInfo.block.ChameleonHash.Hash), c.privateKey)
+	if !c.isValidator() {
+		c.stepInfo.commit = nil
+		return
+	}
+	// 签名者会拒绝可能导致重复签名的请求
+	vote, err := types.NewCommitVote(height, round, c.voteBlockHash(), c.signer)
+	if err != nil {
+		c.Logger.Error("COMMIT_VOTE step: failed to sign CommitVote", "err", err)
+		return
+	}
 	if c.isLeader() {
 		c.stepInfo.voteSet.AddCommitVote(c.stepInfo.round, vote)
 		ok := c.stepInfo.voteSet.CheckCommitVoteIsComplete(c.stepInfo.round, c.state.Validators)
 		if ok {
-			c.scheduleStep(time.Millisecond*100, c.stepInfo.height, c.stepInfo.round, DecideStep)
+			c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, DecideStep)
 		}
 	} else {
 		c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
@@ -656,38 +1004,131 @@ func (c *Core) proposeDecideMsg(height int64, round int16) {
 		c.newStep()
 	}()
 	agg := c.stepInfo.voteSet.CreateThresholdSigForCommitVote(round, c.cryptoBLS12)
-	decide := types.NewDecide(agg, types.GenerateDecideValueHash(c.stepInfo.block.ChameleonHash.Hash), c.publicKey.ToID(), height)
+	decide := types.NewDecide(agg, types.GenerateDecideValueHash(height, round, c.stepInfo.block.ChameleonHash.Hash), c.stepInfo.block.ChameleonHash.Hash, c.publicKey.ToID(), height, round)
 	c.sendInternalMessage(MessageInfo{Msg: decide, NodeID: ""})
 }
 
-func (c *Core) applyBlock() {
-	newState, err := c.blockExec.ApplyBlock(c.state, c.stepInfo.block)
+func (c *Core) applyBlock(commit *types.CommitBlock) {
+	newState, err := c.blockExec.ApplyBlock(c.state, c.stepInfo.block, commit)
 	c.hasTxs = false
 	if err != nil {
+		haltOnApplyFailure(err)
 		c.Logger.Error("failed to apply block", "err", err)
 		return
 	}
 	c.state = newState
 	c.stepInfo.previousBlock = c.stepInfo.block
-	if !c.isLeader() {
+	// 当前高度的区块已经被执行，节点重启后无需再重放该高度的消息。重放WAL时执行的区块在崩溃之前没有被执行，同样需要写入
+	// 结束标志，否则下一次重启时找不到当前高度的起点
+	if err = c.wal.WriteSync(EndHeightMessage{Height: newState.LastBlockHeight}); err != nil {
+		panic(fmt.Sprintf("failed to write EndHeightMessage{%d} to consensus WAL due to %v; check your file system and restart the node", newState.LastBlockHeight, err))
+	}
+	if !c.isHeightLeader() {
 		c.stepInfo.height += 1
-		c.eventSwitch.FireEvent(events.EventNextView, c.nextView())
+		if c.isValidator() {
+			c.fireEvent(events.EventNextView, c.nextView())
+		}
 		c.scheduleNewHeight(c.stepInfo)
 		//c.stepInfo.step = NewHeightStep
+	} else {
+		c.tryEnterNextHeight()
+	}
+}
+
+// haltOnApplyFailure 被2/3以上验证者确认的区块与自己的应用状态不一致，说明自己的应用已经与其他节点分叉；应用在EndBlock
+// 里返回了不合法的更新时，区块里的交易已经执行但区块无法提交，应用停在了区块中间。这两种情况下继续运行只会对外提供错误的
+// 状态，因此直接停止节点。
+func haltOnApplyFailure(err error) {
+	if errors.Is(err, state2.ErrAppHashMismatch) {
+		panic(fmt.Sprintf("CONSENSUS FAILURE: local application state diverged from the network: %v; check the application and restart the node after fixing its state", err))
+	}
+	if errors.Is(err, state2.ErrInvalidEndBlock) {
+		panic(fmt.Sprintf("CONSENSUS FAILURE: application returned an invalid EndBlock update after executing the block: %v; fix the application and restart the node", err))
+	}
+}
+
+// verifyVote 验证投票的签名和基本格式，并检查投票者是否在同一轮次为不同的区块投过同一类型的票。
+func (c *Core) verifyVote(vote *types.Vote, typ pbtypes.VoteType) error {
+	if vote == nil || vote.Signature == nil {
+		return fmt.Errorf("empty %s", typ.String())
+	}
+	if vote.Height != c.stepInfo.height {
+		return fmt.Errorf("invalid %s message, my height: %d, message's height: %d", typ.String(), c.stepInfo.height, vote.Height)
+	}
+	if vote.VoteType != typ {
+		return fmt.Errorf("invalid vote type, want %s, got %s", typ.String(), vote.VoteType.String())
+	}
+	validator := c.state.Validators.GetValidatorByID(vote.Signature.Signer())
+	if validator == nil {
+		return fmt.Errorf("cannot find this validator: %s", vote.Signature.Signer())
+	}
+	if err := vote.ValidateBasic(); err != nil {
+		return fmt.Errorf("validator %s sent invalid %s message to me: %w", vote.Signature.Signer(), typ.String(), err)
+	}
+	if !validator.PublicKey.Verify(vote.Signature, vote.ValueHash) {
+		return fmt.Errorf("validator %s sent invalid %s message to me", vote.Signature.Signer(), typ.String())
+	}
+	if conflicting := c.stepInfo.voteSet.RecordVote(vote.Round, vote); conflicting != nil {
+		c.reportEvidence(types.NewDuplicateVoteEvidence(conflicting, vote, time.Now()))
+		return fmt.Errorf("validator %s voted for different blocks at height %d round %d", vote.Signature.Signer(), vote.Height, vote.Round)
+	}
+	return nil
+}
+
+// checkSafeProposal 实现HotStuff的safeNode规则：如果自己已经锁定了某个区块，那么只有当提案与锁定的区块相同，
+// 或者提案携带的QC比锁定的QC更新时，才能为提案投票。提案携带的QC必须认证了被提出的区块，并且签名者的投票权之和
+// 超过了2/3，否则一个作恶的主节点只需要自己签名就能解除副本节点的锁定。
+func (c *Core) checkSafeProposal(prepare *types.Prepare) error {
+	if prepare.HighQC != nil {
+		hash := types.GeneratePreCommitValueHash(prepare.Height, prepare.HighQC.Round, prepare.Block.ChameleonHash.Hash)
+		if !bytes.Equal(hash, prepare.HighQC.ValueHash) {
+			return fmt.Errorf("the high QC in Prepare message from %s does not certify the proposed block", prepare.ID)
+		}
+		if err := c.state.Validators.VerifyAggregateSignature(prepare.HighQC.AggregateSignature, prepare.HighQC.ValueHash); err != nil {
+			return fmt.Errorf("the high QC in Prepare message from %s has invalid aggregated signature: %w", prepare.ID, err)
+		}
+	}
+	locked := c.stepInfo.lockedBlock
+	if locked == nil {
+		return nil
+	}
+	if bytes.Equal(locked.ChameleonHash.Hash, prepare.Block.ChameleonHash.Hash) {
+		return nil
+	}
+	if prepare.HighQC == nil {
+		return fmt.Errorf("unsafe Prepare from %s: locked on block %X at round %d, but the proposed block %X carries no QC", prepare.ID, locked.ChameleonHash.Hash, c.stepInfo.lockedRound, prepare.Block.ChameleonHash.Hash)
+	}
+	if prepare.HighQC.Round <= c.stepInfo.lockedRound {
+		return fmt.Errorf("unsafe Prepare from %s: locked on block %X at round %d, but the QC of the proposed block %X is from round %d", prepare.ID, locked.ChameleonHash.Hash, c.stepInfo.lockedRound, prepare.Block.ChameleonHash.Hash, prepare.HighQC.Round)
+	}
+	return nil
+}
+
+// reportEvidence 将检测到的作恶证据交给证据池，证据池会将其广播给其他节点，并由主节点打包进区块里。
+func (c *Core) reportEvidence(evidence types.Evidence) {
+	c.Logger.Warn("detected byzantine behavior", "evidence", evidence)
+	if err := c.evpool.AddEvidence(evidence); err != nil {
+		c.Logger.Error("failed to add evidence to pool", "err", err)
 	}
 }
 
 func (c *Core) createBlock() *types.Block {
+	var lastBlockHash []byte
 	switch {
 	case c.stepInfo.height == c.state.InitialHeight:
-		lastBlockHash := sha256.Sum([]byte("first block"))
-		return c.blockExec.CreateBlock(c.stepInfo.height, c.state, c.publicKey.ToID(), lastBlockHash[:])
+		lastBlockHash = c.state.PreviousBlockHash(c.stepInfo.height)
 	case c.stepInfo.previousBlock != nil:
-		return c.blockExec.CreateBlock(c.stepInfo.height, c.state, c.publicKey.ToID(), c.stepInfo.previousBlock.ChameleonHash.Hash)
+		lastBlockHash = c.stepInfo.previousBlock.ChameleonHash.Hash
 	default:
 		c.Logger.Error("PREPARE step: cannot propose Prepare message")
 		return nil
 	}
+	block, err := c.blockExec.CreateBlock(c.stepInfo.height, c.state, c.publicKey.ToID(), lastBlockHash)
+	if err != nil {
+		c.Logger.Error("PREPARE step: failed to create block", "err", err)
+		return nil
+	}
+	return block
 }
 
 func (c *Core) hasPrepare() bool {
@@ -704,22 +1145,44 @@ func (c *Core) hasCommit() bool {
 
 func (c *Core) newStep() {
 	esi := c.stepInfo.EventStepInfo()
-	c.eventSwitch.FireEvent(events.EventNewStep, &esi)
+	if !c.replayMode {
+		_ = c.wal.Write(esi)
+	}
+	c.fireEvent(events.EventNewStep, &esi)
+}
+
+// fireEvent 通知Reactor把共识消息或者自己的状态发送给其他节点。重放WAL时不通知，因为这些消息在崩溃之前已经发送过了，
+// 重放期间的中间状态也不应该被其他节点看到。
+func (c *Core) fireEvent(event string, data events.EventData) {
+	if c.replayMode {
+		return
+	}
+	c.eventSwitch.FireEvent(event, data)
+}
+
+// schedulePacemaker 为当前高度的当前轮次设置共识超时时间，轮次越大，超时时间越长，以便于各节点能够在某一轮重新同步。
+func (c *Core) schedulePacemaker() {
+	duration := c.cfg.TimeoutConsensus * time.Duration(c.stepInfo.round)
+	c.viewTicker.ScheduleTimeout(TimeoutInfo{Duration: duration, Height: c.stepInfo.height, Round: c.stepInfo.round, Step: ConsensusTimeout})
 }
 
 func (c *Core) scheduleStep(duration time.Duration, height int64, round int16, step Step) {
-	c.scheduledTicker.ScheduleTimeout(timeoutInfo{Duration: duration, Height: height, Round: round, Step: step})
+	c.scheduledTicker.ScheduleTimeout(TimeoutInfo{Duration: duration, Height: height, Round: round, Step: step})
 }
 
-// scheduleNewHeight 副本节点在确认过一个区块后，本地的区块高度会自增1，然后等待1秒中后进入下一个区块高度，如果自己是主导下一个
+// scheduleNewHeight 副本节点在确认过一个区块后，本地的区块高度会自增1，然后等待共识参数里的NewHeightDelay后进入下一个区块高度，如果自己是主导下一个
 // 区块的主节点，那么在交易池里有交易数据的情况下，打包交易数据，提出新的区块，促使其他节点和自己进入到下一轮共识中；如果自己在下一
 // 轮共识中依然是副本节点，那就只将自己的step更新为NewHeightStep。
 func (c *Core) scheduleNewHeight(stepInfo *StepInfo) {
 	//duration := time.Now().Sub(c.stepInfo.startTime)
-	c.scheduledTicker.ScheduleTimeout(timeoutInfo{Duration: time.Second, Height: stepInfo.height, Round: 1, Step: NewHeightStep})
+	c.scheduledTicker.ScheduleTimeout(TimeoutInfo{Duration: c.state.ConsensusParams.Timeout.NewHeightDelay, Height: stepInfo.height, Round: 1, Step: NewHeightStep})
 }
 
 func (c *Core) sendInternalMessage(info MessageInfo) {
+	if c.replayMode {
+		// 重放时，自己当初产生的消息已经被记录在WAL里了，会被依次重放
+		return
+	}
 	select {
 	case c.internalMsgQueue <- info:
 	default:
@@ -743,10 +1206,37 @@ func (c *Core) nextView() *types.NextView {
 	}
 }
 
+// updateToState 根据最新的状态更新共识的区块高度，节点重启或者通过区块同步追上其他节点后，需要从最新的高度开始参与共识。
+func (c *Core) updateToState(stat *state2.State) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state = stat
+	if stat.LastBlockHeight >= stat.InitialHeight && stat.LastBlockHeight > 0 {
+		c.stepInfo.height = stat.LastBlockHeight + 1
+		c.stepInfo.previousBlock = stat.PreviousBlock
+	}
+	c.stepInfo.Reset()
+	if c.chained != nil {
+		c.chained.prune(stat.LastBlockHeight)
+	}
+}
+
+// isValidator 判断自己是否参与投票：节点没有以观察者模式运行，并且自己的公钥在当前的验证者集合里。验证者集合会随着
+// 区块的执行而变化，因此每次都需要重新判断。
+func (c *Core) isValidator() bool {
+	return !c.observer && c.state.Validators.GetValidatorByID(c.id) != nil
+}
+
 func (c *Core) isLeader() bool {
-	return c.state.Validators.GetLeader(c.stepInfo.round).ID == c.publicKey.ToID()
+	return c.isValidator() && c.state.Validators.GetLeader(c.stepInfo.round).ID == c.publicKey.ToID()
+}
+
+// isHeightLeader 判断自己是否是当前状态下一个高度第1轮的主节点，执行完区块后，状态里的验证者集合已经轮换到了下一个高度。
+func (c *Core) isHeightLeader() bool {
+	return c.isValidator() && c.state.Validators.GetLeader(1).ID == c.publicKey.ToID()
 }
 
 func (c *Core) isNextLeader() bool {
-	return c.state.Validators.GetLeader(c.stepInfo.round+1).ID == c.publicKey.ToID()
+	return c.isValidator() && c.state.Validators.GetLeader(c.stepInfo.round+1).ID == c.publicKey.ToID()
 }
@@ -0,0 +1,114 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/stch"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+// newSafetyTestQC 由signers里的验证者为height高度round轮的区块blockHash投票，并把投票聚合成QC。
+func newSafetyTestQC(t *testing.T, signers []types.Signer, height int64, round int16, blockHash []byte) *types.PreCommit {
+	sigs := make([]*bls12.Signature, 0, len(signers))
+	for _, signer := range signers {
+		vote, err := types.NewPrepareVote(height, round, blockHash, signer)
+		assert.Nil(t, err)
+		sigs = append(sigs, vote.Vote.Signature)
+	}
+	agg, err := bls12.NewCryptoBLS12().CreateThresholdSignature(sigs)
+	assert.Nil(t, err)
+	return types.NewPreCommit(agg, types.GeneratePreCommitValueHash(height, round, blockHash), blockHash, signers[0].PublicKey().ToID(), height, round)
+}
+
+func TestCheckSafeProposalRejectsMinorityQC(t *testing.T) {
+	validators, signerSet := newVoteTreeTestValidators(t, 4)
+	signers := make([]types.Signer, 0, len(validators.Validators))
+	for _, val := range validators.Validators {
+		signers = append(signers, signerSet[val.ID])
+	}
+	c := &Core{stepInfo: NewStepInfo(), state: &state.State{Validators: validators}}
+	c.stepInfo.height = 3
+
+	// 副本节点在第1轮锁定了区块locked
+	locked := &types.Block{ChameleonHash: &types.ChameleonHash{Hash: []byte("locked block")}}
+	assert.True(t, c.stepInfo.Lock(1, locked, &types.Commit{}))
+
+	proposed := &types.Block{ChameleonHash: &types.ChameleonHash{Hash: []byte("proposed block")}}
+	prepare := &types.Prepare{ID: signers[0].PublicKey().ToID(), Height: 3, Round: 2, Block: proposed}
+
+	// 只有主节点自己签名的QC轮次更高，但是不能解除锁定
+	prepare.HighQC = newSafetyTestQC(t, signers[:1], 3, 2, proposed.ChameleonHash.Hash)
+	assert.NotNil(t, c.checkSafeProposal(prepare))
+	// 一半的验证者签名依然不够
+	prepare.HighQC = newSafetyTestQC(t, signers[:2], 3, 2, proposed.ChameleonHash.Hash)
+	assert.NotNil(t, c.checkSafeProposal(prepare))
+	// 2/3以上的验证者签名的QC比锁定的QC更新，可以解除锁定
+	prepare.HighQC = newSafetyTestQC(t, signers[:3], 3, 2, proposed.ChameleonHash.Hash)
+	assert.Nil(t, c.checkSafeProposal(prepare))
+	// 合法的QC如果不比锁定的QC更新，依然不能解除锁定
+	prepare.HighQC = newSafetyTestQC(t, signers[:3], 3, 1, proposed.ChameleonHash.Hash)
+	assert.NotNil(t, c.checkSafeProposal(prepare))
+}
+
+func TestHaltOnApplyFailure(t *testing.T) {
+	// 应用状态分叉或者应用在执行完区块后返回了不合法的EndBlock更新，都必须停止节点
+	assert.Panics(t, func() {
+		haltOnApplyFailure(fmt.Errorf("%w at height 3: block has 01, but local application has 02", state.ErrAppHashMismatch))
+	})
+	assert.Panics(t, func() {
+		haltOnApplyFailure(fmt.Errorf("%w at height 3: invalid block max bytes", state.ErrInvalidEndBlock))
+	})
+	// 其他错误只记录日志
+	assert.NotPanics(t, func() { haltOnApplyFailure(errors.New("failed to save block")) })
+}
+
+func TestHandleNewViewValidatesHighQCBlock(t *testing.T) {
+	validators, signerSet := newVoteTreeTestValidators(t, 4)
+	signers := make([]types.Signer, 0, len(validators.Validators))
+	for _, val := range validators.Validators {
+		signers = append(signers, signerSet[val.ID])
+	}
+	stat := &state.State{Validators: validators}
+	stat.SetChameleon(stch.NewChameleonWithAlpha(signers[0].PublicKey().ToID(), 4, big.NewInt(3)))
+	c := &Core{stepInfo: NewStepInfo(), state: stat}
+	c.SetLogger(log.New())
+	c.stepInfo.height = 3
+
+	newHighQCBlock := func(tx string) *types.Block {
+		block := &types.Block{
+			Header: &types.Header{PreviousBlockHash: []byte("block 2"), Height: 3, Proposer: signers[0].PublicKey().ToID()},
+			Body:   &types.Data{Txs: types.Txs{types.Tx(tx)}},
+		}
+		stat.Chameleon.Hash(block)
+		return block
+	}
+	newView := func(block *types.Block) *types.NewView {
+		qc := newSafetyTestQC(t, signers[:3], 3, 1, block.ChameleonHash.Hash)
+		view, err := types.NewNewView(3, 2, 1, block, qc, signers[1])
+		assert.Nil(t, err)
+		return view
+	}
+
+	// 区块的内容被篡改，但是仍然携带着被QC认证过的变色龙哈希
+	forged := newHighQCBlock("a=1")
+	forged.Body.Txs = types.Txs{types.Tx("a=2")}
+	forged.BlockDataHash()
+	assert.NotNil(t, c.handleNewView(newView(forged)))
+	assert.Nil(t, c.stepInfo.highQCBlock)
+
+	// 区块不满足基本的格式要求
+	malformed := newHighQCBlock("a=1")
+	malformed.Header.Proposer = ""
+	assert.NotNil(t, c.handleNewView(newView(malformed)))
+	assert.Nil(t, c.stepInfo.highQCBlock)
+
+	block := newHighQCBlock("a=1")
+	assert.Nil(t, c.handleNewView(newView(block)))
+	assert.Equal(t, block, c.stepInfo.highQCBlock)
+}
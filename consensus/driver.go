@@ -0,0 +1,200 @@
+package consensus
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/types"
+)
+
+const driverSubscriber = "consensus-driver"
+
+// Outbound 同步驱动Core时，Core产生的需要发送给其他节点的共识消息，To为空表示广播给除自己以外的所有节点。
+type Outbound struct {
+	Channel byte
+	To      crypto.ID
+	Msg     Message
+}
+
+// Driver 在调用者的goroutine里同步地驱动Core，不会启动receiveRoutine和真实的定时器：其他节点发来的消息、超时事件以及
+// 交易池的提醒，都由调用者按照自己决定的顺序逐个交给Driver处理，Core产生的共识消息则按照Reactor的路由规则返回给调用者。
+// 确定性的仿真测试依靠它获得可重现的执行顺序。
+type Driver struct {
+	core   *Core
+	outbox []Outbound
+}
+
+// NewDriver 用scheduled和view替换Core原有的两个定时器，它们触发超时事件时，调用者需要将事件交给Driver.Timeout处理。
+// 被Driver驱动的Core不能再调用Start。
+func NewDriver(core *Core, scheduled, view Ticker) *Driver {
+	d := &Driver{core: core}
+	core.scheduledTicker = scheduled
+	core.viewTicker = view
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventNextView,
+		func(data events.EventData) {
+			// 与Reactor一样，NextView消息发送给下一个高度第1轮的主节点
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaNextViewChannel, To: core.state.Validators.GetLeader(1).ID, Msg: data.(*types.NextView)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventNewView,
+		func(data events.EventData) {
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaNextViewChannel, Msg: data.(*types.NewView)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventEquivocation,
+		func(data events.EventData) {
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.LeaderProposeChannel, Msg: data.(*types.Prepare)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventChainedProposal,
+		func(data events.EventData) {
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.LeaderProposeChannel, Msg: data.(*types.ChainedProposal)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventChainedVote,
+		func(data events.EventData) {
+			vote := data.(*types.ChainedVote)
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaVoteChannel, To: core.chainedLeader(vote.View + 1).ID, Msg: vote})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventChainedNewView,
+		func(data events.EventData) {
+			view := data.(*types.ChainedNewView)
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaNextViewChannel, To: core.chainedLeader(view.View).ID, Msg: view})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventTimeout,
+		func(data events.EventData) {
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaNextViewChannel, Msg: data.(*types.Timeout)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := core.eventSwitch.AddListenerWithEvent(driverSubscriber, events.EventBlockRequest,
+		func(data events.EventData) {
+			d.outbox = append(d.outbox, Outbound{Channel: p2p.BlockFetchChannel, Msg: data.(*types.BlockRequest)})
+		}); err != nil {
+		core.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	return d
+}
+
+// Deliver 处理节点from发来的共识消息。
+func (d *Driver) Deliver(from crypto.ID, msg Message) []Outbound {
+	if req, ok := msg.(*types.BlockRequest); ok {
+		// 与Reactor一样，BlockRequest不交给Core处理，有被索要的区块时直接回复给from
+		if req.ValidateBasic() != nil {
+			return nil
+		}
+		if block := d.core.lookupBlock(req.Height, req.BlockHash); block != nil {
+			return []Outbound{{Channel: p2p.BlockFetchChannel, To: from, Msg: &types.BlockResponse{Block: block}}}
+		}
+		return nil
+	}
+	mi := MessageInfo{Msg: msg, NodeID: from}
+	_ = d.core.wal.Write(mi)
+	d.core.handleMsg(mi)
+	return d.flush()
+}
+
+// Timeout 处理定时器触发的超时事件。
+func (d *Driver) Timeout(ti TimeoutInfo) []Outbound {
+	_ = d.core.wal.Write(ti)
+	if ti.Step == ConsensusTimeout {
+		d.core.handleViewTimeout(ti)
+	} else {
+		d.core.handleScheduled(ti, *d.core.stepInfo)
+	}
+	return d.flush()
+}
+
+// TxsAvailable 检查交易池是否发出了有交易数据的提醒，有的话就像receiveRoutine一样处理它。
+func (d *Driver) TxsAvailable() []Outbound {
+	select {
+	case <-d.core.txsPool.TxsAvailable():
+		if !d.core.hasTxs {
+			d.core.hasTxs = true
+			d.core.handleAvailableTxs()
+		}
+	default:
+	}
+	return d.flush()
+}
+
+// flush 处理Core发给自己的内部消息，直到内部消息通道为空，然后返回这期间产生的需要发送给其他节点的消息。
+func (d *Driver) flush() []Outbound {
+	c := d.core
+	d.collect()
+	for len(c.internalMsgQueue) > 0 {
+		mi := <-c.internalMsgQueue
+		if err := c.wal.WriteSync(mi); err != nil {
+			panic(fmt.Sprintf("failed to write %T msg to consensus WAL due to %v; check your file system and restart the node", mi.Msg, err))
+		}
+		c.handleMsg(mi)
+		d.collect()
+	}
+	out := d.outbox
+	d.outbox = nil
+	return out
+}
+
+// collect 按照Reactor的路由规则收集Core产生的消息：主节点的提案广播给所有节点，投票发送给投票所在轮次的主节点。
+func (d *Driver) collect() {
+	out := d.core.takeOutbound()
+	for _, msg := range out.proposals {
+		d.outbox = append(d.outbox, Outbound{Channel: p2p.LeaderProposeChannel, Msg: msg})
+	}
+	for _, vote := range out.votes {
+		d.outbox = append(d.outbox, Outbound{Channel: p2p.ReplicaVoteChannel, To: d.core.leaderOf(voteOf(vote).Round), Msg: vote})
+	}
+}
+
+// Replay 打开WAL并重放其中当前高度的消息，相当于Core.Start里除了启动goroutine以外的部分，返回重放之后需要重新发送给
+// 其他节点的消息。
+func (d *Driver) Replay() ([]Outbound, error) {
+	if err := d.core.loadWalFile(); err != nil {
+		return nil, err
+	}
+	if err := d.core.catchupReplay(d.core.stepInfo.height); err != nil {
+		return nil, err
+	}
+	return d.flush(), nil
+}
+
+// Stop 停止定时器并关闭WAL，之后Driver不能再被使用，用来模拟节点崩溃。
+func (d *Driver) Stop() {
+	_ = d.core.scheduledTicker.Stop()
+	_ = d.core.viewTicker.Stop()
+	if err := d.core.wal.Stop(); err != nil {
+		d.core.Logger.Error("failed to stop consensus WAL", "err", err)
+	}
+}
+
+// Status 共识的当前进度，仿真测试用它检查节点重启之后是否恢复到了崩溃之前的状态。
+type Status struct {
+	Height      int64
+	Round       int16
+	Step        Step
+	BlockHash   []byte // 当前轮次正在投票的区块
+	HighQCRound int16
+	LockedRound int16
+	LockedHash  []byte // 锁定的区块
+}
+
+func (d *Driver) Status() Status {
+	si := d.core.stepInfo
+	status := Status{Height: si.height, Round: si.round, Step: si.step, HighQCRound: si.highQCRound, LockedRound: si.lockedRound}
+	if si.block != nil {
+		status.BlockHash = si.block.ChameleonHash.Hash
+	}
+	if si.lockedBlock != nil {
+		status.LockedHash = si.lockedBlock.ChameleonHash.Hash
+	}
+	return status
+}
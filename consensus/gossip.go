@@ -0,0 +1,287 @@
+package consensus
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"sync"
+	"time"
+)
+
+// leaderMsgRetransmit 主节点消息发送给某个节点之后，节点的状态在这段时间内一直没有变化，就重新发送一次；自己投出的票在这段
+// 时间内没有得到主节点的回应，也会重新发送一次。
+const leaderMsgRetransmit = time.Second
+
+// outbound Core产生的需要发送给其他节点的消息：主节点的提案需要发送给所有节点，投票需要发送给投票所在轮次的主节点。
+type outbound struct {
+	proposals []Message
+	votes     []Message
+}
+
+// notifyOutbound 提醒Reactor取走Core产生的消息，已经有一个没有被处理的提醒时直接返回。
+func (c *Core) notifyOutbound() {
+	select {
+	case c.outboundReady <- struct{}{}:
+	default:
+	}
+}
+
+// takeOutbound 取出Core产生的、还没有被发送出去的消息。
+func (c *Core) takeOutbound() outbound {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var out outbound
+	select {
+	case prepare := <-c.stepInfo.prepare:
+		out.proposals = append(out.proposals, prepare)
+	default:
+	}
+	select {
+	case preCommit := <-c.stepInfo.preCommit:
+		out.proposals = append(out.proposals, preCommit)
+	default:
+	}
+	select {
+	case commit := <-c.stepInfo.commit:
+		out.proposals = append(out.proposals, commit)
+	default:
+	}
+	select {
+	case decide := <-c.stepInfo.decide:
+		out.proposals = append(out.proposals, decide)
+	default:
+	}
+
+	for len(c.prepareVotesQueue) > 0 {
+		out.votes = append(out.votes, <-c.prepareVotesQueue)
+	}
+	for len(c.preCommitVotesQueue) > 0 {
+		out.votes = append(out.votes, <-c.preCommitVotesQueue)
+	}
+	for len(c.commitVotesQueue) > 0 {
+		out.votes = append(out.votes, <-c.commitVotesQueue)
+	}
+	return out
+}
+
+// leaderOf 返回当前高度第round轮的主节点。
+func (c *Core) leaderOf(round int16) crypto.ID {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.state.Validators.GetLeader(round).ID
+}
+
+// leaderMsgs 自己作为主节点最近一次提出的各类消息，以及自己最近一次确认区块时收到的Decide消息，每个节点都有一个goroutine
+// 根据PeerState把节点还缺少的消息发送给它。
+type leaderMsgs struct {
+	mu        sync.Mutex
+	prepare   *types.Prepare
+	header    *types.BlockPartSetHeader // Prepare消息的分片集合的头部，发送Prepare消息时只发送它
+	preCommit *types.PreCommit
+	commit    *types.Commit
+	decide    *types.Decide
+}
+
+func (lm *leaderMsgs) get() (*types.Prepare, *types.BlockPartSetHeader, *types.PreCommit, *types.Commit, *types.Decide) {
+	lm.mu.Lock()
+	defer lm.mu.Unlock()
+	return lm.prepare, lm.header, lm.preCommit, lm.commit, lm.decide
+}
+
+// dispatchRoutine 等待Core的提醒，把主节点的提案保存下来并唤醒所有向其他节点发送消息的goroutine，把投票发送给投票所在
+// 轮次的主节点，开启了投票聚合树时投票沿着聚合树发送，见vote_tree.go。还没有得到回应的投票每隔leaderMsgRetransmit重新
+// 发送一次。
+func (r *Reactor) dispatchRoutine() {
+	ticker := time.NewTicker(leaderMsgRetransmit)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-r.core.outboundReady:
+		case <-ticker.C:
+			r.retransmitVotes()
+			continue
+		case <-r.WaitStop():
+			return
+		}
+		out := r.core.takeOutbound()
+		if len(out.proposals) > 0 {
+			r.leader.mu.Lock()
+			for _, msg := range out.proposals {
+				switch msg := msg.(type) {
+				case *types.Prepare:
+					r.leader.prepare, r.leader.header = msg, r.proposeBlockParts(msg)
+				case *types.PreCommit:
+					r.leader.preCommit = msg
+				case *types.Commit:
+					r.leader.commit = msg
+				case *types.Decide:
+					r.leader.decide = msg
+				}
+			}
+			r.leader.mu.Unlock()
+			for _, peer := range r.Switch.Peers().Peers() {
+				if ps, ok := peer.Get(types.PeerStateKey).(*PeerState); ok {
+					ps.Wake()
+				}
+			}
+		}
+		for _, vote := range out.votes {
+			r.sendVote(vote)
+		}
+	}
+}
+
+// gossipRoutine 在节点的状态发生变化、自己提出了新的消息或者等待重传超时后，把节点还缺少的主节点消息发送给它，发送失败的
+// 消息会在下一次被唤醒时重新发送。
+func (r *Reactor) gossipRoutine(peer *p2p.Peer) {
+	timer := time.NewTimer(leaderMsgRetransmit)
+	defer timer.Stop()
+	for {
+		if !r.IsRunning() || !peer.IsRunning() {
+			return
+		}
+		ps, ok := peer.Get(types.PeerStateKey).(*PeerState)
+		if !ok {
+			return
+		}
+		r.gossipLeaderMsgs(peer, ps)
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(leaderMsgRetransmit)
+		select {
+		case <-ps.WakeChan():
+		case <-timer.C:
+		case <-peer.WaitStop():
+			return
+		case <-r.WaitStop():
+			return
+		}
+	}
+}
+
+// gossipLeaderMsgs 按照共识推进的顺序把节点还缺少的主节点消息发送给它。
+func (r *Reactor) gossipLeaderMsgs(peer *p2p.Peer, ps *PeerState) {
+	prepare, header, preCommit, commit, decide := r.leader.get()
+	if prepare != nil && ps.NeedsPrepare(prepare, leaderMsgRetransmit) {
+		// Prepare消息被切分成分片，这里只发送分片集合的头部，分片由gossipBlockPartsRoutine发送
+		if peer.Send(p2p.BlockPartChannel, MustEncode(header)) {
+			ps.SetPrepare(prepare)
+			ps.SetBlockPartSetHeader(header.Header)
+		} else {
+			r.Logger.Debug("failed to send Prepare message", "to", peer.NodeID())
+		}
+	}
+	if preCommit != nil && ps.NeedsPreCommit(preCommit, leaderMsgRetransmit) {
+		if peer.Send(p2p.LeaderProposeChannel, MustEncode(preCommit)) {
+			ps.SetPreCommit(preCommit)
+		} else {
+			r.Logger.Debug("failed to send PreCommit message", "to", peer.NodeID())
+		}
+	}
+	if commit != nil && ps.NeedsCommit(commit, leaderMsgRetransmit) {
+		if peer.Send(p2p.LeaderProposeChannel, MustEncode(commit)) {
+			ps.SetCommit(commit)
+		} else {
+			r.Logger.Debug("failed to send Commit message", "to", peer.NodeID())
+		}
+	}
+	if decide != nil && ps.NeedsDecide(decide, leaderMsgRetransmit) {
+		if peer.Send(p2p.LeaderProposeChannel, MustEncode(decide)) {
+			ps.SetDecide(decide)
+		} else {
+			r.Logger.Debug("failed to send Decide message", "to", peer.NodeID())
+		}
+	}
+}
+
+// routeVote 把投票发送给leader，leader没有与自己直接相连或者发送失败时，把投票交给所有节点，由与leader相连的节点转交。
+func (r *Reactor) routeVote(leader crypto.ID, vote Message) {
+	bz := MustEncode(vote)
+	if r.Switch.SendToPeer(p2p.ReplicaVoteChannel, leader, bz) {
+		return
+	}
+	r.Switch.Broadcast(p2p.ReplicaVoteChannel, bz)
+}
+
+// unackedVotes 自己直接发送给主节点、还没有得到回应的投票。
+type unackedVotes struct {
+	mu    sync.Mutex
+	votes []Message
+}
+
+// trackVote 记录直接发送给主节点的投票，等待重传。
+func (r *Reactor) trackVote(msg Message) {
+	r.unacked.mu.Lock()
+	defer r.unacked.mu.Unlock()
+	r.unacked.votes = append(r.unacked.votes, msg)
+}
+
+// retransmitVotes 重新发送还没有得到回应的投票。主节点收集到足够的投票后会发出下一个步骤的消息，自己随之离开投票后的
+// 等待步骤；轮次或者高度发生了变化时投票也不再需要了。这两种情况下投票都被视为已经得到回应，不再重传。
+func (r *Reactor) retransmitVotes() {
+	height, round, step := r.core.roundStep()
+	r.unacked.mu.Lock()
+	votes := r.unacked.votes[:0]
+	for _, msg := range r.unacked.votes {
+		vote := voteOf(msg)
+		if vote.Height == height && vote.Round == round && step.awaitsVoteResponse(vote.VoteType) {
+			votes = append(votes, msg)
+		}
+	}
+	r.unacked.votes = votes
+	resend := append([]Message(nil), votes...)
+	r.unacked.mu.Unlock()
+
+	for _, msg := range resend {
+		vote := voteOf(msg)
+		r.Logger.Debug("retransmit vote", "height", vote.Height, "round", vote.Round, "type", vote.VoteType.String())
+		r.routeVote(r.core.leaderOf(vote.Round), msg)
+	}
+}
+
+// awaitsVoteResponse 自己投出typ类型的票之后处于这些步骤，直到收到主节点下一个步骤的消息。
+func (s Step) awaitsVoteResponse(typ pbtypes.VoteType) bool {
+	switch typ {
+	case pbtypes.PrepareVoteType:
+		return s == PrepareVoteStep || s == TwoPhaseVoteStep
+	case pbtypes.PreCommitVoteType:
+		return s == PreCommitVoteStep || s == TwoPhaseLockVoteStep
+	case pbtypes.CommitVoteType:
+		return s == CommitVoteStep
+	}
+	return false
+}
+
+// relayVote 收到了不属于自己的投票时，如果投票来自它的投出者，并且自己与leader直接相连，就把投票转交给leader。投票只会被转交
+// 一次，因此不会在节点之间循环。投票聚合树里的部分聚合签名只要发送者是参与者之一，也会被转交。返回true表示投票不需要交给Core处理。
+func (r *Reactor) relayVote(src *p2p.Peer, msg Message, bz []byte) bool {
+	var round int16
+	var fromSigner bool
+	if agg, ok := msg.(*types.AggregateVote); ok {
+		if agg.AggregateSignature == nil {
+			return false
+		}
+		round, fromSigner = agg.Round, agg.AggregateSignature.Participants().Contains(src.NodeID()) >= 0
+	} else {
+		vote := voteOf(msg)
+		if vote == nil || vote.Signature == nil {
+			return false
+		}
+		round, fromSigner = vote.Round, vote.Signature.Signer() == src.NodeID()
+	}
+	leader := r.core.leaderOf(round)
+	if leader == r.core.id {
+		return false
+	}
+	if fromSigner && leader != src.NodeID() {
+		_ = r.Switch.SendToPeer(p2p.ReplicaVoteChannel, leader, bz)
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/proto/pbtypes"
@@ -21,7 +22,37 @@ func MustEncode(msg Message) []byte {
 	if msg == nil {
 		panic("consensus: message is nil")
 	}
+	bz, err := proto.Marshal(MsgToProto(msg))
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func MustDecode(bz []byte) (msg Message) {
+	pb := &pbtypes.Message{}
+	var err error
+	if err = proto.Unmarshal(bz, pb); err != nil {
+		panic(err)
+	}
+	return MsgFromProto(pb)
+}
+
+// decodeMsg 与MustDecode一样解析共识消息，但是无法解析时返回错误，用于解析由区块分片拼接出来的数据。
+func decodeMsg(bz []byte) (Message, error) {
 	pb := &pbtypes.Message{}
+	if err := proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	if pb.Msg == nil {
+		return nil, errors.New("empty consensus message")
+	}
+	return MsgFromProto(pb), nil
+}
+
+// MsgToProto 将共识消息转换为protobuf格式，WAL和网络传输都基于该格式。
+func MsgToProto(msg Message) *pbtypes.Message {
+	var pb *pbtypes.Message
 	switch message := msg.(type) {
 	case *types.NextView:
 		pb = &pbtypes.Message{
@@ -29,6 +60,12 @@ func MustEncode(msg Message) []byte {
 				NextView: message.ToProto(),
 			},
 		}
+	case *types.NewView:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_NewView{
+				NewView: message.ToProto(),
+			},
+		}
 	case *types.Prepare:
 		pb = &pbtypes.Message{
 			Msg: &pbtypes.Message_Prepare{
@@ -77,25 +114,97 @@ func MustEncode(msg Message) []byte {
 				Decide: message.ToProto(),
 			},
 		}
+	case *types.ChainedProposal:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_ChainedProposal{
+				ChainedProposal: message.ToProto(),
+			},
+		}
+	case *types.ChainedVote:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_ChainedVote{
+				ChainedVote: message.ToProto(),
+			},
+		}
+	case *types.ChainedNewView:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_ChainedNewView{
+				ChainedNewView: message.ToProto(),
+			},
+		}
+	case *types.Timeout:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_Timeout{
+				Timeout: message.ToProto(),
+			},
+		}
+	case *types.CompactPrepare:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_CompactPrepare{
+				CompactPrepare: message.ToProto(),
+			},
+		}
+	case *types.BlockTxsRequest:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockTxsRequest{
+				BlockTxsRequest: message.ToProto(),
+			},
+		}
+	case *types.BlockTxsResponse:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockTxsResponse{
+				BlockTxsResponse: message.ToProto(),
+			},
+		}
+	case *types.BlockPartSetHeader:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockPartSetHeader{
+				BlockPartSetHeader: message.ToProto(),
+			},
+		}
+	case *types.BlockPart:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockPart{
+				BlockPart: message.ToProto(),
+			},
+		}
+	case *types.HasBlockPart:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_HasBlockPart{
+				HasBlockPart: message.ToProto(),
+			},
+		}
+	case *types.BlockRequest:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockRequest{
+				BlockRequest: message.ToProto(),
+			},
+		}
+	case *types.BlockResponse:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_BlockResponse{
+				BlockResponse: message.ToProto(),
+			},
+		}
+	case *types.AggregateVote:
+		pb = &pbtypes.Message{
+			Msg: &pbtypes.Message_AggregateVote{
+				AggregateVote: message.ToProto(),
+			},
+		}
 	default:
 		panic(fmt.Sprintf("unknown message type: %T", msg))
 	}
-	bz, err := proto.Marshal(pb)
-	if err != nil {
-		panic(err)
-	}
-	return bz
+	return pb
 }
 
-func MustDecode(bz []byte) (msg Message) {
-	pb := &pbtypes.Message{}
-	var err error
-	if err = proto.Unmarshal(bz, pb); err != nil {
-		panic(err)
-	}
+// MsgFromProto 将protobuf格式的共识消息转换为共识消息。
+func MsgFromProto(pb *pbtypes.Message) (msg Message) {
 	switch m := pb.Msg.(type) {
 	case *pbtypes.Message_NextView:
 		msg = types.NextViewFromProto(m.NextView)
+	case *pbtypes.Message_NewView:
+		msg = types.NewViewFromProto(m.NewView)
 	case *pbtypes.Message_Prepare:
 		msg = types.PrepareFromProto(m.Prepare)
 	case *pbtypes.Message_PrepareVote:
@@ -110,6 +219,32 @@ func MustDecode(bz []byte) (msg Message) {
 		msg = types.CommitVoteFromProto(m.CommitVote)
 	case *pbtypes.Message_Decide:
 		msg = types.DecideFromProto(m.Decide)
+	case *pbtypes.Message_ChainedProposal:
+		msg = types.ChainedProposalFromProto(m.ChainedProposal)
+	case *pbtypes.Message_ChainedVote:
+		msg = types.ChainedVoteFromProto(m.ChainedVote)
+	case *pbtypes.Message_ChainedNewView:
+		msg = types.ChainedNewViewFromProto(m.ChainedNewView)
+	case *pbtypes.Message_Timeout:
+		msg = types.TimeoutFromProto(m.Timeout)
+	case *pbtypes.Message_CompactPrepare:
+		msg = types.CompactPrepareFromProto(m.CompactPrepare)
+	case *pbtypes.Message_BlockTxsRequest:
+		msg = types.BlockTxsRequestFromProto(m.BlockTxsRequest)
+	case *pbtypes.Message_BlockTxsResponse:
+		msg = types.BlockTxsResponseFromProto(m.BlockTxsResponse)
+	case *pbtypes.Message_BlockPartSetHeader:
+		msg = types.BlockPartSetHeaderFromProto(m.BlockPartSetHeader)
+	case *pbtypes.Message_BlockPart:
+		msg = types.BlockPartFromProto(m.BlockPart)
+	case *pbtypes.Message_HasBlockPart:
+		msg = types.HasBlockPartFromProto(m.HasBlockPart)
+	case *pbtypes.Message_BlockRequest:
+		msg = types.BlockRequestFromProto(m.BlockRequest)
+	case *pbtypes.Message_BlockResponse:
+		msg = types.BlockResponseFromProto(m.BlockResponse)
+	case *pbtypes.Message_AggregateVote:
+		msg = types.AggregateVoteFromProto(m.AggregateVote)
 	default:
 		panic(fmt.Sprintf("unknown message type: %T", pb.Msg))
 	}
@@ -2,6 +2,7 @@ package consensus
 
 import (
 	"fmt"
+	"github.com/232425wxy/meta--/crypto/bls12"
 	"github.com/232425wxy/meta--/proto/pbtypes"
 	"github.com/232425wxy/meta--/types"
 	"github.com/stretchr/testify/assert"
@@ -26,3 +27,97 @@ func TestEncodeDecodeMsg(t *testing.T) {
 		panic(fmt.Sprintf("unknown message type: %T", m))
 	}
 }
+
+func TestEncodeDecodeNewView(t *testing.T) {
+	privateKey, err := bls12.GeneratePrivateKey()
+	assert.Nil(t, err)
+	nv, err := types.NewNewView(12, 3, 0, nil, nil, types.NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	assert.Nil(t, nv.ValidateBasic())
+
+	res := MustDecode(MustEncode(nv))
+	m, ok := res.(*types.NewView)
+	assert.True(t, ok)
+	assert.Equal(t, nv.ID, m.ID)
+	assert.Equal(t, nv.Height, m.Height)
+	assert.Equal(t, nv.Round, m.Round)
+	assert.Nil(t, m.HighQC)
+	assert.Nil(t, m.HighQCBlock)
+	assert.True(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+
+	// 篡改轮次后签名不再有效
+	m.Round = 4
+	assert.False(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+
+	// highQC携带的区块没有区块头
+	nv, err = types.NewNewView(12, 3, 2, &types.Block{}, &types.PreCommit{Height: 12, Round: 2}, types.NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	assert.NotPanics(t, func() { assert.NotNil(t, nv.ValidateBasic()) })
+}
+
+func TestEncodeDecodeChainedNewView(t *testing.T) {
+	privateKey, err := bls12.GeneratePrivateKey()
+	assert.Nil(t, err)
+	nv, err := types.NewChainedNewView(8, nil, types.NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	assert.Nil(t, nv.ValidateBasic())
+
+	res := MustDecode(MustEncode(nv))
+	m, ok := res.(*types.ChainedNewView)
+	assert.True(t, ok)
+	assert.Equal(t, nv.ID, m.ID)
+	assert.Equal(t, nv.View, m.View)
+	assert.Nil(t, m.HighQC)
+	assert.True(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+
+	// 篡改视图后签名不再有效
+	m.View = 9
+	assert.False(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+}
+
+func TestEncodeDecodeTimeout(t *testing.T) {
+	privateKey, err := bls12.GeneratePrivateKey()
+	assert.Nil(t, err)
+	timeout, err := types.NewTimeout(5, 2, nil, nil, types.NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	assert.Nil(t, timeout.ValidateBasic())
+
+	res := MustDecode(MustEncode(timeout))
+	m, ok := res.(*types.Timeout)
+	assert.True(t, ok)
+	assert.Equal(t, timeout.ID, m.ID)
+	assert.Equal(t, timeout.Height, m.Height)
+	assert.Equal(t, timeout.Round, m.Round)
+	assert.Nil(t, m.HighQC)
+	assert.True(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+
+	// 篡改轮次后签名不再有效
+	m.Round = 3
+	assert.False(t, privateKey.PublicKey().Verify(m.Signature, m.ValueHash()))
+}
+
+func TestEncodeDecodeBlockFetch(t *testing.T) {
+	prepare, _ := newCompactTestPrepare(t, types.Txs{[]byte("a=1"), []byte("b=2")})
+	hash := prepare.Block.ChameleonHash.Hash
+
+	decide := types.NewDecide(nil, types.GenerateDecideValueHash(3, 1, hash), hash, prepare.ID, 3, 1)
+	m, ok := MustDecode(MustEncode(decide)).(*types.Decide)
+	assert.True(t, ok)
+	assert.Equal(t, hash, m.BlockHash)
+	assert.Equal(t, decide.ValueHash, types.GenerateDecideValueHash(m.Height, m.Round, m.BlockHash))
+
+	req := &types.BlockRequest{Height: 3, BlockHash: hash}
+	assert.Nil(t, req.ValidateBasic())
+	assert.Equal(t, req, MustDecode(MustEncode(req)))
+	assert.NotNil(t, (&types.BlockRequest{Height: 3}).ValidateBasic())
+
+	resp, ok := MustDecode(MustEncode(&types.BlockResponse{Block: prepare.Block})).(*types.BlockResponse)
+	assert.True(t, ok)
+	assert.Nil(t, resp.ValidateBasic())
+	assert.Equal(t, hash, resp.Block.ChameleonHash.Hash)
+	assert.Equal(t, prepare.Block.Body.Txs, resp.Block.Body.Txs)
+
+	// 被篡改的区块无法通过ValidateBasic
+	resp.Block.Body.Txs[0] = []byte("forged tx")
+	assert.NotNil(t, resp.ValidateBasic())
+}
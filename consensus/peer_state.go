@@ -2,82 +2,209 @@ package consensus
 
 import (
 	"bytes"
-	"fmt"
 	"github.com/232425wxy/meta--/types"
+	"sync"
+	"time"
 )
 
 type PeerState struct {
 	Height    int64 `json:"height"`
 	Round     int16 `json:"round"`
 	Step      Step  `json:"step"`
-	prepare   []byte
-	preCommit []byte
-	commit    []byte
-	decide    []byte
+	prepare   sentMsg
+	preCommit sentMsg
+	commit    sentMsg
+	decide    sentMsg
+
+	mu        sync.Mutex // 保护PeerState的所有字段，它们会同时被Receive和向节点发送消息的goroutine访问
+	partsHash []byte     // 节点正在收集的分片集合
+	parts     map[uint32]struct{}
+
+	wake chan struct{} // 节点的状态发生变化或者有新的消息需要发送给节点时，唤醒向它发送消息的goroutine
+}
+
+// sentMsg 记录发送给节点的主节点消息，以及发送时节点所处的状态，用来判断消息是否需要重传。
+type sentMsg struct {
+	key    []byte // Prepare消息为区块哈希，其他消息为ValueHash
+	height int64
+	round  int16
+	step   Step
+	at     time.Time
 }
 
 func NewPeerState() *PeerState {
 	return &PeerState{
-		Height:    -1,
-		Round:     -1,
-		Step:      -1,
-		prepare:   nil,
-		preCommit: nil,
-		commit:    nil,
-		decide:    nil,
+		Height: -1,
+		Round:  -1,
+		Step:   -1,
+		wake:   make(chan struct{}, 1),
 	}
 }
 
 func (ps *PeerState) SetHeight(height int64) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 	ps.Height = height
 }
 
 func (ps *PeerState) GetHeight() int64 {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 	return ps.Height
 }
 
 func (ps *PeerState) SetRound(round int16) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 	ps.Round = round
 }
 
 func (ps *PeerState) SetStep(step Step) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 	ps.Step = step
 }
 
+// Wake 唤醒向节点发送消息的goroutine，已经有一个没有被处理的唤醒信号时直接返回。
+func (ps *PeerState) Wake() {
+	select {
+	case ps.wake <- struct{}{}:
+	default:
+	}
+}
+
+// WakeChan 返回唤醒信号的通道。
+func (ps *PeerState) WakeChan() <-chan struct{} {
+	return ps.wake
+}
+
 func (ps *PeerState) SetPrepare(prepare *types.Prepare) {
-	ps.prepare = prepare.Block.ChameleonHash.Hash
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.prepare = ps.sent(prepare.Block.ChameleonHash.Hash)
 }
 
 func (ps *PeerState) HasPrepare(prepare *types.Prepare) bool {
-	return bytes.Equal(ps.prepare, prepare.Block.ChameleonHash.Hash)
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return bytes.Equal(ps.prepare.key, prepare.Block.ChameleonHash.Hash)
+}
+
+// NeedsPrepare 判断是否需要把prepare发送给节点，见needs。
+func (ps *PeerState) NeedsPrepare(prepare *types.Prepare, retransmit time.Duration) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.needs(&ps.prepare, prepare.Block.ChameleonHash.Hash, prepare.Height, prepare.Round, retransmit)
 }
 
 func (ps *PeerState) SetPreCommit(preCommit *types.PreCommit) {
-	ps.preCommit = preCommit.ValueHash[:]
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.preCommit = ps.sent(preCommit.ValueHash)
 }
 
 func (ps *PeerState) HasPreCommit(preCommit *types.PreCommit) bool {
-	return bytes.Equal(ps.preCommit, preCommit.ValueHash[:])
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return bytes.Equal(ps.preCommit.key, preCommit.ValueHash)
+}
+
+func (ps *PeerState) NeedsPreCommit(preCommit *types.PreCommit, retransmit time.Duration) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.needs(&ps.preCommit, preCommit.ValueHash, preCommit.Height, preCommit.Round, retransmit)
 }
 
 func (ps *PeerState) SetCommit(commit *types.Commit) {
-	ps.commit = commit.ValueHash[:]
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.commit = ps.sent(commit.ValueHash)
 }
 
 func (ps *PeerState) HasCommit(commit *types.Commit) bool {
-	return bytes.Equal(ps.commit, commit.ValueHash[:])
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return bytes.Equal(ps.commit.key, commit.ValueHash)
+}
+
+func (ps *PeerState) NeedsCommit(commit *types.Commit, retransmit time.Duration) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.needs(&ps.commit, commit.ValueHash, commit.Height, commit.Round, retransmit)
 }
 
 func (ps *PeerState) SetDecide(decide *types.Decide) {
-	if decide == nil {
-		fmt.Println("decide是nil")
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.decide = ps.sent(decide.ValueHash)
+}
+
+func (ps *PeerState) HasDecide(decide *types.Decide) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return bytes.Equal(ps.decide.key, decide.ValueHash)
+}
+
+func (ps *PeerState) NeedsDecide(decide *types.Decide, retransmit time.Duration) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.needs(&ps.decide, decide.ValueHash, decide.Height, decide.Round, retransmit)
+}
+
+// sent 记录发送消息时节点所处的状态。
+func (ps *PeerState) sent(key []byte) sentMsg {
+	return sentMsg{key: key, height: ps.Height, round: ps.Round, step: ps.Step, at: time.Now()}
+}
+
+// needs 节点已经进入了比消息更高的高度或者轮次时，不再需要这条消息；还没有发送过这条消息时需要发送；发送之后节点的状态
+// 在retransmit时间内一直没有变化，则认为消息在路上丢失了，需要重新发送。
+func (ps *PeerState) needs(sent *sentMsg, key []byte, height int64, round int16, retransmit time.Duration) bool {
+	if ps.Height > height || (ps.Height == height && ps.Round > round) {
+		return false
 	}
-	if len(decide.ValueHash[:]) == 0 {
-		fmt.Println("decide.ValueHash是空的")
+	if !bytes.Equal(sent.key, key) {
+		return true
 	}
-	ps.decide = decide.ValueHash[:]
+	unchanged := sent.height == ps.Height && sent.round == ps.Round && sent.step == ps.Step
+	return unchanged && time.Since(sent.at) >= retransmit
 }
 
-func (ps *PeerState) HasDecide(decide *types.Decide) bool {
-	return bytes.Equal(ps.decide, decide.ValueHash[:])
+// SetBlockPartSetHeader 记录节点开始收集header所代表的分片集合。
+func (ps *PeerState) SetBlockPartSetHeader(header types.PartSetHeader) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.resetBlockParts(header.Hash)
+}
+
+// SetHasBlockPart 记录节点已经有了分片集合hash里的第index个分片，节点开始收集另一个分片集合时，之前的记录会被清空。
+func (ps *PeerState) SetHasBlockPart(hash []byte, index uint32) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.resetBlockParts(hash)
+	ps.parts[index] = struct{}{}
+}
+
+// CollectsBlockParts 判断节点是否正在收集分片集合hash。
+func (ps *PeerState) CollectsBlockParts(hash []byte) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.partsHash != nil && bytes.Equal(ps.partsHash, hash)
+}
+
+func (ps *PeerState) HasBlockPart(hash []byte, index uint32) bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if !bytes.Equal(ps.partsHash, hash) {
+		return false
+	}
+	_, ok := ps.parts[index]
+	return ok
+}
+
+func (ps *PeerState) resetBlockParts(hash []byte) {
+	if ps.partsHash != nil && bytes.Equal(ps.partsHash, hash) {
+		return
+	}
+	ps.partsHash = hash
+	ps.parts = make(map[uint32]struct{})
 }
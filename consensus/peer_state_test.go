@@ -3,8 +3,11 @@ package consensus
 import (
 	"github.com/232425wxy/meta--/common/cmap"
 	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbtypes"
 	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestPeerData(t *testing.T) {
@@ -24,3 +27,51 @@ func TestPeerData(t *testing.T) {
 
 	t.Log("ps:", ps)
 }
+
+func TestPeerStateNeeds(t *testing.T) {
+	prepare, _ := newCompactTestPrepare(t, types.Txs{[]byte("a=1")})
+	ps := NewPeerState()
+	ps.SetHeight(3)
+	ps.SetRound(1)
+	ps.SetStep(NewRoundStep)
+
+	// 还没有发送过
+	assert.True(t, ps.NeedsPrepare(prepare, time.Hour))
+	ps.SetPrepare(prepare)
+	assert.True(t, ps.HasPrepare(prepare))
+	assert.False(t, ps.NeedsPrepare(prepare, time.Hour))
+
+	// 节点的状态一直没有变化，超时后重传
+	assert.True(t, ps.NeedsPrepare(prepare, 0))
+
+	// 节点的状态变化了，说明它收到了消息
+	ps.SetStep(PrepareVoteStep)
+	assert.False(t, ps.NeedsPrepare(prepare, 0))
+
+	// 节点已经进入了更高的轮次，不再需要这条消息
+	ps.SetRound(2)
+	ps.SetPrepare(&types.Prepare{Block: &types.Block{ChameleonHash: &types.ChameleonHash{Hash: []byte("other")}}})
+	assert.False(t, ps.NeedsPrepare(prepare, 0))
+
+	// 唤醒信号不会阻塞，也不会堆积
+	ps.Wake()
+	ps.Wake()
+	<-ps.WakeChan()
+	select {
+	case <-ps.WakeChan():
+		t.Fatal("wake signals should not pile up")
+	default:
+	}
+}
+
+func TestStepAwaitsVoteResponse(t *testing.T) {
+	// 投票之后一直停留在等待主节点回应的步骤里，投票就需要重传
+	assert.True(t, PrepareVoteStep.awaitsVoteResponse(pbtypes.PrepareVoteType))
+	assert.True(t, TwoPhaseVoteStep.awaitsVoteResponse(pbtypes.PrepareVoteType))
+	assert.True(t, TwoPhaseLockVoteStep.awaitsVoteResponse(pbtypes.PreCommitVoteType))
+	assert.True(t, CommitVoteStep.awaitsVoteResponse(pbtypes.CommitVoteType))
+	// 收到了主节点下一个步骤的消息，说明投票已经被收集到了
+	assert.False(t, PreCommitVoteStep.awaitsVoteResponse(pbtypes.PrepareVoteType))
+	assert.False(t, DecideStep.awaitsVoteResponse(pbtypes.CommitVoteType))
+	assert.False(t, NewRoundStep.awaitsVoteResponse(pbtypes.PreCommitVoteType))
+}
@@ -3,6 +3,7 @@ package consensus
 import (
 	"fmt"
 	"github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/events"
 	"github.com/232425wxy/meta--/p2p"
 	"github.com/232425wxy/meta--/types"
@@ -13,11 +14,18 @@ type Reactor struct {
 	p2p.BaseReactor
 	core     *Core
 	waitSync bool
+	relay    *compactRelay   // 无论自己是否开启了compact_blocks，都能还原其他主节点发来的紧凑提案
+	leader   leaderMsgs      // 自己作为主节点最近一次提出的消息，见gossip.go
+	votes    *voteAggregator // 开启投票聚合树时自己正在聚合的投票，见vote_tree.go
+	unacked  unackedVotes    // 自己直接发送给主节点、还没有得到回应的投票，见gossip.go
 	mu       sync.RWMutex
+
+	partsMu   sync.Mutex
+	proposals map[crypto.ID]*proposalParts // 每个节点发来的最新的Prepare消息的分片集合，见block_parts.go
 }
 
 func NewReactor(core *Core) *Reactor {
-	r := &Reactor{core: core, waitSync: true}
+	r := &Reactor{core: core, waitSync: true, relay: newCompactRelay(core.txsPool), votes: newVoteAggregator(), proposals: make(map[crypto.ID]*proposalParts)}
 	r.BaseReactor = *p2p.NewBaseReactor("Consensus")
 	return r
 }
@@ -29,10 +37,23 @@ func (r *Reactor) Start() error {
 			return err
 		}
 	}
-	go r.gossipRoutine()
+	go r.dispatchRoutine()
 	return r.BaseService.Start()
 }
 
+func (r *Reactor) Stop() error {
+	r.unsubscribeEvents()
+	r.mu.RLock()
+	waitSync := r.waitSync
+	r.mu.RUnlock()
+	if !waitSync {
+		if err := r.core.Stop(); err != nil {
+			r.Logger.Error("failed to stop consensus core", "err", err)
+		}
+	}
+	return r.BaseService.Stop()
+}
+
 func (r *Reactor) InitPeer(peer *p2p.Peer) *p2p.Peer {
 	stat := NewPeerState()
 	peer.Set(types.PeerStateKey, stat)
@@ -40,7 +61,12 @@ func (r *Reactor) InitPeer(peer *p2p.Peer) *p2p.Peer {
 }
 
 func (r *Reactor) AddPeer(peer *p2p.Peer) {
+	go r.gossipRoutine(peer)
+	go r.gossipBlockPartsRoutine(peer)
+}
 
+func (r *Reactor) RemovePeer(peer *p2p.Peer, reason error) {
+	r.removeProposalParts(peer.NodeID())
 }
 
 func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
@@ -73,6 +99,27 @@ func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			RecvBufferCapacity:  1024 * 1024,
 			RecvMessageCapacity: 1024 * 1024,
 		},
+		{
+			ID:                  p2p.BlockPartChannel,
+			Priority:            8,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  1024 * 1024 * 10,
+			RecvMessageCapacity: 1024 * 1024,
+		},
+		{
+			ID:                  p2p.BlockFetchChannel,
+			Priority:            6,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  1024 * 1024 * 10,
+			RecvMessageCapacity: 1024 * 1024 * 2, // 回复里携带的是完整的区块
+		},
+		{
+			ID:                  p2p.BlockTxsChannel,
+			Priority:            8,
+			SendQueueCapacity:   100,
+			RecvBufferCapacity:  1024 * 1024 * 10,
+			RecvMessageCapacity: 1024 * 1024,
+		},
 	}
 }
 
@@ -85,8 +132,49 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, bz []byte) {
 	switch chID {
 	case p2p.ReplicaNextViewChannel, p2p.LeaderProposeChannel, p2p.ReplicaVoteChannel:
 		msg := MustDecode(bz)
+		if cp, ok := msg.(*types.CompactPrepare); ok {
+			r.receiveCompactPrepare(src, cp)
+			return
+		}
+		if chID == p2p.ReplicaVoteChannel && (r.receiveTreeVote(src, msg) || r.relayVote(src, msg, bz)) {
+			return
+		}
 		info := MessageInfo{Msg: msg, NodeID: src.NodeID()}
 		r.core.sendExternalMessage(info)
+	case p2p.BlockPartChannel:
+		var err error
+		switch msg := MustDecode(bz).(type) {
+		case *types.BlockPartSetHeader:
+			err = r.receiveBlockPartSetHeader(src, msg)
+		case *types.BlockPart:
+			err = r.receiveBlockPart(src, ps, msg)
+		case *types.HasBlockPart:
+			if msg.ValidateBasic() == nil {
+				ps.SetHasBlockPart(msg.Hash, msg.Index)
+			}
+		}
+		if err != nil {
+			r.Logger.Warn("failed to handle block part message", "from", src.NodeID(), "err", err)
+		}
+	case p2p.BlockTxsChannel:
+		switch msg := MustDecode(bz).(type) {
+		case *types.BlockTxsRequest:
+			resp, err := r.relay.serve(msg)
+			if err != nil {
+				r.Logger.Warn("failed to serve block txs request", "from", src.NodeID(), "err", err)
+				return
+			}
+			src.Send(p2p.BlockTxsChannel, MustEncode(resp))
+		case *types.BlockTxsResponse:
+			prepare, err := r.relay.fill(src.NodeID(), msg)
+			if err != nil {
+				r.Logger.Warn("failed to restore compact Prepare message", "from", src.NodeID(), "err", err)
+				return
+			}
+			r.core.sendExternalMessage(MessageInfo{Msg: prepare, NodeID: src.NodeID()})
+		}
+	case p2p.BlockFetchChannel:
+		r.receiveBlockFetch(src, MustDecode(bz))
 	case p2p.ReplicaStateChannel:
 		msg := events.MustDecode(bz)
 		switch msg := msg.(type) {
@@ -94,6 +182,7 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, bz []byte) {
 			ps.SetHeight(msg.Height)
 			ps.SetRound(msg.Round)
 			ps.SetStep(Step(msg.Step))
+			ps.Wake()
 			//r.Logger.Trace("收到了其他节点的状态信息", "状态", msg)
 		}
 	default:
@@ -111,6 +200,7 @@ func (r *Reactor) SwitchToConsensus(stat *state.State) {
 	r.waitSync = false
 	r.mu.Unlock()
 
+	r.core.updateToState(stat)
 	if err := r.core.Start(); err != nil {
 		panic(err)
 	}
@@ -124,6 +214,48 @@ func (r *Reactor) subscribeEvents() {
 		}); err != nil {
 		r.Logger.Warn("failed to add listener for events", "err", err)
 	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventNewView,
+		func(data events.EventData) {
+			r.broadcastNewView(data.(*types.NewView))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventEquivocation,
+		func(data events.EventData) {
+			r.broadcastEquivocation(data.(*types.Prepare))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventChainedProposal,
+		func(data events.EventData) {
+			r.broadcastChainedProposal(data.(*types.ChainedProposal))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventChainedVote,
+		func(data events.EventData) {
+			r.sendChainedVoteToLeader(data.(*types.ChainedVote))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventChainedNewView,
+		func(data events.EventData) {
+			r.sendChainedNewViewToLeader(data.(*types.ChainedNewView))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventTimeout,
+		func(data events.EventData) {
+			r.broadcastTimeout(data.(*types.Timeout))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
+	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventBlockRequest,
+		func(data events.EventData) {
+			r.broadcastBlockRequest(data.(*types.BlockRequest))
+		}); err != nil {
+		r.Logger.Warn("failed to add listener for events", "err", err)
+	}
 	if err := r.core.eventSwitch.AddListenerWithEvent(subscriber, events.EventNewStep,
 		func(data events.EventData) {
 			r.broadcastNewStep(data.(*events.EventDataNewStep))
@@ -137,104 +269,71 @@ func (r *Reactor) unsubscribeEvents() {
 	r.core.eventSwitch.RemoveListener(subscriber)
 }
 
-func (r *Reactor) gossipRoutine() {
-	logger := r.Logger.New()
-
-	for {
-		if r.core.isLeader() {
-			select {
-			case prepare := <-r.core.stepInfo.prepare:
-				for _, p := range r.Switch.Peers().Peers() {
-					// 用PeerState来保证只会给节点发送一次主节点提出的共识消息
-					ps := p.Data.Get(types.PeerStateKey).(*PeerState)
-					if (r.core.stepInfo.step == PrepareStep || r.core.stepInfo.step == PrepareVoteStep) && !ps.HasPrepare(prepare) {
-						msg := MustEncode(prepare)
-						if ok := p.Send(p2p.LeaderProposeChannel, msg); ok {
-							ps.SetPrepare(prepare)
-							//logger.Info("leader is me, send Prepare message", "to", peer.NodeID())
-						} else {
-							logger.Error("failed to send Prepare message", "to", p.NodeID())
-							return
-						}
-					}
-				}
-
-			case preCommit := <-r.core.stepInfo.preCommit:
-				for _, p := range r.Switch.Peers().Peers() {
-					// 用PeerState来保证只会给节点发送一次主节点提出的共识消息
-					ps := p.Data.Get(types.PeerStateKey).(*PeerState)
-					if (r.core.stepInfo.step == PreCommitStep || r.core.stepInfo.step == PreCommitVoteStep) && !ps.HasPreCommit(preCommit) {
-						msg := MustEncode(preCommit)
-						if ok := p.Send(p2p.LeaderProposeChannel, msg); ok {
-							ps.SetPreCommit(preCommit)
-							//logger.Info("leader is me, send PreCommit message", "to", peer.NodeID())
-						} else {
-							logger.Error("failed to send PreCommit message", "to", p.NodeID())
-							return
-						}
-					}
-				}
-
-			case commit := <-r.core.stepInfo.commit:
-				for _, p := range r.Switch.Peers().Peers() {
-					// 用PeerState来保证只会给节点发送一次主节点提出的共识消息
-					ps := p.Data.Get(types.PeerStateKey).(*PeerState)
-					if (r.core.stepInfo.step == CommitStep || r.core.stepInfo.step == CommitVoteStep) && !ps.HasCommit(commit) {
-						msg := MustEncode(commit)
-						if ok := p.Send(p2p.LeaderProposeChannel, msg); ok {
-							ps.SetCommit(commit)
-							//logger.Info("leader is me, send Commit message", "to", peer.NodeID())
-						} else {
-							logger.Error("failed to send Commit message", "to", p.NodeID())
-							return
-						}
-					}
-				}
-
-			case decide := <-r.core.stepInfo.decide:
-				for _, p := range r.Switch.Peers().Peers() {
-					// 用PeerState来保证只会给节点发送一次主节点提出的共识消息
-					ps := p.Data.Get(types.PeerStateKey).(*PeerState)
-					if r.core.stepInfo.step == DecideStep && !ps.HasDecide(decide) {
-						msg := MustEncode(decide)
-						if ok := p.Send(p2p.LeaderProposeChannel, msg); ok {
-							ps.SetDecide(decide)
-							//logger.Trace("leader is me, send Decide message", "to", p.NodeID())
-						} else {
-							logger.Error("failed to send Decide message", "to", p.NodeID())
-							return
-						}
-					}
-				}
-
-			default:
-
-			}
-		}
-
-		// 只给leader节点发送投票信息
-		select {
-		case vote := <-r.core.prepareVotesQueue:
-			msg := MustEncode(vote)
-			_ = r.Switch.SendToPeer(p2p.ReplicaVoteChannel, r.core.state.Validators.GetLeader(r.core.stepInfo.round).ID, msg)
-			//logger.Info("send PrepareVote to leader", "me", r.core.publicKey.ToID(), "leader", peer.NodeID())
-		case vote := <-r.core.preCommitVotesQueue:
-			msg := MustEncode(vote)
-			_ = r.Switch.SendToPeer(p2p.ReplicaVoteChannel, r.core.state.Validators.GetLeader(r.core.stepInfo.round).ID, msg)
-			//logger.Info("send PreCommitVote to leader", "me", r.core.publicKey.ToID(), "leader", peer.NodeID())
-		case vote := <-r.core.commitVotesQueue:
-			msg := MustEncode(vote)
-			_ = r.Switch.SendToPeer(p2p.ReplicaVoteChannel, r.core.state.Validators.GetLeader(r.core.stepInfo.round).ID, msg)
-			//logger.Info("send CommitVote to leader", "me", r.core.publicKey.ToID(), "leader", peer.NodeID())
-		default:
+// encodePrepare 开启了compact_blocks时，Prepare消息以紧凑区块的形式发送，否则发送完整的区块。
+func (r *Reactor) encodePrepare(prepare *types.Prepare) []byte {
+	if r.core.cfg.CompactBlocks {
+		return MustEncode(r.relay.compact(prepare))
+	}
+	return MustEncode(prepare)
+}
 
+// receiveCompactPrepare 用交易池里的交易还原主节点发来的紧凑提案，交易池里缺少的交易向主节点索要，还原出的Prepare
+// 消息与直接收到的Prepare消息一样交给Core处理。
+func (r *Reactor) receiveCompactPrepare(src *p2p.Peer, cp *types.CompactPrepare) {
+	prepare, req, err := r.relay.restore(src.NodeID(), cp)
+	if err != nil {
+		r.Logger.Warn("failed to restore compact Prepare message", "from", src.NodeID(), "err", err)
+		return
+	}
+	if req != nil {
+		if ok := src.Send(p2p.BlockTxsChannel, MustEncode(req)); !ok {
+			r.Logger.Error("failed to send block txs request", "to", src.NodeID())
 		}
+		return
 	}
+	r.core.sendExternalMessage(MessageInfo{Msg: prepare, NodeID: src.NodeID()})
 }
 
 func (r *Reactor) sendNextViewToLeader(view *types.NextView) {
 	bz := MustEncode(view)
-	_ = r.Switch.SendToPeer(p2p.ReplicaNextViewChannel, r.core.state.Validators.GetLeader(r.core.stepInfo.round).ID, bz)
+	// 执行完区块后，状态里的验证者集合已经轮换到了下一个高度，NextView消息发送给下一个高度第1轮的主节点
+	_ = r.Switch.SendToPeer(p2p.ReplicaNextViewChannel, r.core.state.Validators.GetLeader(1).ID, bz)
+}
+
+// broadcastNewView 共识超时后，将自己的NewView消息广播给所有节点，新一轮的主节点收集到足够多的NewView消息后会继续推进共识。
+func (r *Reactor) broadcastNewView(view *types.NewView) {
+	bz := MustEncode(view)
+	r.Switch.Broadcast(p2p.ReplicaNextViewChannel, bz)
+}
+
+// broadcastEquivocation 拜占庭主节点绕过PeerState，把与正常提案相冲突的Prepare消息直接广播给所有节点。
+func (r *Reactor) broadcastEquivocation(prepare *types.Prepare) {
+	bz := MustEncode(prepare)
+	r.Switch.Broadcast(p2p.LeaderProposeChannel, bz)
+}
+
+// broadcastChainedProposal 链式HotStuff里主节点把自己提出的区块广播给所有节点。
+func (r *Reactor) broadcastChainedProposal(proposal *types.ChainedProposal) {
+	bz := MustEncode(proposal)
+	r.Switch.Broadcast(p2p.LeaderProposeChannel, bz)
+}
+
+// sendChainedVoteToLeader 链式HotStuff里为view视图的区块投出的票由view+1视图的主节点收集。
+func (r *Reactor) sendChainedVoteToLeader(vote *types.ChainedVote) {
+	bz := MustEncode(vote)
+	_ = r.Switch.SendToPeer(p2p.ReplicaVoteChannel, r.core.chainedLeader(vote.View+1).ID, bz)
+}
+
+// sendChainedNewViewToLeader 视图超时后，把携带highQC的ChainedNewView消息发送给新视图的主节点。
+func (r *Reactor) sendChainedNewViewToLeader(view *types.ChainedNewView) {
+	bz := MustEncode(view)
+	_ = r.Switch.SendToPeer(p2p.ReplicaNextViewChannel, r.core.chainedLeader(view.View).ID, bz)
+}
+
+// broadcastTimeout 两阶段共识里把自己的Timeout消息广播给所有节点，每个节点都可以用收集到的Timeout消息组成超时证书。
+func (r *Reactor) broadcastTimeout(timeout *types.Timeout) {
+	bz := MustEncode(timeout)
+	r.Switch.Broadcast(p2p.ReplicaNextViewChannel, bz)
 }
 
 func (r *Reactor) broadcastNewStep(step *events.EventDataNewStep) {
@@ -0,0 +1,75 @@
+package consensus
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/events"
+	"io"
+)
+
+// catchupReplay 节点重启后，重放WAL里当前高度的消息，使节点恢复到崩溃前所处的轮次和阶段，
+// 并且找回崩溃前收到的区块和QC，避免在同一高度对不同的区块进行投票。
+func (c *Core) catchupReplay(height int64) error {
+	c.replayMode = true
+	defer func() { c.replayMode = false }()
+
+	// 如果WAL里已经存在当前高度的结束标志，说明当前高度的区块已经被执行了，但是状态里记录的高度
+	// 却没有更新，这种情况不应该发生
+	dec, found, err := c.wal.SearchForEndHeight(height)
+	if err != nil {
+		return err
+	}
+	if found {
+		_ = dec.Close()
+		return fmt.Errorf("WAL should not contain #ENDHEIGHT %d", height)
+	}
+
+	dec, found, err = c.wal.SearchForEndHeight(height - 1)
+	if err == io.EOF {
+		c.Logger.Error("replay: WAL file ends without #ENDHEIGHT", "height", height-1)
+	} else if err != nil {
+		return err
+	}
+	if !found {
+		c.Logger.Warn("cannot replay consensus messages, because cannot find #ENDHEIGHT in WAL", "height", height-1)
+		return nil
+	}
+	defer func() { _ = dec.Close() }()
+
+	c.Logger.Info("catchup by replaying consensus messages", "height", height)
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		} else if IsDataCorruptionError(err) {
+			c.Logger.Error("data has been corrupted in last height of consensus WAL", "err", err, "height", height)
+			return err
+		} else if err != nil {
+			return err
+		}
+		c.readReplayMessage(msg)
+	}
+	c.Logger.Info("replay: done", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	return nil
+}
+
+// readReplayMessage 按照WAL里记录的顺序重新处理消息。
+func (c *Core) readReplayMessage(msg *TimedWALMessage) {
+	switch m := msg.Msg.(type) {
+	case events.EventDataNewStep:
+		c.Logger.Trace("replay: new step", "height", m.Height, "round", m.Round, "step", Step(m.Step))
+	case MessageInfo:
+		c.Logger.Trace("replay: message", "type", fmt.Sprintf("%T", m.Msg), "peer", m.NodeID)
+		c.handleMsg(m)
+	case TimeoutInfo:
+		c.Logger.Trace("replay: timeout", "height", m.Height, "round", m.Round, "step", m.Step, "duration", m.Duration)
+		if m.Step == ConsensusTimeout {
+			c.handleViewTimeout(m)
+		} else {
+			c.handleScheduled(m, *c.stepInfo)
+		}
+	case EndHeightMessage:
+		c.Logger.Trace("replay: end height", "height", m.Height)
+	default:
+		c.Logger.Error("replay: unknown TimedWALMessage type", "type", fmt.Sprintf("%T", msg.Msg))
+	}
+}
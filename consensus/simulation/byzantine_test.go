@@ -0,0 +1,109 @@
+package simulation
+
+import (
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func byzantineConfig(node int, byzantine *config.ByzantineConfig) *Config {
+	cfg := DefaultConfig()
+	cfg.Byzantine = map[int]*config.ByzantineConfig{node: byzantine}
+	return cfg
+}
+
+func TestSimulationEquivocatePrepare(t *testing.T) {
+	sim := newSimulation(t, byzantineConfig(0, &config.ByzantineConfig{EquivocatePrepare: "1-"}))
+	require.Nil(t, sim.WaitForHeight(6, 2*time.Minute))
+	require.Nil(t, sim.CheckSafety())
+
+	// 诚实节点发现了主节点提出的相互冲突的区块，并且将证据打包进了区块里
+	byzantine := sim.Nodes()[0].ID
+	honest := sim.Nodes()[1]
+	found := false
+	for h := int64(1); h <= honest.Height(); h++ {
+		for _, ev := range honest.Block(h).Evidence {
+			if _, ok := ev.(*types.ConflictingPrepareEvidence); ok && ev.Offender() == byzantine {
+				found = true
+			}
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestSimulationWithholdDecide(t *testing.T) {
+	// 作恶的主节点自己提交了区块，其他节点切换视图后在新的一轮里提交同一个区块；作恶的节点没有同步模块，可能会落后
+	sim := newSimulation(t, byzantineConfig(0, &config.ByzantineConfig{WithholdDecide: "1-"}))
+	assert.Nil(t, sim.WaitForHeight(5, 2*time.Minute, 1, 2, 3))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestSimulationWrongVoteHash(t *testing.T) {
+	// 主节点拒绝错误的投票，剩下的3个诚实节点依然满足法定人数
+	sim := newSimulation(t, byzantineConfig(0, &config.ByzantineConfig{WrongVoteHash: "1-"}))
+	assert.Nil(t, sim.WaitForHeight(5, 2*time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+// faultsFrom 返回节点发现的由offender造成的错误。
+func faultsFrom(node *Node, offender crypto.ID) []Fault {
+	var faults []Fault
+	for _, fault := range node.Faults() {
+		if fault.From == offender {
+			faults = append(faults, fault)
+		}
+	}
+	return faults
+}
+
+func TestSimulationCorruptDKGShares(t *testing.T) {
+	cfg := byzantineConfig(0, &config.ByzantineConfig{CorruptDKGShares: "0-"})
+	cfg.DKG = true
+	sim := newSimulation(t, cfg)
+
+	// 作恶节点算出的变色龙哈希函数的公钥与其他节点的不同，诚实节点在分布式密钥生成结束时就发现了它
+	byzantine := sim.Nodes()[0]
+	for _, node := range sim.Nodes()[1:] {
+		assert.NotEmpty(t, faultsFrom(node, byzantine.ID), "node %d", node.Index)
+	}
+
+	// 诚实节点之间的公钥是一致的，它们拒绝作恶节点用错误公钥生成的区块，剩下的3个节点依然满足法定人数
+	assert.Nil(t, sim.WaitForHeight(5, 2*time.Minute, 1, 2, 3))
+	assert.Nil(t, sim.CheckSafety())
+	for h := int64(1); h <= 5; h++ {
+		for _, node := range sim.Nodes()[1:] {
+			assert.NotEqual(t, byzantine.ID, node.Block(h).Header.Proposer)
+		}
+	}
+}
+
+func TestSimulationCorruptRedactShares(t *testing.T) {
+	cfg := byzantineConfig(2, &config.ByzantineConfig{CorruptRedactShares: "1-"})
+	cfg.DKG = true
+	sim := newSimulation(t, cfg)
+	require.Nil(t, sim.WaitForHeight(4, time.Minute))
+
+	height := redactableHeight(t, sim.Nodes()[0])
+	origin := sim.Nodes()[0].Block(height).Copy()
+	require.Nil(t, sim.Redact(0, height, 0, []byte("key"), []byte("redacted")))
+
+	// 诚实节点发现了错误的签名片段，修订无法完成，所有节点的区块都保持原样，变色龙哈希依然有效
+	byzantine := sim.Nodes()[2].ID
+	for _, node := range sim.Nodes() {
+		if node.ID != byzantine {
+			assert.NotEmpty(t, faultsFrom(node, byzantine), "node %d", node.Index)
+		}
+		block := node.Block(height)
+		assert.Equal(t, origin.Body.Txs, block.Body.Txs)
+		assert.Equal(t, origin.ChameleonHash.Hash, block.ChameleonHash.Hash)
+		assert.Nil(t, node.chameleon.VerifyHash(block))
+	}
+
+	// 失败的修订不影响共识
+	assert.Nil(t, sim.WaitForHeight(sim.Nodes()[0].Height()+3, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+}
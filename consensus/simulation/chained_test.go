@@ -0,0 +1,74 @@
+package simulation
+
+import (
+	"github.com/232425wxy/meta--/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func chainedConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Protocol = config.ProtocolChained
+	return cfg
+}
+
+func TestChainedProgress(t *testing.T) {
+	sim := newSimulation(t, chainedConfig())
+	assert.Nil(t, sim.WaitForHeight(10, 30*time.Second))
+	assert.Nil(t, sim.CheckSafety())
+
+	for h := int64(1); h <= 10; h++ {
+		block := sim.Nodes()[0].Block(h)
+		for _, node := range sim.Nodes()[1:] {
+			assert.Equal(t, block.ChameleonHash.Hash, node.Block(h).ChameleonHash.Hash)
+		}
+		// 父区块还没有被提交时，区块头里携带的是执行完最后提交的区块后的状态哈希，前几个区块携带的是创世时为空的状态哈希
+		if h > 4 {
+			assert.NotEmpty(t, block.Header.AppHash)
+			assert.Less(t, block.Header.AppHashHeight(), h)
+		}
+	}
+}
+
+func TestChainedSilentNode(t *testing.T) {
+	sim := newSimulation(t, chainedConfig())
+	// 节点2不发出任何消息，轮到它担任主节点时只能超时，其余3个节点依然能够推进共识
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		return env.From != 2
+	})
+	assert.Nil(t, sim.WaitForHeight(40, 2*time.Minute, 0, 1, 3))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestChainedNoConflictingTxs(t *testing.T) {
+	sim := newSimulation(t, chainedConfig())
+	require.Nil(t, sim.WaitForHeight(10, 30*time.Second))
+
+	// 流水线里尚未提交的区块不会重复打包同一笔交易
+	seen := make(map[string]int64)
+	node := sim.Nodes()[0]
+	for h := int64(1); h <= node.Height(); h++ {
+		for _, tx := range node.Block(h).Body.Txs {
+			_, ok := seen[string(tx)]
+			assert.False(t, ok, "tx %s is committed twice", tx)
+			seen[string(tx)] = h
+		}
+	}
+}
+
+func TestChainedThroughput(t *testing.T) {
+	run := func(protocol string) int64 {
+		cfg := DefaultConfig()
+		cfg.Protocol = protocol
+		cfg.TxInterval = 10 * time.Millisecond
+		sim := newSimulation(t, cfg)
+		sim.RunFor(10 * time.Second)
+		require.Nil(t, sim.CheckSafety())
+		return sim.Nodes()[0].Height()
+	}
+	basic, chained := run(config.ProtocolHotStuff), run(config.ProtocolChained)
+	t.Logf("committed %d blocks with four-phase HotStuff, %d blocks with chained HotStuff", basic, chained)
+	assert.GreaterOrEqual(t, chained, 3*basic)
+}
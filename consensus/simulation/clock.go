@@ -0,0 +1,140 @@
+package simulation
+
+import (
+	"container/heap"
+	"github.com/232425wxy/meta--/consensus"
+	"time"
+)
+
+// event 虚拟时钟上一个等待触发的事件，触发时间相同的事件按照被安排的先后顺序触发。
+type event struct {
+	at   time.Duration
+	seq  uint64
+	fire func()
+}
+
+type eventQueue []*event
+
+func (q eventQueue) Len() int { return len(q) }
+
+func (q eventQueue) Less(i, j int) bool {
+	if q[i].at != q[j].at {
+		return q[i].at < q[j].at
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q eventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *eventQueue) Push(x interface{}) { *q = append(*q, x.(*event)) }
+
+func (q *eventQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// Clock 虚拟时钟，时间从0开始，只有在触发事件时才会向前推进，与真实时间的流逝无关，因此几十秒的共识过程可以在
+// 很短的真实时间里跑完。
+type Clock struct {
+	now    time.Duration
+	seq    uint64
+	events eventQueue
+}
+
+func NewClock() *Clock {
+	return &Clock{}
+}
+
+// Now 返回从仿真开始到现在经过的虚拟时间。
+func (c *Clock) Now() time.Duration {
+	return c.now
+}
+
+// AfterFunc 在虚拟时间经过d之后调用fn。
+func (c *Clock) AfterFunc(d time.Duration, fn func()) {
+	if d < 0 {
+		d = 0
+	}
+	c.seq++
+	heap.Push(&c.events, &event{at: c.now + d, seq: c.seq, fire: fn})
+}
+
+// next 返回最早的待触发事件的触发时间，没有待触发的事件时返回false。
+func (c *Clock) next() (time.Duration, bool) {
+	if len(c.events) == 0 {
+		return 0, false
+	}
+	return c.events[0].at, true
+}
+
+// step 将时间推进到最早的待触发事件，并触发它。
+func (c *Clock) step() {
+	e := heap.Pop(&c.events).(*event)
+	c.now = e.at
+	e.fire()
+}
+
+// advance 触发所有不晚于deadline的事件，然后将时间推进到deadline。
+func (c *Clock) advance(deadline time.Duration) {
+	for {
+		at, ok := c.next()
+		if !ok || at > deadline {
+			break
+		}
+		c.step()
+	}
+	if c.now < deadline {
+		c.now = deadline
+	}
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// ticker 由虚拟时钟驱动的consensus.Ticker，与TimeoutTicker一样，只有最新安排的超时事件会被触发，并且更早的高度、
+// 轮次或者步骤的超时事件不会覆盖已经安排的事件。
+type ticker struct {
+	clock     *Clock
+	info      consensus.TimeoutInfo
+	gen       uint64 // 每安排一次新的超时事件就加1，被覆盖的旧事件触发时发现gen变了，就什么也不做
+	onTimeout func(ti consensus.TimeoutInfo)
+}
+
+func newTicker(clock *Clock, onTimeout func(ti consensus.TimeoutInfo)) *ticker {
+	return &ticker{clock: clock, onTimeout: onTimeout}
+}
+
+func (t *ticker) Start() error {
+	return nil
+}
+
+func (t *ticker) Stop() error {
+	t.gen++
+	return nil
+}
+
+func (t *ticker) ScheduleTimeout(ti consensus.TimeoutInfo) {
+	if ti.Height < t.info.Height {
+		return
+	} else if ti.Height == t.info.Height {
+		if ti.Round < t.info.Round {
+			return
+		} else if ti.Round == t.info.Round && t.info.Step > 0 && ti.Step <= t.info.Step {
+			return
+		}
+	}
+	t.info = ti
+	t.gen++
+	gen := t.gen
+	t.clock.AfterFunc(ti.Duration, func() {
+		if gen == t.gen {
+			t.onTimeout(ti)
+		}
+	})
+}
+
+// TockChan 超时事件通过onTimeout回调同步地交给仿真节点处理，不经过通道。
+func (t *ticker) TockChan() <-chan consensus.TimeoutInfo {
+	return nil
+}
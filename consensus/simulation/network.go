@@ -0,0 +1,119 @@
+package simulation
+
+import (
+	"github.com/232425wxy/meta--/common/rand"
+	"github.com/232425wxy/meta--/consensus"
+	"time"
+)
+
+// Envelope 虚拟网络里一条从From节点发往To节点的共识消息，From和To是节点在仿真里的序号。
+type Envelope struct {
+	From    int
+	To      int
+	Channel byte
+	Msg     consensus.Message
+	Delay   time.Duration // 消息在网络中传输的时间
+}
+
+// Filter 在消息被发送之前检查它，返回false表示丢弃这条消息，也可以通过修改Envelope.Delay让消息更晚到达。
+type Filter func(env *Envelope) bool
+
+// Network 代替p2p.Switch在仿真节点之间传递消息。消息的传输延迟、丢包率、网络分区和过滤器都可以在仿真过程中随时调整，
+// 所有的随机性都来自用种子初始化的随机数发生器，因此同样的种子和同样的脚本总是得到同样的消息投递顺序。网络分区和过滤
+// 器只作用于之后发送的消息，已经在传输中的消息不受影响。
+type Network struct {
+	clock    *Clock
+	rand     *rand.Rand
+	minDelay time.Duration
+	maxDelay time.Duration
+	dropRate float64
+	groups   map[int]int // 节点序号 -> 所在分区，为nil时表示网络没有分区
+	filters  []Filter
+	deliver  func(from, to int, msg consensus.Message)
+	sent     int
+	dropped  int
+}
+
+func newNetwork(clock *Clock, r *rand.Rand, deliver func(from, to int, msg consensus.Message)) *Network {
+	return &Network{clock: clock, rand: r, deliver: deliver}
+}
+
+// SetDelay 设置消息传输延迟的范围，每条消息的延迟在[min, max]之间随机选取。
+func (n *Network) SetDelay(min, max time.Duration) {
+	if max < min {
+		max = min
+	}
+	n.minDelay, n.maxDelay = min, max
+}
+
+// SetDropRate 设置随机丢弃消息的概率，取值范围是[0, 1]。
+func (n *Network) SetDropRate(rate float64) {
+	n.dropRate = rate
+}
+
+// Partition 将网络划分为若干个互不连通的分区，没有出现在任何分区里的节点被单独隔离。
+func (n *Network) Partition(groups ...[]int) {
+	n.groups = make(map[int]int)
+	for i, group := range groups {
+		for _, node := range group {
+			n.groups[node] = i
+		}
+	}
+}
+
+// Heal 消除网络分区。
+func (n *Network) Heal() {
+	n.groups = nil
+}
+
+// AddFilter 添加一个消息过滤器，消息需要通过所有的过滤器才会被发送。
+func (n *Network) AddFilter(filter Filter) {
+	n.filters = append(n.filters, filter)
+}
+
+// ClearFilters 删除所有的消息过滤器。
+func (n *Network) ClearFilters() {
+	n.filters = nil
+}
+
+// Stats 返回目前为止发送的消息数量和被丢弃的消息数量。
+func (n *Network) Stats() (sent, dropped int) {
+	return n.sent, n.dropped
+}
+
+func (n *Network) connected(from, to int) bool {
+	if n.groups == nil || from == to {
+		return true
+	}
+	g1, ok1 := n.groups[from]
+	g2, ok2 := n.groups[to]
+	return ok1 && ok2 && g1 == g2
+}
+
+// send 将消息交给网络传输，消息按照编码后再解码的形式到达接收方，接收方不会与发送方共享同一个消息对象。
+func (n *Network) send(env *Envelope) {
+	n.sent++
+	env.Delay = n.minDelay
+	if n.maxDelay > n.minDelay {
+		env.Delay += time.Duration(n.rand.Int63n(int64(n.maxDelay - n.minDelay + 1)))
+	}
+	if !n.connected(env.From, env.To) {
+		n.dropped++
+		return
+	}
+	if n.dropRate > 0 && n.rand.Float64() < n.dropRate {
+		n.dropped++
+		return
+	}
+	for _, filter := range n.filters {
+		if !filter(env) {
+			n.dropped++
+			return
+		}
+	}
+	bz := consensus.MustEncode(env.Msg)
+	from, to := env.From, env.To
+	n.clock.AfterFunc(env.Delay, func() {
+		n.deliver(from, to, consensus.MustDecode(bz))
+	})
+}
@@ -0,0 +1,515 @@
+// Package simulation 在一个进程里运行N个共识节点，用虚拟网络代替p2p.Switch，用虚拟时钟代替TimeoutTicker，消息的
+// 投递顺序、延迟、丢弃和网络分区都可以通过脚本控制，并且所有的随机性都来自同一个种子，因此同样的种子和脚本总能重现
+// 同样的共识过程。仿真过程中会持续检查安全性（不同节点不会在同一高度提交不同的区块），并提供活性检查（在规定的虚拟时间
+// 内区块高度有所推进）。
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/abci/apps"
+	"github.com/232425wxy/meta--/common/rand"
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/consensus"
+	"github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/evidence"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/stch"
+	"github.com/232425wxy/meta--/store"
+	"github.com/232425wxy/meta--/txspool"
+	"github.com/232425wxy/meta--/types"
+	"math/big"
+	"path/filepath"
+	"time"
+)
+
+// Config 仿真的配置。
+type Config struct {
+	Validators       int                             // 验证者节点的数量
+	Observers        int                             // 观察者节点的数量，它们排在验证者之后，不在验证者集合里，也从不投票
+	Seed             int64                           // 随机数种子，决定验证者的密钥、消息的延迟和丢弃
+	MinDelay         time.Duration                   // 消息传输的最小延迟
+	MaxDelay         time.Duration                   // 消息传输的最大延迟
+	DropRate         float64                         // 随机丢弃消息的概率
+	TimeoutConsensus time.Duration                   // 共识超时的基本时长，见ConsensusConfig.TimeoutConsensus
+	TxInterval       time.Duration                   // 每隔多久向所有节点提交一笔新的交易，为0时不自动提交交易
+	ConsensusParams  *types.ConsensusParams          // 为nil时使用默认的共识参数
+	Byzantine        map[int]*config.ByzantineConfig // 节点序号 -> 该节点的拜占庭行为，没有出现的节点总是诚实的
+	Protocol         string                          // 共识协议，为空时使用默认的四阶段共识，见ConsensusConfig.Protocol
+	WALDir           string                          // 每个节点的共识WAL都保存在这个目录下，为空时不启用WAL，节点也不能重启
+	DKG              bool                            // 为true时验证者之间先运行变色龙哈希的分布式密钥生成，之后才能修订区块，不能与观察者同时使用
+	Logger           log.Logger                      // 为nil时不输出日志
+}
+
+func DefaultConfig() *Config {
+	return &Config{
+		Validators:       4,
+		Seed:             1,
+		MinDelay:         10 * time.Millisecond,
+		MaxDelay:         50 * time.Millisecond,
+		DropRate:         0,
+		TimeoutConsensus: 2 * time.Second,
+		TxInterval:       200 * time.Millisecond,
+	}
+}
+
+// Node 仿真里的一个验证者节点或者观察者节点。
+type Node struct {
+	Index      int
+	ID         crypto.ID
+	driver     *consensus.Driver
+	blockStore *store.BlockStore
+	txsPool    *txspool.TxsPool
+	eventBus   *events.EventBus
+	height     int64 // 已经检查过安全性的区块高度
+	stch       *stch.Driver
+	faults     []Fault
+
+	// 重启节点时用来重新创建共识模块，它们在崩溃之后依然保留着，相当于保存在硬盘上的数据
+	privateKey   *bls12.PrivateKey
+	genesis      *types.Genesis
+	chameleon    *stch.Chameleon
+	stateStore   *state.StoreState
+	blockExec    *state.BlockExecutor
+	evidencePool *evidence.Pool
+	observer     bool
+	logger       log.Logger
+}
+
+// Fault 节点在处理变色龙哈希的消息时发现的错误，From是发送该消息的节点。
+type Fault struct {
+	From crypto.ID
+	Err  error
+}
+
+// Faults 返回节点在分布式密钥生成和修订区块的过程中发现的所有错误。
+func (n *Node) Faults() []Fault {
+	return n.faults
+}
+
+// Height 返回节点已经提交的最新区块的高度。
+func (n *Node) Height() int64 {
+	return n.blockStore.Height()
+}
+
+// Status 返回节点的共识进度。
+func (n *Node) Status() consensus.Status {
+	return n.driver.Status()
+}
+
+// Block 返回节点在height高度提交的区块。
+func (n *Node) Block(height int64) *types.Block {
+	return n.blockStore.LoadBlockByHeight(height)
+}
+
+// Simulation 在一个进程里同步地运行多个共识节点，所有的事件都在调用者的goroutine里按照虚拟时间的先后顺序依次处理。
+type Simulation struct {
+	cfg       *Config
+	clock     *Clock
+	network   *Network
+	rand      *rand.Rand
+	nodes     []*Node
+	index     map[crypto.ID]int
+	committed map[int64][]byte // 区块高度 -> 第一个提交该高度区块的节点所提交的区块哈希
+	violation error
+	txs       int
+}
+
+func New(cfg *Config) (*Simulation, error) {
+	if cfg.Validators <= 0 {
+		return nil, fmt.Errorf("the number of validators must be positive, got %d", cfg.Validators)
+	}
+	if cfg.DKG && cfg.Observers > 0 {
+		return nil, fmt.Errorf("observers cannot take part in distributed key generation")
+	}
+	logger := cfg.Logger
+	if logger == nil {
+		logger = log.New()
+		logger.SetHandler(log.DiscardHandler())
+	}
+	params := cfg.ConsensusParams
+	if params == nil {
+		params = types.DefaultConsensusParams()
+	}
+	if err := params.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	r := rand.NewRand()
+	r.Seed(cfg.Seed)
+	s := &Simulation{
+		cfg:       cfg,
+		clock:     NewClock(),
+		rand:      r,
+		index:     make(map[crypto.ID]int),
+		committed: make(map[int64][]byte),
+	}
+	s.network = newNetwork(s.clock, r, s.deliver)
+	s.network.SetDelay(cfg.MinDelay, cfg.MaxDelay)
+	s.network.SetDropRate(cfg.DropRate)
+
+	// 验证者的私钥和变色龙哈希的公共参数都由种子决定，这样每次仿真里主节点的轮换顺序都是一样的
+	privateKeys := make([]*bls12.PrivateKey, cfg.Validators)
+	for i := range privateKeys {
+		key := new(big.Int).SetBytes(r.Bytes(31))
+		privateKeys[i] = &bls12.PrivateKey{Key: key.Add(key, big.NewInt(1))}
+	}
+	alpha := new(big.Int).SetBytes(r.Bytes(32))
+	// 观察者的私钥在验证者的私钥和公共参数之后生成，这样加入观察者不会改变验证者的密钥
+	for i := 0; i < cfg.Observers; i++ {
+		key := new(big.Int).SetBytes(r.Bytes(31))
+		privateKeys = append(privateKeys, &bls12.PrivateKey{Key: key.Add(key, big.NewInt(1))})
+	}
+
+	for i, privateKey := range privateKeys {
+		// 每个节点都使用自己的验证者对象，因为验证者集合在轮换主节点时会修改验证者的优先级
+		validators := make([]*types.Validator, cfg.Validators)
+		for j, key := range privateKeys[:cfg.Validators] {
+			validators[j] = types.NewValidator(key.PublicKey(), 10)
+		}
+		genesis := &types.Genesis{
+			InitialHeight:   1,
+			Validators:      validators,
+			ConsensusParams: params.Copy(),
+		}
+		node, err := s.newNode(i, privateKey, genesis, alpha, i >= cfg.Validators, logger.New("node", i))
+		if err != nil {
+			s.Stop()
+			return nil, err
+		}
+		s.nodes = append(s.nodes, node)
+		s.index[node.ID] = i
+	}
+	if cfg.DKG {
+		var out []stchEnvelope
+		for _, node := range s.nodes {
+			out = append(out, s.route(node, node.stch.Hello())...)
+		}
+		s.exchange(out)
+	}
+
+	if cfg.TxInterval > 0 {
+		s.clock.AfterFunc(cfg.TxInterval, s.submitNextTx)
+	}
+	return s, nil
+}
+
+func (s *Simulation) newNode(index int, privateKey *bls12.PrivateKey, genesis *types.Genesis, alpha *big.Int, observer bool, logger log.Logger) (*Node, error) {
+	id := privateKey.PublicKey().ToID()
+	eventBus, err := events.CreateAndStartEventBus(logger)
+	if err != nil {
+		return nil, err
+	}
+	stateStore := state.NewStoreState(database.NewMemDB())
+	blockStore := store.NewStoreBlock(database.NewMemDB())
+	stat := stateStore.LoadFromDBOrGenesis(genesis)
+	if s.cfg.DKG {
+		// 变色龙哈希的密钥份额不由种子决定，但是它们不会影响消息的投递顺序
+		ch := stch.NewChameleon(id, len(genesis.Validators))
+		ch.SetBlockStore(blockStore)
+		ch.SetByzantineConfig(s.cfg.Byzantine[index])
+		stat.SetChameleon(ch)
+	} else {
+		stat.SetChameleon(stch.NewChameleonWithAlpha(id, len(genesis.Validators), alpha))
+	}
+	stat.SetBlockStore(blockStore)
+
+	proxyAppConns := proxy.NewAppConns(apps.NewKVStoreApp(fmt.Sprintf("kvstore%d", index), "", database.MemDBBackend), logger)
+	if err = proxyAppConns.Start(); err != nil {
+		return nil, err
+	}
+	txsPool := txspool.NewTxsPool(config.DefaultTxsPoolConfig(), proxyAppConns.TxsPool(), stat.LastBlockHeight)
+	txsPool.SetLogger(logger)
+	evidencePool, err := evidence.NewPool(database.NewMemDB(), stat, logger.New("module", "evidence"))
+	if err != nil {
+		return nil, err
+	}
+	blockExec := state.NewBlockExecutor(stateStore, blockStore, proxyAppConns.Consensus(), txsPool, evidencePool, logger.New("module", "state"))
+
+	node := &Node{
+		Index:        index,
+		ID:           id,
+		blockStore:   blockStore,
+		txsPool:      txsPool,
+		eventBus:     eventBus,
+		privateKey:   privateKey,
+		genesis:      genesis,
+		chameleon:    stat.Chameleon,
+		stch:         stch.NewDriver(stat.Chameleon),
+		stateStore:   stateStore,
+		blockExec:    blockExec,
+		evidencePool: evidencePool,
+		observer:     observer,
+		logger:       logger,
+	}
+	s.newDriver(node, stat)
+	if s.cfg.WALDir != "" {
+		if _, err = node.driver.Replay(); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+// newDriver 用保存下来的状态为节点创建共识模块。
+func (s *Simulation) newDriver(node *Node, stat *state.State) {
+	cryptoBLS12 := bls12.NewCryptoBLS12()
+	cryptoBLS12.Init(node.privateKey)
+	cfg := config.DefaultConsensusConfig()
+	cfg.WalPath = ""
+	if s.cfg.WALDir != "" {
+		cfg.Home = s.cfg.WALDir
+		cfg.WalPath = filepath.Join(fmt.Sprintf("node%d", node.Index), "cs.wal", "wal")
+	}
+	cfg.TimeoutConsensus = s.cfg.TimeoutConsensus
+	if s.cfg.Protocol != "" {
+		cfg.Protocol = s.cfg.Protocol
+	}
+	var signer types.Signer = types.NewPrivateKeySigner(node.privateKey)
+	if node.observer {
+		cfg.Mode = config.ModeObserver
+		signer = types.NewObserverSigner(node.privateKey.PublicKey())
+	}
+	core := consensus.NewCore(cfg, signer, stat, node.blockExec, node.txsPool, node.evidencePool, cryptoBLS12)
+	core.SetLogger(node.logger.New("module", "consensus"))
+	core.SetByzantineConfig(s.cfg.Byzantine[node.Index])
+	core.SetEventBus(node.eventBus)
+
+	onTimeout := func(ti consensus.TimeoutInfo) {
+		s.handle(node, node.driver.Timeout(ti))
+	}
+	node.driver = consensus.NewDriver(core, newTicker(s.clock, onTimeout), newTicker(s.clock, onTimeout))
+}
+
+// Restart 模拟节点崩溃之后立即重启：丢弃节点内存里的共识状态，用保存下来的状态、区块和WAL重新创建共识模块，并重放
+// WAL里当前高度的消息。崩溃之前安排的超时事件不会再被触发，已经在网络上传输的消息会被重启之后的节点收到。
+func (s *Simulation) Restart(index int) error {
+	if s.cfg.WALDir == "" {
+		return fmt.Errorf("cannot restart node %d without consensus WAL", index)
+	}
+	node := s.nodes[index]
+	node.driver.Stop()
+	stat := node.stateStore.LoadFromDBOrGenesis(node.genesis)
+	stat.SetChameleon(node.chameleon)
+	stat.SetBlockStore(node.blockStore)
+	s.newDriver(node, stat)
+	out, err := node.driver.Replay()
+	if err != nil {
+		return err
+	}
+	s.handle(node, out)
+	return nil
+}
+
+// Clock 返回仿真使用的虚拟时钟。
+func (s *Simulation) Clock() *Clock {
+	return s.clock
+}
+
+// Network 返回仿真使用的虚拟网络，可以通过它调整延迟、丢包率、网络分区和消息过滤器。
+func (s *Simulation) Network() *Network {
+	return s.network
+}
+
+// Nodes 返回所有的仿真节点。
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Node 返回指定ID的仿真节点。
+func (s *Simulation) Node(id crypto.ID) *Node {
+	if i, ok := s.index[id]; ok {
+		return s.nodes[i]
+	}
+	return nil
+}
+
+// At 在虚拟时间t执行fn，用来编排仿真脚本，例如在某个时刻划分网络分区，在另一个时刻消除分区。
+func (s *Simulation) At(t time.Duration, fn func()) {
+	s.clock.AfterFunc(t-s.clock.Now(), fn)
+}
+
+// SubmitTx 将交易提交到所有节点的交易池里。
+func (s *Simulation) SubmitTx(tx types.Tx) error {
+	for _, node := range s.nodes {
+		if err := node.txsPool.CheckTx(tx, "simulation"); err != nil {
+			return err
+		}
+	}
+	for _, node := range s.nodes {
+		s.handle(node, nil)
+	}
+	return nil
+}
+
+func (s *Simulation) submitNextTx() {
+	s.txs++
+	if err := s.SubmitTx(types.Tx(fmt.Sprintf("key%d=value%d", s.txs, s.txs))); err != nil {
+		panic(fmt.Sprintf("failed to submit tx to simulated nodes: %v", err))
+	}
+	s.clock.AfterFunc(s.cfg.TxInterval, s.submitNextTx)
+}
+
+// Redact 由第index个节点发起修订任务，将height高度区块里的第txIndex笔交易改成key=value。变色龙哈希的消息不经过虚拟
+// 网络，在返回之前就按照发送的先后顺序全部处理完，处理过程中发现的错误记录在各个节点的Faults里。
+func (s *Simulation) Redact(index int, height int64, txIndex int, key, value []byte) error {
+	if !s.cfg.DKG {
+		return fmt.Errorf("cannot redact blocks without distributed key generation")
+	}
+	node := s.nodes[index]
+	out, err := node.stch.Redact(&stch.Task{BlockHeight: height, TxIndex: txIndex, Key: key, Value: value})
+	if err != nil {
+		return err
+	}
+	s.exchange(s.route(node, out))
+	return nil
+}
+
+// stchEnvelope 一条等待投递的变色龙哈希消息。
+type stchEnvelope struct {
+	from, to int
+	msg      stch.Message
+}
+
+// route 按照Reactor的路由规则确定消息的接收方，每个接收方都得到一份消息的拷贝，因为处理消息时会修改消息里的字段。
+func (s *Simulation) route(node *Node, out []stch.Outbound) []stchEnvelope {
+	var envelopes []stchEnvelope
+	for _, ob := range out {
+		for _, other := range s.nodes {
+			if other.Index == node.Index || (ob.To != "" && ob.To != other.ID) {
+				continue
+			}
+			envelopes = append(envelopes, stchEnvelope{from: node.Index, to: other.Index, msg: stch.MustDecode(stch.MustEncode(ob.Msg))})
+		}
+	}
+	return envelopes
+}
+
+// exchange 按照先进先出的顺序投递变色龙哈希的消息，直到没有新的消息产生。
+func (s *Simulation) exchange(queue []stchEnvelope) {
+	for len(queue) > 0 {
+		env := queue[0]
+		queue = queue[1:]
+		from, node := s.nodes[env.from], s.nodes[env.to]
+		out, err := node.stch.Receive(from.ID, env.msg)
+		if err != nil {
+			node.logger.Error("Failed to handle chameleon message", "from", from.Index, "err", err)
+			node.faults = append(node.faults, Fault{From: from.ID, Err: err})
+		}
+		queue = append(queue, s.route(node, out)...)
+	}
+}
+
+// RunFor 运行仿真，直到虚拟时间又过去了d。
+func (s *Simulation) RunFor(d time.Duration) {
+	s.clock.advance(s.clock.Now() + d)
+}
+
+// RunUntil 运行仿真，直到cond返回true，如果虚拟时间又过去了limit之后cond依然不成立，则返回false。
+func (s *Simulation) RunUntil(cond func() bool, limit time.Duration) bool {
+	deadline := s.clock.Now() + limit
+	for !cond() {
+		at, ok := s.clock.next()
+		if !ok || at > deadline {
+			s.clock.advance(deadline)
+			return cond()
+		}
+		s.clock.step()
+	}
+	return true
+}
+
+// WaitForHeight 活性检查：运行仿真，直到指定的节点（不指定时为所有节点）都提交了height高度的区块，如果在虚拟时间
+// 又过去了within之后依然没有达到，则返回错误。仿真过程中如果发现了违反安全性的情况，也会返回错误。
+func (s *Simulation) WaitForHeight(height int64, within time.Duration, nodes ...int) error {
+	if len(nodes) == 0 {
+		for i := range s.nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	reached := func() bool {
+		if s.violation != nil {
+			return true
+		}
+		for _, i := range nodes {
+			if s.nodes[i].Height() < height {
+				return false
+			}
+		}
+		return true
+	}
+	ok := s.RunUntil(reached, within)
+	if s.violation != nil {
+		return s.violation
+	}
+	if !ok {
+		heights := make([]int64, len(nodes))
+		for j, i := range nodes {
+			heights[j] = s.nodes[i].Height()
+		}
+		return fmt.Errorf("liveness violated: nodes %v did not reach height %d within %s, their heights are %v (virtual time %s)", nodes, height, within, heights, s.clock.Now())
+	}
+	return nil
+}
+
+// CheckSafety 安全性检查：返回仿真过程中第一次发现的不同节点在同一高度提交了不同区块的情况。
+func (s *Simulation) CheckSafety() error {
+	return s.violation
+}
+
+// Stop 释放仿真节点占用的资源。
+func (s *Simulation) Stop() {
+	for _, node := range s.nodes {
+		node.driver.Stop()
+		_ = node.eventBus.Stop()
+	}
+}
+
+// deliver 网络将消息投递给接收方。
+func (s *Simulation) deliver(from, to int, msg consensus.Message) {
+	node := s.nodes[to]
+	s.handle(node, node.driver.Deliver(s.nodes[from].ID, msg))
+}
+
+// handle 在节点处理完一个事件之后，检查交易池的提醒和新提交的区块，然后将节点产生的消息交给网络发送。
+func (s *Simulation) handle(node *Node, out []consensus.Outbound) {
+	out = append(out, node.driver.TxsAvailable()...)
+	s.checkCommits(node)
+	for _, ob := range out {
+		if ob.To == "" {
+			for _, other := range s.nodes {
+				if other.Index != node.Index {
+					s.network.send(&Envelope{From: node.Index, To: other.Index, Channel: ob.Channel, Msg: ob.Msg})
+				}
+			}
+			continue
+		}
+		to, ok := s.index[ob.To]
+		if !ok {
+			continue
+		}
+		s.network.send(&Envelope{From: node.Index, To: to, Channel: ob.Channel, Msg: ob.Msg})
+	}
+}
+
+// checkCommits 检查节点新提交的区块是否与其他节点在同一高度提交的区块相同。
+func (s *Simulation) checkCommits(node *Node) {
+	for h := node.height + 1; h <= node.Height(); h++ {
+		block := node.Block(h)
+		if block == nil || block.ChameleonHash == nil {
+			continue
+		}
+		hash := block.ChameleonHash.Hash
+		if committed, ok := s.committed[h]; !ok {
+			s.committed[h] = hash
+		} else if !bytes.Equal(committed, hash) && s.violation == nil {
+			s.violation = fmt.Errorf("safety violated: node %d committed block %X at height %d, but block %X has been committed at the same height (virtual time %s)", node.Index, hash, h, committed, s.clock.Now())
+		}
+		node.height = h
+	}
+}
@@ -0,0 +1,204 @@
+package simulation
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/consensus"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newSimulation(t *testing.T, cfg *Config) *Simulation {
+	sim, err := New(cfg)
+	require.Nil(t, err)
+	t.Cleanup(sim.Stop)
+	return sim
+}
+
+func TestSimulationProgress(t *testing.T) {
+	sim := newSimulation(t, DefaultConfig())
+	assert.Nil(t, sim.WaitForHeight(5, 30*time.Second))
+	assert.Nil(t, sim.CheckSafety())
+
+	// 所有节点提交的区块都是一样的
+	for h := int64(1); h <= 5; h++ {
+		hash := sim.Nodes()[0].Block(h).ChameleonHash.Hash
+		for _, node := range sim.Nodes()[1:] {
+			assert.Equal(t, hash, node.Block(h).ChameleonHash.Hash)
+		}
+	}
+}
+
+func TestSimulationSilentNode(t *testing.T) {
+	sim := newSimulation(t, DefaultConfig())
+	// 节点2不发出任何消息，下一个高度的主节点只能收到另外2个节点的NextView消息，加上自己的才满足法定人数
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		return env.From != 2
+	})
+	assert.Nil(t, sim.WaitForHeight(8, 2*time.Minute, 0, 1, 3))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestSimulationDeterministic(t *testing.T) {
+	// 同样的种子得到同样的执行过程，即使因为丢包导致部分节点落后
+	run := func() ([]int64, int, int) {
+		cfg := DefaultConfig()
+		cfg.Seed = 7
+		cfg.DropRate = 0.05
+		sim := newSimulation(t, cfg)
+		sim.RunFor(30 * time.Second)
+		require.Nil(t, sim.CheckSafety())
+		heights := make([]int64, 0)
+		for _, node := range sim.Nodes() {
+			heights = append(heights, node.Height())
+		}
+		sent, dropped := sim.Network().Stats()
+		return heights, sent, dropped
+	}
+	heights1, sent1, dropped1 := run()
+	heights2, sent2, dropped2 := run()
+	assert.Equal(t, heights1, heights2)
+	assert.Equal(t, sent1, sent2)
+	assert.Equal(t, dropped1, dropped2)
+	assert.NotZero(t, dropped1)
+}
+
+func TestSimulationPartition(t *testing.T) {
+	sim := newSimulation(t, DefaultConfig())
+	require.Nil(t, sim.WaitForHeight(1, 30*time.Second))
+
+	// 隔离节点3，剩下的3个节点依然满足2/3的法定人数，能够继续推进共识
+	sim.Network().Partition([]int{0, 1, 2})
+	assert.Nil(t, sim.WaitForHeight(4, time.Minute, 0, 1, 2))
+	assert.Less(t, sim.Nodes()[3].Height(), int64(4))
+
+	// 只剩一半的节点连通时无法推进共识
+	sim.Network().Partition([]int{0, 1}, []int{2, 3})
+	height := sim.Nodes()[0].Height()
+	assert.NotNil(t, sim.WaitForHeight(height+2, 20*time.Second, 0, 1))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestSimulationDropLeaderProposals(t *testing.T) {
+	sim := newSimulation(t, DefaultConfig())
+	// 在前10秒里丢掉所有的Prepare消息，节点只能不断地切换视图，10秒之后网络恢复正常，共识应当继续推进
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		_, ok := env.Msg.(*types.Prepare)
+		return !ok || sim.Clock().Now() >= 10*time.Second
+	})
+	assert.Nil(t, sim.WaitForHeight(2, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestSimulationFetchMissingBlock(t *testing.T) {
+	sim := newSimulation(t, DefaultConfig())
+	var fetched int
+	// 节点3永远收不到其他主节点的Prepare消息，它需要根据PreCommit消息向其他节点索要区块，才能跟上其他节点
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		if _, ok := env.Msg.(*types.BlockResponse); ok && env.To == 3 {
+			fetched++
+		}
+		_, ok := env.Msg.(*types.Prepare)
+		return !ok || env.To != 3
+	})
+	assert.Nil(t, sim.WaitForHeight(5, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+	assert.NotZero(t, fetched)
+}
+
+func TestSimulationObserver(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Observers = 1
+	sim := newSimulation(t, cfg)
+	var signed, decides int
+	// 观察者（节点4）只与验证者3相连，它需要依靠节点3转交的Decide消息和区块才能跟上其他节点
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		if env.From == 4 {
+			switch env.Msg.(type) {
+			case *types.Prepare, *types.PrepareVote, *types.PreCommitVote, *types.CommitVote, *types.NewView, *types.NextView:
+				signed++
+			}
+		}
+		if env.To == 4 {
+			if _, ok := env.Msg.(*types.Decide); ok && env.From == 3 {
+				decides++
+			}
+			return env.From == 3
+		}
+		return true
+	})
+	assert.Nil(t, sim.WaitForHeight(5, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+	assert.Zero(t, signed)
+	assert.NotZero(t, decides)
+}
+
+func TestSimulationRestart(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.WALDir = t.TempDir()
+	sim := newSimulation(t, cfg)
+	require.Nil(t, sim.WaitForHeight(2, 30*time.Second))
+
+	// 节点1锁定了区块但是还没有提交它的时候崩溃重启，重放WAL之后应当回到崩溃之前的高度、轮次、步骤以及锁定的区块
+	node := sim.Nodes()[1]
+	locked := func() bool {
+		status := node.Status()
+		return status.LockedHash != nil && status.Step < consensus.DecideStep
+	}
+	require.True(t, sim.RunUntil(locked, 30*time.Second))
+	before := node.Status()
+	require.Nil(t, sim.Restart(1))
+	assert.Equal(t, before, node.Status())
+
+	// 在其他时刻反复重启，每次都能恢复崩溃之前的状态，并且之后继续参与共识
+	for i := 0; i < 20; i++ {
+		sim.RunFor(time.Duration(50+i*13) * time.Millisecond)
+		before = node.Status()
+		require.Nil(t, sim.Restart(1))
+		after := node.Status()
+		if before.Step == consensus.DecideStep {
+			// 崩溃之前已经提交了当前高度的区块，重启之后直接进入下一个高度
+			assert.Equal(t, node.Height()+1, after.Height, "restart %d", i)
+			assert.Equal(t, consensus.NewHeightStep, after.Step, "restart %d", i)
+		} else {
+			assert.Equal(t, before, after, "restart %d", i)
+		}
+	}
+	assert.Nil(t, sim.WaitForHeight(node.Height()+3, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+// redactableHeight 返回节点已经提交的第一个包含交易的区块的高度。
+func redactableHeight(t *testing.T, node *Node) int64 {
+	for h := int64(1); h <= node.Height(); h++ {
+		if len(node.Block(h).Body.Txs) > 0 {
+			return h
+		}
+	}
+	t.Fatal("no committed block contains txs")
+	return 0
+}
+
+func TestSimulationRedact(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DKG = true
+	sim := newSimulation(t, cfg)
+	require.Nil(t, sim.WaitForHeight(4, time.Minute))
+
+	height := redactableHeight(t, sim.Nodes()[0])
+	hash := sim.Nodes()[0].Block(height).ChameleonHash.Hash
+	require.Nil(t, sim.Redact(0, height, 0, []byte("key"), []byte("redacted")))
+
+	// 所有节点都修改了区块里的交易，区块的变色龙哈希保持不变
+	for _, node := range sim.Nodes() {
+		assert.Empty(t, node.Faults())
+		block := node.Block(height)
+		assert.Equal(t, []byte(fmt.Sprintf("%x=%x", "key", "redacted")), []byte(block.Body.Txs[0]))
+		assert.Equal(t, hash, block.ChameleonHash.Hash)
+		assert.Nil(t, node.chameleon.VerifyHash(block))
+	}
+	assert.Nil(t, sim.WaitForHeight(sim.Nodes()[0].Height()+2, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+}
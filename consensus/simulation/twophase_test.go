@@ -0,0 +1,101 @@
+package simulation
+
+import (
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func twoPhaseConfig() *Config {
+	cfg := DefaultConfig()
+	cfg.Protocol = config.ProtocolTwoPhase
+	return cfg
+}
+
+func TestTwoPhaseProgress(t *testing.T) {
+	sim := newSimulation(t, twoPhaseConfig())
+	assert.Nil(t, sim.WaitForHeight(10, 30*time.Second))
+	assert.Nil(t, sim.CheckSafety())
+
+	for h := int64(1); h <= 10; h++ {
+		hash := sim.Nodes()[0].Block(h).ChameleonHash.Hash
+		for _, node := range sim.Nodes()[1:] {
+			assert.Equal(t, hash, node.Block(h).ChameleonHash.Hash)
+		}
+	}
+}
+
+func TestTwoPhaseSilentNode(t *testing.T) {
+	sim := newSimulation(t, twoPhaseConfig())
+	// 节点2不发出任何消息，轮到它担任主节点时，其余3个节点用超时证书进入下一轮
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		return env.From != 2
+	})
+	assert.Nil(t, sim.WaitForHeight(10, 2*time.Minute, 0, 1, 3))
+	assert.Nil(t, sim.CheckSafety())
+}
+
+func TestTwoPhaseDropLeaderProposals(t *testing.T) {
+	sim := newSimulation(t, twoPhaseConfig())
+	var timeouts int
+	// 在前10秒里丢掉所有的Prepare消息，节点只能不断地交换Timeout消息切换视图，10秒之后网络恢复正常，共识应当继续推进
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		if _, ok := env.Msg.(*types.Timeout); ok {
+			timeouts++
+		}
+		_, ok := env.Msg.(*types.Prepare)
+		return !ok || sim.Clock().Now() >= 10*time.Second
+	})
+	assert.Nil(t, sim.WaitForHeight(3, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+	assert.NotZero(t, timeouts)
+}
+
+func TestTwoPhaseReproposeHighQC(t *testing.T) {
+	sim := newSimulation(t, twoPhaseConfig())
+	var reproposed int
+	// 在前10秒里丢掉所有的PreCommitVote消息，节点已经形成了QC却无法确认区块，之后的主节点必须携带超时证书重新提出QC所认证的区块
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		if prepare, ok := env.Msg.(*types.Prepare); ok && prepare.TC != nil && prepare.HighQC != nil {
+			reproposed++
+		}
+		_, ok := env.Msg.(*types.PreCommitVote)
+		return !ok || sim.Clock().Now() >= 10*time.Second
+	})
+	assert.Nil(t, sim.WaitForHeight(5, 2*time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+	assert.NotZero(t, reproposed)
+}
+
+func TestTwoPhaseLatency(t *testing.T) {
+	// 两阶段共识少了两轮投票，确认同样多的区块所需的（虚拟）时间更短
+	run := func(protocol string) time.Duration {
+		cfg := DefaultConfig()
+		cfg.Protocol = protocol
+		sim := newSimulation(t, cfg)
+		require.Nil(t, sim.WaitForHeight(10, time.Minute))
+		require.Nil(t, sim.CheckSafety())
+		return sim.Clock().Now()
+	}
+	basic, twoPhase := run(config.ProtocolHotStuff), run(config.ProtocolTwoPhase)
+	t.Logf("committed 10 blocks in %v with four-phase HotStuff, %v with two-phase HotStuff", basic, twoPhase)
+	assert.Less(t, twoPhase, basic)
+}
+
+func TestTwoPhaseFetchMissingBlock(t *testing.T) {
+	sim := newSimulation(t, twoPhaseConfig())
+	var fetched int
+	sim.Network().AddFilter(func(env *Envelope) bool {
+		if _, ok := env.Msg.(*types.BlockResponse); ok && env.To == 3 {
+			fetched++
+		}
+		_, ok := env.Msg.(*types.Prepare)
+		return !ok || env.To != 3
+	})
+	assert.Nil(t, sim.WaitForHeight(5, time.Minute))
+	assert.Nil(t, sim.CheckSafety())
+	assert.NotZero(t, fetched)
+}
@@ -1,8 +1,9 @@
 package state
 
 import (
+	"bytes"
 	"errors"
-	"github.com/232425wxy/meta--/config"
+	"fmt"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/events"
 	"github.com/232425wxy/meta--/log"
@@ -13,23 +14,37 @@ import (
 	"github.com/232425wxy/meta--/types"
 )
 
+// ErrProposalRejected 应用通过ProcessProposal拒绝了主节点提出的区块。
+var ErrProposalRejected = errors.New("proposal rejected by application")
+
+// ErrAppHashMismatch 区块头里的应用状态哈希与自己执行完前一个区块后得到的不一致。
+var ErrAppHashMismatch = errors.New("app hash mismatch")
+
+// ErrInvalidEndBlock 应用在EndBlock里返回了不合法的验证者更新或共识参数更新。此时区块里的交易已经在应用里执行过了，
+// 但是区块没有被提交，应用停在了区块中间，节点无法再与其他节点保持一致。
+var ErrInvalidEndBlock = errors.New("invalid EndBlock response")
+
 type BlockExecutor struct {
 	store          *StoreState
 	blockStore     *store.BlockStore
 	proxyConsensus *proxy.AppConnConsensus
 	txsPool        *txspool.TxsPool
+	evpool         EvidencePool
 	eventBus       *events.EventBus
-	cfg            *config.TxsPoolConfig
+	pruner         *Pruner
 	logger         log.Logger
 }
 
-func NewBlockExecutor(cfg *config.Config, store *StoreState, blockStore *store.BlockStore, consensus *proxy.AppConnConsensus, txsPool *txspool.TxsPool, logger log.Logger) *BlockExecutor {
+func NewBlockExecutor(store *StoreState, blockStore *store.BlockStore, consensus *proxy.AppConnConsensus, txsPool *txspool.TxsPool, evpool EvidencePool, logger log.Logger) *BlockExecutor {
+	if evpool == nil {
+		evpool = EmptyEvidencePool{}
+	}
 	return &BlockExecutor{
 		store:          store,
 		blockStore:     blockStore,
 		proxyConsensus: consensus,
 		txsPool:        txsPool,
-		cfg:            cfg.TxsPoolConfig,
+		evpool:         evpool,
 		logger:         logger,
 	}
 }
@@ -38,24 +53,218 @@ func (be *BlockExecutor) SetEventBUs(bus *events.EventBus) {
 	be.eventBus = bus
 }
 
-func (be *BlockExecutor) CreateBlock(height int64, state *State, proposer crypto.ID, lastBlockHash []byte) *types.Block {
-	txs := be.txsPool.ReapMaxBytes(be.cfg.MaxTxBytes * be.cfg.MaxSize)
-	return state.MakeBlock(height, txs, proposer, lastBlockHash)
+// SetPruner 设置在后台删除旧区块和状态数据的Pruner，没有设置时不删除任何数据。
+func (be *BlockExecutor) SetPruner(pruner *Pruner) {
+	be.pruner = pruner
+}
+
+// CreateBlock 按照共识参数的限制从交易池里取出交易数据，交给应用通过PrepareProposal决定区块里最终的交易数据及其顺序，
+// 然后打包成区块。
+func (be *BlockExecutor) CreateBlock(height int64, state *State, proposer crypto.ID, lastBlockHash []byte) (*types.Block, error) {
+	return be.createBlock(height, state, proposer, lastBlockHash, nil)
+}
+
+// CreateBlockOnAncestors 链式HotStuff的主节点在尚未提交的祖先区块之后创建新的区块，ancestors从父区块开始依次向前
+// 排列，为空时新的区块直接接在状态里最后一个区块之后。祖先区块里已经打包过的交易和证据依然留在交易池和证据池里，
+// 它们不会被再次打包。
+func (be *BlockExecutor) CreateBlockOnAncestors(state *State, proposer crypto.ID, ancestors Ancestors) (*types.Block, error) {
+	height, lastBlockHash := ancestors.next(state)
+	return be.createBlock(height, state, proposer, lastBlockHash, ancestors)
+}
+
+// createBlock 创建区块，区块头里携带的总是执行完最后提交的区块后的应用状态哈希，链式HotStuff里父区块还没有被提交时，
+// 它比前一个区块落后若干个高度。
+func (be *BlockExecutor) createBlock(height int64, state *State, proposer crypto.ID, lastBlockHash []byte, ancestors Ancestors) (*types.Block, error) {
+	params := state.ConsensusParams
+	evidence := ancestors.excludeEvidence(be.evpool.PendingEvidence(params.Evidence.MaxBytes))
+	reaped := be.txsPool.ReapMaxBytesMaxTxs(int(params.Block.MaxBytes), int(params.Block.MaxTxs))
+	included := ancestors.txSet()
+	_txs := make([][]byte, 0, len(reaped))
+	for _, tx := range reaped {
+		if _, ok := included[string(tx)]; !ok {
+			_txs = append(_txs, tx)
+		}
+	}
+	res := be.proxyConsensus.PrepareProposal(pbabci.RequestPrepareProposal{
+		Height:     height,
+		Txs:        _txs,
+		MaxTxBytes: params.Block.MaxBytes,
+		Proposer:   string(proposer),
+	})
+	// 应用没有打包的交易可能只是被推迟到之后的区块里，它们依然留在交易池里，不合法的交易由应用在CheckTx里拒绝
+	txs := make(types.Txs, len(res.Txs))
+	for i, tx := range res.Txs {
+		txs[i] = tx
+	}
+	if size := txs.ByteSize(); size > params.Block.MaxBytes {
+		return nil, fmt.Errorf("application returned %d bytes of txs in PrepareProposal, exceeding the limit %d", size, params.Block.MaxBytes)
+	}
+	if int64(len(txs)) > params.Block.MaxTxs {
+		return nil, fmt.Errorf("application returned %d txs in PrepareProposal, exceeding the limit %d", len(txs), params.Block.MaxTxs)
+	}
+	return state.MakeBlock(height, txs, evidence, proposer, lastBlockHash, state.AppHash, state.LastBlockHeight), nil
+}
+
+// Ancestors 链式HotStuff里新区块之前尚未被提交的祖先区块，从父区块开始依次向前排列，最后一个祖先区块的父区块是
+// 状态里最后一个被提交的区块。
+type Ancestors []*types.Block
+
+// next 返回接在这些祖先区块之后的区块的高度和它的前一个区块的哈希值。
+func (a Ancestors) next(state *State) (int64, []byte) {
+	if len(a) == 0 {
+		height := state.NextHeight()
+		return height, state.PreviousBlockHash(height)
+	}
+	return a[0].Header.Height + 1, a[0].ChameleonHash.Hash
+}
+
+// parent 返回新区块的父区块的区块头，父区块已经被提交时就是状态里的最后一个区块，创世之后的第一个区块没有父区块。
+func (a Ancestors) parent(state *State) *types.Header {
+	if len(a) > 0 {
+		return a[0].Header
+	}
+	if state.PreviousBlock != nil && state.LastBlockHeight > 0 {
+		return state.PreviousBlock.Header
+	}
+	return nil
+}
+
+// txSet 返回祖先区块里已经打包过的交易。
+func (a Ancestors) txSet() map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, block := range a {
+		for _, tx := range block.Body.Txs {
+			set[string(tx)] = struct{}{}
+		}
+	}
+	return set
+}
+
+// excludeEvidence 去掉已经被祖先区块打包过的证据。
+func (a Ancestors) excludeEvidence(evidence types.EvidenceList) types.EvidenceList {
+	if len(a) == 0 || len(evidence) == 0 {
+		return evidence
+	}
+	included := make(map[string]struct{})
+	for _, block := range a {
+		for _, ev := range block.Evidence {
+			included[string(ev.Hash())] = struct{}{}
+		}
+	}
+	result := make(types.EvidenceList, 0, len(evidence))
+	for _, ev := range evidence {
+		if _, ok := included[string(ev.Hash())]; !ok {
+			result = append(result, ev)
+		}
+	}
+	return result
+}
+
+// ValidateBlock 副本节点在为主节点提出的区块投票之前，完整地检查区块：区块的格式、默克尔根和区块数据哈希、高度、前一个
+// 区块的哈希值、应用状态哈希、提出者是否为proposer、变色龙哈希是否与区块内容相符、区块携带的证据，最后交给应用通过
+// ProcessProposal检查交易数据。proposer由调用者根据区块被提出的轮次或视图算出，是那一轮的主节点。
+func (be *BlockExecutor) ValidateBlock(state *State, block *types.Block, proposer crypto.ID) error {
+	return be.ValidateBlockOnAncestors(state, block, nil, proposer)
+}
+
+// ValidateBlockOnAncestors 与ValidateBlock相同，但是区块接在尚未提交的祖先区块之后，区块的高度和前一个区块的哈希值
+// 根据ancestors检查，并且区块不能再打包祖先区块里已经打包过的交易和证据。
+func (be *BlockExecutor) ValidateBlockOnAncestors(state *State, block *types.Block, ancestors Ancestors, proposer crypto.ID) error {
+	height, lastBlockHash := ancestors.next(state)
+	if err := validateBlockAt(state, block, height, lastBlockHash, proposer); err != nil {
+		return err
+	}
+	if err := validateAppHash(state, block, ancestors); err != nil {
+		return err
+	}
+	included := ancestors.txSet()
+	for _, tx := range block.Body.Txs {
+		if _, ok := included[string(tx)]; ok {
+			return fmt.Errorf("tx %X has been included in an uncommitted ancestor block", tx.Hash())
+		}
+	}
+	if len(ancestors.excludeEvidence(block.Evidence)) != len(block.Evidence) {
+		return errors.New("evidence has been included in an uncommitted ancestor block")
+	}
+	if err := state.Chameleon.VerifyHash(block); err != nil {
+		return err
+	}
+	if err := be.CheckEvidence(block); err != nil {
+		return fmt.Errorf("invalid evidence: %w", err)
+	}
+	txs := make([][]byte, len(block.Body.Txs))
+	for i, tx := range block.Body.Txs {
+		txs[i] = tx
+	}
+	res := be.proxyConsensus.ProcessProposal(pbabci.RequestProcessProposal{
+		Height:   block.Header.Height,
+		Txs:      txs,
+		Hash:     block.ChameleonHash.Hash,
+		Proposer: string(block.Header.Proposer),
+	})
+	if !res.OK {
+		return ErrProposalRejected
+	}
+	return nil
+}
+
+// LoadBlock 返回已经提交的height高度的区块，还没有提交该高度的区块时返回nil。
+func (be *BlockExecutor) LoadBlock(height int64) *types.Block {
+	if be.blockStore == nil || height <= 0 || height > be.blockStore.Height() {
+		return nil
+	}
+	return be.blockStore.LoadBlockByHeight(height)
 }
 
-func (be *BlockExecutor) ApplyBlock(state *State, block *types.Block) (*State, error) {
-	responses, err := execBlockOnProxyConsensus(be.proxyConsensus, block, be.logger)
+// CheckEvidence 检查区块里携带的证据是否合法，并且没有被提交过。
+func (be *BlockExecutor) CheckEvidence(block *types.Block) error {
+	if len(block.Evidence) == 0 {
+		return nil
+	}
+	return be.evpool.CheckEvidence(block.Evidence)
+}
+
+// ApplyBlock 执行已经被确认的区块，commit是证明该区块已经被2/3以上验证者确认的提交证明，它会随区块一起被保存下来。
+// 区块头里的应用状态哈希与自己的不一致时返回ErrAppHashMismatch，此时2/3以上的验证者执行出了与自己不同的状态，
+// 调用者应当停止节点；应用在EndBlock里返回了不合法的更新时返回ErrInvalidEndBlock，调用者同样应当停止节点。
+func (be *BlockExecutor) ApplyBlock(state *State, block *types.Block, commit *types.CommitBlock) (*State, error) {
+	if len(block.Header.AppHash) > 0 {
+		if err := checkAppHash(state, be.store, block.Header.AppHashHeight(), block.Header.AppHash); err != nil {
+			return state, err
+		}
+	}
+	responses, err := execBlockOnProxyConsensus(be.proxyConsensus, block, func(height int64) *types.ValidatorSet {
+		return validatorsAt(state, be.store, height)
+	}, be.logger)
 	if err != nil {
 		return state, err
 	}
-	be.blockStore.SaveBlock(block)
+	if err = validateEndBlock(state, responses.EndBlock); err != nil {
+		return state, fmt.Errorf("%w at height %d: %v", ErrInvalidEndBlock, block.Header.Height, err)
+	}
+	// 在应用提交之前保存执行结果和为该区块投票的验证者集合，节点在提交之后、保存状态之前崩溃时，握手可以据此恢复状态
+	if err = be.store.SaveABCIResponses(block.Header.Height, responses); err != nil {
+		return state, err
+	}
+	if err = be.store.SaveValidators(block.Header.Height, state.Validators); err != nil {
+		return state, err
+	}
+	be.blockStore.SaveBlock(block, commit)
 	be.txsPool.Lock()
 	defer be.txsPool.Unlock()
+	// 应用在提交时返回执行完该区块后的状态哈希，提交期间交易池被锁住，不会有新的交易通过CheckTx
+	commitRes := be.proxyConsensus.Commit(pbabci.RequestCommit{})
 	// TODO 这里直接将区块里的交易数据从交易池里删除了
 	be.txsPool.Update(block.Header.Height, block.Body.Txs)
+	updateState(state, responses.EndBlock, block, commitRes.AppHash)
 	if err = be.store.SaveState(state); err != nil {
 		return state, err
 	}
+	// 区块里的证据已经被提交，从证据池里删除它们
+	be.evpool.Update(state, block.Evidence)
+	if be.pruner != nil {
+		be.pruner.SetRetainHeight(commitRes.RetainHeight)
+	}
 	if err = be.eventBus.PublishEventNewBlock(events.EventDataNewBlock{
 		Block:            block,
 		ResultBeginBlock: responses.BeginBlock,
@@ -72,12 +281,36 @@ func (be *BlockExecutor) ApplyBlock(state *State, block *types.Block) (*State, e
 			be.logger.Error("failed to publish events TX", "err", err)
 		}
 	}
-	validatorUpdates := responses.EndBlock.ValidatorUpdates
-	updateState(state, validatorUpdates, block)
 	return state, nil
 }
 
-func execBlockOnProxyConsensus(proxyConsensus *proxy.AppConnConsensus, block *types.Block, logger log.Logger) (*pbabci.ABCIResponses, error) {
+// validatorsAt 返回为高度为height的区块投票的验证者集合，用来确定证据里作恶者在作恶高度上的投票权。与证据池验证证据时
+// 一样，height高于最后提交的高度或者状态存储里没有该高度的验证者集合时（例如旧版本的节点没有保存），使用当前的验证者集合。
+func validatorsAt(state *State, store *StoreState, height int64) *types.ValidatorSet {
+	if store == nil || height > state.LastBlockHeight {
+		return state.Validators
+	}
+	validators, err := store.LoadValidators(height)
+	if err != nil {
+		return state.Validators
+	}
+	return validators
+}
+
+// LoadValidators 返回为高度为height的区块投票的验证者集合，height是下一个高度时就是状态里的验证者集合，否则从状态存储里加载。
+func (be *BlockExecutor) LoadValidators(state *State, height int64) (*types.ValidatorSet, error) {
+	if height == state.NextHeight() {
+		return state.Validators, nil
+	}
+	return be.store.LoadValidators(height)
+}
+
+// LoadABCIResponses 返回应用执行高度为height的区块时返回的全部结果。
+func (be *BlockExecutor) LoadABCIResponses(height int64) (*pbabci.ABCIResponses, error) {
+	return be.store.LoadABCIResponses(height)
+}
+
+func execBlockOnProxyConsensus(proxyConsensus *proxy.AppConnConsensus, block *types.Block, validatorsAt func(height int64) *types.ValidatorSet, logger log.Logger) (*pbabci.ABCIResponses, error) {
 	var validTxs, invalidTxs = 0, 0
 	responses := new(pbabci.ABCIResponses)
 	responses.DeliverTxs = make([]*pbabci.ResponseDeliverTx, len(block.Body.Txs))
@@ -87,7 +320,7 @@ func execBlockOnProxyConsensus(proxyConsensus *proxy.AppConnConsensus, block *ty
 		return nil, errors.New("empty block header")
 	}
 	beginBlock := proxyConsensus.BeginBlock(pbabci.RequestBeginBlock{
-		Evidences: nil,
+		Evidences: block.Evidence.ToABCI(validatorsAt),
 		Height:    block.Header.Height,
 	})
 	responses.BeginBlock = &beginBlock
@@ -106,11 +339,112 @@ func execBlockOnProxyConsensus(proxyConsensus *proxy.AppConnConsensus, block *ty
 	return responses, nil
 }
 
-func updateState(state *State, validatorUpdates []*pbabci.ValidatorUpdate, block *types.Block) {
-	if len(validatorUpdates) > 0 {
-		state.Validators.Update(validatorUpdates)
+// validateBlock 检查区块本身是否合法，以及区块是否能接在当前状态的最后一个区块之后。
+func validateBlock(state *State, block *types.Block, proposer crypto.ID) error {
+	height := state.NextHeight()
+	if err := validateBlockAt(state, block, height, state.PreviousBlockHash(height), proposer); err != nil {
+		return err
+	}
+	return validateAppHash(state, block, nil)
+}
+
+// validateAppHash 检查区块头里的应用状态哈希。主节点总是携带执行完自己最后提交的区块后的状态哈希，它与副本节点提交到了
+// 同一个高度，因此区块携带的必须就是自己的状态哈希：四阶段和两阶段共识里父区块就是最后提交的区块，AppHashLag必须为0；
+// 链式HotStuff里父区块可能还没有被提交，AppHashLag必须等于尚未提交的祖先区块的数量。否则主节点可以一直携带某个旧高度
+// 的状态哈希，让应用状态的分叉永远不会被发现。
+func validateAppHash(state *State, block *types.Block, ancestors Ancestors) error {
+	header := block.Header
+	if header.AppHashLag != int64(len(ancestors)) {
+		return fmt.Errorf("%w: block carries app hash at height %d, but the last committed height is %d", ErrAppHashMismatch, header.AppHashHeight(), state.LastBlockHeight)
+	}
+	return checkAppHash(state, nil, header.AppHashHeight(), header.AppHash)
+}
+
+// checkAppHash 检查appHash是否是执行完height高度的区块后应用状态的哈希值。height等于最后提交的高度时与自己的状态哈希
+// 比较，低于最后提交的高度时与保存下来的历史状态哈希比较，历史状态哈希无法加载时拒绝通过检查；height高于最后提交的高度
+// 时还无法检查。
+func checkAppHash(state *State, store *StoreState, height int64, appHash []byte) error {
+	if height > state.LastBlockHeight {
+		return nil
+	}
+	expected := state.AppHash
+	if height < state.LastBlockHeight {
+		if store == nil {
+			return fmt.Errorf("cannot check app hash at height %d: no state store", height)
+		}
+		var err error
+		if expected, err = store.LoadAppHash(height); err != nil {
+			return fmt.Errorf("cannot check app hash at height %d: %w", height, err)
+		}
+	}
+	if !bytes.Equal(expected, appHash) {
+		return fmt.Errorf("%w at height %d: block has %X, but local application has %X", ErrAppHashMismatch, height, appHash, expected)
+	}
+	return nil
+}
+
+// validateBlockAt 检查区块本身是否合法，以及区块是否能作为height高度的区块接在哈希值为previous的区块之后，并且区块是由
+// proposer提出的。
+func validateBlockAt(state *State, block *types.Block, height int64, previous []byte, proposer crypto.ID) error {
+	if err := block.ValidateBasic(); err != nil {
+		return err
+	}
+	if block.Header.Height != height {
+		return fmt.Errorf("wrong block height, expected %d, got %d", height, block.Header.Height)
+	}
+	if !bytes.Equal(previous, block.Header.PreviousBlockHash) {
+		return fmt.Errorf("wrong previous block hash, expected %X, got %X", previous, block.Header.PreviousBlockHash)
+	}
+	if state.Validators.GetValidatorByID(block.Header.Proposer) == nil {
+		return fmt.Errorf("block proposer %s is not a validator", block.Header.Proposer)
+	}
+	if block.Header.Proposer != proposer {
+		return fmt.Errorf("wrong block proposer, expected leader %s, got %s", proposer, block.Header.Proposer)
+	}
+	params := state.ConsensusParams
+	if size := block.Body.Txs.ByteSize(); size > params.Block.MaxBytes {
+		return fmt.Errorf("block txs too big: %d > %d", size, params.Block.MaxBytes)
+	}
+	if num := int64(len(block.Body.Txs)); num > params.Block.MaxTxs {
+		return fmt.Errorf("too many txs in block: %d > %d", num, params.Block.MaxTxs)
+	}
+	if size := block.Evidence.ByteSize(); size > params.Evidence.MaxBytes {
+		return fmt.Errorf("block evidence too big: %d > %d", size, params.Evidence.MaxBytes)
+	}
+	return nil
+}
+
+// validateEndBlock 检查应用在EndBlock里返回的验证者更新和共识参数更新是否合法。
+func validateEndBlock(state *State, endBlock *pbabci.ResponseEndBlock) error {
+	params := state.ConsensusParams.Update(endBlock.ConsensusParamUpdates)
+	if err := params.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid consensus params update: %w", err)
+	}
+	for _, update := range endBlock.ValidatorUpdates {
+		if update.BLS12PublicKey == nil {
+			return errors.New("validator update without public key")
+		}
+		if !params.IsValidPublicKeyType(types.BLS12PublicKeyType) {
+			return fmt.Errorf("validator public key type %s is not allowed by consensus params", types.BLS12PublicKeyType)
+		}
+	}
+	return nil
+}
+
+func updateState(state *State, endBlock *pbabci.ResponseEndBlock, block *types.Block, appHash []byte) {
+	if len(endBlock.ValidatorUpdates) > 0 {
+		state.Validators.Update(endBlock.ValidatorUpdates)
+	}
+	if endBlock.ConsensusParamUpdates != nil {
+		// 新的共识参数从下一个高度开始生效
+		state.ConsensusParams = state.ConsensusParams.Update(endBlock.ConsensusParamUpdates)
+	}
+	if len(state.Validators.Validators) > 0 {
+		// 累加验证者的优先级，选出下一个高度的主节点
+		state.Validators.IncrementLeaderPriority(1)
 	}
 	state.PreviousBlock = block
 	state.LastBlockHeight = block.Header.Height
 	state.LastBlockTime = block.Header.Timestamp
+	state.AppHash = appHash
 }
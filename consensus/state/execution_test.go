@@ -0,0 +1,273 @@
+package state
+
+import (
+	"github.com/232425wxy/meta--/abci/apps"
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/stch"
+	"github.com/232425wxy/meta--/txspool"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestValidateBlock(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	proposer := privateKey.PublicKey().ToID()
+	stat := &State{
+		InitialHeight:   1,
+		PreviousBlock:   &types.Block{},
+		Validators:      types.NewValidatorSet([]*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)}),
+		ConsensusParams: types.DefaultConsensusParams(),
+	}
+	newBlock := func(height int64, previous []byte, txs ...string) *types.Block {
+		block := &types.Block{
+			Header: &types.Header{PreviousBlockHash: previous, Height: height, Timestamp: time.Now(), Proposer: proposer},
+			Body:   &types.Data{},
+		}
+		for _, tx := range txs {
+			block.Body.Txs = append(block.Body.Txs, types.Tx(tx))
+		}
+		block.BlockDataHash()
+		return block
+	}
+
+	block := newBlock(1, stat.PreviousBlockHash(1), "a=1", "b=2")
+	assert.Nil(t, validateBlock(stat, block, proposer))
+
+	// 高度不对
+	assert.NotNil(t, validateBlock(stat, newBlock(2, stat.PreviousBlockHash(1), "a=1"), proposer))
+	// 前一个区块的哈希值不对
+	assert.NotNil(t, validateBlock(stat, newBlock(1, []byte("wrong hash"), "a=1"), proposer))
+
+	// 篡改交易数据后，默克尔根对不上
+	tampered := newBlock(1, stat.PreviousBlockHash(1), "a=1", "b=2")
+	tampered.Body.Txs[1] = types.Tx("b=3")
+	assert.NotNil(t, validateBlock(stat, tampered, proposer))
+
+	// 提出者不是验证者
+	other, _ := bls12.GeneratePrivateKey()
+	unknown := newBlock(1, stat.PreviousBlockHash(1), "a=1")
+	unknown.Header.Proposer = other.PublicKey().ToID()
+	unknown.BlockDataHash()
+	assert.NotNil(t, validateBlock(stat, unknown, proposer))
+
+	// 提出者是验证者，但不是这一轮的主节点
+	stat.Validators = types.NewValidatorSet([]*types.Validator{types.NewValidator(privateKey.PublicKey(), 10), types.NewValidator(other.PublicKey(), 10)})
+	assert.Nil(t, validateBlock(stat, block, proposer))
+	assert.NotNil(t, validateBlock(stat, block, other.PublicKey().ToID()))
+	wrongLeader := newBlock(1, stat.PreviousBlockHash(1), "a=1")
+	wrongLeader.Header.Proposer = other.PublicKey().ToID()
+	wrongLeader.BlockDataHash()
+	assert.NotNil(t, validateBlock(stat, wrongLeader, proposer))
+
+	// 交易数量超过共识参数的限制
+	stat.ConsensusParams.Block.MaxTxs = 1
+	assert.NotNil(t, validateBlock(stat, block, proposer))
+	stat.ConsensusParams.Block.MaxTxs = 2
+	assert.Nil(t, validateBlock(stat, block, proposer))
+
+	// 下一个高度的区块需要引用上一个区块的哈希值
+	block.ChameleonHash = &types.ChameleonHash{Hash: []byte("block 1")}
+	stat.PreviousBlock = block
+	stat.LastBlockHeight = 1
+	assert.Nil(t, validateBlock(stat, newBlock(2, []byte("block 1"), "c=3"), proposer))
+	assert.NotNil(t, validateBlock(stat, newBlock(2, stat.PreviousBlockHash(1), "c=3"), proposer))
+
+	// 区块头里的应用状态哈希必须与自己执行完上一个区块后的一致
+	stat.AppHash = []byte("app hash 1")
+	assert.ErrorIs(t, validateBlock(stat, newBlock(2, []byte("block 1"), "c=3"), proposer), ErrAppHashMismatch)
+	withAppHash := newBlock(2, []byte("block 1"), "c=3")
+	withAppHash.Header.AppHash = []byte("app hash 1")
+	withAppHash.BlockDataHash()
+	assert.Nil(t, validateBlock(stat, withAppHash, proposer))
+	// 应用状态哈希被区块数据哈希覆盖，篡改后无法通过检查
+	withAppHash.Header.AppHash = []byte("forged app hash")
+	stat.AppHash = []byte("forged app hash")
+	assert.NotNil(t, validateBlock(stat, withAppHash, proposer))
+}
+
+func TestCreateBlockKeepsDroppedTxs(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	proposer := privateKey.PublicKey().ToID()
+	stat := MakeGenesisState(&types.Genesis{
+		InitialHeight: 1,
+		Validators:    []*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)},
+	})
+	stat.SetChameleon(stch.NewChameleonWithAlpha(proposer, 1, big.NewInt(3)))
+	app := apps.NewKVStoreApp("kvstore", "", database.MemDBBackend)
+	pool := txspool.NewTxsPool(config.DefaultTxsPoolConfig(), proxy.NewAppConnTxsPool(app, nil), 0)
+	for _, tx := range []string{"a=1", "not a key value pair", "b=2"} {
+		assert.Nil(t, pool.CheckTx(types.Tx(tx), "test"))
+	}
+	be := NewBlockExecutor(NewStoreState(database.NewMemDB()), nil, proxy.NewAppConnConsensus(app), pool, nil, log.New())
+
+	// 应用在PrepareProposal里没有打包的交易可能只是被推迟了，创建区块不会把它们从交易池里删除
+	block, err := be.CreateBlock(1, stat, proposer, stat.PreviousBlockHash(1))
+	assert.Nil(t, err)
+	assert.Equal(t, types.Txs{types.Tx("a=1"), types.Tx("b=2")}, block.Body.Txs)
+	assert.Equal(t, 3, pool.TxsNumInPool())
+	_, ok := pool.GetTx(types.Tx("not a key value pair").Hash())
+	assert.True(t, ok)
+}
+
+func TestConsensusParamsUpdate(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	stat := MakeGenesisState(&types.Genesis{
+		InitialHeight: 1,
+		Validators:    []*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)},
+	})
+	store := NewStoreState(database.NewMemDB())
+	assert.Nil(t, store.SaveState(stat))
+
+	endBlock := &pbabci.ResponseEndBlock{ConsensusParamUpdates: &pbtypes.ConsensusParams{
+		Block: &pbtypes.BlockParams{MaxBytes: 2048, MaxTxs: 10},
+	}}
+	assert.Nil(t, validateEndBlock(stat, endBlock))
+	block := &types.Block{Header: &types.Header{Height: 1}, Body: &types.Data{}}
+	updateState(stat, endBlock, block, []byte("app hash 1"))
+	assert.Nil(t, store.SaveState(stat))
+	assert.Equal(t, int64(2048), stat.ConsensusParams.Block.MaxBytes)
+	assert.Equal(t, types.DefaultConsensusParams().Timeout, stat.ConsensusParams.Timeout)
+
+	// 每个高度的共识参数都被保存了下来
+	params, err := store.LoadConsensusParams(1)
+	assert.Nil(t, err)
+	assert.Equal(t, types.DefaultConsensusParams(), params)
+	params, err = store.LoadConsensusParams(2)
+	assert.Nil(t, err)
+	assert.Equal(t, stat.ConsensusParams, params)
+
+	loaded, err := store.LoadState()
+	assert.Nil(t, err)
+	assert.Equal(t, stat.ConsensusParams, loaded.ConsensusParams)
+	assert.Equal(t, []byte("app hash 1"), loaded.AppHash)
+
+	// 不合法的共识参数更新
+	invalid := &pbabci.ResponseEndBlock{ConsensusParamUpdates: &pbtypes.ConsensusParams{Block: &pbtypes.BlockParams{MaxBytes: 0, MaxTxs: 10}}}
+	assert.NotNil(t, validateEndBlock(stat, invalid))
+}
+
+func TestAncestors(t *testing.T) {
+	stat := &State{InitialHeight: 1, LastBlockHeight: 4, PreviousBlock: &types.Block{ChameleonHash: &types.ChameleonHash{Hash: []byte("block 4")}}, AppHash: []byte("app hash 4")}
+	height, previous := Ancestors(nil).next(stat)
+	assert.Equal(t, int64(5), height)
+	assert.Equal(t, []byte("block 4"), previous)
+
+	privateKey, _ := bls12.GeneratePrivateKey()
+	signer := types.NewPrivateKeySigner(privateKey)
+	vote1, _ := types.NewVote(pbtypes.PrepareVoteType, 5, 1, []byte("a"), signer)
+	vote2, _ := types.NewVote(pbtypes.PrepareVoteType, 5, 1, []byte("b"), signer)
+	vote3, _ := types.NewVote(pbtypes.PrepareVoteType, 5, 2, []byte("c"), signer)
+	included := types.NewDuplicateVoteEvidence(vote1, vote2, time.Now())
+	fresh := types.NewDuplicateVoteEvidence(vote1, vote3, time.Now())
+
+	// 父区块在前，祖父区块在后
+	ancestors := Ancestors{
+		{Header: &types.Header{Height: 6}, Body: &types.Data{Txs: types.Txs{types.Tx("b=2")}}, ChameleonHash: &types.ChameleonHash{Hash: []byte("block 6")}},
+		{Header: &types.Header{Height: 5}, Body: &types.Data{Txs: types.Txs{types.Tx("a=1")}}, Evidence: types.EvidenceList{included}, ChameleonHash: &types.ChameleonHash{Hash: []byte("block 5")}},
+	}
+	height, previous = ancestors.next(stat)
+	assert.Equal(t, int64(7), height)
+	assert.Equal(t, []byte("block 6"), previous)
+
+	txs := ancestors.txSet()
+	assert.Len(t, txs, 2)
+	assert.Contains(t, txs, "a=1")
+	assert.Contains(t, txs, "b=2")
+	assert.Equal(t, types.EvidenceList{fresh}, ancestors.excludeEvidence(types.EvidenceList{included, fresh}))
+}
+
+func TestValidateAppHashOnAncestors(t *testing.T) {
+	store := NewStoreState(database.NewMemDB())
+	assert.Nil(t, store.db.SetSync(calcAppHashKey(3), []byte("app hash 3")))
+	stat := &State{InitialHeight: 1, LastBlockHeight: 4, AppHash: []byte("app hash 4")}
+	header := func(height, appHashHeight int64, appHash string) *types.Header {
+		return &types.Header{Height: height, AppHash: []byte(appHash), AppHashLag: height - 1 - appHashHeight}
+	}
+	// 父区块在高度6，它和高度5的区块都还没有被提交，它们携带的是高度4的状态哈希
+	ancestors := Ancestors{
+		{Header: header(6, 4, "app hash 4")},
+		{Header: header(5, 4, "app hash 4")},
+	}
+	validate := func(h *types.Header) error {
+		return validateAppHash(stat, &types.Block{Header: h}, ancestors)
+	}
+
+	// 区块必须携带祖先区块之前最后一个被提交的区块的状态哈希，并且与自己的一致
+	assert.Nil(t, validate(header(7, 4, "app hash 4")))
+	assert.ErrorIs(t, validate(header(7, 4, "forged app hash")), ErrAppHashMismatch)
+	// 不能携带更旧的状态哈希，即使它与保存下来的历史状态哈希一致
+	assert.ErrorIs(t, validate(header(7, 3, "app hash 3")), ErrAppHashMismatch)
+	// 也不能携带还没有被提交的高度的状态哈希
+	assert.ErrorIs(t, validate(header(7, 5, "app hash 5")), ErrAppHashMismatch)
+	// 四阶段和两阶段共识没有尚未提交的祖先区块，AppHashLag必须为0
+	ancestors = nil
+	assert.Nil(t, validate(header(5, 4, "app hash 4")))
+	assert.ErrorIs(t, validate(header(5, 3, "app hash 3")), ErrAppHashMismatch)
+
+	// 提交区块时与保存下来的历史状态哈希比较，无法加载时拒绝通过检查
+	stat.LastBlockHeight, stat.AppHash = 5, []byte("app hash 5")
+	assert.ErrorIs(t, checkAppHash(stat, store, 5, []byte("forged app hash")), ErrAppHashMismatch)
+	assert.Nil(t, checkAppHash(stat, store, 5, []byte("app hash 5")))
+	assert.Nil(t, checkAppHash(stat, store, 3, []byte("app hash 3")))
+	assert.ErrorIs(t, checkAppHash(stat, store, 3, []byte("forged app hash")), ErrAppHashMismatch)
+	assert.NotNil(t, checkAppHash(stat, store, 2, []byte("app hash 2")))
+	assert.NotNil(t, checkAppHash(stat, nil, 3, []byte("app hash 3")))
+}
+
+func TestHistoricalValidatorsAndABCIResponses(t *testing.T) {
+	_, store, states := makeHandshakeTestChain(t, 3)
+	for height := int64(1); height <= 3; height++ {
+		validators, err := store.LoadValidators(height)
+		assert.Nil(t, err)
+		assert.Equal(t, states[height-1].Validators.ToProto(), validators.ToProto())
+
+		responses, err := store.LoadABCIResponses(height)
+		assert.Nil(t, err)
+		assert.Len(t, responses.DeliverTxs, 1)
+		assert.True(t, responses.DeliverTxs[0].OK)
+		assert.NotNil(t, responses.EndBlock)
+	}
+	_, err := store.LoadValidators(4)
+	assert.NotNil(t, err)
+	_, err = store.LoadABCIResponses(4)
+	assert.NotNil(t, err)
+
+	// 下一个高度的验证者集合就是状态里的验证者集合
+	be := NewBlockExecutor(store, nil, nil, nil, nil, nil)
+	validators, err := be.LoadValidators(states[3], 4)
+	assert.Nil(t, err)
+	assert.Equal(t, states[3].Validators, validators)
+	validators, err = be.LoadValidators(states[3], 2)
+	assert.Nil(t, err)
+	assert.Equal(t, states[1].Validators.ToProto(), validators.ToProto())
+}
+
+func TestEvidencePowerAtEvidenceHeight(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	signer := types.NewPrivateKeySigner(privateKey)
+	vote1, _ := types.NewVote(pbtypes.PrepareVoteType, 3, 1, []byte("a"), signer)
+	vote2, _ := types.NewVote(pbtypes.PrepareVoteType, 3, 1, []byte("b"), signer)
+	evidence := types.EvidenceList{types.NewDuplicateVoteEvidence(vote1, vote2, time.Now())}
+
+	// 作恶者在第3个高度有10份投票权，之后被EndBlock调整为20份
+	store := NewStoreState(database.NewMemDB())
+	assert.Nil(t, store.SaveValidators(3, types.NewValidatorSet([]*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)})))
+	stat := &State{InitialHeight: 1, LastBlockHeight: 5, Validators: types.NewValidatorSet([]*types.Validator{types.NewValidator(privateKey.PublicKey(), 20)})}
+
+	abci := evidence.ToABCI(func(height int64) *types.ValidatorSet { return validatorsAt(stat, store, height) })
+	assert.Len(t, abci, 1)
+	assert.Equal(t, int64(10), abci[0].Validator.Power)
+
+	// 状态存储里没有作恶高度的验证者集合时，与证据池一样使用当前的验证者集合
+	abci = evidence.ToABCI(func(height int64) *types.ValidatorSet { return validatorsAt(stat, nil, height) })
+	assert.Equal(t, int64(20), abci[0].Validator.Power)
+}
@@ -0,0 +1,186 @@
+package state
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/store"
+	"github.com/232425wxy/meta--/types"
+)
+
+// Handshaker 节点启动时与应用握手：通过Info得到应用最后提交的区块高度和应用状态哈希，与区块存储和状态存储做比较，
+// 把应用或状态落后的区块从区块存储里取出来重新执行一遍，使三者重新一致。
+//
+// ApplyBlock依次保存区块、让应用提交、保存状态，所以正常情况下三者的高度满足：状态高度 <= 应用高度 <= 区块存储高度，
+// 并且区块存储最多比状态多出一个区块。不满足这个关系时，说明数据已经损坏，握手直接失败。
+type Handshaker struct {
+	store      *StoreState
+	blockStore *store.BlockStore
+	logger     log.Logger
+	nBlocks    int // 握手期间重新执行的区块数量
+}
+
+func NewHandshaker(store *StoreState, blockStore *store.BlockStore, logger log.Logger) *Handshaker {
+	return &Handshaker{
+		store:      store,
+		blockStore: blockStore,
+		logger:     logger,
+	}
+}
+
+// NBlocks 返回握手期间重新执行的区块数量。
+func (h *Handshaker) NBlocks() int {
+	return h.nBlocks
+}
+
+// Handshake 让应用和状态追上区块存储，返回追上之后的状态。
+func (h *Handshaker) Handshake(state *State, proxyConsensus *proxy.AppConnConsensus) (*State, error) {
+	res := proxyConsensus.Info(pbabci.RequestInfo{})
+	appHeight, appHash := res.LastBlockHeight, res.LastBlockAppHash
+	if appHeight < 0 {
+		return state, fmt.Errorf("got a negative last block height %d from application", appHeight)
+	}
+	h.logger.Info("ABCI handshake", "app_type", res.Type, "app_height", appHeight, "app_hash", fmt.Sprintf("%X", appHash))
+
+	stateHeight, storeHeight := state.LastBlockHeight, h.blockStore.Height()
+	switch {
+	case appHeight > storeHeight:
+		return state, fmt.Errorf("application height %d is higher than block store height %d", appHeight, storeHeight)
+	case stateHeight > storeHeight:
+		return state, fmt.Errorf("state height %d is higher than block store height %d", stateHeight, storeHeight)
+	case storeHeight > stateHeight && storeHeight != state.NextHeight():
+		return state, fmt.Errorf("block store height %d is more than one block ahead of state height %d", storeHeight, stateHeight)
+	}
+
+	if appHeight < stateHeight {
+		// 应用落后于状态，只需要把缺少的区块交给应用执行并提交，状态本身已经是最新的
+		var err error
+		if appHash, err = h.replayBlocks(state, proxyConsensus, appHeight, appHash); err != nil {
+			return state, err
+		}
+		appHeight = stateHeight
+	}
+
+	if storeHeight == stateHeight {
+		if !bytes.Equal(appHash, state.AppHash) {
+			return state, fmt.Errorf("%w at height %d: state has %X, but application has %X", ErrAppHashMismatch, stateHeight, state.AppHash, appHash)
+		}
+		h.logger.Info("completed ABCI handshake", "height", stateHeight, "app_hash", fmt.Sprintf("%X", appHash), "replayed_blocks", h.nBlocks)
+		return state, nil
+	}
+
+	// 区块存储比状态多出一个区块
+	block := h.blockStore.LoadBlockByHeight(storeHeight)
+	if block == nil {
+		return state, fmt.Errorf("missing block %d in block store", storeHeight)
+	}
+	var err error
+	if appHeight == storeHeight {
+		// 应用已经提交了最后一个区块，但是状态还没来得及保存，用保存下来的执行结果推出新的状态
+		state, err = h.updateState(state, block, appHash)
+	} else {
+		state, err = h.replayBlock(state, block, proxyConsensus)
+	}
+	if err != nil {
+		return state, err
+	}
+	h.logger.Info("completed ABCI handshake", "height", state.LastBlockHeight, "app_hash", fmt.Sprintf("%X", state.AppHash), "replayed_blocks", h.nBlocks)
+	return state, nil
+}
+
+// replayBlocks 把应用高度之后直到状态高度为止的区块交给应用执行并提交，每提交一个区块，就把应用返回的状态哈希与下一个
+// 区块头里记录的应用状态哈希做比较，最后一个区块与状态里的应用状态哈希做比较，返回应用最后的状态哈希。
+func (h *Handshaker) replayBlocks(state *State, proxyConsensus *proxy.AppConnConsensus, appHeight int64, appHash []byte) ([]byte, error) {
+	first := appHeight + 1
+	if appHeight == 0 {
+		first = state.InitialHeight
+	}
+	if err := h.checkAppHash(state, first, appHash); err != nil {
+		return appHash, err
+	}
+	for height := first; height <= state.LastBlockHeight; height++ {
+		block := h.blockStore.LoadBlockByHeight(height)
+		if block == nil {
+			return appHash, fmt.Errorf("missing block %d in block store", height)
+		}
+		h.logger.Info("replay block on application", "height", height)
+		if _, err := execBlockOnProxyConsensus(proxyConsensus, block, func(evidenceHeight int64) *types.ValidatorSet {
+			return validatorsAt(state, h.store, evidenceHeight)
+		}, h.logger); err != nil {
+			return appHash, err
+		}
+		appHash = proxyConsensus.Commit(pbabci.RequestCommit{}).AppHash
+		h.nBlocks++
+		if err := h.checkAppHash(state, height+1, appHash); err != nil {
+			return appHash, err
+		}
+	}
+	return appHash, nil
+}
+
+// checkAppHash 检查应用在执行完height之前的区块后的状态哈希：height高度的区块已经在区块存储里并且区块头携带了应用状态
+// 哈希时与它比较，height就是下一个高度时与状态里的应用状态哈希比较。
+func (h *Handshaker) checkAppHash(state *State, height int64, appHash []byte) error {
+	var expected []byte
+	if height == state.NextHeight() {
+		expected = state.AppHash
+	} else if block := h.blockStore.LoadBlockByHeight(height); block != nil && len(block.Header.AppHash) > 0 {
+		expected = block.Header.AppHash
+	} else {
+		// 链式HotStuff里父区块未提交时区块头不携带应用状态哈希，这时无从比较
+		return nil
+	}
+	if !bytes.Equal(expected, appHash) {
+		return fmt.Errorf("%w after replaying block %d: expected %X, but application has %X", ErrAppHashMismatch, height-1, expected, appHash)
+	}
+	return nil
+}
+
+// replayBlock 按照ApplyBlock的流程重新执行区块存储里比状态多出的那个区块，并保存新的状态。交易池和证据池在握手时还没有
+// 创建，它们会在启动后根据新的状态自行更新。
+func (h *Handshaker) replayBlock(state *State, block *types.Block, proxyConsensus *proxy.AppConnConsensus) (*State, error) {
+	if len(block.Header.AppHash) > 0 {
+		if err := checkAppHash(state, h.store, block.Header.AppHashHeight(), block.Header.AppHash); err != nil {
+			return state, err
+		}
+	}
+	h.logger.Info("replay last block", "height", block.Header.Height)
+	responses, err := execBlockOnProxyConsensus(proxyConsensus, block, func(height int64) *types.ValidatorSet {
+		return validatorsAt(state, h.store, height)
+	}, h.logger)
+	if err != nil {
+		return state, err
+	}
+	if err = validateEndBlock(state, responses.EndBlock); err != nil {
+		return state, fmt.Errorf("%w at height %d: %v", ErrInvalidEndBlock, block.Header.Height, err)
+	}
+	if err = h.store.SaveABCIResponses(block.Header.Height, responses); err != nil {
+		return state, err
+	}
+	if err = h.store.SaveValidators(block.Header.Height, state.Validators); err != nil {
+		return state, err
+	}
+	commitRes := proxyConsensus.Commit(pbabci.RequestCommit{})
+	h.nBlocks++
+	updateState(state, responses.EndBlock, block, commitRes.AppHash)
+	if err = h.store.SaveState(state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// updateState 应用已经提交了区块存储里比状态多出的那个区块，用执行该区块时保存下来的EndBlock结果推出新的状态并保存。
+func (h *Handshaker) updateState(state *State, block *types.Block, appHash []byte) (*State, error) {
+	responses, err := h.store.LoadABCIResponses(block.Header.Height)
+	if err != nil {
+		return state, fmt.Errorf("application already committed block %d but the state was not saved: %w", block.Header.Height, err)
+	}
+	h.logger.Info("recover state from saved ABCI responses", "height", block.Header.Height)
+	updateState(state, responses.EndBlock, block, appHash)
+	if err = h.store.SaveState(state); err != nil {
+		return state, err
+	}
+	return state, nil
+}
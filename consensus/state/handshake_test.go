@@ -0,0 +1,116 @@
+package state
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/abci/apps"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/store"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func newHandshakeTestApp() *proxy.AppConnConsensus {
+	return proxy.NewAppConnConsensus(apps.NewKVStoreApp("kvstore", "", database.MemDBBackend))
+}
+
+// makeHandshakeTestChain 用一个应用执行并提交n个区块，返回保存了这些区块的区块存储、保存了每个高度的执行结果和验证者
+// 集合的状态存储，以及每个高度执行完之后的状态，states[0]是创世状态。
+func makeHandshakeTestChain(t *testing.T, n int) (*store.BlockStore, *StoreState, []*State) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	proposer := privateKey.PublicKey().ToID()
+	stat := MakeGenesisState(&types.Genesis{
+		InitialHeight: 1,
+		Validators:    []*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)},
+	})
+	blockStore := store.NewStoreBlock(database.NewMemDB())
+	stateStore := NewStoreState(database.NewMemDB())
+	app := newHandshakeTestApp()
+	logger := log.New()
+	states := []*State{stat.Copy()}
+	for i := 1; i <= n; i++ {
+		height := stat.NextHeight()
+		block := &types.Block{
+			Header: &types.Header{PreviousBlockHash: stat.PreviousBlockHash(height), Height: height, Timestamp: time.Now(), Proposer: proposer, AppHash: stat.AppHash},
+			Body:   &types.Data{Txs: types.Txs{types.Tx(fmt.Sprintf("key%d=value%d", i, i))}},
+		}
+		block.BlockDataHash()
+		block.ChameleonHash = &types.ChameleonHash{R1: big.NewInt(1), R2: big.NewInt(1), Alpha: big.NewInt(1), Hash: []byte(fmt.Sprintf("block %d", i))}
+		responses, err := execBlockOnProxyConsensus(app, block, func(int64) *types.ValidatorSet { return stat.Validators }, logger)
+		assert.Nil(t, err)
+		assert.Nil(t, stateStore.SaveABCIResponses(height, responses))
+		assert.Nil(t, stateStore.SaveValidators(height, stat.Validators))
+		assert.Nil(t, stateStore.SaveConsensusParams(height, stat.ConsensusParams))
+		blockStore.SaveBlock(block, &types.CommitBlock{Height: height, Hash: block.ChameleonHash.Hash})
+		updateState(stat, responses.EndBlock, block, app.Commit(pbabci.RequestCommit{}).AppHash)
+		states = append(states, stat.Copy())
+	}
+	return blockStore, stateStore, states
+}
+
+func TestHandshake(t *testing.T) {
+	blockStore, chainStore, states := makeHandshakeTestChain(t, 3)
+	logger := log.New()
+
+	// 应用的数据全部丢失，重新执行所有区块
+	app := newHandshakeTestApp()
+	handshaker := NewHandshaker(NewStoreState(database.NewMemDB()), blockStore, logger)
+	stat, err := handshaker.Handshake(states[3].Copy(), app)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, handshaker.NBlocks())
+	assert.Equal(t, int64(3), stat.LastBlockHeight)
+	info := app.Info(pbabci.RequestInfo{})
+	assert.Equal(t, int64(3), info.LastBlockHeight)
+	assert.Equal(t, states[3].AppHash, info.LastBlockAppHash)
+
+	// 三者已经一致时什么也不做
+	handshaker = NewHandshaker(NewStoreState(database.NewMemDB()), blockStore, logger)
+	_, err = handshaker.Handshake(states[3].Copy(), app)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, handshaker.NBlocks())
+
+	// 区块已经保存，但是应用和状态都还没有执行最后一个区块：应用先追上状态，再按照完整的流程执行最后一个区块并保存状态
+	stateStore := NewStoreState(database.NewMemDB())
+	handshaker = NewHandshaker(stateStore, blockStore, logger)
+	stat, err = handshaker.Handshake(states[2].Copy(), newHandshakeTestApp())
+	assert.Nil(t, err)
+	assert.Equal(t, 3, handshaker.NBlocks())
+	assert.Equal(t, int64(3), stat.LastBlockHeight)
+	assert.Equal(t, states[3].AppHash, stat.AppHash)
+	assert.Equal(t, []byte("block 3"), stat.PreviousBlock.ChameleonHash.Hash)
+	loaded, err := stateStore.LoadState()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), loaded.LastBlockHeight)
+	assert.Equal(t, states[3].AppHash, loaded.AppHash)
+
+	// 应用已经提交了最后一个区块，但是状态还停留在前一个高度，用保存下来的执行结果恢复状态
+	handshaker = NewHandshaker(chainStore, blockStore, logger)
+	stat, err = handshaker.Handshake(states[2].Copy(), app)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, handshaker.NBlocks())
+	assert.Equal(t, int64(3), stat.LastBlockHeight)
+	assert.Equal(t, states[3].AppHash, stat.AppHash)
+	// 没有保存执行结果时无法恢复
+	_, err = NewHandshaker(NewStoreState(database.NewMemDB()), blockStore, logger).Handshake(states[2].Copy(), app)
+	assert.NotNil(t, err)
+
+	// 状态里的应用状态哈希与应用执行区块后得到的不一致
+	diverged := states[3].Copy()
+	diverged.AppHash = []byte("diverged app hash")
+	_, err = NewHandshaker(NewStoreState(database.NewMemDB()), blockStore, logger).Handshake(diverged, newHandshakeTestApp())
+	assert.ErrorIs(t, err, ErrAppHashMismatch)
+
+	// 应用的高度超过了区块存储的高度
+	_, err = NewHandshaker(NewStoreState(database.NewMemDB()), store.NewStoreBlock(database.NewMemDB()), logger).Handshake(states[0].Copy(), app)
+	assert.NotNil(t, err)
+
+	// 区块存储比状态多出不止一个区块
+	_, err = NewHandshaker(NewStoreState(database.NewMemDB()), blockStore, logger).Handshake(states[1].Copy(), newHandshakeTestApp())
+	assert.NotNil(t, err)
+}
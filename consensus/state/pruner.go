@@ -0,0 +1,94 @@
+package state
+
+import (
+	"github.com/232425wxy/meta--/common/service"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/store"
+)
+
+// Pruner 在后台删除保留高度以下的区块、区块哈希索引、提交证明，以及每个高度的验证者集合和执行结果。保留高度由应用在
+// Commit时通过RetainHeight给出，节点至少保留最近minRetainBlocks个区块，minRetainBlocks为0时不做限制。
+type Pruner struct {
+	service.BaseService
+	stateStore      *StoreState
+	blockStore      *store.BlockStore
+	minRetainBlocks int64
+	retainCh        chan int64 // 只保留应用最近一次给出的保留高度
+}
+
+func NewPruner(stateStore *StoreState, blockStore *store.BlockStore, minRetainBlocks int64, logger log.Logger) *Pruner {
+	return &Pruner{
+		BaseService:     *service.NewBaseService(logger, "Pruner"),
+		stateStore:      stateStore,
+		blockStore:      blockStore,
+		minRetainBlocks: minRetainBlocks,
+		retainCh:        make(chan int64, 1),
+	}
+}
+
+func (p *Pruner) Start() error {
+	go p.pruneRoutine()
+	return p.BaseService.Start()
+}
+
+// SetRetainHeight 通知后台删除retainHeight以下的数据，不会被阻塞，还没来得及处理的旧的保留高度会被新的替换掉。
+func (p *Pruner) SetRetainHeight(retainHeight int64) {
+	if retainHeight <= 0 {
+		return
+	}
+	select {
+	case <-p.retainCh:
+	default:
+	}
+	select {
+	case p.retainCh <- retainHeight:
+	default:
+	}
+}
+
+func (p *Pruner) pruneRoutine() {
+	for {
+		select {
+		case retainHeight := <-p.retainCh:
+			if _, err := p.Prune(retainHeight); err != nil {
+				p.Logger.Error("failed to prune blocks and states", "retain_height", retainHeight, "err", err)
+			}
+		case <-p.WaitStop():
+			return
+		}
+	}
+}
+
+// Prune 删除应用给出的保留高度以下的数据，返回被删除的区块数量。先删除状态数据再删除区块，中途崩溃时区块存储的最低高度
+// 还没有改变，下一次会重新删除这一段状态数据。
+func (p *Pruner) Prune(appRetainHeight int64) (int64, error) {
+	retainHeight := p.retainHeight(appRetainHeight)
+	base := p.blockStore.Base()
+	if base == 0 || retainHeight <= base {
+		return 0, nil
+	}
+	if err := p.stateStore.PruneStates(base, retainHeight); err != nil {
+		return 0, err
+	}
+	pruned, err := p.blockStore.PruneBlocks(retainHeight)
+	if err != nil {
+		return 0, err
+	}
+	p.Logger.Info("pruned blocks and states", "pruned", pruned, "base", retainHeight)
+	return pruned, nil
+}
+
+// retainHeight 根据最少保留的区块数量限制应用给出的保留高度，并且不会超过区块存储的高度。
+func (p *Pruner) retainHeight(appRetainHeight int64) int64 {
+	height := p.blockStore.Height()
+	retainHeight := appRetainHeight
+	if p.minRetainBlocks > 0 {
+		if limit := height - p.minRetainBlocks + 1; retainHeight > limit {
+			retainHeight = limit
+		}
+	}
+	if retainHeight > height {
+		retainHeight = height
+	}
+	return retainHeight
+}
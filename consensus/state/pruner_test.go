@@ -0,0 +1,61 @@
+package state
+
+import (
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/store"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPruner(t *testing.T) {
+	blockStore, stateStore, _ := makeHandshakeTestChain(t, 10)
+	assert.Equal(t, int64(1), blockStore.Base())
+	pruner := NewPruner(stateStore, blockStore, 3, log.New())
+
+	// 应用没有给出保留高度时不删除任何数据
+	pruned, err := pruner.Prune(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), pruned)
+
+	pruned, err = pruner.Prune(5)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(4), pruned)
+	assert.Equal(t, int64(5), blockStore.Base())
+	assert.Nil(t, blockStore.LoadBlockByHeight(4))
+	assert.Nil(t, blockStore.LoadCommit(4))
+	assert.Nil(t, blockStore.LoadBlockByHash([]byte("block 4")))
+	assert.NotNil(t, blockStore.LoadBlockByHeight(5))
+	assert.NotNil(t, blockStore.LoadCommit(5))
+	_, err = stateStore.LoadValidators(4)
+	assert.NotNil(t, err)
+	_, err = stateStore.LoadABCIResponses(4)
+	assert.NotNil(t, err)
+	_, err = stateStore.LoadConsensusParams(4)
+	assert.NotNil(t, err)
+	_, err = stateStore.LoadValidators(5)
+	assert.Nil(t, err)
+	_, err = stateStore.LoadABCIResponses(5)
+	assert.Nil(t, err)
+	_, err = stateStore.LoadConsensusParams(5)
+	assert.Nil(t, err)
+
+	// 至少保留最近3个区块
+	pruned, err = pruner.Prune(100)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), pruned)
+	assert.Equal(t, int64(8), blockStore.Base())
+
+	// 最低的区块高度被保存了下来
+	reloaded := store.NewStoreBlock(blockStore.DB())
+	assert.Equal(t, int64(8), reloaded.Base())
+	assert.Equal(t, int64(10), reloaded.Height())
+
+	// 后台删除应用在提交时给出的保留高度以下的数据
+	pruner = NewPruner(stateStore, blockStore, 0, log.New())
+	assert.Nil(t, pruner.Start())
+	defer pruner.Stop()
+	pruner.SetRetainHeight(10)
+	assert.Eventually(t, func() bool { return blockStore.Base() == 10 }, time.Second, 10*time.Millisecond)
+	assert.NotNil(t, blockStore.LoadBlockByHeight(10))
+}
@@ -0,0 +1,24 @@
+package state
+
+import "github.com/232425wxy/meta--/types"
+
+// EvidencePool 证据池为区块执行器提供待打包的证据，检查区块里携带的证据，并在区块被执行后清理已经被提交的证据。
+type EvidencePool interface {
+	PendingEvidence(maxBytes int64) types.EvidenceList
+	AddEvidence(evidence types.Evidence) error
+	CheckEvidence(evidenceList types.EvidenceList) error
+	Update(state *State, evidenceList types.EvidenceList)
+}
+
+// EmptyEvidencePool 不保存任何证据的证据池，在没有配置证据池时使用。
+type EmptyEvidencePool struct{}
+
+var _ EvidencePool = EmptyEvidencePool{}
+
+func (EmptyEvidencePool) PendingEvidence(maxBytes int64) types.EvidenceList { return nil }
+
+func (EmptyEvidencePool) AddEvidence(evidence types.Evidence) error { return nil }
+
+func (EmptyEvidencePool) CheckEvidence(evidenceList types.EvidenceList) error { return nil }
+
+func (EmptyEvidencePool) Update(state *State, evidenceList types.EvidenceList) {}
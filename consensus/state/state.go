@@ -1,10 +1,11 @@
 package state
 
 import (
-	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/proto/pbabci"
 	"github.com/232425wxy/meta--/proto/pbstate"
 	"github.com/232425wxy/meta--/proto/pbtypes"
 	"github.com/232425wxy/meta--/stch"
@@ -16,6 +17,9 @@ import (
 
 var StoreStateKey = []byte("meta--/store-state")
 var ValidatorsKey = []byte("meta--/state/validators")
+var ConsensusParamsKey = []byte("meta--/state/consensus-params")
+var ABCIResponsesKey = []byte("meta--/state/abci-responses")
+var AppHashKey = []byte("meta--/state/app-hash")
 
 type State struct {
 	InitialHeight   int64
@@ -23,6 +27,8 @@ type State struct {
 	PreviousBlock   *types.Block
 	LastBlockTime   time.Time
 	Validators      *types.ValidatorSet
+	ConsensusParams *types.ConsensusParams // 下一个高度的区块所使用的共识参数
+	AppHash         []byte                 // 执行完最后一个区块后应用状态的哈希值，会被写进下一个区块的区块头里
 	BlockStore      *store.BlockStore
 	Chameleon       *stch.Chameleon
 }
@@ -34,6 +40,8 @@ func (s *State) Copy() *State {
 		PreviousBlock:   s.PreviousBlock,
 		LastBlockTime:   s.LastBlockTime,
 		Validators:      s.Validators.Copy(),
+		ConsensusParams: s.ConsensusParams.Copy(),
+		AppHash:         s.AppHash,
 	}
 }
 
@@ -45,10 +53,12 @@ func (s *State) SetBlockStore(store *store.BlockStore) {
 	s.BlockStore = store
 }
 
-func (s *State) MakeBlock(height int64, txs []types.Tx, proposer crypto.ID, lastBlockHash []byte) *types.Block {
+// MakeBlock 创建height高度的区块，appHash是执行完appHashHeight高度的区块后应用状态的哈希值。
+func (s *State) MakeBlock(height int64, txs []types.Tx, evidence types.EvidenceList, proposer crypto.ID, lastBlockHash []byte, appHash []byte, appHashHeight int64) *types.Block {
 	block := &types.Block{
-		Header: &types.Header{PreviousBlockHash: lastBlockHash, Height: height, Timestamp: time.Now(), Proposer: proposer},
-		Body:   &types.Data{Txs: txs},
+		Header:   &types.Header{PreviousBlockHash: lastBlockHash, Height: height, Timestamp: time.Now(), Proposer: proposer, AppHash: appHash, AppHashLag: height - 1 - appHashHeight},
+		Body:     &types.Data{Txs: txs},
+		Evidence: evidence,
 	}
 	//_txs := make([][]byte, len(txs))
 	//for i, tx := range txs {
@@ -59,16 +69,41 @@ func (s *State) MakeBlock(height int64, txs []types.Tx, proposer crypto.ID, last
 	return block
 }
 
+// PreviousBlockHash 返回高度为height的区块应当引用的前一个区块的哈希值，初始高度的区块引用一个固定的哈希值。
+func (s *State) PreviousBlockHash(height int64) []byte {
+	if height == s.InitialHeight {
+		h := sha256.Sum([]byte("first block"))
+		return h[:]
+	}
+	if s.PreviousBlock == nil || s.PreviousBlock.ChameleonHash == nil {
+		return nil
+	}
+	return s.PreviousBlock.ChameleonHash.Hash
+}
+
 func MakeGenesisState(gen *types.Genesis) *State {
+	params := gen.ConsensusParams
+	if params == nil {
+		params = types.DefaultConsensusParams()
+	}
 	return &State{
 		InitialHeight:   gen.InitialHeight,
 		LastBlockHeight: 0,
 		PreviousBlock:   &types.Block{},
 		LastBlockTime:   gen.GenesisTime,
 		Validators:      types.NewValidatorSet(gen.Validators),
+		ConsensusParams: params.Copy(),
 	}
 }
 
+// NextHeight 返回下一个需要被确认的区块的高度。
+func (s *State) NextHeight() int64 {
+	if s.LastBlockHeight == 0 {
+		return s.InitialHeight
+	}
+	return s.LastBlockHeight + 1
+}
+
 func (s *State) ToProto() *pbstate.State {
 	if s == nil {
 		return nil
@@ -79,6 +114,8 @@ func (s *State) ToProto() *pbstate.State {
 		PreviousBlock:   s.PreviousBlock.ToProto(),
 		LastBlockTime:   s.LastBlockTime,
 		Validators:      s.Validators.ToProto(),
+		ConsensusParams: s.ConsensusParams.ToProto(),
+		AppHash:         s.AppHash,
 	}
 }
 
@@ -86,12 +123,19 @@ func StateFromProto(pb *pbstate.State) *State {
 	if pb == nil {
 		return nil
 	}
+	params := types.ConsensusParamsFromProto(pb.ConsensusParams)
+	if params == nil {
+		// 旧版本保存的状态里没有共识参数
+		params = types.DefaultConsensusParams()
+	}
 	return &State{
 		InitialHeight:   pb.InitialHeight,
 		LastBlockHeight: pb.LastBlockHeight,
 		PreviousBlock:   types.BlockFromProto(pb.PreviousBlock),
 		LastBlockTime:   pb.LastBlockTime,
 		Validators:      types.ValidatorSetFromProto(pb.Validators),
+		ConsensusParams: params,
+		AppHash:         pb.AppHash,
 	}
 }
 
@@ -155,7 +199,19 @@ func (s *StoreState) LoadState() (*State, error) {
 	return stat, nil
 }
 
+// SaveState 保存最新的状态，同时记录下一个高度所使用的共识参数和执行完最后一个区块后的应用状态哈希，以便之后查询任意
+// 高度的共识参数和应用状态哈希。
 func (s *StoreState) SaveState(stat *State) error {
+	if stat.ConsensusParams != nil {
+		if err := s.SaveConsensusParams(stat.NextHeight(), stat.ConsensusParams); err != nil {
+			return err
+		}
+	}
+	if len(stat.AppHash) > 0 {
+		if err := s.db.SetSync(calcAppHashKey(stat.LastBlockHeight), stat.AppHash); err != nil {
+			return err
+		}
+	}
 	return s.db.SetSync(StoreStateKey, stat.ToBytes())
 }
 
@@ -163,6 +219,7 @@ func (s *StoreState) Bootstrap(stat *State) error {
 	return s.SaveState(stat)
 }
 
+// SaveValidators 保存为高度为height的区块投票的验证者集合。
 func (s *StoreState) SaveValidators(height int64, validators *types.ValidatorSet) error {
 	pb := validators.ToProto()
 	bz, err := proto.Marshal(pb)
@@ -172,13 +229,14 @@ func (s *StoreState) SaveValidators(height int64, validators *types.ValidatorSet
 	return s.db.SetSync(calcValidatorsKey(height), bz)
 }
 
+// LoadValidators 加载为高度为height的区块投票的验证者集合。
 func (s *StoreState) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	bz, err := s.db.Get(calcValidatorsKey(height))
 	if err != nil {
 		return nil, err
 	}
 	if len(bz) == 0 {
-		return nil, errors.New("validators retrieved from db is empty")
+		return nil, fmt.Errorf("validators at height %d not found", height)
 	}
 	pb := &pbtypes.ValidatorSet{}
 	if err = proto.Unmarshal(bz, pb); err != nil {
@@ -187,6 +245,103 @@ func (s *StoreState) LoadValidators(height int64) (*types.ValidatorSet, error) {
 	return types.ValidatorSetFromProto(pb), nil
 }
 
+func (s *StoreState) SaveConsensusParams(height int64, params *types.ConsensusParams) error {
+	bz, err := proto.Marshal(params.ToProto())
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(calcConsensusParamsKey(height), bz)
+}
+
+// LoadConsensusParams 加载高度为height的区块所使用的共识参数。
+func (s *StoreState) LoadConsensusParams(height int64) (*types.ConsensusParams, error) {
+	bz, err := s.db.Get(calcConsensusParamsKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("consensus params at height %d not found", height)
+	}
+	pb := &pbtypes.ConsensusParams{}
+	if err = proto.Unmarshal(bz, pb); err != nil {
+		return nil, err
+	}
+	return types.ConsensusParamsFromProto(pb), nil
+}
+
+// SaveABCIResponses 保存应用执行高度为height的区块时返回的全部结果。
+func (s *StoreState) SaveABCIResponses(height int64, responses *pbabci.ABCIResponses) error {
+	bz, err := proto.Marshal(responses)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(calcABCIResponsesKey(height), bz)
+}
+
+// LoadABCIResponses 加载应用执行高度为height的区块时返回的全部结果。
+func (s *StoreState) LoadABCIResponses(height int64) (*pbabci.ABCIResponses, error) {
+	bz, err := s.db.Get(calcABCIResponsesKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("ABCI responses at height %d not found", height)
+	}
+	responses := new(pbabci.ABCIResponses)
+	if err = proto.Unmarshal(bz, responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// LoadAppHash 加载执行完height高度的区块后应用状态的哈希值。
+func (s *StoreState) LoadAppHash(height int64) ([]byte, error) {
+	bz, err := s.db.Get(calcAppHashKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("app hash at height %d not found", height)
+	}
+	return bz, nil
+}
+
+// PruneStates 删除[from, to)高度区间内每个高度的验证者集合、共识参数、执行结果和应用状态哈希。
+func (s *StoreState) PruneStates(from, to int64) error {
+	if from <= 0 || to <= from {
+		return fmt.Errorf("invalid height range [%d, %d)", from, to)
+	}
+	batch := s.db.NewBatch()
+	defer batch.Close()
+	for height := from; height < to; height++ {
+		if err := batch.Delete(calcValidatorsKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcConsensusParamsKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcABCIResponsesKey(height)); err != nil {
+			return err
+		}
+		if err := batch.Delete(calcAppHashKey(height)); err != nil {
+			return err
+		}
+	}
+	return batch.WriteSync()
+}
+
+func calcConsensusParamsKey(height int64) []byte {
+	return append(ConsensusParamsKey, fmt.Sprintf("%d", height)...)
+}
+
 func calcValidatorsKey(height int64) []byte {
 	return append(ValidatorsKey, fmt.Sprintf("%d", height)...)
 }
+
+func calcABCIResponsesKey(height int64) []byte {
+	return append(ABCIResponsesKey, fmt.Sprintf("%d", height)...)
+}
+
+func calcAppHashKey(height int64) []byte {
+	return append(AppHashKey, fmt.Sprintf("%d", height)...)
+}
@@ -1,9 +1,11 @@
 package consensus
 
 import (
+	"bytes"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12"
 	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/proto/pbtypes"
 	"github.com/232425wxy/meta--/types"
 )
 
@@ -20,6 +22,12 @@ const (
 	CommitVoteStep
 	DecideStep
 	ConsensusTimeout
+	// 两阶段共识的步骤，排在四阶段共识的步骤之后，数值依然按照共识推进的顺序递增
+	TwoPhaseProposeStep
+	TwoPhaseVoteStep
+	TwoPhaseLockStep
+	TwoPhaseLockVoteStep
+	TwoPhaseDecideStep
 )
 
 func (s Step) String() string {
@@ -42,16 +50,44 @@ func (s Step) String() string {
 		return "COMMIT_VOTE_STEP 8/9"
 	case DecideStep:
 		return "DECIDE_STEP 9/9"
+	case ConsensusTimeout:
+		return "CONSENSUS_TIMEOUT"
+	case TwoPhaseProposeStep:
+		return "TWO_PHASE_PROPOSE_STEP 3/7"
+	case TwoPhaseVoteStep:
+		return "TWO_PHASE_VOTE_STEP 4/7"
+	case TwoPhaseLockStep:
+		return "TWO_PHASE_LOCK_STEP 5/7"
+	case TwoPhaseLockVoteStep:
+		return "TWO_PHASE_LOCK_VOTE_STEP 6/7"
+	case TwoPhaseDecideStep:
+		return "TWO_PHASE_DECIDE_STEP 7/7"
 	default:
 		panic("unknown step")
 	}
 }
 
+// isPrepareStep 主节点处于这些步骤时，才会把Prepare消息发送给其他节点。
+func (s Step) isPrepareStep() bool {
+	return s == PrepareStep || s == PrepareVoteStep || s == TwoPhaseProposeStep || s == TwoPhaseVoteStep
+}
+
+// isPreCommitStep 主节点处于这些步骤时，才会把PreCommit消息发送给其他节点。
+func (s Step) isPreCommitStep() bool {
+	return s == PreCommitStep || s == PreCommitVoteStep || s == TwoPhaseLockStep || s == TwoPhaseLockVoteStep
+}
+
+// isDecideStep 主节点处于这些步骤时，才会把Decide消息发送给其他节点。
+func (s Step) isDecideStep() bool {
+	return s == DecideStep || s == TwoPhaseDecideStep
+}
+
 type StepInfo struct {
 	height          int64
 	round           int16
 	step            Step
 	block           *types.Block
+	proposal        *types.Prepare // 当前轮次收到的Prepare消息，用来检测主节点是否在同一轮次提出了相互冲突的区块
 	previousBlock   *types.Block
 	prepare         chan *types.Prepare
 	preCommit       chan *types.PreCommit
@@ -59,6 +95,16 @@ type StepInfo struct {
 	decide          chan *types.Decide
 	voteSet         *VoteSet
 	collectNextView map[crypto.ID]*types.NextView
+	collectNewView  map[int16]map[crypto.ID]*types.NewView // round -> NewView消息
+	highQC          *types.PreCommit                       // 当前高度下所知道的最高的QC
+	highQCRound     int16
+	highQCBlock     *types.Block  // highQC所认证的区块
+	lockedQC        *types.Commit // 副本节点锁定的QC，被锁定后只为扩展锁定区块或者携带更新QC的提案投票
+	lockedRound     int16
+	lockedBlock     *types.Block                           // lockedQC所认证的区块
+	collectTimeout  map[int16]map[crypto.ID]*types.Timeout // 两阶段共识里 round -> Timeout消息
+	tc              *types.TimeoutCert                     // 两阶段共识里当前高度下所知道的最新一轮的超时证书
+	fetch           *blockFetch                            // 正在向其他节点索要的QC所认证的区块，见block_fetch.go
 }
 
 func NewStepInfo() *StepInfo {
@@ -66,6 +112,8 @@ func NewStepInfo() *StepInfo {
 		round:           1,
 		voteSet:         NewVoteSet(),
 		collectNextView: make(map[crypto.ID]*types.NextView),
+		collectNewView:  make(map[int16]map[crypto.ID]*types.NewView),
+		collectTimeout:  make(map[int16]map[crypto.ID]*types.Timeout),
 		prepare:         make(chan *types.Prepare, 1),
 		preCommit:       make(chan *types.PreCommit, 1),
 		commit:          make(chan *types.Commit, 1),
@@ -77,12 +125,68 @@ func (si *StepInfo) Reset() {
 	si.round = 1
 	si.step = NewHeightStep
 	si.block = nil
+	si.proposal = nil
 	si.prepare = make(chan *types.Prepare, 1)
 	si.preCommit = make(chan *types.PreCommit, 1)
 	si.commit = make(chan *types.Commit, 1)
 	si.decide = make(chan *types.Decide, 1)
 	si.voteSet.Reset()
 	si.collectNextView = make(map[crypto.ID]*types.NextView)
+	si.collectNewView = make(map[int16]map[crypto.ID]*types.NewView)
+	si.highQC = nil
+	si.highQCRound = 0
+	si.highQCBlock = nil
+	si.lockedQC = nil
+	si.lockedRound = 0
+	si.lockedBlock = nil
+	si.collectTimeout = make(map[int16]map[crypto.ID]*types.Timeout)
+	si.tc = nil
+	si.fetch = nil
+}
+
+// EnterRound 进入同一高度下的新一轮共识，清除上一轮留下的区块和共识消息，但是保留highQC和lockedQC。
+func (si *StepInfo) EnterRound(round int16) {
+	si.round = round
+	si.step = NewRoundStep
+	si.block = nil
+	si.proposal = nil
+	si.prepare = make(chan *types.Prepare, 1)
+	si.preCommit = make(chan *types.PreCommit, 1)
+	si.commit = make(chan *types.Commit, 1)
+	si.decide = make(chan *types.Decide, 1)
+	for r := range si.collectNewView {
+		if r < round {
+			delete(si.collectNewView, r)
+		}
+	}
+}
+
+// UpdateHighQC 如果给定的QC比当前所知道的highQC更新，则用其替换highQC。
+func (si *StepInfo) UpdateHighQC(round int16, block *types.Block, qc *types.PreCommit) bool {
+	if qc == nil || block == nil {
+		return false
+	}
+	if si.highQC != nil && round <= si.highQCRound {
+		return false
+	}
+	si.highQC = qc
+	si.highQCRound = round
+	si.highQCBlock = block
+	return true
+}
+
+// Lock 收到Commit消息后，锁定该消息所认证的区块，只有更新轮次的QC才能替换已锁定的QC。
+func (si *StepInfo) Lock(round int16, block *types.Block, qc *types.Commit) bool {
+	if qc == nil || block == nil {
+		return false
+	}
+	if si.lockedQC != nil && round <= si.lockedRound {
+		return false
+	}
+	si.lockedQC = qc
+	si.lockedRound = round
+	si.lockedBlock = block
+	return true
 }
 
 func (si *StepInfo) EventStepInfo() events.EventDataNewStep {
@@ -102,10 +206,22 @@ func (si *StepInfo) AddNextView(view *types.NextView) {
 	si.collectNextView = collect
 }
 
-func (si *StepInfo) CheckCollectNextViewIsComplete(validators *types.ValidatorSet) bool {
+// CheckCollectNextViewIsComplete 下一个高度的主节点self检查是否收集到了足够多的NextView消息。self不会给自己发送NextView
+// 消息，但它执行完区块这件事本身就说明它已经准备好进入下一个高度，所以它自己的投票权也计入法定人数，否则4个验证者里只要
+// 有1个联系不上，主节点就永远等不到法定人数。
+func (si *StepInfo) CheckCollectNextViewIsComplete(validators *types.ValidatorSet, self crypto.ID) bool {
 	var hasPower int64 = 0
 	for id := range si.collectNextView {
+		if id == self {
+			continue
+		}
 		validator := validators.GetValidatorByID(id)
+		if validator == nil {
+			continue
+		}
+		hasPower += validator.VotingPower
+	}
+	if validator := validators.GetValidatorByID(self); validator != nil {
 		hasPower += validator.VotingPower
 	}
 	if hasPower >= validators.PowerMajor23() {
@@ -114,43 +230,190 @@ func (si *StepInfo) CheckCollectNextViewIsComplete(validators *types.ValidatorSe
 	return false
 }
 
+func (si *StepInfo) AddNewView(view *types.NewView) {
+	collect := si.collectNewView[view.Round]
+	if collect == nil {
+		collect = make(map[crypto.ID]*types.NewView)
+	}
+	collect[view.ID] = view
+	si.collectNewView[view.Round] = collect
+}
+
+func (si *StepInfo) CheckCollectNewViewIsComplete(round int16, validators *types.ValidatorSet) bool {
+	var hasPower int64 = 0
+	for id := range si.collectNewView[round] {
+		validator := validators.GetValidatorByID(id)
+		if validator == nil {
+			continue
+		}
+		hasPower += validator.VotingPower
+	}
+	if hasPower >= validators.PowerMajor23() {
+		return true
+	}
+	return false
+}
+
+func (si *StepInfo) AddTimeout(timeout *types.Timeout) {
+	collect := si.collectTimeout[timeout.Round]
+	if collect == nil {
+		collect = make(map[crypto.ID]*types.Timeout)
+	}
+	collect[timeout.ID] = timeout
+	si.collectTimeout[timeout.Round] = collect
+}
+
+// timeoutPower 返回在round轮发出Timeout消息的验证者的投票权之和。
+func (si *StepInfo) timeoutPower(round int16, validators *types.ValidatorSet) int64 {
+	var hasPower int64 = 0
+	for id := range si.collectTimeout[round] {
+		validator := validators.GetValidatorByID(id)
+		if validator == nil {
+			continue
+		}
+		hasPower += validator.VotingPower
+	}
+	return hasPower
+}
+
+func (si *StepInfo) CheckCollectTimeoutIsComplete(round int16, validators *types.ValidatorSet) bool {
+	return si.timeoutPower(round, validators) >= validators.PowerMajor23()
+}
+
+// CheckCollectTimeoutHasOneThird 超过1/3的验证者在round轮超时，说明至少有一个诚实节点已经放弃了这一轮。
+func (si *StepInfo) CheckCollectTimeoutHasOneThird(round int16, validators *types.ValidatorSet) bool {
+	return si.timeoutPower(round, validators)*3 > validators.PowerMajorFull()
+}
+
+// CreateTimeoutCert 将round轮收集到的Timeout消息的签名聚合成超时证书，每个验证者签署的最高QC轮次可能不同。
+func (si *StepInfo) CreateTimeoutCert(round int16, cryptoBLS12 *bls12.CryptoBLS12) (*types.TimeoutCert, error) {
+	timeouts := si.collectTimeout[round]
+	rounds := make(map[crypto.ID]int16, len(timeouts))
+	sigs := make([]*bls12.Signature, 0, len(timeouts))
+	for id, timeout := range timeouts {
+		rounds[id] = timeout.HighQCRound()
+		sigs = append(sigs, timeout.Signature)
+	}
+	agg, err := cryptoBLS12.CreateThresholdSignatureForMessageSet(sigs)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewTimeoutCert(si.height, round, rounds, agg), nil
+}
+
 type RoundVoteSet struct {
 	PrepareVoteSet   map[crypto.ID]*types.PrepareVote
 	PreCommitVoteSet map[crypto.ID]*types.PreCommitVote
 	CommitVoteSet    map[crypto.ID]*types.CommitVote
+	// Aggregates 投票聚合树发来的部分聚合签名，同一类型的聚合签名的参与者互不相交，被聚合签名覆盖的验证者不会再出现在
+	// 上面的投票集合里
+	Aggregates map[pbtypes.VoteType][]*bls12.AggregateSignature
+}
+
+// covers 判断id的typ类型的投票是否已经包含在某个聚合签名里。
+func (rvs *RoundVoteSet) covers(typ pbtypes.VoteType, id crypto.ID) bool {
+	for _, agg := range rvs.Aggregates[typ] {
+		if agg.Participants().Contains(id) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// power 统计typ类型的单个投票和聚合签名里的投票权之和。
+func (rvs *RoundVoteSet) power(typ pbtypes.VoteType, validators *types.ValidatorSet) int64 {
+	var ids []crypto.ID
+	switch typ {
+	case pbtypes.PrepareVoteType:
+		for id := range rvs.PrepareVoteSet {
+			ids = append(ids, id)
+		}
+	case pbtypes.PreCommitVoteType:
+		for id := range rvs.PreCommitVoteSet {
+			ids = append(ids, id)
+		}
+	case pbtypes.CommitVoteType:
+		for id := range rvs.CommitVoteSet {
+			ids = append(ids, id)
+		}
+	}
+	for _, agg := range rvs.Aggregates[typ] {
+		ids = append(ids, agg.Participants().IDs...)
+	}
+	var power int64 = 0
+	for _, id := range ids {
+		if validator := validators.GetValidatorByID(id); validator != nil {
+			power += validator.VotingPower
+		}
+	}
+	return power
+}
+
+// thresholdSig 把typ类型的单个投票的签名和聚合签名合并成一个聚合签名。
+func (rvs *RoundVoteSet) thresholdSig(typ pbtypes.VoteType, sigs []*bls12.Signature, cb *bls12.CryptoBLS12) *bls12.AggregateSignature {
+	aggs := append([]*bls12.AggregateSignature(nil), rvs.Aggregates[typ]...)
+	if len(sigs) > 0 {
+		agg, err := cb.CreateThresholdSignature(sigs)
+		if err != nil {
+			return nil
+		}
+		aggs = append(aggs, agg)
+	}
+	if len(aggs) == 0 {
+		return nil
+	}
+	agg, err := cb.CombineThresholdSignatures(aggs)
+	if err != nil {
+		return nil
+	}
+	return agg
 }
 
 type VoteSet struct {
-	roundVoteSets map[int16]*RoundVoteSet // round -> RoundVoteSet
+	roundVoteSets map[int16]*RoundVoteSet                                  // round -> RoundVoteSet
+	seenVotes     map[int16]map[pbtypes.VoteType]map[crypto.ID]*types.Vote // round -> 投票类型 -> 验证者第一次投出的票
 }
 
 func NewVoteSet() *VoteSet {
 	return &VoteSet{
 		roundVoteSets: make(map[int16]*RoundVoteSet),
+		seenVotes:     make(map[int16]map[pbtypes.VoteType]map[crypto.ID]*types.Vote),
 	}
 }
 
 func (vs *VoteSet) Reset() {
 	vs.roundVoteSets = make(map[int16]*RoundVoteSet)
+	vs.seenVotes = make(map[int16]map[pbtypes.VoteType]map[crypto.ID]*types.Vote)
 }
 
-func (vs *VoteSet) AddPrepareVote(round int16, vote *types.PrepareVote) {
-	if vs.roundVoteSets == nil {
-		vs.roundVoteSets = make(map[int16]*RoundVoteSet)
+// RecordVote 记录验证者在某一轮投出的票（不论投给了哪个区块），如果该验证者在同一轮已经为另一个区块投过同一类型的票，
+// 则返回之前的那张票，两张票可以作为该验证者作恶的证据。
+func (vs *VoteSet) RecordVote(round int16, vote *types.Vote) *types.Vote {
+	if vs.seenVotes == nil {
+		vs.seenVotes = make(map[int16]map[pbtypes.VoteType]map[crypto.ID]*types.Vote)
 	}
-	roundVoteSet := vs.roundVoteSets[round]
-	if roundVoteSet == nil {
-		roundVoteSet = &RoundVoteSet{
-			PrepareVoteSet:   make(map[crypto.ID]*types.PrepareVote),
-			PreCommitVoteSet: make(map[crypto.ID]*types.PreCommitVote),
-			CommitVoteSet:    make(map[crypto.ID]*types.CommitVote),
+	typeVotes := vs.seenVotes[round]
+	if typeVotes == nil {
+		typeVotes = make(map[pbtypes.VoteType]map[crypto.ID]*types.Vote)
+		vs.seenVotes[round] = typeVotes
+	}
+	votes := typeVotes[vote.VoteType]
+	if votes == nil {
+		votes = make(map[crypto.ID]*types.Vote)
+		typeVotes[vote.VoteType] = votes
+	}
+	signer := vote.Signature.Signer()
+	if existing, ok := votes[signer]; ok {
+		if !bytes.Equal(existing.BlockHash, vote.BlockHash) {
+			return existing
 		}
+		return nil
 	}
-	roundVoteSet.PrepareVoteSet[vote.Vote.Signature.Signer()] = vote
-	vs.roundVoteSets[round] = roundVoteSet
+	votes[signer] = vote
+	return nil
 }
 
-func (vs *VoteSet) AddPreCommitVote(round int16, vote *types.PreCommitVote) {
+func (vs *VoteSet) roundVoteSet(round int16) *RoundVoteSet {
 	if vs.roundVoteSets == nil {
 		vs.roundVoteSets = make(map[int16]*RoundVoteSet)
 	}
@@ -160,102 +423,108 @@ func (vs *VoteSet) AddPreCommitVote(round int16, vote *types.PreCommitVote) {
 			PrepareVoteSet:   make(map[crypto.ID]*types.PrepareVote),
 			PreCommitVoteSet: make(map[crypto.ID]*types.PreCommitVote),
 			CommitVoteSet:    make(map[crypto.ID]*types.CommitVote),
+			Aggregates:       make(map[pbtypes.VoteType][]*bls12.AggregateSignature),
 		}
+		vs.roundVoteSets[round] = roundVoteSet
+	}
+	return roundVoteSet
+}
+
+func (vs *VoteSet) AddPrepareVote(round int16, vote *types.PrepareVote) {
+	roundVoteSet := vs.roundVoteSet(round)
+	if roundVoteSet.covers(pbtypes.PrepareVoteType, vote.Vote.Signature.Signer()) {
+		return
+	}
+	roundVoteSet.PrepareVoteSet[vote.Vote.Signature.Signer()] = vote
+}
+
+func (vs *VoteSet) AddPreCommitVote(round int16, vote *types.PreCommitVote) {
+	roundVoteSet := vs.roundVoteSet(round)
+	if roundVoteSet.covers(pbtypes.PreCommitVoteType, vote.Vote.Signature.Signer()) {
+		return
 	}
 	roundVoteSet.PreCommitVoteSet[vote.Vote.Signature.Signer()] = vote
-	vs.roundVoteSets[round] = roundVoteSet
 }
 
 func (vs *VoteSet) AddCommitVote(round int16, vote *types.CommitVote) {
-	if vs.roundVoteSets == nil {
-		vs.roundVoteSets = make(map[int16]*RoundVoteSet)
-	}
-	roundVoteSet := vs.roundVoteSets[round]
-	if roundVoteSet == nil {
-		roundVoteSet = &RoundVoteSet{
-			PrepareVoteSet:   make(map[crypto.ID]*types.PrepareVote),
-			PreCommitVoteSet: make(map[crypto.ID]*types.PreCommitVote),
-			CommitVoteSet:    make(map[crypto.ID]*types.CommitVote),
-		}
+	roundVoteSet := vs.roundVoteSet(round)
+	if roundVoteSet.covers(pbtypes.CommitVoteType, vote.Vote.Signature.Signer()) {
+		return
 	}
 	roundVoteSet.CommitVoteSet[vote.Vote.Signature.Signer()] = vote
-	vs.roundVoteSets[round] = roundVoteSet
 }
 
-func (vs *VoteSet) CheckPrepareVoteIsComplete(round int16, validators *types.ValidatorSet) bool {
-	roundVoteSet := vs.roundVoteSets[round]
-	var hasVotePower int64 = 0
-	for id := range roundVoteSet.PrepareVoteSet {
-		validator := validators.GetValidatorByID(id)
-		hasVotePower += validator.VotingPower
+// AddAggregateVote 加入投票聚合树发来的部分聚合签名。它与已有的某个聚合签名的参与者有重叠时，只有它完全包含了那个聚合
+// 签名才会替换掉它，否则被丢弃；它所包含的验证者的单个投票会被移除，避免同一张票被计算两次。
+func (vs *VoteSet) AddAggregateVote(round int16, vote *types.AggregateVote) bool {
+	roundVoteSet := vs.roundVoteSet(round)
+	participants := vote.AggregateSignature.Participants()
+	kept := make([]*bls12.AggregateSignature, 0, len(roundVoteSet.Aggregates[vote.VoteType])+1)
+	for _, agg := range roundVoteSet.Aggregates[vote.VoteType] {
+		overlap, contained := false, true
+		for _, id := range agg.Participants().IDs {
+			if participants.Contains(id) >= 0 {
+				overlap = true
+			} else {
+				contained = false
+			}
+		}
+		switch {
+		case !overlap:
+			kept = append(kept, agg)
+		case !contained:
+			return false
+		}
 	}
-	if hasVotePower >= validators.PowerMajor23() {
-		return true
+	roundVoteSet.Aggregates[vote.VoteType] = append(kept, vote.AggregateSignature)
+	for _, id := range participants.IDs {
+		switch vote.VoteType {
+		case pbtypes.PrepareVoteType:
+			delete(roundVoteSet.PrepareVoteSet, id)
+		case pbtypes.PreCommitVoteType:
+			delete(roundVoteSet.PreCommitVoteSet, id)
+		case pbtypes.CommitVoteType:
+			delete(roundVoteSet.CommitVoteSet, id)
+		}
 	}
-	return false
+	return true
+}
+
+func (vs *VoteSet) CheckPrepareVoteIsComplete(round int16, validators *types.ValidatorSet) bool {
+	return vs.roundVoteSet(round).power(pbtypes.PrepareVoteType, validators) >= validators.PowerMajor23()
 }
 
 func (vs *VoteSet) CheckPreCommitVoteIsComplete(round int16, validators *types.ValidatorSet) bool {
-	roundVoteSet := vs.roundVoteSets[round]
-	var hasVotePower int64 = 0
-	for id := range roundVoteSet.PreCommitVoteSet {
-		validator := validators.GetValidatorByID(id)
-		hasVotePower += validator.VotingPower
-	}
-	if hasVotePower >= validators.PowerMajor23() {
-		return true
-	}
-	return false
+	return vs.roundVoteSet(round).power(pbtypes.PreCommitVoteType, validators) >= validators.PowerMajor23()
 }
 
 func (vs *VoteSet) CheckCommitVoteIsComplete(round int16, validators *types.ValidatorSet) bool {
-	roundVoteSet := vs.roundVoteSets[round]
-	var hasVotePower int64 = 0
-	for id := range roundVoteSet.CommitVoteSet {
-		validator := validators.GetValidatorByID(id)
-		hasVotePower += validator.VotingPower
-	}
-	if hasVotePower >= validators.PowerMajor23() {
-		return true
-	}
-	return false
+	return vs.roundVoteSet(round).power(pbtypes.CommitVoteType, validators) >= validators.PowerMajor23()
 }
 
 func (vs *VoteSet) CreateThresholdSigForPrepareVote(round int16, cb *bls12.CryptoBLS12) *bls12.AggregateSignature {
-	roundVoteSet := vs.roundVoteSets[round]
+	roundVoteSet := vs.roundVoteSet(round)
 	sigs := make([]*bls12.Signature, 0)
 	for _, vote := range roundVoteSet.PrepareVoteSet {
 		sigs = append(sigs, vote.Vote.Signature)
 	}
-	agg, err := cb.CreateThresholdSignature(sigs)
-	if err != nil {
-		return nil
-	}
-	return agg
+	return roundVoteSet.thresholdSig(pbtypes.PrepareVoteType, sigs, cb)
 }
 
 func (vs *VoteSet) CreateThresholdSigForPreCommitVote(round int16, cb *bls12.CryptoBLS12) *bls12.AggregateSignature {
-	roundVoteSet := vs.roundVoteSets[round]
+	roundVoteSet := vs.roundVoteSet(round)
 	sigs := make([]*bls12.Signature, 0)
 	for _, vote := range roundVoteSet.PreCommitVoteSet {
 		sigs = append(sigs, vote.Vote.Signature)
 	}
-	agg, err := cb.CreateThresholdSignature(sigs)
-	if err != nil {
-		return nil
-	}
-	return agg
+	return roundVoteSet.thresholdSig(pbtypes.PreCommitVoteType, sigs, cb)
 }
 
 func (vs *VoteSet) CreateThresholdSigForCommitVote(round int16, cb *bls12.CryptoBLS12) *bls12.AggregateSignature {
-	roundVoteSet := vs.roundVoteSets[round]
+	roundVoteSet := vs.roundVoteSet(round)
 	sigs := make([]*bls12.Signature, 0)
 	for _, vote := range roundVoteSet.CommitVoteSet {
 		sigs = append(sigs, vote.Vote.Signature)
 	}
-	agg, err := cb.CreateThresholdSignature(sigs)
-	if err != nil {
-		return nil
-	}
-	return agg
+	return roundVoteSet.thresholdSig(pbtypes.CommitVoteType, sigs, cb)
 }
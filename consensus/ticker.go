@@ -7,26 +7,37 @@ import (
 
 const tickTockBufferSize = 10
 
-type timeoutInfo struct {
+// TimeoutInfo 描述一次超时事件：在Duration之后，height高度round轮次的共识需要进入Step阶段，Step为ConsensusTimeout
+// 时表示共识超时，需要进行视图切换。
+type TimeoutInfo struct {
 	Duration time.Duration `json:"duration"`
 	Height   int64         `json:"height"`
 	Round    int16         `json:"round"`
 	Step     Step          `json:"step"`
 }
 
+// Ticker 为共识模块安排超时事件，新安排的超时事件会覆盖还未触发的旧事件，但是不会被更早的高度、轮次或者步骤覆盖。
+// TimeoutTicker基于真实时间实现了该接口，仿真测试则用虚拟时钟实现它。
+type Ticker interface {
+	Start() error
+	Stop() error
+	ScheduleTimeout(ti TimeoutInfo)
+	TockChan() <-chan TimeoutInfo
+}
+
 type TimeoutTicker struct {
 	service.BaseService
 	timer    *time.Timer
-	tickChan chan timeoutInfo
-	tockChan chan timeoutInfo
+	tickChan chan TimeoutInfo
+	tockChan chan TimeoutInfo
 }
 
 func NewTimeoutTicker() *TimeoutTicker {
 	tt := &TimeoutTicker{
 		BaseService: *service.NewBaseService(nil, "TimeoutTicker"),
 		timer:       time.NewTimer(0),
-		tickChan:    make(chan timeoutInfo, tickTockBufferSize),
-		tockChan:    make(chan timeoutInfo, tickTockBufferSize),
+		tickChan:    make(chan TimeoutInfo, tickTockBufferSize),
+		tockChan:    make(chan TimeoutInfo, tickTockBufferSize),
 	}
 	tt.stopTimer()
 	return tt
@@ -42,11 +53,11 @@ func (tt *TimeoutTicker) Stop() error {
 	return tt.BaseService.Stop()
 }
 
-func (tt *TimeoutTicker) ScheduleTimeout(ti timeoutInfo) {
+func (tt *TimeoutTicker) ScheduleTimeout(ti TimeoutInfo) {
 	tt.tickChan <- ti
 }
 
-func (tt *TimeoutTicker) TockChan() <-chan timeoutInfo {
+func (tt *TimeoutTicker) TockChan() <-chan TimeoutInfo {
 	return tt.tockChan
 }
 
@@ -60,7 +71,7 @@ func (tt *TimeoutTicker) stopTimer() {
 }
 
 func (tt *TimeoutTicker) workRoutine() {
-	var info timeoutInfo
+	var info TimeoutInfo
 	for {
 		select {
 		case newInfo := <-tt.tickChan:
@@ -84,7 +95,7 @@ func (tt *TimeoutTicker) workRoutine() {
 			tt.Logger.Debug("scheduled timeout", "duration", info.Duration, "height", info.Height, "round", info.Round, "step", info.Step)
 		case <-tt.timer.C:
 			tt.Logger.Debug("timed out", "duration", info.Duration, "height", info.Height, "round", info.Round, "step", info.Step)
-			go func(tock timeoutInfo) { tt.tockChan <- tock }(info)
+			go func(tock TimeoutInfo) { tt.tockChan <- tock }(info)
 		case <-tt.WaitStop():
 			return
 		}
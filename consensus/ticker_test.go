@@ -26,7 +26,7 @@ func TestTicker(t *testing.T) {
 	go func() {
 		for {
 			time.Sleep(time.Millisecond * 1100)
-			ti := timeoutInfo{
+			ti := TimeoutInfo{
 				Duration: time.Second,
 				Height:   3,
 				Round:    0,
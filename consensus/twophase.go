@@ -0,0 +1,474 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/config"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"time"
+)
+
+// 两阶段共识（Jolteon/Fast-HotStuff风格），在ConsensusConfig.Protocol为ProtocolTwoPhase时代替四阶段共识。
+//
+// 正常情况下区块经过两轮投票就会被确认：
+//  1. 主节点广播Prepare消息，验证者为其投出PrepareVote；
+//  2. 主节点把PrepareVote聚合成QC，以PreCommit消息广播，验证者收到后把它作为highQC（即锁定在该QC上），并投出PreCommitVote；
+//  3. 主节点把PreCommitVote聚合成Decide消息广播，验证者收到后提交区块。
+//
+// 视图切换是二次方复杂度的：验证者在某一轮超时后广播携带highQC的Timeout消息，签名之后不再为这一轮投票，每个节点收集到
+// 2/3以上验证者在同一轮的Timeout消息后，用CryptoBLS12.CreateThresholdSignatureForMessageSet把它们的签名聚合成超时
+// 证书（TC）。下一轮的主节点在Prepare消息里附上TC，并重新提出自己所知道的最高QC所认证的区块，验证者只有在Prepare消息
+// 携带的QC不低于TC里任何一个QC时才为它投票。如果某个区块在第r轮被确认，那么2/3以上的验证者在第r轮或者之后的超时消息里
+// 携带的QC都不低于第r轮，任何一个TC都至少包含其中一个诚实节点，因此之后的主节点只能重新提出这个区块。
+
+// propose 根据共识协议提出当前高度第round轮的区块。
+func (c *Core) propose(height int64, round int16) {
+	if c.twoPhase {
+		c.proposeTwoPhase(height, round)
+	} else {
+		c.proposePrepareMsg(height, round)
+	}
+}
+
+// proposeStep 主节点提出区块的步骤。
+func (c *Core) proposeStep() Step {
+	if c.twoPhase {
+		return TwoPhaseProposeStep
+	}
+	return PrepareStep
+}
+
+// decideStep 区块被确认后所处的步骤。
+func (c *Core) decideStep() Step {
+	if c.twoPhase {
+		return TwoPhaseDecideStep
+	}
+	return DecideStep
+}
+
+// decideValueHash Decide消息里聚合签名所签署的值，两阶段共识的Decide消息携带的是对PreCommitVote的聚合签名。
+func (c *Core) decideValueHash(height int64, round int16, blockHash []byte) []byte {
+	if c.twoPhase {
+		return types.GenerateCommitValueHash(height, round, blockHash)
+	}
+	return types.GenerateDecideValueHash(height, round, blockHash)
+}
+
+// handleTwoPhaseMsg 处理两阶段共识的消息，返回false表示该消息不属于两阶段共识，交由四阶段共识处理。
+func (c *Core) handleTwoPhaseMsg(mi MessageInfo) bool {
+	var err error
+	switch msg := mi.Msg.(type) {
+	case *types.Prepare:
+		err = c.handleTwoPhasePrepare(msg)
+	case *types.PrepareVote:
+		if mi.NodeID == "" {
+			return false
+		}
+		err = c.handleTwoPhasePrepareVote(msg)
+	case *types.PreCommit:
+		err = c.handleTwoPhasePreCommit(msg)
+	case *types.PreCommitVote:
+		if mi.NodeID == "" {
+			return false
+		}
+		err = c.handleTwoPhasePreCommitVote(msg)
+	case *types.Timeout:
+		err = c.handleTimeout(msg)
+	default:
+		return false
+	}
+	if err != nil {
+		c.Logger.Error(fmt.Sprintf("failed to handle %T message", mi.Msg), "err", err)
+	}
+	return true
+}
+
+// proposeTwoPhase 主节点在第round轮提出区块：第1轮之后的轮次必须先拿到上一轮的超时证书，如果自己知道某个区块的QC，
+// 则重新提出这个区块，否则打包新的区块。
+func (c *Core) proposeTwoPhase(height int64, round int16) {
+	if c.stepInfo.height != height || round < c.stepInfo.round || (c.stepInfo.round == round && TwoPhaseProposeStep <= c.stepInfo.step) {
+		c.Logger.Warn("entering TWO_PHASE_PROPOSE step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+		return
+	}
+	if !c.isValidator() || c.state.Validators.GetLeader(round).ID != c.publicKey.ToID() {
+		return
+	}
+	var tc *types.TimeoutCert
+	if round > 1 {
+		if c.stepInfo.tc == nil || c.stepInfo.tc.Round != round-1 {
+			c.Logger.Debug("waiting for timeout certificate before proposing", "height", height, "round", round)
+			return
+		}
+		tc = c.stepInfo.tc
+	}
+	block, highQC := c.stepInfo.highQCBlock, c.stepInfo.highQC
+	if block == nil {
+		block = c.createBlock()
+		if block == nil || len(block.Body.Txs) == 0 {
+			return
+		}
+	}
+	prepare, err := types.NewPrepare(height, round, block, highQC, c.signer)
+	if err != nil {
+		c.Logger.Error("TWO_PHASE_PROPOSE step: failed to sign Prepare message", "err", err)
+		return
+	}
+	prepare.TC = tc
+	c.stepInfo.round = round
+	c.stepInfo.step = TwoPhaseProposeStep
+	c.newStep()
+	c.Logger.Info("=> TWO_PHASE_PROPOSE step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	c.sendInternalMessage(MessageInfo{Msg: prepare, NodeID: ""})
+	if c.misbehave(config.EquivocatePrepare) {
+		c.equivocate(prepare)
+	}
+}
+
+func (c *Core) handleTwoPhasePrepare(prepare *types.Prepare) error {
+	if prepare.Height != c.stepInfo.height || prepare.Round < c.stepInfo.round {
+		return nil
+	}
+	if err := prepare.ValidateBasic(); err != nil {
+		return err
+	}
+	if prepare.Block == nil || prepare.Block.ChameleonHash == nil || prepare.Signature == nil {
+		return fmt.Errorf("leader %s sent an incomplete prepare message to me", prepare.ID)
+	}
+	leader := c.state.Validators.GetLeader(prepare.Round)
+	ok := prepare.Signature.Signer() == leader.ID && leader.PublicKey.Verify(prepare.Signature, types.GeneratePrepareSignBytes(prepare.Height, prepare.Round, prepare.Block.ChameleonHash.Hash))
+	if !ok {
+		return fmt.Errorf("leader %s sent an invalid prepare message to me", leader.ID)
+	}
+	if proposal := c.stepInfo.proposal; proposal != nil && proposal.Round == prepare.Round {
+		if !bytes.Equal(proposal.Block.ChameleonHash.Hash, prepare.Block.ChameleonHash.Hash) {
+			c.reportEvidence(types.NewConflictingPrepareEvidence(proposal, prepare, time.Now()))
+			return fmt.Errorf("leader %s proposed conflicting blocks at height %d round %d", leader.ID, prepare.Height, prepare.Round)
+		}
+		return nil
+	}
+	if prepare.Round > 1 {
+		// 第1轮之后的轮次都是因为上一轮超时才进入的，主节点必须用超时证书证明这一点
+		if prepare.TC == nil {
+			return fmt.Errorf("leader %s sent Prepare message without timeout certificate at round %d", leader.ID, prepare.Round)
+		}
+		if err := prepare.TC.Verify(c.state.Validators); err != nil {
+			return fmt.Errorf("the timeout certificate in Prepare message from %s is invalid: %w", leader.ID, err)
+		}
+	}
+	if prepare.HighQC != nil {
+		hash := types.GeneratePreCommitValueHash(prepare.Height, prepare.HighQC.Round, prepare.Block.ChameleonHash.Hash)
+		if !bytes.Equal(hash, prepare.HighQC.ValueHash) {
+			return fmt.Errorf("the high QC in Prepare message from %s does not certify the proposed block", leader.ID)
+		}
+		if err := c.state.Validators.VerifyAggregateSignature(prepare.HighQC.AggregateSignature, prepare.HighQC.ValueHash); err != nil {
+			return fmt.Errorf("the high QC in Prepare message from %s has invalid aggregated signature: %w", leader.ID, err)
+		}
+	}
+	if err := c.checkSafeTwoPhaseProposal(prepare); err != nil {
+		return err
+	}
+	if prepare.TC != nil {
+		c.updateTimeoutCert(prepare.TC)
+	}
+	if prepare.HighQC != nil {
+		c.stepInfo.UpdateHighQC(prepare.HighQC.Round, prepare.Block, prepare.HighQC)
+	}
+	if prepare.Round > c.stepInfo.round {
+		// 超时证书证明了大多数节点已经进入了新的一轮，自己也跟着进入
+		c.enterNewRound(c.stepInfo.height, prepare.Round)
+	}
+	if err := c.blockExec.ValidateBlock(c.state, prepare.Block, c.prepareProposer(prepare)); err != nil {
+		// 拒绝为不合法的区块投票，并且不再等待超时，直接放弃这一轮
+		c.Logger.Warn("reject proposal", "height", prepare.Height, "round", prepare.Round, "leader", leader.ID, "err", err)
+		c.timeoutTwoPhase()
+		return fmt.Errorf("leader %s proposed an invalid block at height %d round %d: %w", leader.ID, prepare.Height, prepare.Round, err)
+	}
+	c.stepInfo.proposal = prepare
+	c.stepInfo.block = prepare.Block
+	c.schedulePacemaker()
+	if c.isLeader() {
+		c.stepInfo.prepare <- prepare
+	}
+	c.enterTwoPhaseVoteStep(c.stepInfo.height, c.stepInfo.round)
+	return nil
+}
+
+// checkSafeTwoPhaseProposal 第1轮之后的提案必须携带不低于超时证书里任何一个QC的highQC，超时证书里没有QC时，
+// 主节点可以提出任意区块。
+func (c *Core) checkSafeTwoPhaseProposal(prepare *types.Prepare) error {
+	if prepare.TC == nil {
+		return nil
+	}
+	maxRound := prepare.TC.MaxHighQCRound()
+	if maxRound == 0 {
+		return nil
+	}
+	if prepare.HighQC == nil {
+		return fmt.Errorf("unsafe Prepare from %s: timeout certificate carries QC from round %d, but the proposed block %X carries no QC", prepare.ID, maxRound, prepare.Block.ChameleonHash.Hash)
+	}
+	if prepare.HighQC.Round < maxRound {
+		return fmt.Errorf("unsafe Prepare from %s: timeout certificate carries QC from round %d, but the QC of the proposed block %X is from round %d", prepare.ID, maxRound, prepare.Block.ChameleonHash.Hash, prepare.HighQC.Round)
+	}
+	return nil
+}
+
+func (c *Core) enterTwoPhaseVoteStep(height int64, round int16) {
+	if c.stepInfo.height != height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step >= TwoPhaseVoteStep) {
+		c.Logger.Warn("entering TWO_PHASE_VOTE step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+		return
+	}
+	c.Logger.Info(">> TWO_PHASE_VOTE step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	defer func() {
+		c.stepInfo.round = round
+		c.stepInfo.step = TwoPhaseVoteStep
+		c.newStep()
+	}()
+	if !c.isValidator() {
+		return
+	}
+	// 签名者会拒绝可能导致重复签名的请求，包括在这一轮超时之后的投票
+	vote, err := types.NewPrepareVote(height, round, c.voteBlockHash(), c.signer)
+	if err != nil {
+		c.Logger.Error("TWO_PHASE_VOTE step: failed to sign PrepareVote", "err", err)
+		return
+	}
+	if c.isLeader() {
+		c.stepInfo.voteSet.AddPrepareVote(round, vote)
+		if c.stepInfo.voteSet.CheckPrepareVoteIsComplete(round, c.state.Validators) {
+			c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, height, round, TwoPhaseLockStep)
+		}
+	} else {
+		c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
+	}
+}
+
+func (c *Core) handleTwoPhasePrepareVote(vote *types.PrepareVote) error {
+	if !c.isLeader() {
+		return nil
+	}
+	if err := c.verifyVote(vote.Vote, pbtypes.PrepareVoteType); err != nil {
+		return err
+	}
+	if vote.Vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
+	}
+	valueHash := types.GeneratePrepareVoteValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(valueHash, vote.Vote.ValueHash) {
+		return fmt.Errorf("validator %s vote for different block", vote.Vote.Signature.Signer())
+	}
+	c.stepInfo.voteSet.AddPrepareVote(c.stepInfo.round, vote)
+	if c.stepInfo.voteSet.CheckPrepareVoteIsComplete(c.stepInfo.round, c.state.Validators) {
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, TwoPhaseLockStep)
+	}
+	return nil
+}
+
+// proposeTwoPhaseLock 主节点把PrepareVote聚合成QC，以PreCommit消息广播出去。
+func (c *Core) proposeTwoPhaseLock(height int64, round int16) {
+	if height != c.stepInfo.height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step >= TwoPhaseLockStep) {
+		c.Logger.Warn("entering TWO_PHASE_LOCK step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+		return
+	}
+	c.Logger.Info("=> TWO_PHASE_LOCK step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	defer func() {
+		c.stepInfo.round = round
+		c.stepInfo.step = TwoPhaseLockStep
+		c.newStep()
+	}()
+	agg := c.stepInfo.voteSet.CreateThresholdSigForPrepareVote(round, c.cryptoBLS12)
+	preCommit := types.NewPreCommit(agg, types.GeneratePreCommitValueHash(height, round, c.stepInfo.block.ChameleonHash.Hash), c.stepInfo.block.ChameleonHash.Hash, c.publicKey.ToID(), height, round)
+	c.sendInternalMessage(MessageInfo{Msg: preCommit, NodeID: ""})
+}
+
+// handleTwoPhasePreCommit 收到当前轮次的QC后，把它作为highQC，此后只为携带不低于它的QC的提案投票。
+func (c *Core) handleTwoPhasePreCommit(preCommit *types.PreCommit) error {
+	if preCommit.Height != c.stepInfo.height || preCommit.Round != c.stepInfo.round {
+		return nil
+	}
+	if preCommit.ID != c.state.Validators.GetLeader(c.stepInfo.round).ID {
+		return fmt.Errorf("PreCommit message is not from leader %s at height %d", c.state.Validators.GetLeader(c.stepInfo.round).ID, c.stepInfo.height)
+	}
+	if c.stepInfo.block == nil {
+		return c.fetchBlock(preCommit, preCommit.Height, preCommit.Round, preCommit.BlockHash, preCommit.ValueHash, types.GeneratePreCommitValueHash(preCommit.Height, preCommit.Round, preCommit.BlockHash), preCommit.AggregateSignature)
+	}
+	hash := types.GeneratePreCommitValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(hash, preCommit.ValueHash) {
+		return fmt.Errorf("leader %s sent invalid PreCommit message to me", preCommit.ID)
+	}
+	if err := c.state.Validators.VerifyAggregateSignature(preCommit.AggregateSignature, preCommit.ValueHash); err != nil {
+		return fmt.Errorf("leader %s sent invalid PreCommit message to me: %w", preCommit.ID, err)
+	}
+	c.stepInfo.UpdateHighQC(preCommit.Round, c.stepInfo.block, preCommit)
+	if c.isLeader() {
+		c.stepInfo.preCommit <- preCommit
+	}
+	c.enterTwoPhaseLockVoteStep(c.stepInfo.height, c.stepInfo.round)
+	return nil
+}
+
+func (c *Core) enterTwoPhaseLockVoteStep(height int64, round int16) {
+	if height != c.stepInfo.height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step >= TwoPhaseLockVoteStep) {
+		c.Logger.Warn("entering TWO_PHASE_LOCK_VOTE step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+		return
+	}
+	c.Logger.Info(">> TWO_PHASE_LOCK_VOTE step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	defer func() {
+		c.stepInfo.round = round
+		c.stepInfo.step = TwoPhaseLockVoteStep
+		c.newStep()
+	}()
+	if !c.isValidator() {
+		return
+	}
+	vote, err := types.NewPreCommitVote(height, round, c.voteBlockHash(), c.signer)
+	if err != nil {
+		c.Logger.Error("TWO_PHASE_LOCK_VOTE step: failed to sign PreCommitVote", "err", err)
+		return
+	}
+	if c.isLeader() {
+		c.stepInfo.voteSet.AddPreCommitVote(round, vote)
+		if c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(round, c.state.Validators) {
+			c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, height, round, TwoPhaseDecideStep)
+		}
+	} else {
+		c.sendInternalMessage(MessageInfo{Msg: vote, NodeID: ""})
+	}
+}
+
+func (c *Core) handleTwoPhasePreCommitVote(vote *types.PreCommitVote) error {
+	if !c.isLeader() {
+		return nil
+	}
+	if err := c.verifyVote(vote.Vote, pbtypes.PreCommitVoteType); err != nil {
+		return err
+	}
+	if vote.Vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
+	}
+	valueHash := types.GeneratePreCommitVoteValueHash(c.stepInfo.height, c.stepInfo.round, c.stepInfo.block.ChameleonHash.Hash)
+	if !bytes.Equal(valueHash, vote.Vote.ValueHash) {
+		return fmt.Errorf("validator %s vote for different block", vote.Vote.Signature.Signer())
+	}
+	c.stepInfo.voteSet.AddPreCommitVote(c.stepInfo.round, vote)
+	if c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(c.stepInfo.round, c.state.Validators) {
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, TwoPhaseDecideStep)
+	}
+	return nil
+}
+
+// proposeTwoPhaseDecide 主节点把PreCommitVote聚合成Decide消息广播出去，它就是区块的提交证明。
+func (c *Core) proposeTwoPhaseDecide(height int64, round int16) {
+	if height != c.stepInfo.height || c.stepInfo.round > round || (c.stepInfo.round == round && c.stepInfo.step >= TwoPhaseDecideStep) {
+		c.Logger.Warn("entering TWO_PHASE_DECIDE step with invalid args", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+		return
+	}
+	c.Logger.Info("=> TWO_PHASE_DECIDE step", "consensus_step", fmt.Sprintf("height:%d round:%d step:%s", c.stepInfo.height, c.stepInfo.round, c.stepInfo.step))
+	defer func() {
+		c.stepInfo.round = round
+		c.stepInfo.step = TwoPhaseDecideStep
+		c.newStep()
+	}()
+	agg := c.stepInfo.voteSet.CreateThresholdSigForPreCommitVote(round, c.cryptoBLS12)
+	decide := types.NewDecide(agg, types.GenerateCommitValueHash(height, round, c.stepInfo.block.ChameleonHash.Hash), c.stepInfo.block.ChameleonHash.Hash, c.publicKey.ToID(), height, round)
+	c.sendInternalMessage(MessageInfo{Msg: decide, NodeID: ""})
+}
+
+// timeoutTwoPhase 放弃当前轮次：为携带highQC的Timeout消息签名并广播，然后进入下一轮，签名之后不会再为这一轮投票。
+func (c *Core) timeoutTwoPhase() {
+	height, round := c.stepInfo.height, c.stepInfo.round
+	if !c.isValidator() {
+		// 不是验证者的节点不为Timeout消息签名，只跟随其他节点进入下一轮
+		c.stepInfo.step = ConsensusTimeout
+		c.newStep()
+		c.enterNewRound(height, round+1)
+		return
+	}
+	timeout, err := types.NewTimeout(height, round, c.stepInfo.highQC, c.stepInfo.highQCBlock, c.signer)
+	c.stepInfo.step = ConsensusTimeout
+	c.newStep()
+	c.enterNewRound(height, round+1)
+	if err != nil {
+		c.Logger.Error("failed to sign Timeout message", "err", err)
+		return
+	}
+	c.fireEvent(events.EventTimeout, timeout)
+	c.sendInternalMessage(MessageInfo{Msg: timeout, NodeID: ""})
+}
+
+// handleTimeout 收集其他节点的Timeout消息：超过1/3的验证者在某一轮超时后，自己也放弃这一轮；2/3以上的验证者在某一轮超时后，
+// 组成超时证书并进入下一轮，如果自己是下一轮的主节点，则携带超时证书提出区块。
+func (c *Core) handleTimeout(timeout *types.Timeout) error {
+	if timeout.Height != c.stepInfo.height {
+		return nil
+	}
+	if err := timeout.ValidateBasic(); err != nil {
+		return fmt.Errorf("validator %s sent invalid Timeout message to me: %w", timeout.ID, err)
+	}
+	validator := c.state.Validators.GetValidatorByID(timeout.ID)
+	if validator == nil {
+		return fmt.Errorf("an unknown validator %s sent Timeout message to me", timeout.ID)
+	}
+	if timeout.Signature.Signer() != timeout.ID || !validator.PublicKey.Verify(timeout.Signature, timeout.ValueHash()) {
+		return fmt.Errorf("validator %s sent Timeout message with invalid signature to me", timeout.ID)
+	}
+	if timeout.HighQC != nil {
+		if timeout.HighQCBlock.ChameleonHash == nil {
+			return fmt.Errorf("validator %s sent Timeout message with incomplete high QC to me", timeout.ID)
+		}
+		hash := types.GeneratePreCommitValueHash(timeout.Height, timeout.HighQC.Round, timeout.HighQCBlock.ChameleonHash.Hash)
+		if !bytes.Equal(hash, timeout.HighQC.ValueHash) {
+			return fmt.Errorf("the high QC in Timeout message from %s does not certify the carried block", timeout.ID)
+		}
+		if err := c.state.Validators.VerifyAggregateSignature(timeout.HighQC.AggregateSignature, timeout.HighQC.ValueHash); err != nil {
+			return fmt.Errorf("the high QC in Timeout message from %s has invalid aggregated signature: %w", timeout.ID, err)
+		}
+		c.stepInfo.UpdateHighQC(timeout.HighQC.Round, timeout.HighQCBlock, timeout.HighQC)
+	}
+	round := timeout.Round
+	if round+1 < c.stepInfo.round || (c.stepInfo.tc != nil && c.stepInfo.tc.Round >= round) {
+		// 已经有了这一轮或者更新轮次的超时证书
+		return nil
+	}
+	c.Logger.Trace("receive a valid Timeout message", "from", timeout.ID, "round", round)
+	c.stepInfo.AddTimeout(timeout)
+	if round >= c.stepInfo.round && c.stepInfo.CheckCollectTimeoutHasOneThird(round, c.state.Validators) {
+		// 至少有一个诚实节点已经放弃了这一轮，自己也跟着放弃，保证所有诚实节点最终都会为这一轮发出Timeout消息
+		if round > c.stepInfo.round {
+			c.enterNewRound(c.stepInfo.height, round)
+		}
+		c.Logger.Warn("validators timed out, change view", "height", c.stepInfo.height, "old_round", round, "new_round", round+1)
+		c.timeoutTwoPhase()
+	}
+	if !c.stepInfo.CheckCollectTimeoutIsComplete(round, c.state.Validators) {
+		return nil
+	}
+	tc, err := c.stepInfo.CreateTimeoutCert(round, c.cryptoBLS12)
+	if err != nil {
+		return fmt.Errorf("failed to create timeout certificate: %w", err)
+	}
+	c.Logger.Debug("receive enough Timeout messages", "height", c.stepInfo.height, "round", round)
+	c.updateTimeoutCert(tc)
+	if c.stepInfo.round <= round {
+		c.enterNewRound(c.stepInfo.height, round+1)
+	}
+	if c.isLeader() && c.stepInfo.round == round+1 && c.stepInfo.step == NewRoundStep {
+		c.Logger.Info("receive enough Timeout messages, resume consensus from the highest QC", "height", c.stepInfo.height, "round", c.stepInfo.round, "high_qc_round", c.stepInfo.highQCRound)
+		c.proposeTwoPhase(c.stepInfo.height, c.stepInfo.round)
+	}
+	return nil
+}
+
+// updateTimeoutCert 保留所知道的最新一轮的超时证书，并清除更早轮次的Timeout消息。
+func (c *Core) updateTimeoutCert(tc *types.TimeoutCert) {
+	if c.stepInfo.tc != nil && c.stepInfo.tc.Round >= tc.Round {
+		return
+	}
+	c.stepInfo.tc = tc
+	for r := range c.stepInfo.collectTimeout {
+		if r <= tc.Round {
+			delete(c.stepInfo.collectTimeout, r)
+		}
+	}
+}
@@ -0,0 +1,473 @@
+package consensus
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"sync"
+	"time"
+)
+
+// 投票聚合树，在ConsensusConfig.VoteTreeFanout大于0时启用，只用于四阶段共识和两阶段共识的投票。
+//
+// 验证者很多时，每个验证者都直接把投票发送给主节点，主节点需要接收n条消息并逐个验证n个签名。开启聚合树后，每一轮的验证者
+// 以主节点为根组成一棵分支数为VoteTreeFanout的树：叶子节点把自己的投票发送给父节点，内部节点验证子节点发来的投票或者
+// 部分聚合签名，把它们与自己的投票聚合成一个AggregateVote发送给父节点，主节点最终只需要验证少量的聚合签名。
+//
+// 树里的任何一个节点都可能失效，因此：
+//  1. 内部节点最多等待子树高度倍的VoteTreeTimeout，超时后把已经收到的投票聚合起来发送给父节点；
+//  2. 与父节点之间没有连接时，直接把投票发送给主节点；
+//  3. 内部节点已经发送过聚合签名之后才到达的投票，以及与自己所投区块不同的投票，直接转交给主节点；
+//  4. 投票发出去之后，如果自己的共识状态在整棵树都超时后依然没有变化，说明投票可能丢在了某棵失效的子树里，此时再把自己
+//     的投票直接发送给主节点，退化成星形拓扑。
+//
+// 主节点的VoteSet保证同一个验证者的投票不会被重复计算。
+
+// voteTree 某一个高度第round轮的投票聚合树，members里的第0个是主节点，其余验证者按照在验证者集合里的顺序排列，第i个
+// 节点的子节点是第i*fanout+1到第i*fanout+fanout个节点。
+type voteTree struct {
+	fanout     int
+	members    []crypto.ID
+	index      map[crypto.ID]int
+	validators *types.ValidatorSet
+}
+
+func newVoteTree(validators *types.ValidatorSet, leader crypto.ID, fanout int) *voteTree {
+	t := &voteTree{
+		fanout:     fanout,
+		members:    []crypto.ID{leader},
+		index:      map[crypto.ID]int{leader: 0},
+		validators: validators,
+	}
+	for _, val := range validators.Validators {
+		if val.ID == leader {
+			continue
+		}
+		t.index[val.ID] = len(t.members)
+		t.members = append(t.members, val.ID)
+	}
+	return t
+}
+
+func (t *voteTree) leader() crypto.ID {
+	return t.members[0]
+}
+
+// parent 返回id的父节点，id是主节点或者不在树里时返回false。
+func (t *voteTree) parent(id crypto.ID) (crypto.ID, bool) {
+	i, ok := t.index[id]
+	if !ok || i == 0 {
+		return "", false
+	}
+	return t.members[(i-1)/t.fanout], true
+}
+
+func (t *voteTree) children(id crypto.ID) []crypto.ID {
+	i, ok := t.index[id]
+	if !ok {
+		return nil
+	}
+	var children []crypto.ID
+	for c := i*t.fanout + 1; c <= i*t.fanout+t.fanout && c < len(t.members); c++ {
+		children = append(children, t.members[c])
+	}
+	return children
+}
+
+// subtree 返回以id为根的子树里的所有节点，包括id自己。
+func (t *voteTree) subtree(id crypto.ID) []crypto.ID {
+	if _, ok := t.index[id]; !ok {
+		return nil
+	}
+	nodes := []crypto.ID{id}
+	for i := 0; i < len(nodes); i++ {
+		nodes = append(nodes, t.children(nodes[i])...)
+	}
+	return nodes
+}
+
+// height 返回以id为根的子树的高度，叶子节点的高度为0。
+func (t *voteTree) height(id crypto.ID) int {
+	i, ok := t.index[id]
+	if !ok {
+		return 0
+	}
+	h := 0
+	for first := i*t.fanout + 1; first < len(t.members); first = first*t.fanout + 1 {
+		h++
+	}
+	return h
+}
+
+// voteTreeOf 返回height高度第round轮的投票聚合树，没有开启投票聚合树、使用链式HotStuff或者height不是当前高度时返回nil。
+func (c *Core) voteTreeOf(height int64, round int16) *voteTree {
+	if c.cfg.VoteTreeFanout <= 0 || c.chained != nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if height != c.stepInfo.height {
+		return nil
+	}
+	return newVoteTree(c.state.Validators, c.state.Validators.GetLeader(round).ID, c.cfg.VoteTreeFanout)
+}
+
+// roundStep 返回当前的共识状态。
+func (c *Core) roundStep() (int64, int16, Step) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.stepInfo.height, c.stepInfo.round, c.stepInfo.step
+}
+
+// handleAggregateVote 主节点验证投票聚合树发来的部分聚合签名，收集到的投票权超过2/3后进入下一个步骤。
+func (c *Core) handleAggregateVote(vote *types.AggregateVote) error {
+	if !c.isLeader() {
+		return nil
+	}
+	if err := vote.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid AggregateVote message: %w", err)
+	}
+	if vote.Height != c.stepInfo.height || vote.Round != c.stepInfo.round || c.stepInfo.block == nil {
+		return nil
+	}
+	if !bytes.Equal(vote.BlockHash, c.stepInfo.block.ChameleonHash.Hash) {
+		return fmt.Errorf("aggregated %s is for different block %X", vote.VoteType.String(), vote.BlockHash)
+	}
+	if _, err := c.state.Validators.VerifyPartialAggregateSignature(vote.AggregateSignature, vote.ValueHash); err != nil {
+		return fmt.Errorf("invalid aggregated %s: %w", vote.VoteType.String(), err)
+	}
+	if !c.stepInfo.voteSet.AddAggregateVote(vote.Round, vote) {
+		// 与之前收到的聚合签名有部分重叠，这些验证者的投票已经被计算过了
+		return nil
+	}
+	var complete bool
+	var next Step
+	switch vote.VoteType {
+	case pbtypes.PrepareVoteType:
+		complete = c.stepInfo.voteSet.CheckPrepareVoteIsComplete(vote.Round, c.state.Validators)
+		next = PreCommitStep
+		if c.twoPhase {
+			next = TwoPhaseLockStep
+		}
+	case pbtypes.PreCommitVoteType:
+		complete = c.stepInfo.voteSet.CheckPreCommitVoteIsComplete(vote.Round, c.state.Validators)
+		next = CommitStep
+		if c.twoPhase {
+			next = TwoPhaseDecideStep
+		}
+	case pbtypes.CommitVoteType:
+		if c.twoPhase {
+			return fmt.Errorf("unexpected aggregated %s in two-phase consensus", vote.VoteType.String())
+		}
+		complete = c.stepInfo.voteSet.CheckCommitVoteIsComplete(vote.Round, c.state.Validators)
+		next = DecideStep
+	}
+	if complete {
+		c.scheduleStep(c.state.ConsensusParams.Timeout.StepDelay, c.stepInfo.height, c.stepInfo.round, next)
+	}
+	return nil
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// Reactor里的投票聚合
+
+type voteKey struct {
+	height int64
+	round  int16
+	typ    pbtypes.VoteType
+}
+
+// voteAggregation 自己在某一个高度的某一轮为某一类投票聚合的子树投票。
+type voteAggregation struct {
+	tree    *voteTree
+	own     Message                     // 自己的投票，还没有投票时为nil
+	vote    *types.Vote                 // own里的投票
+	sigs    []*bls12.Signature          // 自己和子节点的单个投票签名
+	aggs    []*bls12.AggregateSignature // 子节点发来的部分聚合签名
+	covered map[crypto.ID]struct{}      // 已经包含在sigs和aggs里的验证者
+	pending []Message                   // 自己投票之前收到的子节点的投票
+	flushed bool                        // 已经把聚合签名发送给了父节点
+}
+
+func newVoteAggregation(tree *voteTree) *voteAggregation {
+	return &voteAggregation{tree: tree, covered: make(map[crypto.ID]struct{})}
+}
+
+// add 把子节点发来的投票加入聚合，返回false表示投票与自己所投的区块不同，或者与已有的投票有重叠，不能被聚合。
+func (va *voteAggregation) add(msg Message) bool {
+	var valueHash []byte
+	var signers []crypto.ID
+	switch msg := msg.(type) {
+	case *types.AggregateVote:
+		valueHash, signers = msg.ValueHash, msg.AggregateSignature.Participants().IDs
+	default:
+		vote := voteOf(msg)
+		valueHash, signers = vote.ValueHash, []crypto.ID{vote.Signature.Signer()}
+	}
+	if !bytes.Equal(valueHash, va.vote.ValueHash) {
+		return false
+	}
+	for _, id := range signers {
+		if _, ok := va.covered[id]; ok {
+			return false
+		}
+	}
+	for _, id := range signers {
+		va.covered[id] = struct{}{}
+	}
+	if agg, ok := msg.(*types.AggregateVote); ok {
+		va.aggs = append(va.aggs, agg.AggregateSignature)
+	} else {
+		va.sigs = append(va.sigs, voteOf(msg).Signature)
+	}
+	return true
+}
+
+// complete 判断子树里所有验证者的投票是否都已经被聚合。
+func (va *voteAggregation) complete(id crypto.ID) bool {
+	return va.vote != nil && len(va.covered) == len(va.tree.subtree(id))
+}
+
+// aggregate 返回需要发送给父节点的消息：只有自己的投票时发送自己的投票，否则发送聚合签名。
+func (va *voteAggregation) aggregate(cb *bls12.CryptoBLS12) (Message, error) {
+	if len(va.sigs) == 1 && len(va.aggs) == 0 {
+		return va.own, nil
+	}
+	agg, err := cb.CreateThresholdSignature(va.sigs)
+	if err != nil {
+		return nil, err
+	}
+	if len(va.aggs) > 0 {
+		if agg, err = cb.CombineThresholdSignatures(append([]*bls12.AggregateSignature{agg}, va.aggs...)); err != nil {
+			return nil, err
+		}
+	}
+	return &types.AggregateVote{
+		VoteType:           va.vote.VoteType,
+		Height:             va.vote.Height,
+		Round:              va.vote.Round,
+		BlockHash:          va.vote.BlockHash,
+		ValueHash:          va.vote.ValueHash,
+		AggregateSignature: agg,
+	}, nil
+}
+
+// voteAggregator 保存自己正在聚合的投票。
+type voteAggregator struct {
+	mu           sync.Mutex
+	aggregations map[voteKey]*voteAggregation
+}
+
+func newVoteAggregator() *voteAggregator {
+	return &voteAggregator{aggregations: make(map[voteKey]*voteAggregation)}
+}
+
+// get 返回key对应的聚合，不存在时用tree创建一个，同时丢弃之前高度的聚合。调用者需要持有锁。
+func (va *voteAggregator) get(key voteKey, tree *voteTree) *voteAggregation {
+	if agg, ok := va.aggregations[key]; ok {
+		return agg
+	}
+	for k := range va.aggregations {
+		if k.height < key.height {
+			delete(va.aggregations, k)
+		}
+	}
+	agg := newVoteAggregation(tree)
+	va.aggregations[key] = agg
+	return agg
+}
+
+func voteOf(msg Message) *types.Vote {
+	switch msg := msg.(type) {
+	case *types.PrepareVote:
+		return msg.Vote
+	case *types.PreCommitVote:
+		return msg.Vote
+	case *types.CommitVote:
+		return msg.Vote
+	}
+	return nil
+}
+
+// sendVote 把Core投出的票发送出去：没有开启投票聚合树时直接发送给投票所在轮次的主节点，否则加入自己的聚合，叶子节点立即
+// 把投票发送给父节点，内部节点等到子树里的投票都到齐或者超时后再发送。直接发送给主节点的投票在得到回应之前会被重传。
+func (r *Reactor) sendVote(msg Message) {
+	vote := voteOf(msg)
+	if vote == nil || vote.Signature == nil {
+		return
+	}
+	tree := r.core.voteTreeOf(vote.Height, vote.Round)
+	if tree == nil || tree.leader() == r.core.id {
+		r.routeVote(r.core.leaderOf(vote.Round), msg)
+		r.trackVote(msg)
+		return
+	}
+	height, round, step := r.core.roundStep()
+	key := voteKey{height: vote.Height, round: vote.Round, typ: vote.VoteType}
+	timeout := r.core.cfg.VoteTreeTimeout
+
+	r.votes.mu.Lock()
+	agg := r.votes.get(key, tree)
+	if agg.own != nil || agg.flushed {
+		r.votes.mu.Unlock()
+		r.routeVote(tree.leader(), msg)
+		return
+	}
+	agg.own, agg.vote = msg, vote
+	agg.sigs = append(agg.sigs, vote.Signature)
+	agg.covered[r.core.id] = struct{}{}
+	var rejected []Message
+	for _, child := range agg.pending {
+		if !agg.add(child) {
+			rejected = append(rejected, child)
+		}
+	}
+	agg.pending = nil
+	complete := agg.complete(r.core.id)
+	r.votes.mu.Unlock()
+
+	for _, child := range rejected {
+		r.routeVote(tree.leader(), child)
+	}
+	if complete {
+		r.flushVotes(key)
+	} else {
+		time.AfterFunc(time.Duration(tree.height(r.core.id))*timeout, func() { r.flushVotes(key) })
+	}
+	// 整棵树都超时之后自己的共识状态依然没有变化，再把投票直接发送给主节点
+	time.AfterFunc(time.Duration(tree.height(tree.leader())+1)*timeout, func() {
+		if h, rd, s := r.core.roundStep(); h == height && rd == round && s == step {
+			r.Logger.Debug("no progress after sending vote through vote tree, send it to leader directly", "height", vote.Height, "round", vote.Round, "type", vote.VoteType.String())
+			r.routeVote(tree.leader(), msg)
+			r.trackVote(msg)
+		}
+	})
+}
+
+// flushVotes 把已经收集到的投票聚合起来发送给父节点，与父节点之间没有连接时发送给主节点。自己没有投票时，把子节点发来的
+// 投票直接转交给主节点。
+func (r *Reactor) flushVotes(key voteKey) {
+	r.votes.mu.Lock()
+	agg, ok := r.votes.aggregations[key]
+	if !ok || agg.flushed {
+		r.votes.mu.Unlock()
+		return
+	}
+	agg.flushed = true
+	tree, pending := agg.tree, agg.pending
+	agg.pending = nil
+	var msg Message
+	var err error
+	if agg.own != nil {
+		msg, err = agg.aggregate(r.core.cryptoBLS12)
+	}
+	r.votes.mu.Unlock()
+
+	for _, child := range pending {
+		r.routeVote(tree.leader(), child)
+	}
+	if err != nil {
+		r.Logger.Error("failed to aggregate votes, send my vote to leader directly", "err", err)
+		r.routeVote(tree.leader(), agg.own)
+		return
+	}
+	if msg == nil {
+		return
+	}
+	parent, _ := tree.parent(r.core.id)
+	if !r.Switch.SendToPeer(p2p.ReplicaVoteChannel, parent, MustEncode(msg)) {
+		r.Logger.Debug("failed to send vote to parent in vote tree, send it to leader directly", "parent", parent)
+		r.routeVote(tree.leader(), msg)
+	}
+}
+
+// receiveTreeVote 处理子节点发来的投票，返回false表示投票不是来自子节点，按照没有投票聚合树时的方式处理。
+func (r *Reactor) receiveTreeVote(src *p2p.Peer, msg Message) bool {
+	var key voteKey
+	switch msg := msg.(type) {
+	case *types.AggregateVote:
+		if msg.ValidateBasic() != nil {
+			return false
+		}
+		key = voteKey{height: msg.Height, round: msg.Round, typ: msg.VoteType}
+	default:
+		vote := voteOf(msg)
+		if vote == nil || vote.Signature == nil || vote.Signature.Signer() != src.NodeID() {
+			return false
+		}
+		key = voteKey{height: vote.Height, round: vote.Round, typ: vote.VoteType}
+	}
+	tree := r.core.voteTreeOf(key.height, key.round)
+	if tree == nil || tree.leader() == r.core.id {
+		return false
+	}
+	if parent, ok := tree.parent(src.NodeID()); !ok || parent != r.core.id {
+		return false
+	}
+	if err := r.verifyTreeVote(tree, src.NodeID(), msg); err != nil {
+		r.Logger.Warn("received invalid vote from child in vote tree", "from", src.NodeID(), "err", err)
+		return true
+	}
+
+	r.votes.mu.Lock()
+	agg := r.votes.get(key, tree)
+	switch {
+	case agg.flushed:
+		r.votes.mu.Unlock()
+		r.routeVote(tree.leader(), msg)
+		return true
+	case agg.own == nil:
+		if len(agg.pending) == 0 {
+			// 自己迟迟没有投票时，子节点的投票也不能一直留在这里
+			time.AfterFunc(time.Duration(tree.height(r.core.id))*r.core.cfg.VoteTreeTimeout, func() { r.flushVotes(key) })
+		}
+		agg.pending = append(agg.pending, msg)
+		r.votes.mu.Unlock()
+		return true
+	}
+	added := agg.add(msg)
+	complete := agg.complete(r.core.id)
+	r.votes.mu.Unlock()
+
+	if !added {
+		r.routeVote(tree.leader(), msg)
+	}
+	if complete {
+		r.flushVotes(key)
+	}
+	return true
+}
+
+// verifyTreeVote 验证子节点发来的投票，部分聚合签名的参与者必须都在子节点的子树里。
+func (r *Reactor) verifyTreeVote(tree *voteTree, child crypto.ID, msg Message) error {
+	if agg, ok := msg.(*types.AggregateVote); ok {
+		subtree := make(map[crypto.ID]struct{})
+		for _, id := range tree.subtree(child) {
+			subtree[id] = struct{}{}
+		}
+		for _, id := range agg.AggregateSignature.Participants().IDs {
+			if _, ok := subtree[id]; !ok {
+				return fmt.Errorf("signer %s is not in the subtree of %s", id, child)
+			}
+		}
+		_, err := tree.validators.VerifyPartialAggregateSignature(agg.AggregateSignature, agg.ValueHash)
+		return err
+	}
+	vote := voteOf(msg)
+	validator := tree.validators.GetValidatorByID(vote.Signature.Signer())
+	if validator == nil {
+		return fmt.Errorf("unknown validator %s", vote.Signature.Signer())
+	}
+	if err := vote.ValidateBasic(); err != nil {
+		return err
+	}
+	if !validator.PublicKey.Verify(vote.Signature, vote.ValueHash) {
+		return fmt.Errorf("%s has invalid signature", vote.VoteType.String())
+	}
+	return nil
+}
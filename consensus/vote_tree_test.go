@@ -0,0 +1,148 @@
+package consensus
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newVoteTreeTestValidators(t *testing.T, n int) (*types.ValidatorSet, map[crypto.ID]types.Signer) {
+	validators := make([]*types.Validator, 0, n)
+	signers := make(map[crypto.ID]types.Signer, n)
+	for i := 0; i < n; i++ {
+		privateKey, err := bls12.GeneratePrivateKey()
+		assert.Nil(t, err)
+		validators = append(validators, types.NewValidator(privateKey.PublicKey(), 1))
+		signers[privateKey.PublicKey().ToID()] = types.NewPrivateKeySigner(privateKey)
+	}
+	return types.NewValidatorSet(validators), signers
+}
+
+func newVoteTreeTestVote(t *testing.T, signer types.Signer, blockHash []byte) *types.PrepareVote {
+	vote, err := types.NewPrepareVote(3, 1, blockHash, signer)
+	assert.Nil(t, err)
+	return vote
+}
+
+func TestVoteTreeLayout(t *testing.T) {
+	validators, _ := newVoteTreeTestValidators(t, 7)
+	leader := validators.Validators[3].ID
+	tree := newVoteTree(validators, leader, 2)
+
+	assert.Equal(t, leader, tree.leader())
+	assert.Equal(t, 7, len(tree.members))
+	_, ok := tree.parent(leader)
+	assert.False(t, ok)
+	assert.Equal(t, []crypto.ID{tree.members[1], tree.members[2]}, tree.children(leader))
+	assert.Equal(t, []crypto.ID{tree.members[5], tree.members[6]}, tree.children(tree.members[2]))
+	assert.Nil(t, tree.children(tree.members[6]))
+	parent, ok := tree.parent(tree.members[4])
+	assert.True(t, ok)
+	assert.Equal(t, tree.members[1], parent)
+
+	assert.Equal(t, 7, len(tree.subtree(leader)))
+	assert.Equal(t, []crypto.ID{tree.members[1], tree.members[3], tree.members[4]}, tree.subtree(tree.members[1]))
+	assert.Equal(t, 2, tree.height(leader))
+	assert.Equal(t, 1, tree.height(tree.members[2]))
+	assert.Equal(t, 0, tree.height(tree.members[5]))
+
+	// 分支数不小于验证者数量时退化成星形拓扑
+	star := newVoteTree(validators, leader, 8)
+	assert.Equal(t, 6, len(star.children(leader)))
+	assert.Equal(t, 1, star.height(leader))
+}
+
+func TestVoteAggregation(t *testing.T) {
+	validators, signers := newVoteTreeTestValidators(t, 7)
+	tree := newVoteTree(validators, validators.Validators[0].ID, 2)
+	blockHash := []byte("block hash")
+	cb := bls12.NewCryptoBLS12()
+
+	// members[1]的子树包括members[3]和members[4]，members[3]又把members[4]的投票聚合了进来（这里只为测试合并）
+	own := newVoteTreeTestVote(t, signers[tree.members[1]], blockHash)
+	child := newVoteTreeTestVote(t, signers[tree.members[3]], blockHash)
+	grandChild := newVoteTreeTestVote(t, signers[tree.members[4]], blockHash)
+	partial, err := cb.CreateThresholdSignature([]*bls12.Signature{grandChild.Vote.Signature})
+	assert.Nil(t, err)
+
+	va := newVoteAggregation(tree)
+	va.own, va.vote = own, own.Vote
+	va.sigs = append(va.sigs, own.Vote.Signature)
+	va.covered[tree.members[1]] = struct{}{}
+	assert.False(t, va.complete(tree.members[1]))
+
+	// 只有自己的投票时，发送的就是自己的投票
+	msg, err := va.aggregate(cb)
+	assert.Nil(t, err)
+	assert.Equal(t, own, msg)
+
+	assert.True(t, va.add(child))
+	assert.False(t, va.add(child))
+	assert.False(t, va.add(newVoteTreeTestVote(t, signers[tree.members[4]], []byte("another block"))))
+	assert.True(t, va.add(&types.AggregateVote{VoteType: pbtypes.PrepareVoteType, Height: 3, Round: 1, BlockHash: blockHash, ValueHash: grandChild.Vote.ValueHash, AggregateSignature: partial}))
+	assert.True(t, va.complete(tree.members[1]))
+
+	msg, err = va.aggregate(cb)
+	assert.Nil(t, err)
+	agg, ok := msg.(*types.AggregateVote)
+	assert.True(t, ok)
+	assert.Nil(t, agg.ValidateBasic())
+	power, err := validators.VerifyPartialAggregateSignature(agg.AggregateSignature, agg.ValueHash)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(3), power)
+
+	// 聚合投票在网络上传输后依然有效
+	decoded, ok := MustDecode(MustEncode(agg)).(*types.AggregateVote)
+	assert.True(t, ok)
+	assert.Equal(t, agg.BlockHash, decoded.BlockHash)
+	_, err = validators.VerifyPartialAggregateSignature(decoded.AggregateSignature, decoded.ValueHash)
+	assert.Nil(t, err)
+}
+
+func TestVoteSetAggregateVote(t *testing.T) {
+	validators, signers := newVoteTreeTestValidators(t, 7)
+	blockHash := []byte("block hash")
+	cb := bls12.NewCryptoBLS12()
+	votes := make([]*types.PrepareVote, 0, 7)
+	for _, val := range validators.Validators {
+		votes = append(votes, newVoteTreeTestVote(t, signers[val.ID], blockHash))
+		assert.Nil(t, bls12.AddBLSPublicKey(val.PublicKey.ToBytes()))
+	}
+	aggregateOf := func(votes ...*types.PrepareVote) *types.AggregateVote {
+		sigs := make([]*bls12.Signature, 0, len(votes))
+		for _, vote := range votes {
+			sigs = append(sigs, vote.Vote.Signature)
+		}
+		agg, err := cb.CreateThresholdSignature(sigs)
+		assert.Nil(t, err)
+		return &types.AggregateVote{VoteType: pbtypes.PrepareVoteType, Height: 3, Round: 1, BlockHash: blockHash, ValueHash: votes[0].Vote.ValueHash, AggregateSignature: agg}
+	}
+
+	vs := NewVoteSet()
+	vs.AddPrepareVote(1, votes[0])
+	vs.AddPrepareVote(1, votes[1])
+	// 聚合签名包含的验证者的单个投票被移除
+	assert.True(t, vs.AddAggregateVote(1, aggregateOf(votes[1], votes[2])))
+	assert.Equal(t, 1, len(vs.roundVoteSet(1).PrepareVoteSet))
+	// 已经包含在聚合签名里的单个投票不再被加入
+	vs.AddPrepareVote(1, votes[2])
+	assert.Equal(t, 1, len(vs.roundVoteSet(1).PrepareVoteSet))
+	assert.False(t, vs.CheckPrepareVoteIsComplete(1, validators))
+
+	// 部分重叠的聚合签名被丢弃，完全包含已有聚合签名的被用来替换它
+	assert.False(t, vs.AddAggregateVote(1, aggregateOf(votes[2], votes[3])))
+	assert.True(t, vs.AddAggregateVote(1, aggregateOf(votes[1], votes[2], votes[3])))
+	assert.Equal(t, 1, len(vs.roundVoteSet(1).Aggregates[pbtypes.PrepareVoteType]))
+	// 7个验证者里只有4个投票，没有严格超过2/3
+	assert.False(t, vs.CheckPrepareVoteIsComplete(1, validators))
+	vs.AddPrepareVote(1, votes[4])
+	assert.True(t, vs.CheckPrepareVoteIsComplete(1, validators))
+
+	agg := vs.CreateThresholdSigForPrepareVote(1, cb)
+	assert.NotNil(t, agg)
+	assert.Equal(t, 5, agg.Participants().Size())
+	assert.Nil(t, validators.VerifyAggregateSignature(agg, votes[0].Vote.ValueHash))
+}
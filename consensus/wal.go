@@ -0,0 +1,400 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/common/service"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/proto/pbconsensus"
+	"github.com/cosmos/gogoproto/proto"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+	"time"
+
+	mos "github.com/232425wxy/meta--/common/os"
+)
+
+const (
+	// walMaxMsgSizeBytes WAL里单条消息的最大字节数
+	walMaxMsgSizeBytes = 10 * 1024 * 1024 // 10MB
+	// walDefaultFlushInterval WAL会定期将缓冲区里的数据刷新到硬盘上
+	walDefaultFlushInterval = 2 * time.Second
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// WAL里的消息
+
+// WALMessage 可以写入WAL的消息有以下几种：MessageInfo、TimeoutInfo、events.EventDataNewStep和EndHeightMessage。
+type WALMessage interface{}
+
+// EndHeightMessage 标志着某个高度的区块已经被确认并执行，节点重启后只需要重放最后一个EndHeightMessage之后的消息。
+type EndHeightMessage struct {
+	Height int64 `json:"height"`
+}
+
+// TimedWALMessage 带有写入时间的WAL消息。
+type TimedWALMessage struct {
+	Time time.Time  `json:"time"`
+	Msg  WALMessage `json:"msg"`
+}
+
+// WALToProto 将WAL消息转换为protobuf格式。
+func WALToProto(msg WALMessage) (*pbconsensus.WALMessage, error) {
+	var pb pbconsensus.WALMessage
+	switch m := msg.(type) {
+	case events.EventDataNewStep:
+		pb = pbconsensus.WALMessage{
+			Sum: &pbconsensus.WALMessage_EventDataNewStep{EventDataNewStep: m.ToProto()},
+		}
+	case MessageInfo:
+		pb = pbconsensus.WALMessage{
+			Sum: &pbconsensus.WALMessage_MsgInfo{MsgInfo: &pbconsensus.MsgInfo{
+				Msg:    MsgToProto(m.Msg),
+				NodeID: string(m.NodeID),
+			}},
+		}
+	case TimeoutInfo:
+		pb = pbconsensus.WALMessage{
+			Sum: &pbconsensus.WALMessage_TimeoutInfo{TimeoutInfo: &pbconsensus.TimeoutInfo{
+				Duration: int64(m.Duration),
+				Height:   m.Height,
+				Round:    int32(m.Round),
+				Step:     int32(m.Step),
+			}},
+		}
+	case EndHeightMessage:
+		pb = pbconsensus.WALMessage{
+			Sum: &pbconsensus.WALMessage_EndHeight{EndHeight: &pbconsensus.EndHeight{Height: m.Height}},
+		}
+	default:
+		return nil, fmt.Errorf("to proto: wal message not recognized: %T", msg)
+	}
+	return &pb, nil
+}
+
+// WALFromProto 将protobuf格式的WAL消息转换为WAL消息。
+func WALFromProto(pb *pbconsensus.WALMessage) (WALMessage, error) {
+	if pb == nil {
+		return nil, errors.New("nil WAL message")
+	}
+	switch m := pb.Sum.(type) {
+	case *pbconsensus.WALMessage_EventDataNewStep:
+		return *events.EventDataNewStepFromProto(m.EventDataNewStep), nil
+	case *pbconsensus.WALMessage_MsgInfo:
+		if m.MsgInfo.Msg == nil {
+			return nil, errors.New("nil consensus message in WAL")
+		}
+		return MessageInfo{Msg: MsgFromProto(m.MsgInfo.Msg), NodeID: crypto.ID(m.MsgInfo.NodeID)}, nil
+	case *pbconsensus.WALMessage_TimeoutInfo:
+		return TimeoutInfo{
+			Duration: time.Duration(m.TimeoutInfo.Duration),
+			Height:   m.TimeoutInfo.Height,
+			Round:    int16(m.TimeoutInfo.Round),
+			Step:     Step(m.TimeoutInfo.Step),
+		}, nil
+	case *pbconsensus.WALMessage_EndHeight:
+		return EndHeightMessage{Height: m.EndHeight.Height}, nil
+	default:
+		return nil, fmt.Errorf("from proto: wal message not recognized: %T", pb.Sum)
+	}
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// WAL
+
+// WAL 共识模块的预写日志，共识模块在处理每一条消息之前，都会先将消息写入WAL，这样节点在崩溃重启后，
+// 可以通过重放WAL里的消息恢复到崩溃前的共识状态，避免对同一高度的不同区块进行投票。
+type WAL interface {
+	Write(WALMessage) error
+	WriteSync(WALMessage) error
+	FlushAndSync() error
+
+	SearchForEndHeight(height int64) (dec *WALDecoder, found bool, err error)
+
+	Start() error
+	Stop() error
+}
+
+// BaseWAL 基于 mos.Group 实现的WAL，消息被写入Group的head文件里，head文件写满后会被轮换。
+type BaseWAL struct {
+	service.BaseService
+	group         *mos.Group
+	enc           *WALEncoder
+	flushTicker   *time.Ticker
+	flushInterval time.Duration
+}
+
+var _ WAL = &BaseWAL{}
+
+// NewWAL 在给定的路径下打开或创建WAL文件。
+func NewWAL(walFile string) (*BaseWAL, error) {
+	if err := mos.EnsureDir(filepath.Dir(walFile), 0700); err != nil {
+		return nil, fmt.Errorf("failed to ensure WAL directory is in place: %w", err)
+	}
+	group, err := mos.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+	return &BaseWAL{
+		BaseService:   *service.NewBaseService(nil, "WAL"),
+		group:         group,
+		enc:           NewWALEncoder(group),
+		flushInterval: walDefaultFlushInterval,
+	}, nil
+}
+
+// Group 返回WAL底层的文件组。
+func (wal *BaseWAL) Group() *mos.Group {
+	return wal.group
+}
+
+// IsEmpty 判断WAL里是否还没有任何数据。
+func (wal *BaseWAL) IsEmpty() bool {
+	return wal.group.ReadGroupInfo().TotalSize == 0
+}
+
+func (wal *BaseWAL) Start() error {
+	wal.group.Start()
+	wal.flushTicker = time.NewTicker(wal.flushInterval)
+	go wal.processFlushTicks()
+	return wal.BaseService.Start()
+}
+
+// Stop 关闭WAL前，将缓冲区里的数据刷新到硬盘上。
+func (wal *BaseWAL) Stop() error {
+	if wal.flushTicker != nil {
+		wal.flushTicker.Stop()
+	}
+	if err := wal.FlushAndSync(); err != nil {
+		wal.Logger.Error("failed to flush WAL", "err", err)
+	}
+	if err := wal.group.Stop(); err != nil {
+		return err
+	}
+	wal.group.Close()
+	return wal.BaseService.Stop()
+}
+
+func (wal *BaseWAL) processFlushTicks() {
+	for {
+		select {
+		case <-wal.flushTicker.C:
+			if err := wal.FlushAndSync(); err != nil {
+				wal.Logger.Error("periodic WAL flush failed", "err", err)
+			}
+		case <-wal.WaitStop():
+			return
+		}
+	}
+}
+
+// FlushAndSync 将缓冲区里的数据刷新到文件里，并同步到硬盘上。
+func (wal *BaseWAL) FlushAndSync() error {
+	return wal.group.FlushAndSync()
+}
+
+// Write 将消息写入WAL的缓冲区里，不保证消息立即落盘，适用于其他节点发来的消息和超时事件，这些消息丢失了
+// 也可以从其他节点重新获得。
+func (wal *BaseWAL) Write(msg WALMessage) error {
+	if wal == nil {
+		return nil
+	}
+	if err := wal.enc.Encode(&TimedWALMessage{Time: time.Now(), Msg: msg}); err != nil {
+		wal.Logger.Error("failed to encode message to WAL", "err", err, "msg", fmt.Sprintf("%T", msg))
+		return err
+	}
+	return nil
+}
+
+// WriteSync 将消息写入WAL并立即落盘，适用于自己产生的消息，例如自己的投票，在消息落盘之前不能对消息进行处理。
+func (wal *BaseWAL) WriteSync(msg WALMessage) error {
+	if wal == nil {
+		return nil
+	}
+	if err := wal.Write(msg); err != nil {
+		return err
+	}
+	if err := wal.FlushAndSync(); err != nil {
+		wal.Logger.Error("WriteSync failed to flush consensus WAL", "err", err)
+		return err
+	}
+	return nil
+}
+
+// SearchForEndHeight 从WAL的最后一个文件开始往前查找指定高度的EndHeightMessage，如果找到了，返回的解码器
+// 会指向该EndHeightMessage之后的第一条消息，调用者需要负责关闭解码器。
+func (wal *BaseWAL) SearchForEndHeight(height int64) (*WALDecoder, bool, error) {
+	if err := wal.FlushAndSync(); err != nil {
+		return nil, false, err
+	}
+	min, max := wal.group.MinIndex(), wal.group.MaxIndex()
+	wal.Logger.Trace("searching for height", "height", height, "min", min, "max", max)
+	for index := max; index >= min; index-- {
+		gr, err := wal.group.NewReader(index)
+		if err != nil {
+			return nil, false, err
+		}
+		dec := NewWALDecoder(gr)
+		for {
+			msg, err := dec.Decode()
+			if err == io.EOF {
+				break
+			}
+			if IsDataCorruptionError(err) {
+				wal.Logger.Error("corrupted entry, skipping...", "err", err, "index", index)
+				continue
+			}
+			if err != nil {
+				_ = dec.Close()
+				return nil, false, err
+			}
+			if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height == height {
+				wal.Logger.Trace("found", "height", height, "index", index)
+				return dec, true, nil
+			}
+		}
+		_ = dec.Close()
+	}
+	return nil, false, nil
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// 编解码
+
+// WALEncoder 将WAL消息编码后写入底层的writer，每条消息的格式为：
+//
+//	4字节的crc32校验和 | 4字节的数据长度 | protobuf编码的TimedWALMessage
+type WALEncoder struct {
+	wr io.Writer
+}
+
+func NewWALEncoder(wr io.Writer) *WALEncoder {
+	return &WALEncoder{wr: wr}
+}
+
+func (enc *WALEncoder) Encode(v *TimedWALMessage) error {
+	pbMsg, err := WALToProto(v.Msg)
+	if err != nil {
+		return err
+	}
+	pv := &pbconsensus.TimedWALMessage{Time: v.Time, Msg: pbMsg}
+	data, err := proto.Marshal(pv)
+	if err != nil {
+		return err
+	}
+	length := uint32(len(data))
+	if length > walMaxMsgSizeBytes {
+		return fmt.Errorf("msg is too big: %d bytes, max: %d bytes", length, walMaxMsgSizeBytes)
+	}
+	msg := make([]byte, 8+length)
+	binary.BigEndian.PutUint32(msg[0:4], crc32.Checksum(data, crc32c))
+	binary.BigEndian.PutUint32(msg[4:8], length)
+	copy(msg[8:], data)
+	_, err = enc.wr.Write(msg)
+	return err
+}
+
+// DataCorruptionError WAL里的数据损坏了，例如校验和不一致或者数据长度不合法。
+type DataCorruptionError struct {
+	cause error
+}
+
+func (e DataCorruptionError) Error() string {
+	return fmt.Sprintf("DataCorruptionError[%v]", e.cause)
+}
+
+func (e DataCorruptionError) Cause() error {
+	return e.cause
+}
+
+// IsDataCorruptionError 判断错误是否是由于WAL数据损坏引起的。
+func IsDataCorruptionError(err error) bool {
+	_, ok := err.(DataCorruptionError)
+	return ok
+}
+
+// WALDecoder 从底层的reader里读取并解码WAL消息，读到末尾时返回 io.EOF。
+type WALDecoder struct {
+	rd io.Reader
+}
+
+func NewWALDecoder(rd io.Reader) *WALDecoder {
+	return &WALDecoder{rd: rd}
+}
+
+// Close 如果底层的reader实现了 io.Closer，则将其关闭。
+func (dec *WALDecoder) Close() error {
+	if c, ok := dec.rd.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (dec *WALDecoder) Decode() (*TimedWALMessage, error) {
+	b := make([]byte, 4)
+
+	_, err := io.ReadFull(dec.rd, b)
+	if errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to read checksum: %v", err)}
+	}
+	crc := binary.BigEndian.Uint32(b)
+
+	b = make([]byte, 4)
+	_, err = io.ReadFull(dec.rd, b)
+	if err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to read length: %v", err)}
+	}
+	length := binary.BigEndian.Uint32(b)
+	if length > walMaxMsgSizeBytes {
+		return nil, DataCorruptionError{fmt.Errorf("length %d exceeded maximum possible value of %d bytes", length, walMaxMsgSizeBytes)}
+	}
+
+	data := make([]byte, length)
+	n, err := io.ReadFull(dec.rd, data)
+	if err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to read data: %v (read: %d, wanted: %d)", err, n, length)}
+	}
+
+	if actual := crc32.Checksum(data, crc32c); actual != crc {
+		return nil, DataCorruptionError{fmt.Errorf("checksums do not match: read: %v, actual: %v", crc, actual)}
+	}
+
+	var res = new(pbconsensus.TimedWALMessage)
+	if err = proto.Unmarshal(data, res); err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to decode data: %v", err)}
+	}
+	walMsg, err := WALFromProto(res.Msg)
+	if err != nil {
+		return nil, DataCorruptionError{fmt.Errorf("failed to convert from proto: %w", err)}
+	}
+	return &TimedWALMessage{Time: res.Time, Msg: walMsg}, nil
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// nilWAL
+
+// nilWAL 不做任何事情的WAL，在没有配置WAL文件路径时使用。
+type nilWAL struct{}
+
+var _ WAL = nilWAL{}
+
+func (nilWAL) Write(WALMessage) error     { return nil }
+func (nilWAL) WriteSync(WALMessage) error { return nil }
+func (nilWAL) FlushAndSync() error        { return nil }
+func (nilWAL) SearchForEndHeight(int64) (*WALDecoder, bool, error) {
+	return nil, false, nil
+}
+func (nilWAL) Start() error { return nil }
+func (nilWAL) Stop() error  { return nil }
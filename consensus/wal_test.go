@@ -0,0 +1,63 @@
+package consensus
+
+import (
+	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWALWriteAndSearch(t *testing.T) {
+	wal, err := NewWAL(filepath.Join(t.TempDir(), "cs.wal", "wal"))
+	assert.Nil(t, err)
+	assert.Nil(t, wal.Start())
+	defer func() { _ = wal.Stop() }()
+	assert.True(t, wal.IsEmpty())
+
+	assert.Nil(t, wal.WriteSync(EndHeightMessage{Height: 0}))
+	assert.Nil(t, wal.Write(events.EventDataNewStep{Height: 1, Round: 1, Step: int8(PrepareStep)}))
+	assert.Nil(t, wal.Write(TimeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: ConsensusTimeout}))
+	assert.Nil(t, wal.WriteSync(MessageInfo{Msg: &types.NextView{Type: pbtypes.NextViewType, ID: "test", Height: 1}, NodeID: "peer"}))
+	assert.Nil(t, wal.WriteSync(EndHeightMessage{Height: 1}))
+	assert.Nil(t, wal.Write(events.EventDataNewStep{Height: 2, Round: 1, Step: int8(NewHeightStep)}))
+	assert.False(t, wal.IsEmpty())
+
+	dec, found, err := wal.SearchForEndHeight(0)
+	assert.Nil(t, err)
+	assert.True(t, found)
+
+	var msgs []WALMessage
+	for {
+		msg, err := dec.Decode()
+		if err == io.EOF {
+			break
+		}
+		assert.Nil(t, err)
+		msgs = append(msgs, msg.Msg)
+	}
+	assert.Nil(t, dec.Close())
+	assert.Len(t, msgs, 5)
+	assert.Equal(t, events.EventDataNewStep{Height: 1, Round: 1, Step: int8(PrepareStep)}, msgs[0])
+	assert.Equal(t, TimeoutInfo{Duration: time.Second, Height: 1, Round: 1, Step: ConsensusTimeout}, msgs[1])
+	mi, ok := msgs[2].(MessageInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "peer", string(mi.NodeID))
+	assert.Equal(t, int64(1), mi.Msg.(*types.NextView).Height)
+	assert.Equal(t, EndHeightMessage{Height: 1}, msgs[3])
+
+	dec, found, err = wal.SearchForEndHeight(1)
+	assert.Nil(t, err)
+	assert.True(t, found)
+	msg, err := dec.Decode()
+	assert.Nil(t, err)
+	assert.Equal(t, events.EventDataNewStep{Height: 2, Round: 1, Step: int8(NewHeightStep)}, msg.Msg)
+	assert.Nil(t, dec.Close())
+
+	_, found, err = wal.SearchForEndHeight(2)
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
@@ -3,6 +3,7 @@ package bls12
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12/bls12381"
@@ -436,9 +437,16 @@ func NewCryptoBLS12() *CryptoBLS12 {
 //
 // Init 初始化，给 *blsCrypto 设置私钥和节点ID。
 func (cb *CryptoBLS12) Init(private *PrivateKey) {
-	public := private.PublicKey()
-
 	cb.private = private
+	cb.InitWithPublicKey(private.PublicKey())
+}
+
+// InitWithPublicKey ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
+//
+//	---------------------------------------------------------
+//
+// InitWithPublicKey 只用公钥进行初始化，私钥保存在独立的签名进程里时使用，此时 Sign 方法不可用。
+func (cb *CryptoBLS12) InitWithPublicKey(public *PublicKey) {
 	cb.public = public
 	cb.id = public.ToID()
 	err := AddBLSPublicKey(public.ToBytes())
@@ -453,6 +461,9 @@ func (cb *CryptoBLS12) Init(private *PrivateKey) {
 //
 // Sign 对一个长度为256比特的哈希值进行签名。
 func (cb *CryptoBLS12) Sign(h []byte) (*Signature, error) {
+	if cb.private == nil {
+		return nil, errors.New("bls12: private key is not available")
+	}
 	sig, err := cb.private.Sign(h)
 	return sig, err
 }
@@ -520,30 +531,38 @@ func (cb *CryptoBLS12) VerifyThresholdSignature(signature *AggregateSignature, h
 
 // VerifyThresholdSignatureForMessageSet ♏ |作者：吴翔宇| 🍁 |日期：2022/11/30|
 //
-// VerifyThresholdSignatureForMessageSet 根据给定的聚合签名和不同消息的哈希值，验证聚合签名是否合法。
+// VerifyThresholdSignatureForMessageSet 根据给定的聚合签名和不同消息的哈希值，验证聚合签名是否合法。hashes记录了每个签名者
+// 所签署的消息哈希值，不同签名者可以签署相同的消息，聚合签名的参与者必须与hashes里的签名者完全一致，并且签名者的数量不少于quorumSize。
 func (cb *CryptoBLS12) VerifyThresholdSignatureForMessageSet(signature *AggregateSignature, hashes map[crypto.ID]sha256.Hash, quorumSize int) bool {
-	hashSet := make(map[sha256.Hash]struct{})
+	participants := signature.Participants()
+	if participants.Size() != len(hashes) || len(hashes) < quorumSize {
+		return false
+	}
+	// 签署相同消息的签名者共用一次哈希到曲线的计算
+	points := make(map[sha256.Hash]*bls12381.PointG2)
 	engine := bls12381.NewEngine()
 	engine.AddPairInv(&bls12381.G1One, signature.sig)
-	for id, hash := range hashes {
-		if _, ok := hashSet[hash]; ok {
-			continue
+	for _, id := range participants.IDs {
+		hash, ok := hashes[id]
+		if !ok {
+			return false
 		}
-		hashSet[hash] = struct{}{}
 		pubKey := GetBLSPublicKeyFromLib(id)
 		if pubKey == nil {
 			return false
 		}
-		p2, err := bls12381.NewG2().HashToCurve(hash[:], domain)
-		if err != nil {
-			return false
+		p2, ok := points[hash]
+		if !ok {
+			var err error
+			p2, err = bls12381.NewG2().HashToCurve(hash[:], domain)
+			if err != nil {
+				return false
+			}
+			points[hash] = p2
 		}
 		engine.AddPair(pubKey.Key, p2)
 	}
-	if !engine.Result().IsOne() {
-		return false
-	}
-	return len(hashSet) >= quorumSize
+	return engine.Result().IsOne()
 }
 
 // CreateThresholdSignature ♏ |作者：吴翔宇| 🍁 |日期：2022/11/30|
@@ -561,6 +580,29 @@ func (cb *CryptoBLS12) CreateThresholdSignature(partialSignatures []*Signature)
 	return cb.aggregateSignatures(sigs), nil
 }
 
+// CombineThresholdSignatures 将参与者互不相交的若干个聚合签名合并成一个聚合签名，投票聚合树里的内部节点用它把子树
+// 发来的部分聚合签名与自己聚合出的签名合并到一起。
+func (cb *CryptoBLS12) CombineThresholdSignatures(aggs []*AggregateSignature) (*AggregateSignature, error) {
+	if len(aggs) == 0 {
+		return nil, errors.New("bls12: no aggregate signature to combine")
+	}
+	g2 := bls12381.NewG2()
+	sig := &bls12381.PointG2{}
+	participants := crypto.NewIDSet(0)
+	seen := make(map[crypto.ID]struct{})
+	for _, agg := range aggs {
+		for _, id := range agg.participants.IDs {
+			if _, ok := seen[id]; ok {
+				return nil, fmt.Errorf("bls12: %q participates in more than one aggregate signature", id)
+			}
+			seen[id] = struct{}{}
+			participants.AddID(id)
+		}
+		g2.Add(sig, sig, agg.sig)
+	}
+	return &AggregateSignature{sig: sig, participants: participants}, nil
+}
+
 // CreateThresholdSignatureForMessageSet ♏ |作者：吴翔宇| 🍁 |日期：2022/11/30|
 //
 // CreateThresholdSignatureForMessageSet 将若干个为不同消息签名的签名聚合成聚合签名。
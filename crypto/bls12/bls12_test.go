@@ -1,6 +1,7 @@
 package bls12
 
 import (
+	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -155,3 +156,71 @@ func TestAnyThreshold(t *testing.T) {
 
 	assert.True(t, cb.VerifyThresholdSignature(thresholdSig1234, h[:]))
 }
+
+func TestThresholdForMessageSet(t *testing.T) {
+	cb := NewCryptoBLS12()
+	privates := make([]*PrivateKey, 4)
+	for i := range privates {
+		private, err := GeneratePrivateKey()
+		assert.Nil(t, err)
+		assert.Nil(t, AddBLSPublicKey(private.PublicKey().ToBytes()))
+		privates[i] = private
+	}
+
+	// 前两个签名者签署相同的消息，后两个签名者各自签署不同的消息
+	msgs := []string{"round 1", "round 1", "round 2", "round 3"}
+	hashes := make(map[crypto.ID]sha256.Hash)
+	sigs := make([]*Signature, 0, len(privates))
+	for i, private := range privates {
+		h := sha256.Sum([]byte(msgs[i]))
+		sig, err := private.Sign(h[:])
+		assert.Nil(t, err)
+		hashes[private.PublicKey().ToID()] = h
+		sigs = append(sigs, sig)
+	}
+
+	agg, err := cb.CreateThresholdSignatureForMessageSet(sigs)
+	assert.Nil(t, err)
+	assert.True(t, cb.VerifyThresholdSignatureForMessageSet(agg, hashes, 3))
+	assert.False(t, cb.VerifyThresholdSignatureForMessageSet(agg, hashes, 5))
+
+	// 篡改其中一个签名者的消息
+	tampered := make(map[crypto.ID]sha256.Hash)
+	for id, h := range hashes {
+		tampered[id] = h
+	}
+	tampered[privates[1].PublicKey().ToID()] = sha256.Sum([]byte("round 2"))
+	assert.False(t, cb.VerifyThresholdSignatureForMessageSet(agg, tampered, 3))
+
+	// 缺少其中一个签名者的消息
+	delete(tampered, privates[1].PublicKey().ToID())
+	assert.False(t, cb.VerifyThresholdSignatureForMessageSet(agg, tampered, 3))
+}
+
+func TestCombineThresholdSignatures(t *testing.T) {
+	h := sha256.Sum([]byte("Let's test combined threshold signature!"))
+	sigs := make([]*Signature, 5)
+	for i := range sigs {
+		private, err := GeneratePrivateKey()
+		assert.Nil(t, err)
+		assert.Nil(t, AddBLSPublicKey(private.PublicKey().ToBytes()))
+		sigs[i], err = private.Sign(h[:])
+		assert.Nil(t, err)
+	}
+
+	cb := NewCryptoBLS12()
+	left, err := cb.CreateThresholdSignature(sigs[:2])
+	assert.Nil(t, err)
+	right, err := cb.CreateThresholdSignature(sigs[2:])
+	assert.Nil(t, err)
+	combined, err := cb.CombineThresholdSignatures([]*AggregateSignature{left, right})
+	assert.Nil(t, err)
+	assert.Equal(t, 5, combined.Participants().Size())
+	assert.True(t, cb.VerifyThresholdSignature(combined, h[:]))
+
+	// 参与者有重叠的聚合签名不能被合并，否则重叠的签名会被计算两次
+	overlapping, err := cb.CreateThresholdSignature(sigs[1:3])
+	assert.Nil(t, err)
+	_, err = cb.CombineThresholdSignatures([]*AggregateSignature{left, overlapping})
+	assert.NotNil(t, err)
+}
@@ -128,6 +128,9 @@ func (p *Proof) Verify(root, item []byte) error {
 	if !bytes.Equal(p.LeafHash, h) {
 		return fmt.Errorf("invalid tx hash: wanted %x, got %X", h, p.LeafHash)
 	}
+	if index, ok := p.indexFromAunts(); !ok || index != p.Index {
+		return fmt.Errorf("the aunts of proof do not lead to leaf #%d of %d", p.Index, p.Total)
+	}
 	computedRoot := p.ComputeRootHash()
 	if !bytes.Equal(computedRoot, root) {
 		return fmt.Errorf("invalid merkle root hash, wanted %X, got %X", root, computedRoot)
@@ -135,6 +138,29 @@ func (p *Proof) Verify(root, item []byte) error {
 	return nil
 }
 
+// indexFromAunts 根据Aunts里记录的兄弟节点在左边还是右边，从根节点往下推算出叶子节点在一棵有Total个叶子节点的默克尔树
+// 里的位置，这样证明就与Index绑定在了一起，不能把一个叶子节点的证明当成另一个位置上的证明来使用。
+func (p *Proof) indexFromAunts() (uint64, bool) {
+	total, index := p.Total, uint64(0)
+	for i := len(p.Aunts) - 1; i >= 0; i-- {
+		if total <= 1 || len(p.Aunts[i]) == 0 {
+			return 0, false
+		}
+		split := getSplitPoint(total)
+		switch p.Aunts[i][0] {
+		case 'r':
+			// 兄弟节点在右边，叶子节点位于左子树里
+			total = split
+		case 'l':
+			index += split
+			total -= split
+		default:
+			return 0, false
+		}
+	}
+	return index, total == 1
+}
+
 // ValidateBasic ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
 //
 //	---------------------------------------------------------
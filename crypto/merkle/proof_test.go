@@ -93,3 +93,18 @@ func BenchmarkCompareProofsToCompute(b *testing.B) {
 	}
 	b.ReportAllocs()
 }
+
+func TestProofVerifyIndex(t *testing.T) {
+	for n := 1; n <= len(items); n++ {
+		root, proofs := ProofsFromByteSlices(items[:n])
+		for i, proof := range proofs {
+			assert.Nil(t, proof.Verify(root, items[i]))
+			if n > 1 {
+				// 证明与叶子节点的位置绑定在一起，改动Index后验证失败
+				moved := *proof
+				moved.Index = uint64((i + 1) % n)
+				assert.NotNil(t, moved.Verify(root, items[i]))
+			}
+		}
+	}
+}
@@ -3,7 +3,9 @@ package database
 import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/errors"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"path/filepath"
 )
 
@@ -79,14 +81,14 @@ func (g *GoLevelDB) DeleteSync(key []byte) error {
 	return nil
 }
 
+// Iterator 返回[start, end)区间内的元素。
 func (g *GoLevelDB) Iterator(start, end []byte) (Iterator, error) {
-	//TODO implement me
-	panic("implement me")
+	return newGoLevelDBIterator(g.db.NewIterator(&util.Range{Start: start, Limit: end}, nil), start, end, false), nil
 }
 
+// ReverseIterator 返回[start, end)区间内的元素，从大到小排列。
 func (g *GoLevelDB) ReverseIterator(start, end []byte) (Iterator, error) {
-	//TODO implement me
-	panic("implement me")
+	return newGoLevelDBIterator(g.db.NewIterator(&util.Range{Start: start, Limit: end}, nil), start, end, true), nil
 }
 
 func (g *GoLevelDB) Close() error {
@@ -94,8 +96,7 @@ func (g *GoLevelDB) Close() error {
 }
 
 func (g *GoLevelDB) NewBatch() Batch {
-	//TODO implement me
-	panic("implement me")
+	return &goLevelBatch{db: g, batch: new(leveldb.Batch)}
 }
 
 func (g *GoLevelDB) Stats() map[string]string {
@@ -138,7 +139,133 @@ var _ DB = (*GoLevelDB)(nil)
 
 // 流处理
 
+type goLevelDBIterator struct {
+	source  iterator.Iterator
+	start   []byte
+	end     []byte
+	reverse bool
+	valid   bool
+}
+
+func newGoLevelDBIterator(source iterator.Iterator, start, end []byte, reverse bool) *goLevelDBIterator {
+	iter := &goLevelDBIterator{source: source, start: start, end: end, reverse: reverse}
+	if reverse {
+		iter.valid = source.Last()
+	} else {
+		iter.valid = source.First()
+	}
+	return iter
+}
+
+func (it *goLevelDBIterator) Domain() (start []byte, end []byte) {
+	return it.start, it.end
+}
+
+func (it *goLevelDBIterator) Valid() bool {
+	return it.valid && it.source.Error() == nil
+}
+
+func (it *goLevelDBIterator) Next() {
+	it.assertIsValid()
+	if it.reverse {
+		it.valid = it.source.Prev()
+	} else {
+		it.valid = it.source.Next()
+	}
+}
+
+// Key 返回当前位置的key的拷贝，底层迭代器移动之后原来的切片会被复用。
+func (it *goLevelDBIterator) Key() (key []byte) {
+	it.assertIsValid()
+	return append([]byte(nil), it.source.Key()...)
+}
+
+func (it *goLevelDBIterator) Value() (value []byte) {
+	it.assertIsValid()
+	return append([]byte(nil), it.source.Value()...)
+}
+
+func (it *goLevelDBIterator) Error() error {
+	return it.source.Error()
+}
+
+func (it *goLevelDBIterator) Close() error {
+	it.source.Release()
+	it.valid = false
+	return nil
+}
+
+func (it *goLevelDBIterator) assertIsValid() {
+	if !it.Valid() {
+		panic("goleveldb iterator is invalid")
+	}
+}
+
+var _ Iterator = (*goLevelDBIterator)(nil)
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// 批量处理
+
 type goLevelBatch struct {
 	db    *GoLevelDB
 	batch *leveldb.Batch
 }
+
+// Set 往batch中插入一条存储键值对的指令。
+func (b *goLevelBatch) Set(key []byte, value []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if len(value) == 0 {
+		return errValueEmpty
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	b.batch.Put(key, value)
+	return nil
+}
+
+// Delete 往batch里插入一条删除键值对的指令。
+func (b *goLevelBatch) Delete(key []byte) error {
+	if len(key) == 0 {
+		return errKeyEmpty
+	}
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	b.batch.Delete(key)
+	return nil
+}
+
+// Write 将batch里的所有指令原子地写进数据库。
+func (b *goLevelBatch) Write() error {
+	return b.write(false)
+}
+
+// WriteSync 将batch里的所有指令原子地写进数据库，并同步到磁盘上。
+func (b *goLevelBatch) WriteSync() error {
+	return b.write(true)
+}
+
+func (b *goLevelBatch) write(sync bool) error {
+	if b.batch == nil {
+		return errBatchClosed
+	}
+	if err := b.db.db.Write(b.batch, &opt.WriteOptions{Sync: sync}); err != nil {
+		return err
+	}
+	// 写入之后batch不能再被使用
+	return b.Close()
+}
+
+func (b *goLevelBatch) Close() error {
+	if b.batch != nil {
+		b.batch.Reset()
+		b.batch = nil
+	}
+	return nil
+}
+
+var _ Batch = (*goLevelBatch)(nil)
@@ -131,8 +131,7 @@ func (m *MemDB) Close() error {
 }
 
 func (m *MemDB) NewBatch() Batch {
-	//TODO implement me
-	panic("implement me")
+	return &memBatch{db: m, ops: make([]operation, 0)}
 }
 
 func (m *MemDB) Stats() map[string]string {
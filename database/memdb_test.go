@@ -60,3 +60,59 @@ func TestName(t *testing.T) {
 		t.Log(i)
 	}
 }
+
+func TestBatch(t *testing.T) {
+	level, err := NewGoLevelDB("batch", t.TempDir())
+	assert.Nil(t, err)
+	defer level.Close()
+	for _, db := range []DB{NewMemDB(), level} {
+		assert.Nil(t, db.Set([]byte("a"), []byte("1")))
+		batch := db.NewBatch()
+		assert.Nil(t, batch.Set([]byte("b"), []byte("2")))
+		assert.Nil(t, batch.Delete([]byte("a")))
+		assert.NotNil(t, batch.Set(nil, []byte("3")))
+
+		// 写入之前数据库不变
+		value, err := db.Get([]byte("a"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("1"), value)
+
+		assert.Nil(t, batch.WriteSync())
+		assert.Nil(t, batch.Close())
+		value, err = db.Get([]byte("a"))
+		assert.Nil(t, err)
+		assert.Nil(t, value)
+		value, err = db.Get([]byte("b"))
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("2"), value)
+		assert.NotNil(t, batch.Set([]byte("c"), []byte("3")))
+	}
+}
+
+func TestGoLevelDBIterator(t *testing.T) {
+	db, err := NewGoLevelDB("iterator", t.TempDir())
+	assert.Nil(t, err)
+	defer db.Close()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		assert.Nil(t, db.Set([]byte(k), []byte("value "+k)))
+	}
+
+	collect := func(iter Iterator) []string {
+		keys := make([]string, 0)
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, string(iter.Key()))
+		}
+		assert.Nil(t, iter.Error())
+		assert.Nil(t, iter.Close())
+		return keys
+	}
+	iter, err := db.Iterator([]byte("b"), []byte("d"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"b", "c"}, collect(iter))
+	iter, err = db.Iterator(nil, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, collect(iter))
+	iter, err = db.ReverseIterator([]byte("b"), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"d", "c", "b"}, collect(iter))
+}
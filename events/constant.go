@@ -3,4 +3,15 @@ package events
 const (
 	EventNewStep  = "EVENT_NEW_STEP"
 	EventNextView = "EVENT_NEXT_VIEW"
+	EventNewView  = "EVENT_NEW_VIEW"
+	// EventEquivocation 只有开启了拜占庭模式的主节点才会触发，事件数据是与正常提案相冲突的Prepare消息
+	EventEquivocation = "EVENT_EQUIVOCATION"
+	// 链式HotStuff产生的需要发送给其他节点的消息，事件数据分别是ChainedProposal、ChainedVote和ChainedNewView
+	EventChainedProposal = "EVENT_CHAINED_PROPOSAL"
+	EventChainedVote     = "EVENT_CHAINED_VOTE"
+	EventChainedNewView  = "EVENT_CHAINED_NEW_VIEW"
+	// EventTimeout 两阶段共识里验证者在某一轮超时，事件数据是需要广播给所有节点的Timeout消息
+	EventTimeout = "EVENT_TIMEOUT"
+	// EventBlockRequest 副本节点缺少QC所认证的区块，事件数据是需要广播给所有节点的BlockRequest消息
+	EventBlockRequest = "EVENT_BLOCK_REQUEST"
 )
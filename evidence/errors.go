@@ -0,0 +1,25 @@
+package evidence
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/types"
+)
+
+// ErrInvalidEvidence 证据没有通过验证，发送这份证据的节点可能是恶意节点。
+type ErrInvalidEvidence struct {
+	Evidence types.Evidence
+	Reason   error
+}
+
+func (e *ErrInvalidEvidence) Error() string {
+	return fmt.Sprintf("invalid evidence: %v, evidence: %s", e.Reason, e.Evidence)
+}
+
+// ErrEvidenceAlreadyCommitted 证据已经被打包进区块里并且被提交了。
+type ErrEvidenceAlreadyCommitted struct {
+	Evidence types.Evidence
+}
+
+func (e *ErrEvidenceAlreadyCommitted) Error() string {
+	return fmt.Sprintf("evidence has already been committed: %s", e.Evidence)
+}
@@ -0,0 +1,268 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/common/clist"
+	"github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"github.com/cosmos/gogoproto/proto"
+	"sync"
+)
+
+const (
+	// MaxEvidenceAge 证据的有效期（以区块数计），超过有效期的证据不会再被接受，也不会再被打包进区块里。
+	MaxEvidenceAge int64 = 100
+
+	keyPending   = "evidence-pending/"
+	keyCommitted = "evidence-committed/"
+)
+
+// Pool 证据池，收集本地检测到的以及从其他节点那里收到的作恶证据，待打包的证据和已经被提交的证据分别存储在数据库里，
+// 节点重启后依然可以继续广播和打包之前收集到的证据。
+type Pool struct {
+	db           database.DB
+	mu           sync.Mutex
+	state        *state.State
+	stateStore   *state.StoreState         // 用于加载证据所在高度的验证者集合，为空时使用当前的验证者集合
+	evidenceList *clist.List               // 待打包的证据，用于向其他节点广播
+	evidenceMap  map[string]*clist.Element // hash(evidence) -> *clist.Element
+	logger       log.Logger
+}
+
+var _ state.EvidencePool = (*Pool)(nil)
+
+func NewPool(db database.DB, stat *state.State, logger log.Logger) (*Pool, error) {
+	pool := &Pool{
+		db:           db,
+		state:        stat,
+		evidenceList: clist.NewList(),
+		evidenceMap:  make(map[string]*clist.Element),
+		logger:       logger,
+	}
+	// 将数据库里待打包的证据重新加载到链表里
+	pending, err := pool.listPendingEvidence()
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range pending {
+		pool.addToList(ev)
+	}
+	return pool, nil
+}
+
+func (p *Pool) SetLogger(logger log.Logger) {
+	p.logger = logger
+}
+
+// SetStateStore 设置状态存储，之后用证据所在高度的验证者集合来验证证据。
+func (p *Pool) SetStateStore(store *state.StoreState) {
+	p.stateStore = store
+}
+
+// MaxEvidenceBytes 返回当前共识参数允许一个区块携带的证据的总字节数。
+func (p *Pool) MaxEvidenceBytes() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.state.ConsensusParams.Evidence.MaxBytes
+}
+
+// EvidenceHead 返回待打包的第一份证据。
+func (p *Pool) EvidenceHead() *clist.Element {
+	return p.evidenceList.Head()
+}
+
+// WaitEvidence 返回一个被阻塞的通道，当证据池里有证据时，该通道不再阻塞。
+func (p *Pool) WaitEvidence() <-chan struct{} {
+	return p.evidenceList.WaitChan()
+}
+
+// Size 返回待打包的证据的个数。
+func (p *Pool) Size() int {
+	return p.evidenceList.Size()
+}
+
+// AddEvidence 验证证据，验证通过后将其存储为待打包的证据。
+func (p *Pool) AddEvidence(evidence types.Evidence) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if evidence == nil {
+		return errors.New("nil evidence")
+	}
+	if _, ok := p.evidenceMap[string(evidence.Hash())]; ok {
+		return nil
+	}
+	if p.isCommitted(evidence) {
+		return &ErrEvidenceAlreadyCommitted{Evidence: evidence}
+	}
+	if err := p.verify(evidence); err != nil {
+		return &ErrInvalidEvidence{Evidence: evidence, Reason: err}
+	}
+	bz, err := proto.Marshal(types.EvidenceToProto(evidence))
+	if err != nil {
+		return err
+	}
+	if err = p.db.SetSync(keyPendingEvidence(evidence), bz); err != nil {
+		return err
+	}
+	p.addToList(evidence)
+	p.logger.Info("verified new evidence of byzantine behavior", "evidence", evidence)
+	return nil
+}
+
+// PendingEvidence 按照收集到的顺序返回待打包的证据，总大小不超过maxBytes。
+func (p *Pool) PendingEvidence(maxBytes int64) types.EvidenceList {
+	evidenceList := make(types.EvidenceList, 0)
+	var size int64 = 0
+	for elem := p.evidenceList.Head(); elem != nil; elem = elem.Next() {
+		ev := elem.Value.(types.Evidence)
+		size += int64(types.EvidenceToProto(ev).Size())
+		if size > maxBytes {
+			break
+		}
+		evidenceList = append(evidenceList, ev)
+	}
+	return evidenceList
+}
+
+// CheckEvidence 检查区块里携带的证据：不能重复，不能是已经被提交过的，并且必须是合法的。
+func (p *Pool) CheckEvidence(evidenceList types.EvidenceList) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	seen := make(map[string]struct{})
+	for _, ev := range evidenceList {
+		hash := string(ev.Hash())
+		if _, ok := seen[hash]; ok {
+			return &ErrInvalidEvidence{Evidence: ev, Reason: errors.New("duplicate evidence in block")}
+		}
+		seen[hash] = struct{}{}
+		if p.isCommitted(ev) {
+			return &ErrEvidenceAlreadyCommitted{Evidence: ev}
+		}
+		if _, ok := p.evidenceMap[hash]; ok {
+			// 证据池里的证据都是验证过的
+			continue
+		}
+		if err := p.verify(ev); err != nil {
+			return &ErrInvalidEvidence{Evidence: ev, Reason: err}
+		}
+	}
+	return nil
+}
+
+// Update 区块被执行后，将区块里的证据标记为已提交，并删除过期的待打包证据。
+func (p *Pool) Update(stat *state.State, evidenceList types.EvidenceList) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.state = stat
+	for _, ev := range evidenceList {
+		bz, err := proto.Marshal(types.EvidenceToProto(ev))
+		if err != nil {
+			panic(err)
+		}
+		if err = p.db.Set(keyCommittedEvidence(ev), bz); err != nil {
+			p.logger.Error("failed to mark evidence as committed", "evidence", ev, "err", err)
+		}
+		p.removePending(ev)
+	}
+	for elem := p.evidenceList.Head(); elem != nil; {
+		next := elem.Next()
+		if ev := elem.Value.(types.Evidence); p.isExpired(ev) {
+			p.removePending(ev)
+		}
+		elem = next
+	}
+}
+
+// verify 检查证据的有效期，并且用证据所在高度的验证者集合验证证据里的签名。
+func (p *Pool) verify(evidence types.Evidence) error {
+	if err := evidence.ValidateBasic(); err != nil {
+		return err
+	}
+	if evidence.Height() > p.state.LastBlockHeight+1 {
+		return fmt.Errorf("evidence is from future height %d, current height is %d", evidence.Height(), p.state.LastBlockHeight+1)
+	}
+	if p.isExpired(evidence) {
+		return fmt.Errorf("evidence from height %d is too old, current height is %d", evidence.Height(), p.state.LastBlockHeight+1)
+	}
+	return evidence.Verify(p.validatorsAt(evidence.Height()))
+}
+
+// validatorsAt 返回为高度为height的区块投票的验证者集合，状态存储里没有该高度的验证者集合时（例如旧版本的节点没有保存）
+// 使用当前的验证者集合。
+func (p *Pool) validatorsAt(height int64) *types.ValidatorSet {
+	if p.stateStore == nil || height > p.state.LastBlockHeight {
+		return p.state.Validators
+	}
+	validators, err := p.stateStore.LoadValidators(height)
+	if err != nil {
+		return p.state.Validators
+	}
+	return validators
+}
+
+func (p *Pool) isExpired(evidence types.Evidence) bool {
+	return p.state.LastBlockHeight-evidence.Height() > MaxEvidenceAge
+}
+
+func (p *Pool) isCommitted(evidence types.Evidence) bool {
+	ok, err := p.db.Has(keyCommittedEvidence(evidence))
+	if err != nil {
+		p.logger.Error("failed to query committed evidence", "err", err)
+		return false
+	}
+	return ok
+}
+
+func (p *Pool) addToList(evidence types.Evidence) {
+	elem := p.evidenceList.Push(evidence)
+	p.evidenceMap[string(evidence.Hash())] = elem
+}
+
+func (p *Pool) removePending(evidence types.Evidence) {
+	hash := string(evidence.Hash())
+	if elem, ok := p.evidenceMap[hash]; ok {
+		p.evidenceList.Remove(elem)
+		elem.DetachPrev()
+		delete(p.evidenceMap, hash)
+	}
+	if err := p.db.Delete(keyPendingEvidence(evidence)); err != nil {
+		p.logger.Error("failed to delete pending evidence", "evidence", evidence, "err", err)
+	}
+}
+
+func (p *Pool) listPendingEvidence() (types.EvidenceList, error) {
+	iter, err := p.db.Iterator([]byte(keyPending), []byte(keyPending[:len(keyPending)-1]+"0"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = iter.Close() }()
+	evidenceList := make(types.EvidenceList, 0)
+	for ; iter.Valid(); iter.Next() {
+		pb := new(pbtypes.Evidence)
+		if err = proto.Unmarshal(iter.Value(), pb); err != nil {
+			return nil, err
+		}
+		ev, err := types.EvidenceFromProto(pb)
+		if err != nil {
+			return nil, err
+		}
+		evidenceList = append(evidenceList, ev)
+	}
+	return evidenceList, iter.Error()
+}
+
+// keyPendingEvidence 按照高度排序存储待打包的证据。
+func keyPendingEvidence(evidence types.Evidence) []byte {
+	return []byte(fmt.Sprintf("%s%020d/%X", keyPending, evidence.Height(), evidence.Hash()))
+}
+
+func keyCommittedEvidence(evidence types.Evidence) []byte {
+	return []byte(fmt.Sprintf("%s%020d/%X", keyCommitted, evidence.Height(), evidence.Hash()))
+}
@@ -0,0 +1,117 @@
+package evidence
+
+import (
+	"github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestPool(t *testing.T, db database.DB) (*Pool, *bls12.PrivateKey, *state.State) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	stat := &state.State{
+		InitialHeight:   1,
+		LastBlockHeight: 4,
+		Validators:      types.NewValidatorSet([]*types.Validator{types.NewValidator(privateKey.PublicKey(), 10)}),
+	}
+	pool, err := NewPool(db, stat, log.New())
+	assert.Nil(t, err)
+	return pool, privateKey, stat
+}
+
+func newTestEvidence(privateKey *bls12.PrivateKey, height int64) types.Evidence {
+	signer := types.NewPrivateKeySigner(privateKey)
+	voteA, _ := types.NewVote(pbtypes.CommitVoteType, height, 1, []byte("block a"), signer)
+	voteB, _ := types.NewVote(pbtypes.CommitVoteType, height, 1, []byte("block b"), signer)
+	return types.NewDuplicateVoteEvidence(voteA, voteB, time.Now())
+}
+
+func TestPool_AddAndUpdate(t *testing.T) {
+	db := database.NewMemDB()
+	pool, privateKey, stat := newTestPool(t, db)
+
+	ev := newTestEvidence(privateKey, 5)
+	assert.Nil(t, pool.AddEvidence(ev))
+	assert.Nil(t, pool.AddEvidence(ev))
+	assert.Equal(t, 1, pool.Size())
+	assert.Equal(t, 1, len(pool.PendingEvidence(types.DefaultConsensusParams().Evidence.MaxBytes)))
+	assert.Equal(t, 0, len(pool.PendingEvidence(1)))
+
+	// 来自未来高度的证据不能被验证
+	_, ok := pool.AddEvidence(newTestEvidence(privateKey, 7)).(*ErrInvalidEvidence)
+	assert.True(t, ok)
+
+	// 伪造的证据
+	other, _ := bls12.GeneratePrivateKey()
+	_, ok = pool.AddEvidence(newTestEvidence(other, 5)).(*ErrInvalidEvidence)
+	assert.True(t, ok)
+
+	// 重启后待打包的证据依然存在
+	reloaded, err := NewPool(db, stat, log.New())
+	assert.Nil(t, err)
+	assert.Equal(t, 1, reloaded.Size())
+
+	assert.Nil(t, pool.CheckEvidence(types.EvidenceList{ev}))
+	assert.NotNil(t, pool.CheckEvidence(types.EvidenceList{ev, ev}))
+
+	// 证据被提交后，不能再被打包
+	stat.LastBlockHeight = 5
+	pool.Update(stat, types.EvidenceList{ev})
+	assert.Equal(t, 0, pool.Size())
+	_, ok = pool.CheckEvidence(types.EvidenceList{ev}).(*ErrEvidenceAlreadyCommitted)
+	assert.True(t, ok)
+	_, ok = pool.AddEvidence(ev).(*ErrEvidenceAlreadyCommitted)
+	assert.True(t, ok)
+}
+
+func TestPool_ExpiredEvidence(t *testing.T) {
+	pool, privateKey, stat := newTestPool(t, database.NewMemDB())
+	assert.Nil(t, pool.AddEvidence(newTestEvidence(privateKey, 4)))
+
+	stat.LastBlockHeight = 4 + MaxEvidenceAge + 1
+	pool.Update(stat, nil)
+	assert.Equal(t, 0, pool.Size())
+	_, ok := pool.AddEvidence(newTestEvidence(privateKey, 4)).(*ErrInvalidEvidence)
+	assert.True(t, ok)
+}
+
+func TestPool_HistoricalValidators(t *testing.T) {
+	pool, privateKey, stat := newTestPool(t, database.NewMemDB())
+	store := state.NewStoreState(database.NewMemDB())
+	pool.SetStateStore(store)
+
+	// 高度3的区块由另一个验证者投票，之后它被换掉了
+	former, _ := bls12.GeneratePrivateKey()
+	assert.Nil(t, store.SaveValidators(3, types.NewValidatorSet([]*types.Validator{types.NewValidator(former.PublicKey(), 10)})))
+	assert.Nil(t, store.SaveValidators(4, stat.Validators))
+	assert.Nil(t, pool.AddEvidence(newTestEvidence(former, 3)))
+	_, ok := pool.AddEvidence(newTestEvidence(privateKey, 3)).(*ErrInvalidEvidence)
+	assert.True(t, ok)
+
+	// 当前高度以及没有保存验证者集合的高度使用当前的验证者集合
+	assert.Nil(t, pool.AddEvidence(newTestEvidence(privateKey, 4)))
+	assert.Nil(t, pool.AddEvidence(newTestEvidence(privateKey, 5)))
+	assert.Nil(t, pool.AddEvidence(newTestEvidence(privateKey, 2)))
+}
+
+func TestReactor_MaxMsgSize(t *testing.T) {
+	pool, privateKey, stat := newTestPool(t, database.NewMemDB())
+	stat.ConsensusParams = types.DefaultConsensusParams()
+	reactor := NewReactor(pool)
+
+	// 单条证据消息的上限由共识参数决定，一份能被打包进区块的证据一定能被发送
+	capacity := reactor.GetChannels()[0].RecvMessageCapacity
+	assert.Equal(t, int(stat.ConsensusParams.Evidence.MaxBytes)+evidenceListOverhead, capacity)
+	ev := newTestEvidence(privateKey, 5)
+	bz, err := types.EvidenceList{ev}.ToProto().Marshal()
+	assert.Nil(t, err)
+	assert.True(t, len(bz) <= int(types.EvidenceList{ev}.ByteSize())+evidenceListOverhead)
+
+	stat.ConsensusParams.Evidence.MaxBytes = 1024
+	assert.Equal(t, 1024+evidenceListOverhead, reactor.GetChannels()[0].RecvMessageCapacity)
+}
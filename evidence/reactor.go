@@ -0,0 +1,133 @@
+package evidence
+
+import (
+	"github.com/232425wxy/meta--/common/clist"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"time"
+)
+
+const broadcastEvidenceIntervalS = 10 // 向节点广播证据失败后，等待一段时间再重试
+
+const evidenceListOverhead = 16 // 将一份证据包装成EvidenceList后额外增加的字节数
+
+type Reactor struct {
+	p2p.BaseReactor
+	pool *Pool
+}
+
+func NewReactor(pool *Pool) *Reactor {
+	return &Reactor{
+		BaseReactor: *p2p.NewBaseReactor("Evidence"),
+		pool:        pool,
+	}
+}
+
+func (r *Reactor) SetLogger(logger log.Logger) {
+	r.Logger = logger
+	r.pool.SetLogger(logger)
+}
+
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{ID: p2p.EvidenceChannel, Priority: 6, RecvMessageCapacity: r.maxMsgSize()},
+	}
+}
+
+// maxMsgSize 证据是一份一份发送的，超过共识参数里Evidence.MaxBytes的证据永远不会被打包进区块，所以单条证据消息的最大
+// 字节数由它决定。
+func (r *Reactor) maxMsgSize() int {
+	return int(r.pool.MaxEvidenceBytes()) + evidenceListOverhead
+}
+
+func (r *Reactor) InitPeer(peer *p2p.Peer) *p2p.Peer {
+	return peer
+}
+
+func (r *Reactor) AddPeer(peer *p2p.Peer) {
+	go r.broadcastEvidenceRoutine(peer)
+}
+
+func (r *Reactor) Receive(chID byte, src *p2p.Peer, msg []byte) {
+	message := &pbtypes.EvidenceList{}
+	if err := message.Unmarshal(msg); err != nil {
+		r.Logger.Error("receive wrong evidence message", "src_peer", src, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+	evidenceList, err := types.EvidenceListFromProto(message)
+	if err != nil {
+		r.Logger.Error("receive wrong evidence message", "src_peer", src, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+	for _, ev := range evidenceList {
+		err = r.pool.AddEvidence(ev)
+		switch err.(type) {
+		case nil:
+		case *ErrInvalidEvidence:
+			// 发送伪造证据的节点是恶意节点
+			r.Logger.Error("receive invalid evidence", "src_peer", src, "err", err)
+			r.Switch.StopPeerForError(src, err)
+			return
+		case *ErrEvidenceAlreadyCommitted:
+		default:
+			r.Logger.Error("failed to add evidence", "src_peer", src, "err", err)
+		}
+	}
+}
+
+// broadcastEvidenceRoutine 将证据池里待打包的证据依次发送给peer节点，只有当peer节点到达证据所在的高度后才发送，
+// 否则peer节点无法验证该证据。
+func (r *Reactor) broadcastEvidenceRoutine(peer *p2p.Peer) {
+	var element *clist.Element
+	for {
+		if !r.IsRunning() || !peer.IsRunning() {
+			return
+		}
+
+		if element == nil {
+			select {
+			case <-r.pool.WaitEvidence():
+				if element = r.pool.EvidenceHead(); element == nil {
+					continue
+				}
+			case <-peer.WaitStop():
+				return
+			case <-r.WaitStop():
+				return
+			}
+		}
+
+		peerState, ok := peer.Get(types.PeerStateKey).(interface{ GetHeight() int64 })
+		if !ok {
+			// 共识模块还没有将peer节点的信息存储下来
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		ev := element.Value.(types.Evidence)
+		if peerState.GetHeight() < ev.Height() {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		bz, err := types.EvidenceList{ev}.ToProto().Marshal()
+		if err != nil {
+			panic(err)
+		}
+		if success := peer.Send(p2p.EvidenceChannel, bz); !success {
+			time.Sleep(broadcastEvidenceIntervalS * time.Second)
+			continue
+		}
+
+		select {
+		case <-element.NextWaitChan():
+			element = element.Next()
+		case <-peer.WaitStop():
+			return
+		case <-r.WaitStop():
+			return
+		}
+	}
+}
@@ -12,9 +12,13 @@ import (
 	"github.com/232425wxy/meta--/crypto/bls12"
 	"github.com/232425wxy/meta--/database"
 	"github.com/232425wxy/meta--/events"
+	"github.com/232425wxy/meta--/evidence"
 	"github.com/232425wxy/meta--/log"
 	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/privval"
+	"github.com/232425wxy/meta--/proto/pbabci"
 	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/statesync"
 	"github.com/232425wxy/meta--/stch"
 	"github.com/232425wxy/meta--/store"
 	"github.com/232425wxy/meta--/syncer"
@@ -49,7 +53,11 @@ func DefaultApplicationProvider(cfg *config.Config) abci.Application {
 	var app abci.Application
 	switch cfg.BasicConfig.App {
 	case "kvstore":
-		app = apps.NewKVStoreApp("kvstore", cfg.BasicConfig.DBPath(), database.BackendType(cfg.BasicConfig.DBBackend))
+		kvstore := apps.NewKVStoreApp("kvstore", cfg.BasicConfig.DBPath(), database.BackendType(cfg.BasicConfig.DBBackend))
+		if cfg.StateSyncConfig != nil {
+			kvstore.SetSnapshotInterval(cfg.StateSyncConfig.SnapshotInterval, 0)
+		}
+		app = kvstore
 	default:
 		panic(fmt.Sprintf("unknown app type: %s", cfg.BasicConfig.App))
 	}
@@ -65,19 +73,61 @@ func DefaultTxsPoolProvider(cfg *config.Config, proxyAppConn *proxy.AppConns, st
 	return pool, reactor
 }
 
-type ConsensusProvider func(cfg *config.Config, stat *state2.State, exec *state2.BlockExecutor, txsPool *txspool.TxsPool, privateKey *bls12.PrivateKey, bls *bls12.CryptoBLS12, logger log.Logger) (*consensus.Core, *consensus.Reactor)
+type EvidenceProvider func(db database.DB, stat *state2.State, logger log.Logger) (*evidence.Pool, *evidence.Reactor, error)
+
+func DefaultEvidenceProvider(db database.DB, stat *state2.State, logger log.Logger) (*evidence.Pool, *evidence.Reactor, error) {
+	pool, err := evidence.NewPool(db, stat, logger.New("module", "Evidence"))
+	if err != nil {
+		return nil, nil, err
+	}
+	reactor := evidence.NewReactor(pool)
+	reactor.SetLogger(logger.New("module", "Evidence"))
+	return pool, reactor, nil
+}
+
+type SignerProvider func(cfg *config.Config, nodeKey *p2p.NodeKey, logger log.Logger) (types.Signer, error)
+
+// DefaultSignerProvider 配置了远程签名进程的地址时，通过签名进程为共识消息签名，否则用节点密钥文件里的私钥在本地签名。
+// 开启了拜占庭模式的节点需要为相互冲突的消息签名，因此直接用私钥签名，不再检查是否重复签名。观察者节点从不签名，
+// 它的Signer拒绝所有的签名请求。
+func DefaultSignerProvider(cfg *config.Config, nodeKey *p2p.NodeKey, logger log.Logger) (types.Signer, error) {
+	if cfg.ConsensusConfig.IsObserver() {
+		logger.Info("running in observer mode, consensus messages will never be signed")
+		return types.NewObserverSigner(nodeKey.PublicKey), nil
+	}
+	if cfg.ConsensusConfig.RemoteSignerAddr != "" {
+		connKey, err := privval.LoadOrGenConnKey(cfg.ConsensusConfig.RemoteSignerConnKeyFile())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load remote signer connection key: %w", err)
+		}
+		signerKey, err := privval.ParseConnPublicKey(cfg.ConsensusConfig.RemoteSignerPubKey)
+		if err != nil {
+			return nil, err
+		}
+		logger.Info("connecting to remote signer", "addr", cfg.ConsensusConfig.RemoteSignerAddr, "conn_key", privval.ConnKeyString(connKey))
+		return privval.NewSignerClient(cfg.ConsensusConfig.RemoteSignerAddr, cfg.ConsensusConfig.RemoteSignerTimeout, connKey, signerKey, logger.New("module", "Signer_Client"))
+	}
+	if cfg.ByzantineConfig.Enabled() {
+		logger.Warn("byzantine mode is enabled, double signing protection is disabled")
+		return types.NewPrivateKeySigner(nodeKey.PrivateKey), nil
+	}
+	return privval.LoadLocalSigner(nodeKey.PrivateKey, cfg.ConsensusConfig.SignStateFile())
+}
+
+type ConsensusProvider func(cfg *config.Config, stat *state2.State, exec *state2.BlockExecutor, txsPool *txspool.TxsPool, evidencePool *evidence.Pool, signer types.Signer, bls *bls12.CryptoBLS12, logger log.Logger) (*consensus.Core, *consensus.Reactor)
 
-func DefaultConsensusProvider(cfg *config.Config, stat *state2.State, exec *state2.BlockExecutor, txsPool *txspool.TxsPool, privateKey *bls12.PrivateKey, bls *bls12.CryptoBLS12, logger log.Logger) (*consensus.Core, *consensus.Reactor) {
-	core := consensus.NewCore(cfg.ConsensusConfig, privateKey, stat, exec, txsPool, bls)
+func DefaultConsensusProvider(cfg *config.Config, stat *state2.State, exec *state2.BlockExecutor, txsPool *txspool.TxsPool, evidencePool *evidence.Pool, signer types.Signer, bls *bls12.CryptoBLS12, logger log.Logger) (*consensus.Core, *consensus.Reactor) {
+	core := consensus.NewCore(cfg.ConsensusConfig, signer, stat, exec, txsPool, evidencePool, bls)
 	core.SetLogger(logger.New("module", "Consensus"))
+	core.SetByzantineConfig(cfg.ByzantineConfig)
 	reactor := consensus.NewReactor(core)
 	reactor.SetLogger(logger.New("module", "Consensus_Reactor"))
 	return core, reactor
 }
 
-type P2PProvider func(cfg *config.Config, nodeInfo *p2p.NodeInfo, nodeKey *p2p.NodeKey, txsPoolReactor *txspool.Reactor, consensusReactor *consensus.Reactor, syncerReactor *syncer.Reactor, stchReactor *stch.Reactor, logger log.Logger) (*p2p.Transport, *p2p.Switch)
+type P2PProvider func(cfg *config.Config, nodeInfo *p2p.NodeInfo, nodeKey *p2p.NodeKey, txsPoolReactor *txspool.Reactor, consensusReactor *consensus.Reactor, syncerReactor *syncer.Reactor, stchReactor *stch.Reactor, evidenceReactor *evidence.Reactor, stateSyncReactor *statesync.Reactor, logger log.Logger) (*p2p.Transport, *p2p.Switch)
 
-func DefaultP2PProvider(cfg *config.Config, nodeInfo *p2p.NodeInfo, nodeKey *p2p.NodeKey, txsPoolReactor *txspool.Reactor, consensusReactor *consensus.Reactor, syncerReactor *syncer.Reactor, stchReactor *stch.Reactor, logger log.Logger) (*p2p.Transport, *p2p.Switch) {
+func DefaultP2PProvider(cfg *config.Config, nodeInfo *p2p.NodeInfo, nodeKey *p2p.NodeKey, txsPoolReactor *txspool.Reactor, consensusReactor *consensus.Reactor, syncerReactor *syncer.Reactor, stchReactor *stch.Reactor, evidenceReactor *evidence.Reactor, stateSyncReactor *statesync.Reactor, logger log.Logger) (*p2p.Transport, *p2p.Switch) {
 	addr, err := p2p.NewNetAddressString(p2p.IDAddressString(nodeKey.GetID(), cfg.P2PConfig.ListenAddress))
 	if err != nil {
 		panic(err)
@@ -89,6 +139,8 @@ func DefaultP2PProvider(cfg *config.Config, nodeInfo *p2p.NodeInfo, nodeKey *p2p
 	sw.AddReactor("CONSENSUS", consensusReactor)
 	sw.AddReactor("SYNCER", syncerReactor)
 	sw.AddReactor("STCH", stchReactor)
+	sw.AddReactor("EVIDENCE", evidenceReactor)
+	sw.AddReactor("STATESYNC", stateSyncReactor)
 	return transport, sw
 }
 
@@ -99,6 +151,16 @@ func DefaultSyncerProvider(stat *state2.State, blockExec *state2.BlockExecutor,
 	return reactor
 }
 
+type StateSyncProvider func(cfg *config.Config, proxyAppConns *proxy.AppConns, stateStore *state2.StoreState, blockStore *store.BlockStore, logger log.Logger) *statesync.Reactor
+
+func DefaultStateSyncProvider(cfg *config.Config, proxyAppConns *proxy.AppConns, stateStore *state2.StoreState, blockStore *store.BlockStore, logger log.Logger) *statesync.Reactor {
+	stateSyncConfig := cfg.StateSyncConfig
+	if stateSyncConfig == nil {
+		stateSyncConfig = config.DefaultStateSyncConfig()
+	}
+	return statesync.NewReactor(stateSyncConfig, proxyAppConns.Snapshot(), stateStore, blockStore, logger.New("module", "StateSync"))
+}
+
 type STCHProvider func(id crypto.ID, participantsNum int, logger log.Logger) *stch.Reactor
 
 func DefaultSTCHProvider(id crypto.ID, participantsNum int, logger log.Logger) *stch.Reactor {
@@ -113,9 +175,12 @@ type Provider struct {
 	GenesisProvider     GenesisProvider
 	ApplicationProvider ApplicationProvider
 	TxspoolProvider     TxspoolProvider
+	EvidenceProvider    EvidenceProvider
+	SignerProvider      SignerProvider
 	ConsensusProvider   ConsensusProvider
 	P2PProvider         P2PProvider
 	SyncerProvider      SyncerProvider
+	StateSyncProvider   StateSyncProvider
 	STCHProvider        STCHProvider
 }
 
@@ -125,36 +190,74 @@ func DefaultProvider() Provider {
 		GenesisProvider:     DefaultGenesisProvider,
 		ApplicationProvider: DefaultApplicationProvider,
 		TxspoolProvider:     DefaultTxsPoolProvider,
+		EvidenceProvider:    DefaultEvidenceProvider,
+		SignerProvider:      DefaultSignerProvider,
 		ConsensusProvider:   DefaultConsensusProvider,
 		P2PProvider:         DefaultP2PProvider,
 		SyncerProvider:      DefaultSyncerProvider,
+		StateSyncProvider:   DefaultStateSyncProvider,
 		STCHProvider:        DefaultSTCHProvider,
 	}
 }
 
 type Node struct {
 	service.BaseService
-	cfg        *config.Config
-	genesis    *types.Genesis
-	transport  *p2p.Transport
-	sw         *p2p.Switch
-	addrBook   *p2p.AddrBook
-	nodeInfo   *p2p.NodeInfo
-	nodeKey    *p2p.NodeKey
-	eventBUs   *events.EventBus
-	stateStore *state2.StoreState
-	blockStore *store.BlockStore
-	txsPool    *txspool.TxsPool
+	cfg          *config.Config
+	genesis      *types.Genesis
+	transport    *p2p.Transport
+	sw           *p2p.Switch
+	addrBook     *p2p.AddrBook
+	nodeInfo     *p2p.NodeInfo
+	nodeKey      *p2p.NodeKey
+	eventBUs     *events.EventBus
+	stateStore   *state2.StoreState
+	blockStore   *store.BlockStore
+	pruner       *state2.Pruner
+	txsPool      *txspool.TxsPool
+	evidencePool *evidence.Pool
 
 	txsPoolReactor   *txspool.Reactor
 	consensusReactor *consensus.Reactor
+	syncerReactor    *syncer.Reactor
+	stateSyncReactor *statesync.Reactor
+	stateSyncState   *state2.State // 不为nil时，节点启动后先做状态同步，它是状态同步所信任的创世状态
 }
 
 func NewNode(cfg *config.Config, logger log.Logger, provider Provider) (*Node, error) {
+	if err := cfg.BasicConfig.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if err := cfg.ConsensusConfig.ValidateBasic(); err != nil {
+		return nil, err
+	}
+	if cfg.ByzantineConfig != nil {
+		if err := cfg.ByzantineConfig.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.StateSyncConfig != nil {
+		if err := cfg.StateSyncConfig.ValidateBasic(); err != nil {
+			return nil, err
+		}
+	}
 	nodeKey, err := p2p.LoadNodeKey(cfg.BasicConfig.KeyFilePath())
 	if err != nil {
 		return nil, err
 	}
+	if nodeKey.PublicKey == nil {
+		if nodeKey.PrivateKey == nil {
+			return nil, fmt.Errorf("node key file %s contains neither private key nor public key", cfg.BasicConfig.KeyFilePath())
+		}
+		nodeKey.PublicKey = nodeKey.PrivateKey.PublicKey()
+	}
+
+	signer, err := provider.SignerProvider(cfg, nodeKey, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create consensus signer: %w", err)
+	}
+	if signer.PublicKey().ToID() != nodeKey.GetID() {
+		return nil, fmt.Errorf("signer's public key (%s) does not match the node key (%s)", signer.PublicKey().ToID(), nodeKey.GetID())
+	}
 
 	kp := stch.LoadInitConfig(cfg.BasicConfig.ChameleonKeyFilePath())
 
@@ -187,12 +290,12 @@ func NewNode(cfg *config.Config, logger log.Logger, provider Provider) (*Node, e
 		PublicKey:   nodeKey.PublicKey.ToBytes(),
 		NodeID:      nodeKey.GetID(),
 		ListenAddr:  cfg.P2PConfig.ListenAddress,
-		Channels:    []byte{p2p.LeaderProposeChannel, p2p.ReplicaVoteChannel, p2p.ReplicaNextViewChannel, p2p.TxsChannel},
+		Channels:    []byte{p2p.LeaderProposeChannel, p2p.ReplicaVoteChannel, p2p.ReplicaNextViewChannel, p2p.TxsChannel, p2p.EvidenceChannel, p2p.BlockTxsChannel, p2p.BlockPartChannel, p2p.BlockFetchChannel},
 		RPCAddress:  "",
 		TxIndex:     "on",
 		CryptoBLS12: bls12.NewCryptoBLS12(),
 	}
-	nodeInfo.CryptoBLS12.Init(nodeKey.PrivateKey)
+	nodeInfo.CryptoBLS12.InitWithPublicKey(nodeKey.PublicKey)
 
 	application := provider.ApplicationProvider(cfg)
 	proxyAppConns := proxy.NewAppConns(application, logger)
@@ -200,22 +303,53 @@ func NewNode(cfg *config.Config, logger log.Logger, provider Provider) (*Node, e
 		return nil, err
 	}
 
+	// 与应用握手，重新执行应用或状态落后于区块存储的区块，三者无法对齐时直接退出
+	handshaker := state2.NewHandshaker(stateStore, blockStore, logger.New("module", "handshake"))
+	if stat, err = handshaker.Handshake(stat, proxyAppConns.Consensus()); err != nil {
+		return nil, fmt.Errorf("error during handshake: %w", err)
+	}
+
 	txsPool, txsPoolReactor := provider.TxspoolProvider(cfg, proxyAppConns, stat, logger)
 	txsPool.SetLogger(logger)
 
-	blockExec := state2.NewBlockExecutor(cfg, stateStore, blockStore, proxyAppConns.Consensus(), txsPool, logger.New("module", "state"))
+	evidenceDB, err := provider.DBProvider("evidence", cfg)
+	if err != nil {
+		return nil, err
+	}
+	evidencePool, evidenceReactor, err := provider.EvidenceProvider(evidenceDB, stat, logger)
+	if err != nil {
+		return nil, err
+	}
+	evidencePool.SetStateStore(stateStore)
+
+	blockExec := state2.NewBlockExecutor(stateStore, blockStore, proxyAppConns.Consensus(), txsPool, evidencePool, logger.New("module", "state"))
+	pruner := state2.NewPruner(stateStore, blockStore, cfg.BasicConfig.MinRetainBlocks, logger.New("module", "Pruner"))
+	blockExec.SetPruner(pruner)
 
-	consensusCore, consensusReactor := provider.ConsensusProvider(cfg, stat, blockExec, txsPool, nodeKey.PrivateKey, nodeInfo.CryptoBLS12, logger)
+	consensusCore, consensusReactor := provider.ConsensusProvider(cfg, stat, blockExec, txsPool, evidencePool, signer, nodeInfo.CryptoBLS12, logger)
 	consensusCore.SetEventBus(eventBus)
 
 	syncerReactor := provider.SyncerProvider(stat, blockExec, blockStore, logger)
 
+	// 只有还没有任何区块的新节点才做状态同步，状态同步完成后再开始同步区块
+	var stateSyncState *state2.State
+	if cfg.StateSyncConfig != nil && cfg.StateSyncConfig.Enable {
+		if blockStore.Height() == 0 {
+			stateSyncState = stat
+			syncerReactor.WaitForStateSync()
+		} else {
+			logger.Info("found local blocks, skip state sync", "height", blockStore.Height())
+		}
+	}
+	stateSyncReactor := provider.StateSyncProvider(cfg, proxyAppConns, stateStore, blockStore, logger)
+
 	stchReactor := provider.STCHProvider(nodeInfo.ID(), len(cfg.P2PConfig.NeighboursSlice()), logger)
 	stchReactor.Chameleon().Init(kp)
+	stchReactor.Chameleon().SetByzantineConfig(cfg.ByzantineConfig)
 	stat.SetChameleon(stchReactor.Chameleon())
 	stat.SetBlockStore(blockStore)
 	stchReactor.Chameleon().SetBlockStore(blockStore)
-	transport, sw := provider.P2PProvider(cfg, nodeInfo, nodeKey, txsPoolReactor, consensusReactor, syncerReactor, stchReactor, logger)
+	transport, sw := provider.P2PProvider(cfg, nodeInfo, nodeKey, txsPoolReactor, consensusReactor, syncerReactor, stchReactor, evidenceReactor, stateSyncReactor, logger)
 
 	addrBook := p2p.NewAddrBook(cfg.P2PConfig.AddrBookPath())
 	if cfg.P2PConfig.ListenAddress != "" {
@@ -239,9 +373,14 @@ func NewNode(cfg *config.Config, logger log.Logger, provider Provider) (*Node, e
 		eventBUs:         eventBus,
 		stateStore:       stateStore,
 		blockStore:       blockStore,
+		pruner:           pruner,
 		txsPool:          txsPool,
+		evidencePool:     evidencePool,
 		txsPoolReactor:   txsPoolReactor,
 		consensusReactor: consensusReactor,
+		syncerReactor:    syncerReactor,
+		stateSyncReactor: stateSyncReactor,
+		stateSyncState:   stateSyncState,
 	}
 	return n, nil
 }
@@ -262,10 +401,40 @@ func (n *Node) Start() error {
 		return err
 	}
 
+	if err := n.pruner.Start(); err != nil {
+		return err
+	}
+
 	n.sw.DialPeerAsync(n.cfg.P2PConfig.NeighboursSlice())
+
+	if n.stateSyncState != nil {
+		go n.startStateSync()
+	}
 	return n.BaseService.Start()
 }
 
+// startStateSync 用其他节点的快照恢复应用的状态，然后从快照的高度开始同步区块，追上其他节点后再切换到共识。
+func (n *Node) startStateSync() {
+	stat, err := n.stateSyncReactor.Sync(n.stateSyncState)
+	if err != nil {
+		n.Logger.Error("state sync failed", "err", err)
+		return
+	}
+	if err = n.syncerReactor.SwitchToSyncer(stat); err != nil {
+		n.Logger.Error("failed to switch to syncer", "err", err)
+	}
+}
+
 func (n *Node) State() *state2.State {
 	return n.consensusReactor.State()
 }
+
+// Validators 返回为高度为height的区块投票的验证者集合。
+func (n *Node) Validators(height int64) (*types.ValidatorSet, error) {
+	return n.stateStore.LoadValidators(height)
+}
+
+// BlockResults 返回应用执行高度为height的区块时返回的全部结果。
+func (n *Node) BlockResults(height int64) (*pbabci.ABCIResponses, error) {
+	return n.stateStore.LoadABCIResponses(height)
+}
@@ -24,8 +24,11 @@ type NodeKey struct {
 //	---------------------------------------------------------
 //
 // GetID 根据BLS12-381密钥信息获取对应的ID：去公钥的前10个字节，将这10个字节编码成16进制的字符串，
-// 以此字符串作为节点的ID。
+// 以此字符串作为节点的ID。私钥保存在独立的签名进程里时，节点密钥文件里可以只有公钥。
 func (key *NodeKey) GetID() crypto.ID {
+	if key.PublicKey != nil {
+		return key.PublicKey.ToID()
+	}
 	return key.PrivateKey.PublicKey().ToID()
 }
 
@@ -48,6 +51,9 @@ func (key *NodeKey) SaveAs(filePath string) error {
 //
 // String 返回节点密钥的字符串形式：NodeKey{PrivateKey:"BLS12-381 PRIVATE KEY":{33184469658132716532202857962421420469965768660734559330213063713395516800091}}
 func (key *NodeKey) String() string {
+	if key.PrivateKey == nil {
+		return fmt.Sprintf("NodeKey{PublicKey:%v}", key.GetID())
+	}
 	return fmt.Sprintf("NodeKey{PrivateKey:%v}", key.PrivateKey.String())
 }
 
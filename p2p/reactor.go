@@ -10,6 +10,12 @@ const (
 	TxsChannel             byte = 0x05
 	SyncerChannel          byte = 0x06
 	STCHChannel            byte = 0x07
+	EvidenceChannel        byte = 0x08
+	BlockTxsChannel        byte = 0x09 // 副本节点还原紧凑区块时，向主节点索要缺少的交易
+	BlockPartChannel       byte = 0x0a // 主节点提出的Prepare消息被切分成分片后在该通道上传播
+	BlockFetchChannel      byte = 0x0b // 错过了Prepare消息的副本节点按照变色龙哈希向其他节点索要区块
+	SnapshotChannel        byte = 0x0c // 状态同步时发现快照以及获取轻区块
+	ChunkChannel           byte = 0x0d // 状态同步时获取快照的分块
 )
 
 type Reactor interface {
@@ -0,0 +1,107 @@
+package privval
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/proto/pbprivval"
+	"github.com/cosmos/gogoproto/proto"
+	"net"
+	"strings"
+	"time"
+)
+
+// maxRemoteSignerMsgSize 签名进程与节点之间传输的单条消息的最大字节数。
+const maxRemoteSignerMsgSize = 1024 * 10
+
+// wrapMsg 将签名请求或响应包装成pbprivval.Message。
+func wrapMsg(pb proto.Message) *pbprivval.Message {
+	msg := &pbprivval.Message{}
+	switch pb := pb.(type) {
+	case *pbprivval.PubKeyRequest:
+		msg.Sum = &pbprivval.Message_PubKeyRequest{PubKeyRequest: pb}
+	case *pbprivval.PubKeyResponse:
+		msg.Sum = &pbprivval.Message_PubKeyResponse{PubKeyResponse: pb}
+	case *pbprivval.SignVoteRequest:
+		msg.Sum = &pbprivval.Message_SignVoteRequest{SignVoteRequest: pb}
+	case *pbprivval.SignPrepareRequest:
+		msg.Sum = &pbprivval.Message_SignPrepareRequest{SignPrepareRequest: pb}
+	case *pbprivval.SignNewViewRequest:
+		msg.Sum = &pbprivval.Message_SignNewViewRequest{SignNewViewRequest: pb}
+	case *pbprivval.SignChainedProposalRequest:
+		msg.Sum = &pbprivval.Message_SignChainedProposalRequest{SignChainedProposalRequest: pb}
+	case *pbprivval.SignChainedVoteRequest:
+		msg.Sum = &pbprivval.Message_SignChainedVoteRequest{SignChainedVoteRequest: pb}
+	case *pbprivval.SignChainedNewViewRequest:
+		msg.Sum = &pbprivval.Message_SignChainedNewViewRequest{SignChainedNewViewRequest: pb}
+	case *pbprivval.SignTimeoutRequest:
+		msg.Sum = &pbprivval.Message_SignTimeoutRequest{SignTimeoutRequest: pb}
+	case *pbprivval.SignatureResponse:
+		msg.Sum = &pbprivval.Message_SignatureResponse{SignatureResponse: pb}
+	case *pbprivval.PingRequest:
+		msg.Sum = &pbprivval.Message_PingRequest{PingRequest: pb}
+	case *pbprivval.PingResponse:
+		msg.Sum = &pbprivval.Message_PingResponse{PingResponse: pb}
+	default:
+		panic(fmt.Sprintf("unknown remote signer message type %T", pb))
+	}
+	return msg
+}
+
+// RemoteSignerError 签名进程返回的错误，例如因为可能重复签名而拒绝签名。
+type RemoteSignerError struct {
+	Description string
+}
+
+func (e *RemoteSignerError) Error() string {
+	return fmt.Sprintf("remote signer error: %s", e.Description)
+}
+
+func newRemoteSignerError(err error) *pbprivval.RemoteSignerError {
+	if err == nil {
+		return nil
+	}
+	return &pbprivval.RemoteSignerError{Description: err.Error()}
+}
+
+// parseAddress 将unix:///path/to/signer.sock或者tcp://10.0.0.2:26659形式的地址拆分成网络类型和地址。签名进程与节点
+// 之间的连接都会经过SecretConnection的认证和加密，因此签名进程和节点可以部署在不同的主机上。
+func parseAddress(addr string) (string, string, error) {
+	parts := strings.SplitN(addr, "://", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid remote signer address %q, want unix://<path> or tcp://<host>:<port>", addr)
+	}
+	switch parts[0] {
+	case "unix":
+		return parts[0], parts[1], nil
+	case "tcp":
+		if _, _, err := net.SplitHostPort(parts[1]); err != nil {
+			return "", "", fmt.Errorf("invalid remote signer address %q: %w", addr, err)
+		}
+		return parts[0], parts[1], nil
+	default:
+		return "", "", errors.New("remote signer only supports unix and tcp protocols")
+	}
+}
+
+// dial 连接到签名进程，并在timeout之内完成SecretConnection握手，connKey是节点的连接私钥，signerKey是签名进程的连接公钥。
+func dial(addr string, timeout time.Duration, connKey ed25519.PrivateKey, signerKey ed25519.PublicKey) (net.Conn, error) {
+	network, address, err := parseAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.DialTimeout(network, address, timeout)
+	if err != nil {
+		return nil, err
+	}
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+	sc, err := MakeSecretConnection(conn, connKey, signerKey)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf("failed to authenticate remote signer %s: %w", addr, err)
+	}
+	return sc, nil
+}
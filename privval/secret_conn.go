@@ -0,0 +1,304 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mos "github.com/232425wxy/meta--/common/os"
+	"io"
+	"math"
+	"net"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	// secretConnDataMaxSize 每一帧里明文的最大字节数。
+	secretConnDataMaxSize = 1024
+	secretConnKeySize     = 32
+	secretConnNonceSize   = 12
+	// secretConnInfo 派生会话密钥时使用的上下文信息。
+	secretConnInfo = "META-- REMOTE SIGNER SECRET CONNECTION"
+	// secretConnHandshakeTimeout 签名进程等待节点完成握手的最长时间。
+	secretConnHandshakeTimeout = 3 * time.Second
+)
+
+var ErrUnexpectedRemoteKey = errors.New("unexpected remote connection key")
+
+// SecretConnection 签名进程与节点之间经过认证和加密的连接。握手时双方先交换临时的X25519公钥，由共享密钥派生出两个方向
+// 的AES-256-GCM密钥和一个挑战值，然后双方在加密的信道里交换各自的ed25519连接公钥以及对挑战值的签名。挑战值由双方的临时
+// 公钥决定，中间人无法转发对方的签名；对方的连接公钥必须与本地事先配置的公钥一致，否则握手失败。
+type SecretConnection struct {
+	net.Conn
+	remoteKey ed25519.PublicKey
+
+	sendMu    sync.Mutex
+	sendAEAD  cipher.AEAD
+	sendNonce uint64
+
+	recvMu    sync.Mutex
+	recvAEAD  cipher.AEAD
+	recvNonce uint64
+	recvBuf   []byte
+}
+
+// MakeSecretConnection 在conn上与对方握手，connKey是本地的连接私钥，remoteKey是事先配置的对方的连接公钥。调用者负责为
+// conn设置握手的超时时间。
+func MakeSecretConnection(conn net.Conn, connKey ed25519.PrivateKey, remoteKey ed25519.PublicKey) (*SecretConnection, error) {
+	if len(connKey) != ed25519.PrivateKeySize {
+		return nil, errors.New("invalid local connection key")
+	}
+	if len(remoteKey) != ed25519.PublicKeySize {
+		return nil, errors.New("invalid remote connection key")
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	localEph := ephemeral.PublicKey().Bytes()
+	bz, err := exchange(conn, localEph, len(localEph))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange ephemeral keys: %w", err)
+	}
+	if bytes.Equal(bz, localEph) {
+		return nil, errors.New("remote ephemeral key is the same as ours")
+	}
+	remoteEph, err := ecdh.X25519().NewPublicKey(bz)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(remoteEph)
+	if err != nil {
+		return nil, err
+	}
+
+	// 临时公钥较小的一方用第一个密钥加密，用第二个密钥解密，另一方反之
+	lo, hi := localEph, bz
+	localIsLo := bytes.Compare(localEph, bz) < 0
+	if !localIsLo {
+		lo, hi = bz, localEph
+	}
+	okm := deriveSecrets(shared, append(append([]byte{}, lo...), hi...), 3*secretConnKeySize)
+	sendKey, recvKey := okm[:secretConnKeySize], okm[secretConnKeySize:2*secretConnKeySize]
+	if !localIsLo {
+		sendKey, recvKey = recvKey, sendKey
+	}
+	challenge := okm[2*secretConnKeySize:]
+
+	sc := &SecretConnection{Conn: conn}
+	if sc.sendAEAD, err = newAEAD(sendKey); err != nil {
+		return nil, err
+	}
+	if sc.recvAEAD, err = newAEAD(recvKey); err != nil {
+		return nil, err
+	}
+
+	// 在加密的信道里交换连接公钥和对挑战值的签名
+	pub := connKey.Public().(ed25519.PublicKey)
+	auth := append(append([]byte{}, pub...), ed25519.Sign(connKey, challenge)...)
+	bz, err = exchange(sc, auth, ed25519.PublicKeySize+ed25519.SignatureSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange connection keys: %w", err)
+	}
+	remotePub, remoteSig := ed25519.PublicKey(bz[:ed25519.PublicKeySize]), bz[ed25519.PublicKeySize:]
+	if !ed25519.Verify(remotePub, challenge, remoteSig) {
+		return nil, errors.New("invalid handshake signature from remote")
+	}
+	if !remotePub.Equal(remoteKey) {
+		return nil, fmt.Errorf("%w: want %X, got %X", ErrUnexpectedRemoteKey, []byte(remoteKey), []byte(remotePub))
+	}
+	sc.remoteKey = remotePub
+	return sc, nil
+}
+
+// RemoteKey 返回对方的连接公钥。
+func (sc *SecretConnection) RemoteKey() ed25519.PublicKey {
+	return sc.remoteKey
+}
+
+// Write 将数据切分成若干帧，每一帧加密后以4个字节的长度作为前缀发送出去。
+func (sc *SecretConnection) Write(data []byte) (int, error) {
+	sc.sendMu.Lock()
+	defer sc.sendMu.Unlock()
+	n := 0
+	for len(data) > 0 {
+		chunk := data
+		if len(chunk) > secretConnDataMaxSize {
+			chunk = chunk[:secretConnDataMaxSize]
+		}
+		nonce, err := nextNonce(&sc.sendNonce)
+		if err != nil {
+			return n, err
+		}
+		frame := make([]byte, 4, 4+len(chunk)+sc.sendAEAD.Overhead())
+		frame = sc.sendAEAD.Seal(frame, nonce, chunk, nil)
+		binary.BigEndian.PutUint32(frame[:4], uint32(len(frame)-4))
+		if _, err = sc.Conn.Write(frame); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		data = data[len(chunk):]
+	}
+	return n, nil
+}
+
+// Read 读取并解密一帧数据，p装不下的部分留到下一次读取。
+func (sc *SecretConnection) Read(p []byte) (int, error) {
+	sc.recvMu.Lock()
+	defer sc.recvMu.Unlock()
+	if len(sc.recvBuf) > 0 {
+		n := copy(p, sc.recvBuf)
+		sc.recvBuf = sc.recvBuf[n:]
+		return n, nil
+	}
+	var header [4]byte
+	if _, err := io.ReadFull(sc.Conn, header[:]); err != nil {
+		return 0, err
+	}
+	size := binary.BigEndian.Uint32(header[:])
+	if size > uint32(secretConnDataMaxSize+sc.recvAEAD.Overhead()) {
+		return 0, fmt.Errorf("secret connection frame too large: %d", size)
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(sc.Conn, sealed); err != nil {
+		return 0, err
+	}
+	nonce, err := nextNonce(&sc.recvNonce)
+	if err != nil {
+		return 0, err
+	}
+	plain, err := sc.recvAEAD.Open(sealed[:0], nonce, sealed, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrypt secret connection frame: %w", err)
+	}
+	n := copy(p, plain)
+	sc.recvBuf = plain[n:]
+	return n, nil
+}
+
+// exchange 同时发送out并读取对方的inLen个字节，避免双方都在等待对方读取。
+func exchange(rw io.ReadWriter, out []byte, inLen int) ([]byte, error) {
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := rw.Write(out)
+		errCh <- err
+	}()
+	in := make([]byte, inLen)
+	_, readErr := io.ReadFull(rw, in)
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	if readErr != nil {
+		return nil, readErr
+	}
+	return in, nil
+}
+
+// deriveSecrets 按照HKDF-SHA256从共享密钥派生出size个字节。
+func deriveSecrets(secret, salt []byte, size int) []byte {
+	extract := hmac.New(sha256.New, salt)
+	extract.Write(secret)
+	prk := extract.Sum(nil)
+	okm := make([]byte, 0, size)
+	var block []byte
+	for counter := byte(1); len(okm) < size; counter++ {
+		expand := hmac.New(sha256.New, prk)
+		expand.Write(block)
+		expand.Write([]byte(secretConnInfo))
+		expand.Write([]byte{counter})
+		block = expand.Sum(nil)
+		okm = append(okm, block...)
+	}
+	return okm[:size]
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// nextNonce 每一帧使用递增的计数器作为nonce，计数器用尽之前必须重新建立连接。
+func nextNonce(counter *uint64) ([]byte, error) {
+	if *counter == math.MaxUint64 {
+		return nil, errors.New("secret connection nonce exhausted")
+	}
+	nonce := make([]byte, secretConnNonceSize)
+	binary.BigEndian.PutUint64(nonce[secretConnNonceSize-8:], *counter)
+	*counter++
+	return nonce, nil
+}
+
+// connKeyJSON 连接私钥文件的格式。
+type connKeyJSON struct {
+	PrivateKey string `json:"private_key"`
+	PublicKey  string `json:"public_key"`
+}
+
+// LoadOrGenConnKey 从给定的文件里读取ed25519连接私钥，如果文件不存在，则生成一个新的私钥并存储到文件里。节点和签名进程
+// 各自持有一个连接私钥，对方通过配置的连接公钥认证自己。
+func LoadOrGenConnKey(filePath string) (ed25519.PrivateKey, error) {
+	if mos.FileExists(filePath) {
+		bz, err := mos.ReadFile(filePath)
+		if err != nil {
+			return nil, err
+		}
+		ck := new(connKeyJSON)
+		if err = json.Unmarshal(bz, ck); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal connection key from %s: %w", filePath, err)
+		}
+		key, err := hex.DecodeString(ck.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection key in %s: %w", filePath, err)
+		}
+		if len(key) != ed25519.PrivateKeySize {
+			return nil, fmt.Errorf("invalid connection key in %s: want %d bytes, got %d", filePath, ed25519.PrivateKeySize, len(key))
+		}
+		return key, nil
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := json.MarshalIndent(connKeyJSON{PrivateKey: hex.EncodeToString(key), PublicKey: ConnKeyString(key)}, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+	if err = mos.EnsureDir(filepath.Dir(filePath), 0700); err != nil {
+		return nil, err
+	}
+	if err = mos.WriteFile(filePath, bz, 0600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// ConnKeyString 返回连接私钥对应的公钥的16进制编码，对方需要把它配置为可信的连接公钥。
+func ConnKeyString(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+// ParseConnPublicKey 解析16进制编码的ed25519连接公钥。
+func ParseConnPublicKey(s string) (ed25519.PublicKey, error) {
+	bz, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid connection public key %q: %w", s, err)
+	}
+	if len(bz) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid connection public key %q: want %d bytes, got %d", s, ed25519.PublicKeySize, len(bz))
+	}
+	return bz, nil
+}
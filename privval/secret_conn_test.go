@@ -0,0 +1,90 @@
+package privval
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+// makeSecretConnPair 在net.Pipe的两端同时握手。
+func makeSecretConnPair(t *testing.T, aKey, bKey ed25519.PrivateKey, aPin, bPin ed25519.PublicKey) (*SecretConnection, *SecretConnection, error, error) {
+	a, b := net.Pipe()
+	t.Cleanup(func() {
+		_ = a.Close()
+		_ = b.Close()
+	})
+	type result struct {
+		sc  *SecretConnection
+		err error
+	}
+	ch := make(chan result, 1)
+	go func() {
+		sc, err := MakeSecretConnection(b, bKey, bPin)
+		if err != nil {
+			_ = b.Close()
+		}
+		ch <- result{sc, err}
+	}()
+	sa, errA := MakeSecretConnection(a, aKey, aPin)
+	if errA != nil {
+		_ = a.Close()
+	}
+	res := <-ch
+	return sa, res.sc, errA, res.err
+}
+
+func TestSecretConnection(t *testing.T) {
+	nodeKey, signerKey := newTestConnKeys(t)
+	node, signer, err1, err2 := makeSecretConnPair(t, nodeKey, signerKey, connPublicKey(signerKey), connPublicKey(nodeKey))
+	assert.Nil(t, err1)
+	assert.Nil(t, err2)
+	assert.Equal(t, connPublicKey(signerKey), node.RemoteKey())
+	assert.Equal(t, connPublicKey(nodeKey), signer.RemoteKey())
+
+	// 超过一帧的数据会被切分成多帧发送
+	msg := bytes.Repeat([]byte("meta--"), secretConnDataMaxSize)
+	go func() { _, _ = node.Write(msg) }()
+	got := make([]byte, len(msg))
+	_, err := io.ReadFull(signer, got)
+	assert.Nil(t, err)
+	assert.Equal(t, msg, got)
+
+	go func() { _, _ = signer.Write([]byte("pong")) }()
+	got = make([]byte, 4)
+	_, err = io.ReadFull(node, got)
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("pong"), got)
+}
+
+func TestSecretConnectionRejectsUnexpectedKey(t *testing.T) {
+	nodeKey, signerKey := newTestConnKeys(t)
+	attackerKey, _ := newTestConnKeys(t)
+
+	// 冒充签名进程的一方没有签名进程的私钥
+	_, _, err, _ := makeSecretConnPair(t, nodeKey, attackerKey, connPublicKey(signerKey), connPublicKey(nodeKey))
+	assert.True(t, errors.Is(err, ErrUnexpectedRemoteKey))
+
+	// 冒充节点的一方没有节点的私钥
+	_, _, _, err = makeSecretConnPair(t, attackerKey, signerKey, connPublicKey(signerKey), connPublicKey(nodeKey))
+	assert.True(t, errors.Is(err, ErrUnexpectedRemoteKey))
+}
+
+func TestConnKeyReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "config", "signer_conn_key.json")
+	key, err := LoadOrGenConnKey(file)
+	assert.Nil(t, err)
+	reloaded, err := LoadOrGenConnKey(file)
+	assert.Nil(t, err)
+	assert.Equal(t, key, reloaded)
+
+	pub, err := ParseConnPublicKey(ConnKeyString(key))
+	assert.Nil(t, err)
+	assert.Equal(t, connPublicKey(key), pub)
+	_, err = ParseConnPublicKey("abcd")
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,158 @@
+package privval
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mos "github.com/232425wxy/meta--/common/os"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/proto/pbcrypto"
+	"os"
+	"path/filepath"
+)
+
+var (
+	ErrHeightRegression = errors.New("height regression")
+	ErrRoundRegression  = errors.New("round regression")
+	ErrStepRegression   = errors.New("step regression")
+	ErrConflictingData  = errors.New("conflicting data")
+)
+
+// LastSignState 记录验证者最后一次签名的高度、轮次、阶段以及被签名的内容，在签名被发送出去之前，LastSignState
+// 会先被写入到磁盘里，这样即便节点在签名后崩溃并重启，也不会在同一高度、同一轮次、同一阶段为不同的内容签名。
+type LastSignState struct {
+	Height    int64  `json:"height"`
+	Round     int16  `json:"round"`
+	Step      int8   `json:"step"`
+	ValueHash []byte `json:"value_hash,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+
+	filePath string
+}
+
+// LoadOrGenLastSignState 从给定的文件里读取最后一次签名的状态，如果文件不存在，则新建一个空的状态并存储到文件里。
+// 如果filePath为空，则返回的状态只保存在内存里。
+func LoadOrGenLastSignState(filePath string) (*LastSignState, error) {
+	if filePath == "" {
+		return &LastSignState{}, nil
+	}
+	if mos.FileExists(filePath) {
+		return LoadLastSignState(filePath)
+	}
+	lss := &LastSignState{filePath: filePath}
+	if err := mos.EnsureDir(filepath.Dir(filePath), 0700); err != nil {
+		return nil, err
+	}
+	if err := lss.save(); err != nil {
+		return nil, err
+	}
+	return lss, nil
+}
+
+// LoadLastSignState 从给定的文件里读取最后一次签名的状态。
+func LoadLastSignState(filePath string) (*LastSignState, error) {
+	bz, err := mos.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	lss := &LastSignState{}
+	if err = json.Unmarshal(bz, lss); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal last sign state from %s: %w", filePath, err)
+	}
+	lss.filePath = filePath
+	return lss, nil
+}
+
+// CheckHRS 检查给定的高度、轮次和阶段是否在最后一次签名之后，如果与最后一次签名的高度、轮次和阶段完全相同，则返回
+// true，此时调用者需要确认待签名的内容与上一次签名的内容一致；如果早于最后一次签名，则返回错误。
+func (lss *LastSignState) CheckHRS(height int64, round int16, step int8) (bool, error) {
+	if lss.Height > height {
+		return false, fmt.Errorf("%w: last sign height %d, got %d", ErrHeightRegression, lss.Height, height)
+	}
+	if lss.Height == height {
+		if lss.Round > round {
+			return false, fmt.Errorf("%w at height %d: last sign round %d, got %d", ErrRoundRegression, height, lss.Round, round)
+		}
+		if lss.Round == round {
+			if lss.Step > step {
+				return false, fmt.Errorf("%w at height %d round %d: last sign step %d, got %d", ErrStepRegression, height, round, lss.Step, step)
+			}
+			if lss.Step == step {
+				if lss.Signature == nil {
+					return false, errors.New("last sign state has no signature for the same height, round and step")
+				}
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Sign 在检查通过后对valueHash进行签名，并在返回签名之前将签名状态持久化到磁盘里。如果在相同的高度、轮次和阶段
+// 已经对相同的内容签过名，则直接返回之前的签名；如果内容不同，则拒绝签名。
+func (lss *LastSignState) Sign(height int64, round int16, step int8, valueHash []byte, privateKey *bls12.PrivateKey) (*bls12.Signature, error) {
+	sameHRS, err := lss.CheckHRS(height, round, step)
+	if err != nil {
+		return nil, err
+	}
+	if sameHRS {
+		if !bytes.Equal(lss.ValueHash, valueHash) {
+			return nil, fmt.Errorf("%w: already signed %X at height %d round %d step %d, refuse to sign %X", ErrConflictingData, lss.ValueHash, height, round, step, valueHash)
+		}
+		pb := new(pbcrypto.Signature)
+		if err = pb.Unmarshal(lss.Signature); err != nil {
+			return nil, err
+		}
+		return bls12.SignatureFromProto(pb), nil
+	}
+	sig, err := privateKey.Sign(valueHash)
+	if err != nil {
+		return nil, err
+	}
+	bz, err := sig.ToProto().Marshal()
+	if err != nil {
+		return nil, err
+	}
+	lss.Height = height
+	lss.Round = round
+	lss.Step = step
+	lss.ValueHash = valueHash
+	lss.Signature = bz
+	if err = lss.save(); err != nil {
+		return nil, fmt.Errorf("failed to persist last sign state: %w", err)
+	}
+	return sig, nil
+}
+
+func (lss *LastSignState) String() string {
+	return fmt.Sprintf("LastSignState{%d/%d/%d %X}", lss.Height, lss.Round, lss.Step, lss.ValueHash)
+}
+
+// save 先将状态写入临时文件并同步到磁盘，然后再重命名为目标文件，保证文件内容要么是旧的状态，要么是新的状态。
+func (lss *LastSignState) save() error {
+	if lss.filePath == "" {
+		return nil
+	}
+	bz, err := json.MarshalIndent(lss, "", "\t")
+	if err != nil {
+		return err
+	}
+	tmp := lss.filePath + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = f.Write(bz); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, lss.filePath)
+}
@@ -0,0 +1,63 @@
+package privval
+
+import (
+	"errors"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+func TestLastSignStateSign(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	file := filepath.Join(t.TempDir(), "data", "sign_state.json")
+	lss, err := LoadOrGenLastSignState(file)
+	assert.Nil(t, err)
+
+	sig, err := lss.Sign(3, 1, 3, []byte("value a"), privateKey)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(sig, []byte("value a")))
+
+	// 相同的高度、轮次和阶段，相同的内容，返回之前的签名
+	again, err := lss.Sign(3, 1, 3, []byte("value a"), privateKey)
+	assert.Nil(t, err)
+	assert.Equal(t, sig.ToProto(), again.ToProto())
+
+	// 相同的高度、轮次和阶段，不同的内容，拒绝签名
+	_, err = lss.Sign(3, 1, 3, []byte("value b"), privateKey)
+	assert.True(t, errors.Is(err, ErrConflictingData))
+
+	_, err = lss.Sign(3, 1, 2, []byte("value a"), privateKey)
+	assert.True(t, errors.Is(err, ErrStepRegression))
+	_, err = lss.Sign(2, 5, 5, []byte("value a"), privateKey)
+	assert.True(t, errors.Is(err, ErrHeightRegression))
+
+	_, err = lss.Sign(3, 2, 3, []byte("value b"), privateKey)
+	assert.Nil(t, err)
+	_, err = lss.Sign(3, 1, 7, []byte("value a"), privateKey)
+	assert.True(t, errors.Is(err, ErrRoundRegression))
+}
+
+func TestLastSignStateReload(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	file := filepath.Join(t.TempDir(), "sign_state.json")
+	lss, err := LoadOrGenLastSignState(file)
+	assert.Nil(t, err)
+	sig, err := lss.Sign(10, 2, 5, []byte("value a"), privateKey)
+	assert.Nil(t, err)
+
+	// 模拟节点重启
+	reloaded, err := LoadOrGenLastSignState(file)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(10), reloaded.Height)
+	assert.Equal(t, int16(2), reloaded.Round)
+	assert.Equal(t, int8(5), reloaded.Step)
+
+	again, err := reloaded.Sign(10, 2, 5, []byte("value a"), privateKey)
+	assert.Nil(t, err)
+	assert.Equal(t, sig.ToProto(), again.ToProto())
+	_, err = reloaded.Sign(10, 2, 5, []byte("value b"), privateKey)
+	assert.True(t, errors.Is(err, ErrConflictingData))
+	_, err = reloaded.Sign(10, 2, 3, []byte("value a"), privateKey)
+	assert.True(t, errors.Is(err, ErrStepRegression))
+}
@@ -0,0 +1,254 @@
+package privval
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// 签名所处的阶段，数值与consensus.Step保持一致，阶段越靠后数值越大。
+const (
+	stepNewView       int8 = 1
+	stepPrepare       int8 = 2
+	stepPrepareVote   int8 = 3
+	stepPreCommitVote int8 = 5
+	stepCommitVote    int8 = 7
+	stepTimeout       int8 = 9
+)
+
+// 链式HotStuff按照视图推进，它的签名状态与四阶段、两阶段共识的签名状态分开保存，签名状态里的Height记录的是视图，
+// Round固定为1，同一视图里主节点先为提案签名，再为自己的投票签名，因此阶段沿用Prepare和PrepareVote的数值。节点在
+// 进入新视图时为ChainedNewView签名，它排在该视图的提案和投票之前。
+const (
+	chainedRound        int16 = 1
+	stepChainedNewView        = stepNewView
+	stepChainedProposal       = stepPrepare
+	stepChainedVote           = stepPrepareVote
+)
+
+// LocalSigner 在本地进程里用私钥为共识消息签名，每次为共识消息签名之前都会检查并持久化最后一次签名的状态，
+// 拒绝在同一高度、同一轮次、同一阶段为不同的内容签名。signState记录四阶段和两阶段共识按高度推进的签名状态，
+// chainedState记录链式HotStuff按视图推进的签名状态，视图和高度不能互相比较，所以二者不能共用一个签名状态。
+type LocalSigner struct {
+	mu           sync.Mutex
+	privateKey   *bls12.PrivateKey
+	signState    *LastSignState
+	chainedState *LastSignState
+}
+
+var _ types.Signer = (*LocalSigner)(nil)
+
+func NewLocalSigner(privateKey *bls12.PrivateKey, signState, chainedState *LastSignState) *LocalSigner {
+	if signState == nil {
+		signState = &LastSignState{}
+	}
+	if chainedState == nil {
+		chainedState = &LastSignState{}
+	}
+	return &LocalSigner{privateKey: privateKey, signState: signState, chainedState: chainedState}
+}
+
+// LoadLocalSigner 加载签名状态文件，创建一个本地签名者，链式HotStuff的签名状态保存在ChainedSignStateFile返回的文件里。
+func LoadLocalSigner(privateKey *bls12.PrivateKey, signStateFile string) (*LocalSigner, error) {
+	if privateKey == nil {
+		return nil, errors.New("private key is required by local signer")
+	}
+	signState, err := LoadOrGenLastSignState(signStateFile)
+	if err != nil {
+		return nil, err
+	}
+	chainedState, err := LoadOrGenLastSignState(ChainedSignStateFile(signStateFile))
+	if err != nil {
+		return nil, err
+	}
+	return NewLocalSigner(privateKey, signState, chainedState), nil
+}
+
+// ChainedSignStateFile 根据签名状态文件的路径得到链式HotStuff签名状态文件的路径，例如sign_state.json对应
+// sign_state_chained.json；如果signStateFile为空，则返回空字符串，此时链式HotStuff的签名状态也只保存在内存里。
+func ChainedSignStateFile(signStateFile string) string {
+	if signStateFile == "" {
+		return ""
+	}
+	ext := filepath.Ext(signStateFile)
+	return strings.TrimSuffix(signStateFile, ext) + "_chained" + ext
+}
+
+func (s *LocalSigner) PublicKey() *bls12.PublicKey {
+	return s.privateKey.PublicKey()
+}
+
+func (s *LocalSigner) SignVote(vote *types.Vote) error {
+	sig, err := s.signVote(vote)
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+func (s *LocalSigner) SignPrepare(prepare *types.Prepare) error {
+	if prepare.Block == nil || prepare.Block.ChameleonHash == nil {
+		return errors.New("cannot sign Prepare without block hash")
+	}
+	sig, err := s.signPrepare(prepare.Height, prepare.Round, prepare.Block.ChameleonHash.Hash)
+	if err != nil {
+		return err
+	}
+	prepare.Signature = sig
+	return nil
+}
+
+// SignNewView 节点在进入新的轮次时为NewView消息签名，签名状态停留在该轮次的第一个阶段，同一轮次不会为携带不同highQC的
+// NewView消息签名，也不会在为更靠后的轮次签过名之后再为这一轮的NewView消息签名。
+func (s *LocalSigner) SignNewView(view *types.NewView) error {
+	sig, err := s.signNewView(view)
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+func (s *LocalSigner) SignChainedProposal(proposal *types.ChainedProposal) error {
+	if proposal.Block == nil || proposal.Block.Header == nil || proposal.Block.ChameleonHash == nil {
+		return errors.New("cannot sign ChainedProposal without block hash")
+	}
+	sig, err := s.signChainedProposal(proposal.View, proposal.Block.Header.Height, proposal.Block.ChameleonHash.Hash)
+	if err != nil {
+		return err
+	}
+	proposal.Signature = sig
+	return nil
+}
+
+func (s *LocalSigner) SignChainedVote(vote *types.ChainedVote) error {
+	sig, err := s.signChainedVote(vote)
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+// SignChainedNewView 与NewView消息一样，节点在进入新视图时为ChainedNewView消息签名，签名状态停留在该视图的第一个阶段。
+func (s *LocalSigner) SignChainedNewView(view *types.ChainedNewView) error {
+	sig, err := s.signChainedNewView(view)
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+// SignTimeout 两阶段共识里，为某一轮的Timeout消息签名之后，签名状态停留在该轮次的最后一个阶段，此后不会再为这一轮的
+// Prepare消息和投票签名，超时证书因此可以证明没有诚实的验证者会在这一轮继续投票。
+func (s *LocalSigner) SignTimeout(timeout *types.Timeout) error {
+	sig, err := s.signTimeout(timeout)
+	if err != nil {
+		return err
+	}
+	timeout.Signature = sig
+	return nil
+}
+
+func (s *LocalSigner) signVote(vote *types.Vote) (*bls12.Signature, error) {
+	var step int8
+	switch vote.VoteType {
+	case pbtypes.PrepareVoteType:
+		step = stepPrepareVote
+	case pbtypes.PreCommitVoteType:
+		step = stepPreCommitVote
+	case pbtypes.CommitVoteType:
+		step = stepCommitVote
+	default:
+		return nil, fmt.Errorf("unknown vote type: %d", vote.VoteType)
+	}
+	if vote.Round < 1 {
+		return nil, errors.New("round must be greater than 0")
+	}
+	if !bytes.Equal(vote.ValueHash, types.GenerateVoteValueHash(vote.VoteType, vote.Height, vote.Round, vote.BlockHash)) {
+		return nil, errors.New("value hash does not match the vote's height, round and block hash")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signState.Sign(vote.Height, vote.Round, step, vote.ValueHash, s.privateKey)
+}
+
+func (s *LocalSigner) signPrepare(height int64, round int16, blockHash []byte) (*bls12.Signature, error) {
+	if len(blockHash) == 0 {
+		return nil, errors.New("cannot sign Prepare with empty block hash")
+	}
+	if round < 1 {
+		return nil, errors.New("round must be greater than 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signState.Sign(height, round, stepPrepare, types.GeneratePrepareSignBytes(height, round, blockHash), s.privateKey)
+}
+
+func (s *LocalSigner) signNewView(view *types.NewView) (*bls12.Signature, error) {
+	if id := s.PublicKey().ToID(); view.ID != id {
+		return nil, fmt.Errorf("cannot sign NewView for %s, my id is %s", view.ID, id)
+	}
+	if view.Round < 1 {
+		return nil, errors.New("round must be greater than 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signState.Sign(view.Height, view.Round, stepNewView, view.ValueHash(), s.privateKey)
+}
+
+func (s *LocalSigner) signChainedProposal(view, height int64, blockHash []byte) (*bls12.Signature, error) {
+	if len(blockHash) == 0 {
+		return nil, errors.New("cannot sign ChainedProposal with empty block hash")
+	}
+	if view < 1 {
+		return nil, errors.New("view must be greater than 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chainedState.Sign(view, chainedRound, stepChainedProposal, types.GenerateChainedProposalSignBytes(view, height, blockHash), s.privateKey)
+}
+
+func (s *LocalSigner) signChainedVote(vote *types.ChainedVote) (*bls12.Signature, error) {
+	if vote.View < 1 {
+		return nil, errors.New("view must be greater than 0")
+	}
+	if !bytes.Equal(vote.ValueHash, types.GenerateChainedVoteValueHash(vote.View, vote.Height, vote.BlockHash)) {
+		return nil, errors.New("value hash does not match the vote's view, height and block hash")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chainedState.Sign(vote.View, chainedRound, stepChainedVote, vote.ValueHash, s.privateKey)
+}
+
+func (s *LocalSigner) signChainedNewView(view *types.ChainedNewView) (*bls12.Signature, error) {
+	if id := s.PublicKey().ToID(); view.ID != id {
+		return nil, fmt.Errorf("cannot sign ChainedNewView for %s, my id is %s", view.ID, id)
+	}
+	if view.View < 1 {
+		return nil, errors.New("view must be greater than 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.chainedState.Sign(view.View, chainedRound, stepChainedNewView, view.ValueHash(), s.privateKey)
+}
+
+func (s *LocalSigner) signTimeout(timeout *types.Timeout) (*bls12.Signature, error) {
+	if id := s.PublicKey().ToID(); timeout.ID != id {
+		return nil, fmt.Errorf("cannot sign Timeout for %s, my id is %s", timeout.ID, id)
+	}
+	if timeout.Round < 1 {
+		return nil, errors.New("round must be greater than 0")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.signState.Sign(timeout.Height, timeout.Round, stepTimeout, timeout.ValueHash(), s.privateKey)
+}
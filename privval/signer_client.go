@@ -0,0 +1,280 @@
+package privval
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/common/protoio"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbcrypto"
+	"github.com/232425wxy/meta--/proto/pbprivval"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"net"
+	"sync"
+	"time"
+)
+
+// SignerClient 远程签名者的客户端，节点通过它请求独立的签名进程为共识消息签名，节点本地不需要保存验证者的私钥。
+// 连接断开后，下一次请求会重新建立连接；签名进程对相同的请求会返回相同的签名，所以失败的请求可以安全地重试一次。
+// 节点用connKey向签名进程证明自己的身份，并且只接受连接公钥为signerKey的签名进程。
+type SignerClient struct {
+	mu        sync.Mutex
+	addr      string
+	timeout   time.Duration
+	connKey   ed25519.PrivateKey
+	signerKey ed25519.PublicKey
+	conn      net.Conn
+	reader    protoio.Reader
+	writer    protoio.Writer
+	publicKey *bls12.PublicKey
+	logger    log.Logger
+}
+
+var _ types.Signer = (*SignerClient)(nil)
+
+// NewSignerClient 连接到给定地址上的签名进程，并获取验证者的公钥。
+func NewSignerClient(addr string, timeout time.Duration, connKey ed25519.PrivateKey, signerKey ed25519.PublicKey, logger log.Logger) (*SignerClient, error) {
+	if logger == nil {
+		logger = log.New()
+	}
+	sc := &SignerClient{addr: addr, timeout: timeout, connKey: connKey, signerKey: signerKey, logger: logger}
+	resp, err := sc.sendRequest(wrapMsg(&pbprivval.PubKeyRequest{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key from remote signer %s: %w", addr, err)
+	}
+	pkResp := resp.GetPubKeyResponse()
+	if pkResp == nil {
+		return nil, fmt.Errorf("unexpected response %T from remote signer", resp.Sum)
+	}
+	if pkResp.Error != nil {
+		return nil, &RemoteSignerError{Description: pkResp.Error.Description}
+	}
+	if pkResp.PublicKey == nil {
+		return nil, errors.New("remote signer returned empty public key")
+	}
+	sc.publicKey = new(bls12.PublicKey)
+	if err = sc.publicKey.FromBytes(pkResp.PublicKey.Key); err != nil {
+		return nil, err
+	}
+	return sc, nil
+}
+
+func (sc *SignerClient) PublicKey() *bls12.PublicKey {
+	return sc.publicKey
+}
+
+func (sc *SignerClient) SignVote(vote *types.Vote) error {
+	req := &pbprivval.SignVoteRequest{Vote: &pbtypes.Vote{
+		VoteType:  vote.VoteType,
+		Height:    vote.Height,
+		Round:     int32(vote.Round),
+		BlockHash: vote.BlockHash,
+		ValueHash: vote.ValueHash,
+		Timestamp: vote.Timestamp,
+	}}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignPrepare(prepare *types.Prepare) error {
+	if prepare.Block == nil || prepare.Block.ChameleonHash == nil {
+		return errors.New("cannot sign Prepare without block hash")
+	}
+	req := &pbprivval.SignPrepareRequest{
+		Height:    prepare.Height,
+		Round:     int32(prepare.Round),
+		BlockHash: prepare.Block.ChameleonHash.Hash,
+	}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	prepare.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignNewView(view *types.NewView) error {
+	req := &pbprivval.SignNewViewRequest{NewView: &pbtypes.NewView{
+		Type:        view.Type,
+		ID:          string(view.ID),
+		Height:      view.Height,
+		Round:       int32(view.Round),
+		HighQCRound: int32(view.HighQCRound),
+		HighQC:      view.HighQC.ToProto(),
+		Timestamp:   view.Timestamp,
+	}}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignChainedProposal(proposal *types.ChainedProposal) error {
+	if proposal.Block == nil || proposal.Block.Header == nil || proposal.Block.ChameleonHash == nil {
+		return errors.New("cannot sign ChainedProposal without block hash")
+	}
+	req := &pbprivval.SignChainedProposalRequest{
+		View:      proposal.View,
+		Height:    proposal.Block.Header.Height,
+		BlockHash: proposal.Block.ChameleonHash.Hash,
+	}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	proposal.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignChainedVote(vote *types.ChainedVote) error {
+	req := &pbprivval.SignChainedVoteRequest{Vote: &pbtypes.ChainedVote{
+		View:      vote.View,
+		Height:    vote.Height,
+		BlockHash: vote.BlockHash,
+		ValueHash: vote.ValueHash,
+		Timestamp: vote.Timestamp,
+	}}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignChainedNewView(view *types.ChainedNewView) error {
+	req := &pbprivval.SignChainedNewViewRequest{NewView: &pbtypes.ChainedNewView{
+		ID:        string(view.ID),
+		View:      view.View,
+		HighQC:    view.HighQC.ToProto(),
+		Timestamp: view.Timestamp,
+	}}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+func (sc *SignerClient) SignTimeout(timeout *types.Timeout) error {
+	req := &pbprivval.SignTimeoutRequest{Timeout: &pbtypes.Timeout{
+		ID:        string(timeout.ID),
+		Height:    timeout.Height,
+		Round:     int32(timeout.Round),
+		HighQC:    timeout.HighQC.ToProto(),
+		Timestamp: timeout.Timestamp,
+	}}
+	sig, err := sc.requestSignature(wrapMsg(req))
+	if err != nil {
+		return err
+	}
+	timeout.Signature = sig
+	return nil
+}
+
+// Ping 检查与签名进程之间的连接是否正常。
+func (sc *SignerClient) Ping() error {
+	resp, err := sc.sendRequest(wrapMsg(&pbprivval.PingRequest{}))
+	if err != nil {
+		return err
+	}
+	if resp.GetPingResponse() == nil {
+		return fmt.Errorf("unexpected response %T from remote signer", resp.Sum)
+	}
+	return nil
+}
+
+// Close 关闭与签名进程之间的连接。
+func (sc *SignerClient) Close() error {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return sc.closeConn()
+}
+
+func (sc *SignerClient) requestSignature(req *pbprivval.Message) (*bls12.Signature, error) {
+	resp, err := sc.sendRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	sigResp := resp.GetSignatureResponse()
+	if sigResp == nil {
+		return nil, fmt.Errorf("unexpected response %T from remote signer", resp.Sum)
+	}
+	if sigResp.Error != nil {
+		return nil, &RemoteSignerError{Description: sigResp.Error.Description}
+	}
+	if sigResp.Signature == nil {
+		return nil, errors.New("remote signer returned empty signature")
+	}
+	return signatureFromProto(sigResp.Signature)
+}
+
+// sendRequest 发送请求并等待响应，如果因为连接问题失败，则重新建立连接后再重试一次。
+func (sc *SignerClient) sendRequest(req *pbprivval.Message) (*pbprivval.Message, error) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	resp, err := sc.roundTrip(req)
+	if err != nil {
+		sc.logger.Warn("request to remote signer failed, reconnect and retry", "addr", sc.addr, "err", err)
+		_ = sc.closeConn()
+		resp, err = sc.roundTrip(req)
+		if err != nil {
+			_ = sc.closeConn()
+		}
+	}
+	return resp, err
+}
+
+func (sc *SignerClient) roundTrip(req *pbprivval.Message) (*pbprivval.Message, error) {
+	if sc.conn == nil {
+		conn, err := dial(sc.addr, sc.timeout, sc.connKey, sc.signerKey)
+		if err != nil {
+			return nil, err
+		}
+		sc.conn = conn
+		sc.reader = protoio.NewDelimitedReader(conn, maxRemoteSignerMsgSize)
+		sc.writer = protoio.NewDelimitedWriter(conn)
+	}
+	if err := sc.conn.SetDeadline(time.Now().Add(sc.timeout)); err != nil {
+		return nil, err
+	}
+	if _, err := sc.writer.WriteMsg(req); err != nil {
+		return nil, err
+	}
+	resp := new(pbprivval.Message)
+	if _, err := sc.reader.ReadMsg(resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (sc *SignerClient) closeConn() error {
+	if sc.conn == nil {
+		return nil
+	}
+	err := sc.conn.Close()
+	sc.conn = nil
+	sc.reader = nil
+	sc.writer = nil
+	return err
+}
+
+// signatureFromProto 与bls12.SignatureFromProto不同，遇到无法解析的签名时返回错误而不是panic。
+func signatureFromProto(pb *pbcrypto.Signature) (sig *bls12.Signature, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("invalid signature from remote signer: %v", r)
+		}
+	}()
+	return bls12.SignatureFromProto(pb), nil
+}
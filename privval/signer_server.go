@@ -0,0 +1,227 @@
+package privval
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/common/protoio"
+	"github.com/232425wxy/meta--/common/service"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbprivval"
+	"github.com/232425wxy/meta--/types"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+// SignerServer 独立运行的签名进程，私钥和签名状态只保存在签名进程里，节点通过unix socket或者tcp连接到签名进程，
+// 请求为共识消息签名，签名进程通过LocalSigner拒绝可能导致重复签名的请求。每个连接都要先完成SecretConnection握手，
+// 签名进程用connKey向节点证明自己的身份，并且只为连接公钥是nodeKey的节点签名。
+type SignerServer struct {
+	service.BaseService
+	addr     string
+	signer   *LocalSigner
+	connKey  ed25519.PrivateKey
+	nodeKey  ed25519.PublicKey
+	listener net.Listener
+	mu       sync.Mutex
+	conns    map[net.Conn]struct{}
+}
+
+func NewSignerServer(addr string, signer *LocalSigner, connKey ed25519.PrivateKey, nodeKey ed25519.PublicKey, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		BaseService: *service.NewBaseService(logger, "Signer_Server"),
+		addr:        addr,
+		signer:      signer,
+		connKey:     connKey,
+		nodeKey:     nodeKey,
+		conns:       make(map[net.Conn]struct{}),
+	}
+}
+
+func (ss *SignerServer) Start() error {
+	network, address, err := parseAddress(ss.addr)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		// 删除上一次运行时遗留下来的socket文件
+		if err = os.Remove(address); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	listener, err := net.Listen(network, address)
+	if err != nil {
+		return err
+	}
+	if network == "unix" {
+		// 只允许与签名进程同一用户的进程连接
+		if err = os.Chmod(address, 0600); err != nil {
+			_ = listener.Close()
+			return err
+		}
+	}
+	ss.listener = listener
+	ss.Logger.Info("remote signer is listening", "addr", ss.addr, "id", ss.signer.PublicKey().ToID(), "conn_key", ConnKeyString(ss.connKey))
+	go ss.acceptRoutine()
+	return ss.BaseService.Start()
+}
+
+func (ss *SignerServer) Stop() error {
+	if ss.listener != nil {
+		_ = ss.listener.Close()
+	}
+	ss.mu.Lock()
+	for conn := range ss.conns {
+		_ = conn.Close()
+	}
+	ss.mu.Unlock()
+	return ss.BaseService.Stop()
+}
+
+// Addr 返回签名进程实际监听的地址。
+func (ss *SignerServer) Addr() net.Addr {
+	return ss.listener.Addr()
+}
+
+func (ss *SignerServer) acceptRoutine() {
+	for {
+		conn, err := ss.listener.Accept()
+		if err != nil {
+			select {
+			case <-ss.WaitStop():
+				return
+			default:
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			ss.Logger.Error("failed to accept connection", "err", err)
+			continue
+		}
+		ss.mu.Lock()
+		ss.conns[conn] = struct{}{}
+		ss.mu.Unlock()
+		ss.Logger.Info("accept connection from node", "remote", conn.RemoteAddr())
+		go ss.handleConn(conn)
+	}
+}
+
+func (ss *SignerServer) handleConn(raw net.Conn) {
+	defer func() {
+		ss.mu.Lock()
+		delete(ss.conns, raw)
+		ss.mu.Unlock()
+		_ = raw.Close()
+	}()
+	if err := raw.SetDeadline(time.Now().Add(secretConnHandshakeTimeout)); err != nil {
+		return
+	}
+	conn, err := MakeSecretConnection(raw, ss.connKey, ss.nodeKey)
+	if err != nil {
+		ss.Logger.Error("failed to authenticate node, close the connection", "remote", raw.RemoteAddr(), "err", err)
+		return
+	}
+	if err = raw.SetDeadline(time.Time{}); err != nil {
+		return
+	}
+	reader := protoio.NewDelimitedReader(conn, maxRemoteSignerMsgSize)
+	writer := protoio.NewDelimitedWriter(conn)
+	for {
+		req := new(pbprivval.Message)
+		if _, err := reader.ReadMsg(req); err != nil {
+			if err != io.EOF && !errors.Is(err, net.ErrClosed) {
+				ss.Logger.Error("failed to read request from node", "remote", conn.RemoteAddr(), "err", err)
+			}
+			return
+		}
+		resp := ss.handleRequest(req)
+		if _, err := writer.WriteMsg(resp); err != nil {
+			ss.Logger.Error("failed to write response to node", "remote", conn.RemoteAddr(), "err", err)
+			return
+		}
+	}
+}
+
+func (ss *SignerServer) handleRequest(req *pbprivval.Message) *pbprivval.Message {
+	switch r := req.Sum.(type) {
+	case *pbprivval.Message_PubKeyRequest:
+		return wrapMsg(&pbprivval.PubKeyResponse{PublicKey: ss.signer.PublicKey().ToProto()})
+	case *pbprivval.Message_SignVoteRequest:
+		if r.SignVoteRequest.Vote == nil {
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(errors.New("empty vote"))})
+		}
+		vote := types.VoteFromProto(r.SignVoteRequest.Vote)
+		sig, err := ss.signer.signVote(vote)
+		if err != nil {
+			ss.Logger.Error("refuse to sign vote", "height", vote.Height, "round", vote.Round, "type", vote.VoteType, "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignPrepareRequest:
+		pr := r.SignPrepareRequest
+		sig, err := ss.signer.signPrepare(pr.Height, int16(pr.Round), pr.BlockHash)
+		if err != nil {
+			ss.Logger.Error("refuse to sign Prepare", "height", pr.Height, "round", pr.Round, "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignNewViewRequest:
+		if r.SignNewViewRequest.NewView == nil {
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(errors.New("empty NewView"))})
+		}
+		sig, err := ss.signer.signNewView(types.NewViewFromProto(r.SignNewViewRequest.NewView))
+		if err != nil {
+			ss.Logger.Error("refuse to sign NewView", "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignChainedProposalRequest:
+		pr := r.SignChainedProposalRequest
+		sig, err := ss.signer.signChainedProposal(pr.View, pr.Height, pr.BlockHash)
+		if err != nil {
+			ss.Logger.Error("refuse to sign ChainedProposal", "view", pr.View, "height", pr.Height, "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignChainedVoteRequest:
+		if r.SignChainedVoteRequest.Vote == nil {
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(errors.New("empty vote"))})
+		}
+		vote := types.ChainedVoteFromProto(r.SignChainedVoteRequest.Vote)
+		sig, err := ss.signer.signChainedVote(vote)
+		if err != nil {
+			ss.Logger.Error("refuse to sign ChainedVote", "view", vote.View, "height", vote.Height, "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignChainedNewViewRequest:
+		if r.SignChainedNewViewRequest.NewView == nil {
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(errors.New("empty ChainedNewView"))})
+		}
+		sig, err := ss.signer.signChainedNewView(types.ChainedNewViewFromProto(r.SignChainedNewViewRequest.NewView))
+		if err != nil {
+			ss.Logger.Error("refuse to sign ChainedNewView", "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_SignTimeoutRequest:
+		if r.SignTimeoutRequest.Timeout == nil {
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(errors.New("empty Timeout"))})
+		}
+		sig, err := ss.signer.signTimeout(types.TimeoutFromProto(r.SignTimeoutRequest.Timeout))
+		if err != nil {
+			ss.Logger.Error("refuse to sign Timeout", "err", err)
+			return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+		}
+		return wrapMsg(&pbprivval.SignatureResponse{Signature: sig.ToProto()})
+	case *pbprivval.Message_PingRequest:
+		return wrapMsg(&pbprivval.PingResponse{})
+	default:
+		err := fmt.Errorf("unknown request type %T", r)
+		ss.Logger.Error("receive unknown request from node", "err", err)
+		return wrapMsg(&pbprivval.SignatureResponse{Error: newRemoteSignerError(err)})
+	}
+}
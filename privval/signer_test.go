@@ -0,0 +1,217 @@
+package privval
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	dir := t.TempDir()
+	signer, err := LoadLocalSigner(privateKey, filepath.Join(dir, "sign_state.json"))
+	assert.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	nodeConnKey, signerConnKey := newTestConnKeys(t)
+	server := NewSignerServer(addr, signer, signerConnKey, connPublicKey(nodeConnKey), log.New())
+	assert.Nil(t, server.Start())
+	defer func() { _ = server.Stop() }()
+
+	client, err := NewSignerClient(addr, time.Second, nodeConnKey, connPublicKey(signerConnKey), log.New())
+	assert.Nil(t, err)
+	defer func() { _ = client.Close() }()
+	assert.Equal(t, privateKey.PublicKey().ToID(), client.PublicKey().ToID())
+	assert.Nil(t, client.Ping())
+
+	vote, err := types.NewVote(pbtypes.PrepareVoteType, 3, 1, []byte("block a"), client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(vote.Signature, vote.ValueHash))
+
+	// 相同的投票再次请求签名，返回相同的签名
+	again, err := types.NewVote(pbtypes.PrepareVoteType, 3, 1, []byte("block a"), client)
+	assert.Nil(t, err)
+	assert.Equal(t, vote.Signature.ToProto(), again.Signature.ToProto())
+
+	// 同一高度、轮次和阶段为不同的区块投票，签名进程拒绝签名
+	_, err = types.NewVote(pbtypes.PrepareVoteType, 3, 1, []byte("block b"), client)
+	var remoteErr *RemoteSignerError
+	assert.True(t, errors.As(err, &remoteErr))
+
+	// 阶段倒退
+	block := &types.Block{Header: &types.Header{Height: 3}, Body: &types.Data{}, ChameleonHash: &types.ChameleonHash{Hash: []byte("block a")}}
+	_, err = types.NewPrepare(3, 1, block, nil, client)
+	assert.True(t, errors.As(err, &remoteErr))
+
+	prepare, err := types.NewPrepare(3, 2, block, nil, client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(prepare.Signature, types.GeneratePrepareSignBytes(3, 2, []byte("block a"))))
+
+	view, err := types.NewNewView(3, 3, 0, nil, nil, client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(view.Signature, view.ValueHash()))
+
+	// 同一轮次携带不同highQC的NewView消息，签名进程拒绝签名
+	_, err = types.NewNewView(3, 3, 2, block, &types.PreCommit{ValueHash: []byte("qc")}, client)
+	assert.True(t, errors.As(err, &remoteErr))
+
+	// 进入下一轮之后不再为之前轮次的NewView消息签名
+	_, err = types.NewPrepare(3, 3, block, nil, client)
+	assert.Nil(t, err)
+	_, err = types.NewNewView(3, 2, 0, nil, nil, client)
+	assert.True(t, errors.As(err, &remoteErr))
+}
+
+func TestRemoteSignerChained(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	dir := t.TempDir()
+	signer, err := LoadLocalSigner(privateKey, filepath.Join(dir, "sign_state.json"))
+	assert.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	nodeConnKey, signerConnKey := newTestConnKeys(t)
+	server := NewSignerServer(addr, signer, signerConnKey, connPublicKey(nodeConnKey), log.New())
+	assert.Nil(t, server.Start())
+	defer func() { _ = server.Stop() }()
+
+	client, err := NewSignerClient(addr, time.Second, nodeConnKey, connPublicKey(signerConnKey), log.New())
+	assert.Nil(t, err)
+	defer func() { _ = client.Close() }()
+
+	block := &types.Block{Header: &types.Header{Height: 3}, Body: &types.Data{}, ChameleonHash: &types.ChameleonHash{Hash: []byte("block a")}}
+	proposal, err := types.NewChainedProposal(5, block, nil, client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(proposal.Signature, types.GenerateChainedProposalSignBytes(5, 3, []byte("block a"))))
+
+	// 主节点为自己的提案投票
+	vote, err := types.NewChainedVote(5, 3, []byte("block a"), client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(vote.Signature, vote.ValueHash))
+
+	// 同一视图为不同的区块投票，签名进程拒绝签名
+	var remoteErr *RemoteSignerError
+	_, err = types.NewChainedVote(5, 3, []byte("block b"), client)
+	assert.True(t, errors.As(err, &remoteErr))
+
+	// 视图倒退
+	_, err = types.NewChainedVote(4, 2, []byte("block c"), client)
+	assert.True(t, errors.As(err, &remoteErr))
+
+	view, err := types.NewChainedNewView(6, nil, client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(view.Signature, view.ValueHash()))
+
+	// 为之前视图的ChainedNewView消息签名会被拒绝
+	_, err = types.NewChainedNewView(5, nil, client)
+	assert.True(t, errors.As(err, &remoteErr))
+}
+
+// newTestConnKeys 生成节点和签名进程各自的连接私钥。
+func newTestConnKeys(t *testing.T) (ed25519.PrivateKey, ed25519.PrivateKey) {
+	_, nodeKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	_, signerKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.Nil(t, err)
+	return nodeKey, signerKey
+}
+
+func connPublicKey(key ed25519.PrivateKey) ed25519.PublicKey {
+	return key.Public().(ed25519.PublicKey)
+}
+
+func TestLocalSignerChainedSignState(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	file := filepath.Join(t.TempDir(), "sign_state.json")
+	signer, err := LoadLocalSigner(privateKey, file)
+	assert.Nil(t, err)
+
+	// 四阶段共识已经推进到了第100个高度，链式HotStuff的视图比高度小，也不会被当成高度倒退
+	_, err = types.NewVote(pbtypes.CommitVoteType, 100, 2, []byte("block a"), signer)
+	assert.Nil(t, err)
+	_, err = types.NewChainedVote(5, 3, []byte("block a"), signer)
+	assert.Nil(t, err)
+	// 视图比高度大，也不会让四阶段共识在之后的高度拒绝签名
+	_, err = types.NewChainedVote(200, 150, []byte("block b"), signer)
+	assert.Nil(t, err)
+	_, err = types.NewVote(pbtypes.PrepareVoteType, 101, 1, []byte("block c"), signer)
+	assert.Nil(t, err)
+
+	// 两个签名状态分别保存在不同的文件里
+	signState, err := LoadLastSignState(file)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(101), signState.Height)
+	chainedState, err := LoadLastSignState(ChainedSignStateFile(file))
+	assert.Nil(t, err)
+	assert.Equal(t, int64(200), chainedState.Height)
+
+	// 重启之后链式HotStuff的签名状态依然生效
+	signer, err = LoadLocalSigner(privateKey, file)
+	assert.Nil(t, err)
+	_, err = types.NewChainedVote(200, 150, []byte("block d"), signer)
+	assert.True(t, errors.Is(err, ErrConflictingData))
+}
+
+func TestRemoteSignerAuthentication(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	signer, err := LoadLocalSigner(privateKey, filepath.Join(t.TempDir(), "sign_state.json"))
+	assert.Nil(t, err)
+	nodeConnKey, signerConnKey := newTestConnKeys(t)
+
+	// 连接经过认证和加密，签名进程可以监听非回环地址
+	server := NewSignerServer("tcp://0.0.0.0:0", signer, signerConnKey, connPublicKey(nodeConnKey), log.New())
+	assert.Nil(t, server.Start())
+	defer func() { _ = server.Stop() }()
+	addr := "tcp://" + server.Addr().String()
+
+	client, err := NewSignerClient(addr, time.Second, nodeConnKey, connPublicKey(signerConnKey), log.New())
+	assert.Nil(t, err)
+	defer func() { _ = client.Close() }()
+	assert.Nil(t, client.Ping())
+
+	// 签名进程的连接公钥与节点配置的不一致
+	otherKey, _ := newTestConnKeys(t)
+	_, err = NewSignerClient(addr, time.Second, nodeConnKey, connPublicKey(otherKey), log.New())
+	assert.True(t, errors.Is(err, ErrUnexpectedRemoteKey))
+
+	// 签名进程不为没有配置过的节点签名
+	_, err = NewSignerClient(addr, time.Second, otherKey, connPublicKey(signerConnKey), log.New())
+	assert.NotNil(t, err)
+}
+
+func TestRemoteSignerTimeout(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	dir := t.TempDir()
+	signer, err := LoadLocalSigner(privateKey, filepath.Join(dir, "sign_state.json"))
+	assert.Nil(t, err)
+	addr := "unix://" + filepath.Join(dir, "signer.sock")
+	nodeConnKey, signerConnKey := newTestConnKeys(t)
+	server := NewSignerServer(addr, signer, signerConnKey, connPublicKey(nodeConnKey), log.New())
+	assert.Nil(t, server.Start())
+	defer func() { _ = server.Stop() }()
+
+	client, err := NewSignerClient(addr, time.Second, nodeConnKey, connPublicKey(signerConnKey), log.New())
+	assert.Nil(t, err)
+	defer func() { _ = client.Close() }()
+
+	_, err = types.NewVote(pbtypes.PrepareVoteType, 3, 2, []byte("block a"), client)
+	assert.Nil(t, err)
+
+	timeout, err := types.NewTimeout(3, 2, nil, nil, client)
+	assert.Nil(t, err)
+	assert.True(t, privateKey.PublicKey().Verify(timeout.Signature, types.GenerateTimeoutValueHash(3, 2, 0)))
+
+	// 在某一轮超时之后，不再为这一轮的投票签名
+	var remoteErr *RemoteSignerError
+	_, err = types.NewVote(pbtypes.PreCommitVoteType, 3, 2, []byte("block a"), client)
+	assert.True(t, errors.As(err, &remoteErr))
+
+	// 下一轮可以继续投票
+	_, err = types.NewVote(pbtypes.PrepareVoteType, 3, 3, []byte("block a"), client)
+	assert.Nil(t, err)
+}
@@ -28,11 +28,13 @@ var _ = time.Kitchen
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type State struct {
-	InitialHeight   int64                 `protobuf:"varint,1,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
-	LastBlockHeight int64                 `protobuf:"varint,2,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
-	PreviousBlock   *pbtypes.Block        `protobuf:"bytes,3,opt,name=previous_block,json=previousBlock,proto3" json:"previous_block,omitempty"`
-	LastBlockTime   time.Time             `protobuf:"bytes,4,opt,name=last_block_time,json=lastBlockTime,proto3,stdtime" json:"last_block_time"`
-	Validators      *pbtypes.ValidatorSet `protobuf:"bytes,5,opt,name=validators,proto3" json:"validators,omitempty"`
+	InitialHeight   int64                    `protobuf:"varint,1,opt,name=initial_height,json=initialHeight,proto3" json:"initial_height,omitempty"`
+	LastBlockHeight int64                    `protobuf:"varint,2,opt,name=last_block_height,json=lastBlockHeight,proto3" json:"last_block_height,omitempty"`
+	PreviousBlock   *pbtypes.Block           `protobuf:"bytes,3,opt,name=previous_block,json=previousBlock,proto3" json:"previous_block,omitempty"`
+	LastBlockTime   time.Time                `protobuf:"bytes,4,opt,name=last_block_time,json=lastBlockTime,proto3,stdtime" json:"last_block_time"`
+	Validators      *pbtypes.ValidatorSet    `protobuf:"bytes,5,opt,name=validators,proto3" json:"validators,omitempty"`
+	ConsensusParams *pbtypes.ConsensusParams `protobuf:"bytes,6,opt,name=consensus_params,json=consensusParams,proto3" json:"consensus_params,omitempty"`
+	AppHash         []byte                   `protobuf:"bytes,7,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
 }
 
 func (m *State) Reset()         { *m = State{} }
@@ -103,6 +105,20 @@ func (m *State) GetValidators() *pbtypes.ValidatorSet {
 	return nil
 }
 
+func (m *State) GetConsensusParams() *pbtypes.ConsensusParams {
+	if m != nil {
+		return m.ConsensusParams
+	}
+	return nil
+}
+
+func (m *State) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*State)(nil), "pbstate.State")
 }
@@ -110,27 +126,31 @@ func init() {
 func init() { proto.RegisterFile("state.proto", fileDescriptor_a888679467bb7853) }
 
 var fileDescriptor_a888679467bb7853 = []byte{
-	// 314 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x90, 0xcd, 0x4a, 0xc3, 0x40,
-	0x14, 0x85, 0x33, 0xad, 0x55, 0x99, 0xd2, 0x16, 0x03, 0x42, 0x0c, 0x38, 0x2d, 0xa2, 0x50, 0x04,
-	0x27, 0x68, 0x2d, 0xb8, 0xce, 0xca, 0x85, 0xab, 0x54, 0xdc, 0x96, 0x89, 0x8e, 0xe9, 0x60, 0xea,
-	0x0c, 0x99, 0x69, 0xc1, 0xb7, 0xe8, 0xca, 0x67, 0xea, 0xb2, 0x4b, 0x57, 0x2a, 0xc9, 0x8b, 0x48,
-	0x6e, 0x7e, 0xac, 0xee, 0xee, 0xb9, 0xe7, 0x9b, 0xdc, 0x73, 0x82, 0xdb, 0xda, 0x30, 0xc3, 0xa9,
-	0x4a, 0xa4, 0x91, 0xf6, 0x9e, 0x0a, 0x41, 0xba, 0x47, 0xa0, 0x3d, 0x15, 0x9a, 0x37, 0xc5, 0xb5,
-	0x17, 0xc6, 0xf2, 0xf1, 0xa5, 0x60, 0xdc, 0xe3, 0xbf, 0xd6, 0x92, 0xc5, 0xe2, 0x89, 0x19, 0x99,
-	0x94, 0xf6, 0x69, 0x24, 0x23, 0x09, 0xe3, 0xc5, 0x25, 0xbd, 0xa6, 0x23, 0xaf, 0xd6, 0x30, 0x95,
-	0xd4, 0xcd, 0x7f, 0x0a, 0xe6, 0x70, 0xf1, 0xec, 0x45, 0x52, 0x46, 0x31, 0xff, 0xd5, 0x46, 0xcc,
-	0xb9, 0x36, 0x6c, 0xae, 0x8a, 0x97, 0x27, 0xef, 0x0d, 0xdc, 0x9a, 0xe4, 0x19, 0xed, 0x33, 0xdc,
-	0x15, 0xaf, 0xc2, 0x08, 0x16, 0x4f, 0x67, 0x5c, 0x44, 0x33, 0xe3, 0xa0, 0x01, 0x1a, 0x36, 0x83,
-	0x4e, 0xb9, 0xbd, 0x85, 0xa5, 0x7d, 0x8e, 0x0f, 0x62, 0xa6, 0xcd, 0x14, 0x3a, 0x54, 0x64, 0x03,
-	0xc8, 0x5e, 0x6e, 0xf8, 0xf9, 0xbe, 0x64, 0xc7, 0xb8, 0xab, 0x12, 0xbe, 0x14, 0x72, 0xa1, 0x0b,
-	0xde, 0x69, 0x0e, 0xd0, 0xb0, 0x7d, 0xd5, 0xa5, 0x65, 0x5d, 0x0a, 0x74, 0xd0, 0xa9, 0x28, 0x90,
-	0xf6, 0x1d, 0xee, 0x6d, 0x9d, 0xc8, 0x13, 0x3b, 0x3b, 0xf0, 0xce, 0xa5, 0x45, 0x1d, 0x5a, 0xd5,
-	0xa1, 0xf7, 0x55, 0x1d, 0x7f, 0x7f, 0xfd, 0xd9, 0xb7, 0x56, 0x5f, 0x7d, 0x14, 0x74, 0xea, 0x18,
-	0xb9, 0x6b, 0x8f, 0x31, 0xae, 0x7f, 0xaa, 0x76, 0x5a, 0xf0, 0xa1, 0xc3, 0x3a, 0xc0, 0x43, 0x65,
-	0x4d, 0xb8, 0x09, 0xb6, 0x40, 0xdf, 0x59, 0xa7, 0x04, 0x6d, 0x52, 0x82, 0xbe, 0x53, 0x82, 0x56,
-	0x19, 0xb1, 0x36, 0x19, 0xb1, 0x3e, 0x32, 0x62, 0x85, 0xbb, 0x70, 0x7d, 0xf4, 0x13, 0x00, 0x00,
-	0xff, 0xff, 0x70, 0xd6, 0x18, 0x6b, 0xeb, 0x01, 0x00, 0x00,
+	// 374 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x91, 0xcd, 0x6e, 0xda, 0x40,
+	0x14, 0x85, 0x3d, 0x50, 0x7e, 0x34, 0x14, 0x68, 0x2d, 0x55, 0x1a, 0x2c, 0xd5, 0xa0, 0xaa, 0x95,
+	0x50, 0xa5, 0x8e, 0xd5, 0x52, 0xa4, 0xac, 0x61, 0xc3, 0x22, 0x8b, 0xc8, 0x44, 0xd9, 0x5a, 0x63,
+	0x32, 0xb1, 0xad, 0x18, 0x66, 0xe4, 0x19, 0x90, 0xf2, 0x16, 0x3c, 0x16, 0x4b, 0x96, 0x59, 0x25,
+	0x11, 0xbc, 0x43, 0xd6, 0x91, 0xaf, 0x7f, 0x02, 0xd9, 0xdd, 0x73, 0xcf, 0x77, 0xed, 0x73, 0x6c,
+	0xdc, 0x52, 0x9a, 0x69, 0x4e, 0x65, 0x22, 0xb4, 0x30, 0x1b, 0xd2, 0x07, 0x69, 0xf5, 0x40, 0x3b,
+	0xd2, 0xd7, 0x0f, 0x92, 0x2b, 0xc7, 0x8f, 0xc5, 0xe2, 0x3e, 0x63, 0xac, 0xef, 0xe7, 0xd6, 0x86,
+	0xc5, 0xd1, 0x2d, 0xd3, 0x22, 0xc9, 0x6d, 0xeb, 0xdc, 0x96, 0x2c, 0x61, 0x4b, 0x95, 0x7b, 0x3f,
+	0x03, 0x11, 0x08, 0x18, 0xff, 0xfc, 0xa5, 0xff, 0xe9, 0xc8, 0x29, 0x35, 0x4c, 0x39, 0x75, 0xf1,
+	0x91, 0x82, 0xd9, 0x5f, 0xdf, 0x39, 0x81, 0x10, 0x41, 0xcc, 0xdf, 0xb5, 0x8e, 0x96, 0x5c, 0x69,
+	0xb6, 0x94, 0xd9, 0xe5, 0x8f, 0xd7, 0x0a, 0xae, 0xcd, 0xd3, 0xfc, 0xe6, 0x2f, 0xdc, 0x89, 0x56,
+	0x91, 0x8e, 0x58, 0xec, 0x85, 0x3c, 0x0a, 0x42, 0x4d, 0xd0, 0x00, 0x0d, 0xab, 0x6e, 0x3b, 0xdf,
+	0xce, 0x60, 0x69, 0xfe, 0xc6, 0x5f, 0x63, 0xa6, 0xb4, 0x07, 0xfd, 0x0a, 0xb2, 0x02, 0x64, 0x37,
+	0x35, 0x26, 0xe9, 0x3e, 0x67, 0xc7, 0xb8, 0x23, 0x13, 0xbe, 0x89, 0xc4, 0x5a, 0x65, 0x3c, 0xa9,
+	0x0e, 0xd0, 0xb0, 0xf5, 0xaf, 0x43, 0xf3, 0xae, 0x14, 0x68, 0xb7, 0x5d, 0x50, 0x20, 0xcd, 0x4b,
+	0xdc, 0x3d, 0x79, 0x45, 0x9a, 0x98, 0x7c, 0x82, 0x3b, 0x8b, 0x66, 0x75, 0x68, 0x51, 0x87, 0x5e,
+	0x17, 0x75, 0x26, 0xcd, 0xdd, 0x53, 0xdf, 0xd8, 0x3e, 0xf7, 0x91, 0xdb, 0x2e, 0x63, 0xa4, 0xae,
+	0x39, 0xc6, 0xb8, 0xfc, 0xe0, 0x8a, 0xd4, 0xe0, 0x41, 0xdf, 0xca, 0x00, 0x37, 0x85, 0x35, 0xe7,
+	0xda, 0x3d, 0x01, 0xcd, 0x29, 0xfe, 0xb2, 0x10, 0x2b, 0xc5, 0x57, 0x6a, 0xad, 0xbc, 0xec, 0x97,
+	0x90, 0x3a, 0x1c, 0x93, 0xf2, 0x78, 0x5a, 0x00, 0x57, 0xe0, 0xbb, 0xdd, 0xc5, 0xf9, 0xc2, 0xec,
+	0xe1, 0x26, 0x93, 0xd2, 0x0b, 0x99, 0x0a, 0x49, 0x63, 0x80, 0x86, 0x9f, 0xdd, 0x06, 0x93, 0x72,
+	0xc6, 0x54, 0x38, 0x21, 0xbb, 0x83, 0x8d, 0xf6, 0x07, 0x1b, 0xbd, 0x1c, 0x6c, 0xb4, 0x3d, 0xda,
+	0xc6, 0xfe, 0x68, 0x1b, 0x8f, 0x47, 0xdb, 0xf0, 0xeb, 0xd0, 0x6e, 0xf4, 0x36, 0x00, 0x52, 0xa0,
+	0x4c, 0xc5, 0x67, 0x02, 0x00, 0x00,
 }
 
 func (m *State) Marshal() (dAtA []byte, err error) {
@@ -153,6 +173,25 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintState(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.ConsensusParams != nil {
+		{
+			size, err := m.ConsensusParams.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintState(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
 	if m.Validators != nil {
 		{
 			size, err := m.Validators.MarshalToSizedBuffer(dAtA[:i])
@@ -165,12 +204,12 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x2a
 	}
-	n2, err2 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.LastBlockTime):])
-	if err2 != nil {
-		return 0, err2
+	n3, err3 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.LastBlockTime, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.LastBlockTime):])
+	if err3 != nil {
+		return 0, err3
 	}
-	i -= n2
-	i = encodeVarintState(dAtA, i, uint64(n2))
+	i -= n3
+	i = encodeVarintState(dAtA, i, uint64(n3))
 	i--
 	dAtA[i] = 0x22
 	if m.PreviousBlock != nil {
@@ -231,6 +270,14 @@ func (m *State) Size() (n int) {
 		l = m.Validators.Size()
 		n += 1 + l + sovState(uint64(l))
 	}
+	if m.ConsensusParams != nil {
+		l = m.ConsensusParams.Size()
+		n += 1 + l + sovState(uint64(l))
+	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
 	return n
 }
 
@@ -412,6 +459,76 @@ func (m *State) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ConsensusParams", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ConsensusParams == nil {
+				m.ConsensusParams = &pbtypes.ConsensusParams{}
+			}
+			if err := m.ConsensusParams.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipState(dAtA[iNdEx:])
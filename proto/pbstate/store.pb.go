@@ -24,6 +24,7 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
 type StoreBlock struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Base   int64 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
 }
 
 func (m *StoreBlock) Reset()         { *m = StoreBlock{} }
@@ -66,6 +67,13 @@ func (m *StoreBlock) GetHeight() int64 {
 	return 0
 }
 
+func (m *StoreBlock) GetBase() int64 {
+	if m != nil {
+		return m.Base
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*StoreBlock)(nil), "pbstate.StoreBlock")
 }
@@ -73,14 +81,15 @@ func init() {
 func init() { proto.RegisterFile("store.proto", fileDescriptor_98bbca36ef968dfc) }
 
 var fileDescriptor_98bbca36ef968dfc = []byte{
-	// 109 bytes of a gzipped FileDescriptorProto
+	// 120 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0x2e, 0xc9, 0x2f,
 	0x4a, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x2f, 0x48, 0x2a, 0x2e, 0x49, 0x2c, 0x49,
-	0x55, 0x52, 0xe1, 0xe2, 0x0a, 0x06, 0x89, 0x3b, 0xe5, 0xe4, 0x27, 0x67, 0x0b, 0x89, 0x71, 0xb1,
-	0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0x30, 0x07, 0x41, 0x79, 0x4e,
-	0x12, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7, 0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7,
-	0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c, 0xc7, 0x90, 0xc4, 0x06, 0x36, 0xcf, 0x18,
-	0x10, 0x00, 0x00, 0xff, 0xff, 0xde, 0xb9, 0x7e, 0x1c, 0x5e, 0x00, 0x00, 0x00,
+	0x55, 0xb2, 0xe0, 0xe2, 0x0a, 0x06, 0x89, 0x3b, 0xe5, 0xe4, 0x27, 0x67, 0x0b, 0x89, 0x71, 0xb1,
+	0x65, 0xa4, 0x66, 0xa6, 0x67, 0x94, 0x48, 0x30, 0x2a, 0x30, 0x6a, 0x30, 0x07, 0x41, 0x79, 0x42,
+	0x42, 0x5c, 0x2c, 0x49, 0x89, 0xc5, 0xa9, 0x12, 0x4c, 0x60, 0x51, 0x30, 0xdb, 0x49, 0xe2, 0xc4,
+	0x23, 0x39, 0xc6, 0x0b, 0x8f, 0xe4, 0x18, 0x1f, 0x3c, 0x92, 0x63, 0x9c, 0xf0, 0x58, 0x8e, 0xe1,
+	0xc2, 0x63, 0x39, 0x86, 0x1b, 0x8f, 0xe5, 0x18, 0x92, 0xd8, 0xc0, 0x76, 0x18, 0x03, 0x06, 0x00,
+	0xa9, 0xac, 0x3a, 0x72, 0x72, 0x00, 0x00, 0x00,
 }
 
 func (m *StoreBlock) Marshal() (dAtA []byte, err error) {
@@ -103,6 +112,11 @@ func (m *StoreBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Base != 0 {
+		i = encodeVarintStore(dAtA, i, uint64(m.Base))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Height != 0 {
 		i = encodeVarintStore(dAtA, i, uint64(m.Height))
 		i--
@@ -131,6 +145,9 @@ func (m *StoreBlock) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovStore(uint64(m.Height))
 	}
+	if m.Base != 0 {
+		n += 1 + sovStore(uint64(m.Base))
+	}
 	return n
 }
 
@@ -188,6 +205,25 @@ func (m *StoreBlock) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			m.Base = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowStore
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Base |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipStore(dAtA[iNdEx:])
@@ -68,7 +68,8 @@ func (m *BlockRequest) GetHeight() int64 {
 }
 
 type BlockResponse struct {
-	Block *pbtypes.Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Block  *pbtypes.Block       `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Commit *pbtypes.CommitBlock `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`
 }
 
 func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
@@ -111,6 +112,13 @@ func (m *BlockResponse) GetBlock() *pbtypes.Block {
 	return nil
 }
 
+func (m *BlockResponse) GetCommit() *pbtypes.CommitBlock {
+	if m != nil {
+		return m.Commit
+	}
+	return nil
+}
+
 type NoBlockResponse struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 }
@@ -193,6 +201,7 @@ var xxx_messageInfo_StatusRequest proto.InternalMessageInfo
 
 type StatusResponse struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Base   int64 `protobuf:"varint,2,opt,name=base,proto3" json:"base,omitempty"`
 }
 
 func (m *StatusResponse) Reset()         { *m = StatusResponse{} }
@@ -235,6 +244,13 @@ func (m *StatusResponse) GetHeight() int64 {
 	return 0
 }
 
+func (m *StatusResponse) GetBase() int64 {
+	if m != nil {
+		return m.Base
+	}
+	return 0
+}
+
 type Message struct {
 	// Types that are valid to be assigned to Sum:
 	//	*Message_BlockRequest
@@ -371,28 +387,29 @@ func init() {
 func init() { proto.RegisterFile("types.proto", fileDescriptor_d938547f84707355) }
 
 var fileDescriptor_d938547f84707355 = []byte{
-	// 325 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0x2e, 0xa9, 0x2c, 0x48,
-	0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0xe2, 0x28, 0x48, 0x2a, 0xae, 0xcc, 0x4b, 0x4e,
-	0x2d, 0x92, 0x92, 0x04, 0x0b, 0xe8, 0x17, 0x24, 0x81, 0xa5, 0xf5, 0x93, 0x72, 0xf2, 0x93, 0xb3,
-	0x21, 0x8a, 0x94, 0xd4, 0xb8, 0x78, 0x9c, 0x40, 0xdc, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12,
-	0x21, 0x31, 0x2e, 0xb6, 0x8c, 0xd4, 0xcc, 0xf4, 0x8c, 0x12, 0x09, 0x46, 0x05, 0x46, 0x0d, 0xe6,
-	0x20, 0x28, 0x4f, 0xc9, 0x94, 0x8b, 0x17, 0xaa, 0xae, 0xb8, 0x20, 0x3f, 0xaf, 0x38, 0x55, 0x48,
-	0x85, 0x8b, 0x15, 0x6c, 0x0e, 0x58, 0x1d, 0xb7, 0x11, 0x9f, 0x1e, 0xd4, 0x74, 0x3d, 0x88, 0x32,
-	0x88, 0xa4, 0x92, 0x26, 0x17, 0xbf, 0x5f, 0x3e, 0xaa, 0x46, 0x5c, 0x36, 0xf0, 0x73, 0xf1, 0x06,
-	0x97, 0x24, 0x96, 0x94, 0x16, 0x43, 0x9d, 0xa2, 0xa4, 0xc1, 0xc5, 0x07, 0x13, 0x20, 0xa0, 0xf5,
-	0x21, 0x13, 0x17, 0xbb, 0x6f, 0x6a, 0x71, 0x71, 0x62, 0x7a, 0xaa, 0x90, 0x2d, 0x17, 0x2f, 0xd8,
-	0xea, 0xf8, 0x22, 0x88, 0x31, 0x50, 0xf7, 0x89, 0xe9, 0xc1, 0x42, 0x43, 0x0f, 0xd9, 0xbf, 0x1e,
-	0x0c, 0x41, 0x3c, 0x49, 0xc8, 0xfe, 0x77, 0xe0, 0xe2, 0x83, 0x69, 0x87, 0x58, 0x2a, 0xc1, 0x04,
-	0xd6, 0x2f, 0x8e, 0xa1, 0x1f, 0x22, 0xed, 0xc1, 0x10, 0xc4, 0x9b, 0x84, 0xe2, 0x3f, 0x77, 0x2e,
-	0xc1, 0xbc, 0xfc, 0x78, 0x34, 0x43, 0x98, 0xc1, 0x86, 0x48, 0x22, 0x0c, 0x41, 0x0b, 0x15, 0x0f,
-	0x86, 0x20, 0xfe, 0x3c, 0xb4, 0x80, 0x72, 0xe0, 0xe2, 0x2b, 0x06, 0xfb, 0x1f, 0xee, 0x15, 0x16,
-	0x74, 0xa7, 0xa0, 0x04, 0x18, 0xc8, 0x29, 0xc5, 0xc8, 0x02, 0x42, 0xce, 0x5c, 0xfc, 0x70, 0x13,
-	0xa0, 0x0e, 0x61, 0x05, 0x1b, 0x21, 0x81, 0x69, 0x04, 0xdc, 0x1d, 0x7c, 0xc5, 0x28, 0x22, 0x4e,
-	0xac, 0x5c, 0xcc, 0xc5, 0xa5, 0xb9, 0x4e, 0x12, 0x27, 0x1e, 0xc9, 0x31, 0x5e, 0x78, 0x24, 0xc7,
-	0xf8, 0xe0, 0x91, 0x1c, 0xe3, 0x84, 0xc7, 0x72, 0x0c, 0x17, 0x1e, 0xcb, 0x31, 0xdc, 0x78, 0x2c,
-	0xc7, 0x90, 0xc4, 0x06, 0x4e, 0x49, 0xc6, 0x80, 0x00, 0x00, 0x00, 0xff, 0xff, 0x4e, 0x37, 0xe9,
-	0x40, 0x7d, 0x02, 0x00, 0x00,
+	// 350 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x92, 0xc1, 0x4e, 0xc2, 0x40,
+	0x10, 0x86, 0x5b, 0x0a, 0x68, 0x06, 0xda, 0xc6, 0x8d, 0xc1, 0xe2, 0xa1, 0x31, 0x8d, 0x31, 0x9a,
+	0x98, 0x92, 0xe8, 0x55, 0x13, 0x02, 0x07, 0xb9, 0xe8, 0xa1, 0x3e, 0x00, 0x61, 0x9b, 0x0d, 0x10,
+	0xa5, 0x5b, 0xd9, 0xe5, 0xc0, 0x5b, 0xf8, 0x58, 0x1e, 0x39, 0x7a, 0x54, 0x78, 0x11, 0xc3, 0xec,
+	0x52, 0xdb, 0x12, 0xbd, 0xb1, 0xff, 0xfc, 0xf3, 0xf3, 0xcd, 0x9f, 0x42, 0x43, 0x2e, 0x53, 0x26,
+	0xc2, 0x74, 0xce, 0x25, 0x27, 0x87, 0x29, 0x15, 0xcb, 0x24, 0x66, 0xf3, 0xd3, 0x36, 0x0a, 0x9d,
+	0x94, 0xe2, 0xb8, 0x43, 0x5f, 0x79, 0xfc, 0xa2, 0x4c, 0xc1, 0x05, 0x34, 0x7b, 0xdb, 0x67, 0xc4,
+	0xde, 0x16, 0x4c, 0x48, 0xd2, 0x82, 0xfa, 0x84, 0x4d, 0xc7, 0x13, 0xe9, 0x99, 0x67, 0xe6, 0xa5,
+	0x15, 0xe9, 0x57, 0x10, 0x83, 0xad, 0x7d, 0x22, 0xe5, 0x89, 0x60, 0xe4, 0x1c, 0x6a, 0x98, 0x83,
+	0xbe, 0xc6, 0x8d, 0x13, 0xea, 0xf4, 0x50, 0xd9, 0xd4, 0x90, 0x5c, 0x43, 0x3d, 0xe6, 0xb3, 0xd9,
+	0x54, 0x7a, 0x15, 0xb4, 0x1d, 0x67, 0xb6, 0x3e, 0xca, 0xca, 0xac, 0x3d, 0xc1, 0x15, 0xb8, 0x4f,
+	0xbc, 0xf8, 0x37, 0x7f, 0xf1, 0xb8, 0x60, 0x3f, 0xcb, 0x91, 0x5c, 0x08, 0x0d, 0x1e, 0xdc, 0x81,
+	0xb3, 0x13, 0xfe, 0x5f, 0x25, 0x04, 0xaa, 0x74, 0x24, 0x18, 0x12, 0x59, 0x11, 0xfe, 0x0e, 0xbe,
+	0x2b, 0x70, 0xf0, 0xc8, 0x84, 0x18, 0x8d, 0x19, 0xb9, 0x07, 0x1b, 0xe1, 0x87, 0x73, 0x15, 0xad,
+	0x2f, 0x6c, 0x85, 0xbb, 0x3e, 0xc3, 0x7c, 0x63, 0x03, 0x23, 0x6a, 0xd2, 0x7c, 0x83, 0x5d, 0x70,
+	0x76, 0xeb, 0x0a, 0x44, 0x9f, 0x7e, 0xb2, 0xb7, 0xaf, 0xc6, 0x03, 0x23, 0xb2, 0x69, 0xe1, 0xe6,
+	0x07, 0x38, 0x4a, 0xf8, 0xb0, 0x14, 0x62, 0x61, 0x48, 0xfb, 0x37, 0xa4, 0xd4, 0xd4, 0xc0, 0x88,
+	0xdc, 0xa4, 0x54, 0x5e, 0x17, 0x1c, 0x81, 0x9d, 0x64, 0xa7, 0x54, 0xcb, 0x28, 0x85, 0x12, 0xb7,
+	0x28, 0x22, 0x2f, 0x90, 0x3e, 0xb8, 0x59, 0x82, 0x06, 0xa9, 0x61, 0x84, 0xb7, 0x1f, 0x91, 0x71,
+	0x38, 0xa2, 0xa0, 0xf4, 0x6a, 0x60, 0x89, 0xc5, 0xac, 0xe7, 0x7d, 0xac, 0x7d, 0x73, 0xb5, 0xf6,
+	0xcd, 0xaf, 0xb5, 0x6f, 0xbe, 0x6f, 0x7c, 0x63, 0xb5, 0xf1, 0x8d, 0xcf, 0x8d, 0x6f, 0xd0, 0x3a,
+	0x7e, 0x8b, 0xb7, 0x3f, 0x03, 0x00, 0x1c, 0x25, 0xac, 0xd8, 0xbf, 0x02, 0x00, 0x00,
 }
 
 func (m *BlockRequest) Marshal() (dAtA []byte, err error) {
@@ -443,6 +460,18 @@ func (m *BlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Commit != nil {
+		{
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintTypes(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.Block != nil {
 		{
 			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
@@ -529,6 +558,11 @@ func (m *StatusResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Base != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Base))
+		i--
+		dAtA[i] = 0x10
+	}
 	if m.Height != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 		i--
@@ -707,6 +741,10 @@ func (m *BlockResponse) Size() (n int) {
 		l = m.Block.Size()
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -740,6 +778,9 @@ func (m *StatusResponse) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovTypes(uint64(m.Height))
 	}
+	if m.Base != 0 {
+		n += 1 + sovTypes(uint64(m.Base))
+	}
 	return n
 }
 
@@ -956,6 +997,42 @@ func (m *BlockResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &pbtypes.CommitBlock{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -1144,6 +1221,25 @@ func (m *StatusResponse) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			m.Base = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Base |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
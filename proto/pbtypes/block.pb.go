@@ -99,6 +99,7 @@ type Block struct {
 	Header        *Header        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
 	Body          *Data          `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`
 	ChameleonHash *ChameleonHash `protobuf:"bytes,3,opt,name=chameleon_hash,json=chameleonHash,proto3" json:"chameleon_hash,omitempty"`
+	Evidence      *EvidenceList  `protobuf:"bytes,4,opt,name=evidence,proto3" json:"evidence,omitempty"`
 }
 
 func (m *Block) Reset()         { *m = Block{} }
@@ -155,6 +156,13 @@ func (m *Block) GetChameleonHash() *ChameleonHash {
 	return nil
 }
 
+func (m *Block) GetEvidence() *EvidenceList {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
 type BlockHeight struct {
 	Height int64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 }
@@ -203,6 +211,9 @@ type CommitBlock struct {
 	Height             int64                        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
 	Hash               []byte                       `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,3,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+	ValueHash          []byte                       `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Round              int32                        `protobuf:"varint,5,opt,name=round,proto3" json:"round,omitempty"`
+	View               int64                        `protobuf:"varint,6,opt,name=view,proto3" json:"view,omitempty"`
 }
 
 func (m *CommitBlock) Reset()         { *m = CommitBlock{} }
@@ -259,12 +270,35 @@ func (m *CommitBlock) GetAggregateSignature() *pbcrypto.AggregateSignature {
 	return nil
 }
 
+func (m *CommitBlock) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
+	}
+	return nil
+}
+
+func (m *CommitBlock) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *CommitBlock) GetView() int64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
+}
+
 type Header struct {
 	PreviousBlockHash []byte    `protobuf:"bytes,1,opt,name=previous_block_hash,json=previousBlockHash,proto3" json:"previous_block_hash,omitempty"`
 	BlockDataHash     []byte    `protobuf:"bytes,2,opt,name=block_data_hash,json=blockDataHash,proto3" json:"block_data_hash,omitempty"`
 	Height            int64     `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
 	Timestamp         time.Time `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	Proposer          string    `protobuf:"bytes,5,opt,name=proposer,proto3" json:"proposer,omitempty"`
+	AppHash           []byte    `protobuf:"bytes,6,opt,name=app_hash,json=appHash,proto3" json:"app_hash,omitempty"`
+	AppHashLag        int64     `protobuf:"varint,7,opt,name=app_hash_lag,json=appHashLag,proto3" json:"app_hash_lag,omitempty"`
 }
 
 func (m *Header) Reset()         { *m = Header{} }
@@ -335,6 +369,20 @@ func (m *Header) GetProposer() string {
 	return ""
 }
 
+func (m *Header) GetAppHash() []byte {
+	if m != nil {
+		return m.AppHash
+	}
+	return nil
+}
+
+func (m *Header) GetAppHashLag() int64 {
+	if m != nil {
+		return m.AppHashLag
+	}
+	return 0
+}
+
 type Data struct {
 	RootHash []byte   `protobuf:"bytes,1,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
 	Txs      [][]byte `protobuf:"bytes,2,rep,name=txs,proto3" json:"txs,omitempty"`
@@ -387,6 +435,197 @@ func (m *Data) GetTxs() [][]byte {
 	return nil
 }
 
+// CompactBlock 紧凑区块，用交易哈希代替交易数据，副本节点用自己交易池里的交易还原出完整的区块。
+type CompactBlock struct {
+	Header        *Header        `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	RootHash      []byte         `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	TxHashes      [][]byte       `protobuf:"bytes,3,rep,name=tx_hashes,json=txHashes,proto3" json:"tx_hashes,omitempty"`
+	ChameleonHash *ChameleonHash `protobuf:"bytes,4,opt,name=chameleon_hash,json=chameleonHash,proto3" json:"chameleon_hash,omitempty"`
+	Evidence      *EvidenceList  `protobuf:"bytes,5,opt,name=evidence,proto3" json:"evidence,omitempty"`
+}
+
+func (m *CompactBlock) Reset()         { *m = CompactBlock{} }
+func (m *CompactBlock) String() string { return proto.CompactTextString(m) }
+func (*CompactBlock) ProtoMessage()    {}
+func (*CompactBlock) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8e550b1f5926e92d, []int{6}
+}
+func (m *CompactBlock) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactBlock) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactBlock.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CompactBlock) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactBlock.Merge(m, src)
+}
+func (m *CompactBlock) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactBlock) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactBlock.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompactBlock proto.InternalMessageInfo
+
+func (m *CompactBlock) GetHeader() *Header {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetRootHash() []byte {
+	if m != nil {
+		return m.RootHash
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetTxHashes() [][]byte {
+	if m != nil {
+		return m.TxHashes
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetChameleonHash() *ChameleonHash {
+	if m != nil {
+		return m.ChameleonHash
+	}
+	return nil
+}
+
+func (m *CompactBlock) GetEvidence() *EvidenceList {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+// PartSetHeader 区块分片集合的头部，total是分片的个数，hash是所有分片组成的默克尔树的根。
+type PartSetHeader struct {
+	Total uint32 `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Hash  []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+}
+
+func (m *PartSetHeader) Reset()         { *m = PartSetHeader{} }
+func (m *PartSetHeader) String() string { return proto.CompactTextString(m) }
+func (*PartSetHeader) ProtoMessage()    {}
+func (*PartSetHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8e550b1f5926e92d, []int{7}
+}
+func (m *PartSetHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *PartSetHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_PartSetHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *PartSetHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartSetHeader.Merge(m, src)
+}
+func (m *PartSetHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *PartSetHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartSetHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartSetHeader proto.InternalMessageInfo
+
+func (m *PartSetHeader) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *PartSetHeader) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+// Part 区块分片，proof证明该分片属于某个分片集合。
+type Part struct {
+	Index uint32          `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Bytes []byte          `protobuf:"bytes,2,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Proof *pbcrypto.Proof `protobuf:"bytes,3,opt,name=proof,proto3" json:"proof,omitempty"`
+}
+
+func (m *Part) Reset()         { *m = Part{} }
+func (m *Part) String() string { return proto.CompactTextString(m) }
+func (*Part) ProtoMessage()    {}
+func (*Part) Descriptor() ([]byte, []int) {
+	return fileDescriptor_8e550b1f5926e92d, []int{8}
+}
+func (m *Part) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Part) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Part.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Part) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Part.Merge(m, src)
+}
+func (m *Part) XXX_Size() int {
+	return m.Size()
+}
+func (m *Part) XXX_DiscardUnknown() {
+	xxx_messageInfo_Part.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Part proto.InternalMessageInfo
+
+func (m *Part) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *Part) GetBytes() []byte {
+	if m != nil {
+		return m.Bytes
+	}
+	return nil
+}
+
+func (m *Part) GetProof() *pbcrypto.Proof {
+	if m != nil {
+		return m.Proof
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*ChameleonHash)(nil), "pbtypes.ChameleonHash")
 	proto.RegisterType((*Block)(nil), "pbtypes.Block")
@@ -394,45 +633,62 @@ func init() {
 	proto.RegisterType((*CommitBlock)(nil), "pbtypes.CommitBlock")
 	proto.RegisterType((*Header)(nil), "pbtypes.Header")
 	proto.RegisterType((*Data)(nil), "pbtypes.Data")
+	proto.RegisterType((*CompactBlock)(nil), "pbtypes.CompactBlock")
+	proto.RegisterType((*PartSetHeader)(nil), "pbtypes.PartSetHeader")
+	proto.RegisterType((*Part)(nil), "pbtypes.Part")
 }
 
 func init() { proto.RegisterFile("block.proto", fileDescriptor_8e550b1f5926e92d) }
 
 var fileDescriptor_8e550b1f5926e92d = []byte{
-	// 518 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x52, 0x4d, 0x6f, 0xd3, 0x40,
-	0x10, 0x8d, 0xe3, 0x7c, 0x8e, 0x1b, 0x0a, 0x5b, 0x54, 0xac, 0x80, 0x9c, 0x60, 0x41, 0xc9, 0x85,
-	0x8d, 0x48, 0x41, 0xe2, 0xc2, 0x81, 0x94, 0x43, 0x24, 0xc4, 0xc5, 0xe5, 0x1e, 0xad, 0x93, 0x65,
-	0x6d, 0x25, 0xee, 0x5a, 0xf6, 0x06, 0x11, 0x7e, 0x01, 0xc7, 0x8a, 0x5f, 0xd5, 0x63, 0x8f, 0x3d,
-	0x15, 0x94, 0xfc, 0x11, 0xe4, 0x59, 0xdb, 0x49, 0x41, 0xdc, 0xe6, 0xcd, 0x7b, 0xa3, 0x7d, 0x6f,
-	0x76, 0xc0, 0xf2, 0x97, 0x72, 0xb6, 0xa0, 0x71, 0x22, 0x95, 0x24, 0xcd, 0xd8, 0x57, 0xeb, 0x98,
-	0xa7, 0x5d, 0x07, 0xf1, 0x30, 0xf6, 0x67, 0xc9, 0x3a, 0x56, 0x72, 0x98, 0x86, 0xe2, 0x82, 0xa9,
-	0x55, 0xc2, 0xb5, 0xb0, 0xfb, 0x56, 0x48, 0x21, 0xb1, 0x7c, 0xf9, 0x8a, 0xbe, 0xa6, 0xa7, 0x43,
-	0xac, 0xfd, 0xd5, 0x97, 0xa1, 0x90, 0x52, 0x2c, 0xf9, 0x0e, 0xab, 0x30, 0xe2, 0xa9, 0x62, 0x51,
-	0x9c, 0x4f, 0x3e, 0xfb, 0x7b, 0xb2, 0xc4, 0x58, 0x69, 0x95, 0xfb, 0x1d, 0x3a, 0x67, 0x01, 0x8b,
-	0xf8, 0x92, 0xcb, 0x8b, 0x09, 0x4b, 0x03, 0xf2, 0x08, 0x9a, 0x62, 0x9a, 0x86, 0x22, 0x62, 0xb6,
-	0xd1, 0x37, 0x06, 0x07, 0x5e, 0x43, 0x9c, 0x67, 0x88, 0x9c, 0x40, 0x2b, 0x58, 0xe4, 0x4c, 0x35,
-	0x63, 0xc6, 0xd6, 0xe6, 0xb6, 0xd7, 0x9c, 0x7c, 0x44, 0xda, 0x6b, 0x06, 0x0b, 0xad, 0x7b, 0x08,
-	0x75, 0xb6, 0x8c, 0x03, 0x66, 0x9b, 0x38, 0xae, 0x01, 0x21, 0x50, 0x0b, 0x58, 0x1a, 0xd8, 0x35,
-	0x6c, 0x62, 0xed, 0xfe, 0x34, 0xa0, 0x3e, 0xce, 0x96, 0x42, 0x5e, 0x40, 0x23, 0xe0, 0x6c, 0xce,
-	0x13, 0x7c, 0xd3, 0x1a, 0x1d, 0xd2, 0x7c, 0x3f, 0x74, 0x82, 0x6d, 0x2f, 0xa7, 0xc9, 0x53, 0xa8,
-	0xf9, 0x72, 0xbe, 0x46, 0x03, 0xd6, 0xa8, 0x53, 0xca, 0x3e, 0x30, 0xc5, 0x3c, 0xa4, 0xc8, 0x3b,
-	0xb8, 0x37, 0x2b, 0x12, 0x4d, 0xf1, 0x4d, 0x13, 0xc5, 0xc7, 0xa5, 0xf8, 0x4e, 0x60, 0xaf, 0x33,
-	0xdb, 0x87, 0xee, 0x73, 0xb0, 0xd0, 0xd3, 0x84, 0x87, 0x22, 0x50, 0xe4, 0x38, 0x73, 0x96, 0x55,
-	0xe8, 0xcc, 0xf4, 0x72, 0xe4, 0xfe, 0x30, 0xc0, 0x3a, 0x93, 0x51, 0x14, 0x2a, 0x9d, 0xe0, 0x3f,
-	0xba, 0x32, 0x77, 0x75, 0x97, 0x9b, 0x7c, 0x82, 0x23, 0x26, 0x44, 0xc2, 0x05, 0x53, 0x7c, 0x5a,
-	0x7e, 0x78, 0x6e, 0xf3, 0x09, 0x2d, 0x6e, 0x81, 0xbe, 0x2f, 0x44, 0xe7, 0x85, 0xc6, 0x23, 0xec,
-	0x9f, 0x9e, 0x7b, 0x63, 0x40, 0x43, 0xaf, 0x89, 0x50, 0x38, 0x8a, 0x13, 0xfe, 0x35, 0x94, 0xab,
-	0x74, 0x8a, 0xe7, 0xa6, 0x17, 0xa0, 0x3f, 0xf2, 0x41, 0x41, 0xe9, 0x7c, 0x99, 0x93, 0x13, 0x38,
-	0xd4, 0xb2, 0x39, 0x53, 0x6c, 0xba, 0x67, 0xb4, 0x83, 0xed, 0x6c, 0xab, 0xa8, 0xdb, 0xa5, 0x33,
-	0xef, 0xa4, 0x1b, 0x43, 0xbb, 0x3c, 0x3b, 0xfc, 0x5a, 0x6b, 0xd4, 0xa5, 0xfa, 0x30, 0x69, 0x71,
-	0x98, 0xf4, 0x73, 0xa1, 0x18, 0xb7, 0xae, 0x6e, 0x7b, 0x95, 0xcb, 0x5f, 0x3d, 0xc3, 0xdb, 0x8d,
-	0x91, 0x2e, 0xb4, 0xe2, 0x44, 0xc6, 0x32, 0xe5, 0x89, 0x5d, 0xef, 0x1b, 0x83, 0xb6, 0x57, 0x62,
-	0xf7, 0x0d, 0xd4, 0x32, 0x0f, 0xe4, 0x31, 0xb4, 0x13, 0x29, 0xd5, 0x7e, 0x9a, 0x56, 0xd6, 0x40,
-	0x73, 0xf7, 0xc1, 0x54, 0xdf, 0x52, 0xbb, 0xda, 0x37, 0x07, 0x07, 0x5e, 0x56, 0x8e, 0xed, 0xab,
-	0x8d, 0x63, 0x5c, 0x6f, 0x1c, 0xe3, 0xf7, 0xc6, 0x31, 0x2e, 0xb7, 0x4e, 0xe5, 0x7a, 0xeb, 0x54,
-	0x6e, 0xb6, 0x4e, 0xc5, 0x6f, 0xa0, 0xab, 0xd3, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x3f, 0x77,
-	0x2b, 0xc4, 0x8d, 0x03, 0x00, 0x00,
+	// 738 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x54, 0x4f, 0x6f, 0xda, 0x48,
+	0x14, 0xc7, 0x18, 0xf3, 0xe7, 0x01, 0x9b, 0xdd, 0x49, 0x36, 0xeb, 0x25, 0x59, 0xc2, 0x5a, 0x9b,
+	0x6c, 0x2e, 0x35, 0x4a, 0xd2, 0x4a, 0xed, 0xa1, 0x87, 0x92, 0x56, 0x42, 0x6a, 0x2a, 0x45, 0x4e,
+	0x2f, 0x3d, 0xa1, 0x01, 0x26, 0xb6, 0x15, 0x60, 0x46, 0xf6, 0x40, 0xa1, 0x9f, 0x22, 0x97, 0x7e,
+	0xa4, 0x4a, 0x39, 0xa6, 0xb7, 0x9e, 0xd2, 0x0a, 0xbe, 0x48, 0xe5, 0x37, 0xb6, 0x21, 0x69, 0x2a,
+	0xb5, 0xbd, 0xbd, 0xdf, 0xfb, 0xfd, 0x9e, 0xdf, 0x9f, 0x79, 0xcf, 0x50, 0xee, 0x0e, 0x78, 0xef,
+	0xc2, 0x16, 0x01, 0x97, 0x9c, 0x14, 0x44, 0x57, 0xce, 0x04, 0x0b, 0x6b, 0x75, 0xc4, 0x4d, 0xd1,
+	0xed, 0x05, 0x33, 0x21, 0x79, 0x33, 0xf4, 0xdd, 0x11, 0x95, 0xe3, 0x80, 0x29, 0x61, 0xad, 0x76,
+	0x87, 0x17, 0x01, 0xe7, 0xe7, 0x31, 0xb7, 0x9d, 0x70, 0xf8, 0xa9, 0x26, 0x9b, 0xf8, 0x7d, 0x36,
+	0xea, 0x25, 0x91, 0x8f, 0x5d, 0xee, 0x72, 0x34, 0x1f, 0x1c, 0xd8, 0x0f, 0xed, 0xa3, 0x26, 0xda,
+	0xdd, 0xf1, 0x79, 0xd3, 0xe5, 0xdc, 0x1d, 0xb0, 0x25, 0x96, 0xfe, 0x90, 0x85, 0x92, 0x0e, 0x45,
+	0x1c, 0xf9, 0xdf, 0xdd, 0xc8, 0x14, 0xa3, 0xa5, 0x54, 0xd6, 0x3b, 0xa8, 0x1e, 0x7b, 0x74, 0xc8,
+	0x06, 0x8c, 0x8f, 0xda, 0x34, 0xf4, 0xc8, 0x5f, 0x50, 0x70, 0x3b, 0xa1, 0xef, 0x0e, 0xa9, 0xa9,
+	0x35, 0xb4, 0xfd, 0x8a, 0x93, 0x77, 0xcf, 0x22, 0x44, 0xf6, 0xa0, 0xe8, 0x5d, 0xc4, 0x4c, 0x36,
+	0x62, 0x5a, 0xe5, 0xf9, 0xcd, 0x4e, 0xa1, 0xfd, 0x12, 0x69, 0xa7, 0xe0, 0x5d, 0x28, 0xdd, 0x06,
+	0x18, 0x74, 0x20, 0x3c, 0x6a, 0xea, 0x18, 0xae, 0x00, 0x21, 0x90, 0xf3, 0x68, 0xe8, 0x99, 0x39,
+	0x74, 0xa2, 0x6d, 0x7d, 0xd0, 0xc0, 0x68, 0x45, 0xe3, 0x24, 0xff, 0x43, 0xde, 0x63, 0xb4, 0xcf,
+	0x02, 0xcc, 0x59, 0x3e, 0x5c, 0xb3, 0xe3, 0x71, 0xd8, 0x6d, 0x74, 0x3b, 0x31, 0x4d, 0xfe, 0x85,
+	0x5c, 0x97, 0xf7, 0x67, 0x58, 0x40, 0xf9, 0xb0, 0x9a, 0xca, 0x9e, 0x53, 0x49, 0x1d, 0xa4, 0xc8,
+	0x53, 0xf8, 0xad, 0x97, 0x74, 0xd4, 0xc1, 0x9c, 0x3a, 0x8a, 0x37, 0x53, 0xf1, 0xad, 0x86, 0x9d,
+	0x6a, 0x6f, 0x15, 0x92, 0x03, 0x28, 0x26, 0x4f, 0x80, 0xc5, 0x96, 0x0f, 0xff, 0x4c, 0x03, 0x5f,
+	0xc4, 0xc4, 0x89, 0x1f, 0x4a, 0x27, 0x95, 0x59, 0xbb, 0x50, 0xc6, 0x36, 0xda, 0xcc, 0x77, 0x3d,
+	0x49, 0x36, 0xa3, 0x66, 0x22, 0x0b, 0x9b, 0xd1, 0x9d, 0x18, 0x59, 0x1f, 0x35, 0x28, 0x1f, 0xf3,
+	0xe1, 0xd0, 0x97, 0xaa, 0xe9, 0xef, 0xe8, 0xd2, 0x51, 0x65, 0x97, 0xa3, 0x22, 0xaf, 0x60, 0x9d,
+	0xba, 0x6e, 0xc0, 0x5c, 0x2a, 0x59, 0x27, 0xdd, 0xae, 0xb8, 0xb3, 0x6d, 0x3b, 0x59, 0x2c, 0xfb,
+	0x59, 0x22, 0x3a, 0x4b, 0x34, 0x0e, 0xa1, 0xdf, 0xf8, 0xc8, 0x3f, 0x00, 0x13, 0x3a, 0x18, 0xb3,
+	0xce, 0xca, 0x9b, 0x94, 0xd0, 0x83, 0x33, 0xd8, 0x00, 0x23, 0xe0, 0xe3, 0x51, 0xdf, 0x34, 0x1a,
+	0xda, 0xbe, 0xe1, 0x28, 0x10, 0xd5, 0x35, 0xf1, 0xd9, 0x5b, 0x33, 0x8f, 0xd5, 0xa2, 0x6d, 0xbd,
+	0xcf, 0x42, 0x5e, 0x3d, 0x11, 0xb1, 0x61, 0x5d, 0x04, 0x6c, 0xe2, 0xf3, 0x71, 0xd8, 0xc1, 0x23,
+	0x51, 0x1f, 0x57, 0x4b, 0xf4, 0x47, 0x42, 0xa9, 0x41, 0x45, 0x49, 0xf6, 0x60, 0x4d, 0xc9, 0xfa,
+	0x54, 0xd2, 0xce, 0x4a, 0xc7, 0x55, 0x74, 0x47, 0x2f, 0x8a, 0xba, 0xe5, 0x98, 0xf4, 0x5b, 0x63,
+	0x6a, 0x41, 0x29, 0x5d, 0xf9, 0xf8, 0xa5, 0x6a, 0xb6, 0x3a, 0x0a, 0x3b, 0x39, 0x0a, 0xfb, 0x75,
+	0xa2, 0x68, 0x15, 0xaf, 0x6e, 0x76, 0x32, 0x97, 0x9f, 0x77, 0x34, 0x67, 0x19, 0x46, 0x6a, 0x50,
+	0x14, 0x01, 0x17, 0x3c, 0x64, 0x01, 0xf6, 0x5a, 0x72, 0x52, 0x4c, 0xfe, 0x86, 0x22, 0x15, 0x42,
+	0x15, 0x96, 0xc7, 0xc2, 0x0a, 0x54, 0x08, 0x2c, 0xa9, 0x01, 0x95, 0x84, 0xea, 0x0c, 0xa8, 0x6b,
+	0x16, 0xb0, 0x30, 0x88, 0xe9, 0x13, 0xea, 0x5a, 0x8f, 0x20, 0x17, 0x35, 0x40, 0xb6, 0xa0, 0x14,
+	0x70, 0x2e, 0x57, 0x47, 0x51, 0x8c, 0x1c, 0xf8, 0x99, 0xdf, 0x41, 0x97, 0xd3, 0xd0, 0xcc, 0x36,
+	0xf4, 0xfd, 0x8a, 0x13, 0x99, 0xd6, 0x42, 0x83, 0xca, 0x31, 0x1f, 0x0a, 0xda, 0x93, 0x3f, 0x79,
+	0x18, 0xb7, 0x12, 0x65, 0xef, 0x24, 0xda, 0x82, 0x92, 0x9c, 0x22, 0xc5, 0x42, 0x53, 0xc7, 0x74,
+	0x45, 0x39, 0x6d, 0x23, 0xbe, 0xe7, 0x5e, 0x72, 0xbf, 0x7a, 0x2f, 0xc6, 0x8f, 0xdd, 0xcb, 0x13,
+	0xa8, 0x9e, 0xd2, 0x40, 0x9e, 0x31, 0x19, 0xaf, 0xce, 0x06, 0x18, 0x92, 0x4b, 0x3a, 0xc0, 0x26,
+	0xab, 0x8e, 0x02, 0xf7, 0xdd, 0x81, 0xf5, 0x06, 0x72, 0x51, 0x68, 0x14, 0xe1, 0x8f, 0xfa, 0x6c,
+	0x9a, 0x44, 0x20, 0x88, 0xbc, 0xdd, 0x99, 0x64, 0x61, 0x1c, 0xa2, 0x00, 0xd9, 0x05, 0x03, 0xff,
+	0xb7, 0xa6, 0x9e, 0x8e, 0x30, 0xbe, 0x96, 0xd3, 0xc8, 0xed, 0x28, 0xb6, 0x65, 0x5e, 0xcd, 0xeb,
+	0xda, 0xf5, 0xbc, 0xae, 0x7d, 0x99, 0xd7, 0xb5, 0xcb, 0x45, 0x3d, 0x73, 0xbd, 0xa8, 0x67, 0x3e,
+	0x2d, 0xea, 0x99, 0x6e, 0x1e, 0xd7, 0xe9, 0xe8, 0xeb, 0x00, 0x44, 0x66, 0x1e, 0xa2, 0xfc, 0x05,
+	0x00, 0x00,
 }
 
 func (m *ChameleonHash) Marshal() (dAtA []byte, err error) {
@@ -506,6 +762,18 @@ func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Evidence != nil {
+		{
+			size, err := m.Evidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.ChameleonHash != nil {
 		{
 			size, err := m.ChameleonHash.MarshalToSizedBuffer(dAtA[:i])
@@ -593,6 +861,23 @@ func (m *CommitBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.View != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.View))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.Round != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.AggregateSignature != nil {
 		{
 			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
@@ -640,6 +925,18 @@ func (m *Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.AppHashLag != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.AppHashLag))
+		i--
+		dAtA[i] = 0x38
+	}
+	if len(m.AppHash) > 0 {
+		i -= len(m.AppHash)
+		copy(dAtA[i:], m.AppHash)
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.AppHash)))
+		i--
+		dAtA[i] = 0x32
+	}
 	if len(m.Proposer) > 0 {
 		i -= len(m.Proposer)
 		copy(dAtA[i:], m.Proposer)
@@ -647,12 +944,12 @@ func (m *Header) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0x2a
 	}
-	n5, err5 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err5 != nil {
-		return 0, err5
+	n6, err6 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err6 != nil {
+		return 0, err6
 	}
-	i -= n5
-	i = encodeVarintBlock(dAtA, i, uint64(n5))
+	i -= n6
+	i = encodeVarintBlock(dAtA, i, uint64(n6))
 	i--
 	dAtA[i] = 0x22
 	if m.Height != 0 {
@@ -716,71 +1013,232 @@ func (m *Data) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintBlock(dAtA []byte, offset int, v uint64) int {
-	offset -= sovBlock(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *CompactBlock) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *ChameleonHash) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.GSigma)
-	if l > 0 {
-		n += 1 + l + sovBlock(uint64(l))
-	}
-	l = len(m.HKSigma)
-	if l > 0 {
-		n += 1 + l + sovBlock(uint64(l))
-	}
-	l = len(m.Alpha)
-	if l > 0 {
-		n += 1 + l + sovBlock(uint64(l))
-	}
-	l = len(m.Hash)
-	if l > 0 {
-		n += 1 + l + sovBlock(uint64(l))
-	}
-	return n
+
+func (m *CompactBlock) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *Block) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *CompactBlock) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Header != nil {
-		l = m.Header.Size()
-		n += 1 + l + sovBlock(uint64(l))
-	}
-	if m.Body != nil {
-		l = m.Body.Size()
-		n += 1 + l + sovBlock(uint64(l))
+	if m.Evidence != nil {
+		{
+			size, err := m.Evidence.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
 	}
 	if m.ChameleonHash != nil {
-		l = m.ChameleonHash.Size()
-		n += 1 + l + sovBlock(uint64(l))
+		{
+			size, err := m.ChameleonHash.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
 	}
-	return n
-}
-
-func (m *BlockHeight) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.TxHashes) > 0 {
+		for iNdEx := len(m.TxHashes) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.TxHashes[iNdEx])
+			copy(dAtA[i:], m.TxHashes[iNdEx])
+			i = encodeVarintBlock(dAtA, i, uint64(len(m.TxHashes[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	var l int
-	_ = l
-	if m.Height != 0 {
-		n += 1 + sovBlock(uint64(m.Height))
+	if len(m.RootHash) > 0 {
+		i -= len(m.RootHash)
+		copy(dAtA[i:], m.RootHash)
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.RootHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PartSetHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Total != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Part) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Part) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Part) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Proof != nil {
+		{
+			size, err := m.Proof.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintBlock(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Bytes) > 0 {
+		i -= len(m.Bytes)
+		copy(dAtA[i:], m.Bytes)
+		i = encodeVarintBlock(dAtA, i, uint64(len(m.Bytes)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Index != 0 {
+		i = encodeVarintBlock(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintBlock(dAtA []byte, offset int, v uint64) int {
+	offset -= sovBlock(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *ChameleonHash) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.GSigma)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	l = len(m.HKSigma)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	l = len(m.Alpha)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	return n
+}
+
+func (m *Block) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Body != nil {
+		l = m.Body.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.ChameleonHash != nil {
+		l = m.ChameleonHash.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Evidence != nil {
+		l = m.Evidence.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	return n
+}
+
+func (m *BlockHeight) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovBlock(uint64(m.Height))
 	}
 	return n
 }
@@ -802,6 +1260,16 @@ func (m *CommitBlock) Size() (n int) {
 		l = m.AggregateSignature.Size()
 		n += 1 + l + sovBlock(uint64(l))
 	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovBlock(uint64(m.Round))
+	}
+	if m.View != 0 {
+		n += 1 + sovBlock(uint64(m.View))
+	}
 	return n
 }
 
@@ -828,6 +1296,13 @@ func (m *Header) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovBlock(uint64(l))
 	}
+	l = len(m.AppHash)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.AppHashLag != 0 {
+		n += 1 + sovBlock(uint64(m.AppHashLag))
+	}
 	return n
 }
 
@@ -850,6 +1325,73 @@ func (m *Data) Size() (n int) {
 	return n
 }
 
+func (m *CompactBlock) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	l = len(m.RootHash)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if len(m.TxHashes) > 0 {
+		for _, b := range m.TxHashes {
+			l = len(b)
+			n += 1 + l + sovBlock(uint64(l))
+		}
+	}
+	if m.ChameleonHash != nil {
+		l = m.ChameleonHash.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Evidence != nil {
+		l = m.Evidence.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	return n
+}
+
+func (m *PartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Total != 0 {
+		n += 1 + sovBlock(uint64(m.Total))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	return n
+}
+
+func (m *Part) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Index != 0 {
+		n += 1 + sovBlock(uint64(m.Index))
+	}
+	l = len(m.Bytes)
+	if l > 0 {
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	if m.Proof != nil {
+		l = m.Proof.Size()
+		n += 1 + l + sovBlock(uint64(l))
+	}
+	return n
+}
+
 func sovBlock(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -887,7 +1429,7 @@ func (m *ChameleonHash) Unmarshal(dAtA []byte) error {
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field R1", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field GSigma", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -921,7 +1463,7 @@ func (m *ChameleonHash) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field R2", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HKSigma", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1179,6 +1721,42 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Evidence == nil {
+				m.Evidence = &EvidenceList{}
+			}
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
@@ -1387,11 +1965,83 @@ func (m *CommitBlock) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipBlock(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			m.View = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.View |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
 			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthBlock
@@ -1589,6 +2239,59 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 			}
 			m.Proposer = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AppHash = append(m.AppHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AppHash == nil {
+				m.AppHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AppHashLag", wireType)
+			}
+			m.AppHashLag = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.AppHashLag |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipBlock(dAtA[iNdEx:])
@@ -1726,6 +2429,472 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *CompactBlock) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactBlock: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactBlock: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RootHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.RootHash = append(m.RootHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.RootHash == nil {
+				m.RootHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxHashes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxHashes = append(m.TxHashes, make([]byte, postIndex-iNdEx))
+			copy(m.TxHashes[len(m.TxHashes)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChameleonHash", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ChameleonHash == nil {
+				m.ChameleonHash = &ChameleonHash{}
+			}
+			if err := m.ChameleonHash.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Evidence == nil {
+				m.Evidence = &EvidenceList{}
+			}
+			if err := m.Evidence.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PartSetHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PartSetHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PartSetHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Part) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowBlock
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Part: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Part: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bytes = append(m.Bytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bytes == nil {
+				m.Bytes = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Proof", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowBlock
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthBlock
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Proof == nil {
+				m.Proof = &pbcrypto.Proof{}
+			}
+			if err := m.Proof.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipBlock(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthBlock
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipBlock(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -35,6 +35,7 @@ const (
 	PreCommitType ConsensusMessageType = 2
 	CommitType    ConsensusMessageType = 3
 	DecideType    ConsensusMessageType = 4
+	NewViewType   ConsensusMessageType = 5
 )
 
 var ConsensusMessageType_name = map[int32]string{
@@ -43,6 +44,7 @@ var ConsensusMessageType_name = map[int32]string{
 	2: "PRE_COMMIT_TYPE",
 	3: "COMMIT_TYPE",
 	4: "DECIDE_TYPE",
+	5: "NEW_VIEW_TYPE",
 }
 
 var ConsensusMessageType_value = map[string]int32{
@@ -51,6 +53,7 @@ var ConsensusMessageType_value = map[string]int32{
 	"PRE_COMMIT_TYPE": 2,
 	"COMMIT_TYPE":     3,
 	"DECIDE_TYPE":     4,
+	"NEW_VIEW_TYPE":   5,
 }
 
 func (x ConsensusMessageType) String() string {
@@ -61,34 +64,6 @@ func (ConsensusMessageType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_56f0f2c53b3de771, []int{0}
 }
 
-type VoteType int32
-
-const (
-	PrepareVoteType   VoteType = 0
-	PreCommitVoteType VoteType = 1
-	CommitVoteType    VoteType = 2
-)
-
-var VoteType_name = map[int32]string{
-	0: "PREPARE_VOTE_TYPE",
-	1: "PRE_COMMIT_VOTE_TYPE",
-	2: "COMMIT_VOTE_TYPE",
-}
-
-var VoteType_value = map[string]int32{
-	"PREPARE_VOTE_TYPE":    0,
-	"PRE_COMMIT_VOTE_TYPE": 1,
-	"COMMIT_VOTE_TYPE":     2,
-}
-
-func (x VoteType) String() string {
-	return proto.EnumName(VoteType_name, int32(x))
-}
-
-func (VoteType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{1}
-}
-
 type NextView struct {
 	Type   ConsensusMessageType `protobuf:"varint,1,opt,name=type,proto3,enum=pbtypes.ConsensusMessageType" json:"type,omitempty"`
 	ID     string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
@@ -149,96 +124,23 @@ func (m *NextView) GetHeight() int64 {
 	return 0
 }
 
-type Vote struct {
-	VoteType  VoteType            `protobuf:"varint,1,opt,name=vote_type,json=voteType,proto3,enum=pbtypes.VoteType" json:"vote_type,omitempty"`
-	Height    int64               `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
-	ValueHash []byte              `protobuf:"bytes,3,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
-	Timestamp time.Time           `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
-	Signature *pbcrypto.Signature `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
-}
-
-func (m *Vote) Reset()         { *m = Vote{} }
-func (m *Vote) String() string { return proto.CompactTextString(m) }
-func (*Vote) ProtoMessage()    {}
-func (*Vote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{1}
-}
-func (m *Vote) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *Vote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_Vote.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
-	}
-}
-func (m *Vote) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Vote.Merge(m, src)
-}
-func (m *Vote) XXX_Size() int {
-	return m.Size()
-}
-func (m *Vote) XXX_DiscardUnknown() {
-	xxx_messageInfo_Vote.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Vote proto.InternalMessageInfo
-
-func (m *Vote) GetVoteType() VoteType {
-	if m != nil {
-		return m.VoteType
-	}
-	return PrepareVoteType
-}
-
-func (m *Vote) GetHeight() int64 {
-	if m != nil {
-		return m.Height
-	}
-	return 0
-}
-
-func (m *Vote) GetValueHash() []byte {
-	if m != nil {
-		return m.ValueHash
-	}
-	return nil
-}
-
-func (m *Vote) GetTimestamp() time.Time {
-	if m != nil {
-		return m.Timestamp
-	}
-	return time.Time{}
-}
-
-func (m *Vote) GetSignature() *pbcrypto.Signature {
-	if m != nil {
-		return m.Signature
-	}
-	return nil
-}
-
 type Prepare struct {
 	Type      ConsensusMessageType `protobuf:"varint,1,opt,name=type,proto3,enum=pbtypes.ConsensusMessageType" json:"type,omitempty"`
 	ID        string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	Height    int64                `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
-	Block     *Block       `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty"`
+	Block     *Block               `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty"`
 	Timestamp time.Time            `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	Signature *pbcrypto.Signature  `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Round     int32                `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	HighQC    *PreCommit           `protobuf:"bytes,8,opt,name=high_qc,json=highQc,proto3" json:"high_qc,omitempty"`
+	TC        *TimeoutCert         `protobuf:"bytes,9,opt,name=tc,proto3" json:"tc,omitempty"`
 }
 
 func (m *Prepare) Reset()         { *m = Prepare{} }
 func (m *Prepare) String() string { return proto.CompactTextString(m) }
 func (*Prepare) ProtoMessage()    {}
 func (*Prepare) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{2}
+	return fileDescriptor_56f0f2c53b3de771, []int{1}
 }
 func (m *Prepare) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -309,6 +211,27 @@ func (m *Prepare) GetSignature() *pbcrypto.Signature {
 	return nil
 }
 
+func (m *Prepare) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Prepare) GetHighQC() *PreCommit {
+	if m != nil {
+		return m.HighQC
+	}
+	return nil
+}
+
+func (m *Prepare) GetTC() *TimeoutCert {
+	if m != nil {
+		return m.TC
+	}
+	return nil
+}
+
 type PrepareVote struct {
 	Vote *Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
 }
@@ -317,7 +240,7 @@ func (m *PrepareVote) Reset()         { *m = PrepareVote{} }
 func (m *PrepareVote) String() string { return proto.CompactTextString(m) }
 func (*PrepareVote) ProtoMessage()    {}
 func (*PrepareVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{3}
+	return fileDescriptor_56f0f2c53b3de771, []int{2}
 }
 func (m *PrepareVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -360,13 +283,15 @@ type PreCommit struct {
 	ValueHash          []byte                       `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
 	Timestamp          time.Time                    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,6,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+	Round              int32                        `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash          []byte                       `protobuf:"bytes,8,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
 }
 
 func (m *PreCommit) Reset()         { *m = PreCommit{} }
 func (m *PreCommit) String() string { return proto.CompactTextString(m) }
 func (*PreCommit) ProtoMessage()    {}
 func (*PreCommit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{4}
+	return fileDescriptor_56f0f2c53b3de771, []int{3}
 }
 func (m *PreCommit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -437,6 +362,20 @@ func (m *PreCommit) GetAggregateSignature() *pbcrypto.AggregateSignature {
 	return nil
 }
 
+func (m *PreCommit) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *PreCommit) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type PreCommitVote struct {
 	Vote *Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
 }
@@ -445,7 +384,7 @@ func (m *PreCommitVote) Reset()         { *m = PreCommitVote{} }
 func (m *PreCommitVote) String() string { return proto.CompactTextString(m) }
 func (*PreCommitVote) ProtoMessage()    {}
 func (*PreCommitVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{5}
+	return fileDescriptor_56f0f2c53b3de771, []int{4}
 }
 func (m *PreCommitVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -488,13 +427,15 @@ type Commit struct {
 	ValueHash          []byte                       `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
 	Timestamp          time.Time                    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,6,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+	Round              int32                        `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash          []byte                       `protobuf:"bytes,8,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
 func (m *Commit) String() string { return proto.CompactTextString(m) }
 func (*Commit) ProtoMessage()    {}
 func (*Commit) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{6}
+	return fileDescriptor_56f0f2c53b3de771, []int{5}
 }
 func (m *Commit) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -565,6 +506,20 @@ func (m *Commit) GetAggregateSignature() *pbcrypto.AggregateSignature {
 	return nil
 }
 
+func (m *Commit) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
+}
+
+func (m *Commit) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
+}
+
 type CommitVote struct {
 	Vote *Vote `protobuf:"bytes,1,opt,name=vote,proto3" json:"vote,omitempty"`
 }
@@ -573,7 +528,7 @@ func (m *CommitVote) Reset()         { *m = CommitVote{} }
 func (m *CommitVote) String() string { return proto.CompactTextString(m) }
 func (*CommitVote) ProtoMessage()    {}
 func (*CommitVote) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{7}
+	return fileDescriptor_56f0f2c53b3de771, []int{6}
 }
 func (m *CommitVote) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -616,13 +571,15 @@ type Decide struct {
 	ValueHash          []byte                       `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
 	Timestamp          time.Time                    `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
 	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,6,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+	Round              int32                        `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash          []byte                       `protobuf:"bytes,8,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
 }
 
 func (m *Decide) Reset()         { *m = Decide{} }
 func (m *Decide) String() string { return proto.CompactTextString(m) }
 func (*Decide) ProtoMessage()    {}
 func (*Decide) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{8}
+	return fileDescriptor_56f0f2c53b3de771, []int{7}
 }
 func (m *Decide) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -693,32 +650,45 @@ func (m *Decide) GetAggregateSignature() *pbcrypto.AggregateSignature {
 	return nil
 }
 
-type Message struct {
-	// Types that are valid to be assigned to Msg:
-	//
-	//	*Message_NextView
-	//	*Message_Prepare
-	//	*Message_PrepareVote
-	//	*Message_PreCommit
-	//	*Message_PreCommitVote
-	//	*Message_Commit
-	//	*Message_CommitVote
-	//	*Message_Decide
-	Msg isMessage_Msg `protobuf_oneof:"Msg"`
+func (m *Decide) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-func (m *Message) Reset()         { *m = Message{} }
-func (m *Message) String() string { return proto.CompactTextString(m) }
-func (*Message) ProtoMessage()    {}
-func (*Message) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56f0f2c53b3de771, []int{9}
+func (m *Decide) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
+	}
+	return nil
 }
-func (m *Message) XXX_Unmarshal(b []byte) error {
+
+// NewView 视图切换消息，副本节点在共识超时后广播该消息，其中携带了自己所知道的最高的QC以及QC对应的区块
+type NewView struct {
+	Type        ConsensusMessageType `protobuf:"varint,1,opt,name=type,proto3,enum=pbtypes.ConsensusMessageType" json:"type,omitempty"`
+	ID          string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Height      int64                `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Round       int32                `protobuf:"varint,4,opt,name=round,proto3" json:"round,omitempty"`
+	HighQCRound int32                `protobuf:"varint,5,opt,name=high_qc_round,json=highQcRound,proto3" json:"high_qc_round,omitempty"`
+	HighQCBlock *Block               `protobuf:"bytes,6,opt,name=high_qc_block,json=highQcBlock,proto3" json:"high_qc_block,omitempty"`
+	HighQC      *PreCommit           `protobuf:"bytes,7,opt,name=high_qc,json=highQc,proto3" json:"high_qc,omitempty"`
+	Timestamp   time.Time            `protobuf:"bytes,8,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature   *pbcrypto.Signature  `protobuf:"bytes,9,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (m *NewView) Reset()         { *m = NewView{} }
+func (m *NewView) String() string { return proto.CompactTextString(m) }
+func (*NewView) ProtoMessage()    {}
+func (*NewView) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{8}
+}
+func (m *NewView) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *NewView) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+		return xxx_messageInfo_NewView.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -728,1214 +698,8012 @@ func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Message) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Message.Merge(m, src)
+func (m *NewView) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_NewView.Merge(m, src)
 }
-func (m *Message) XXX_Size() int {
+func (m *NewView) XXX_Size() int {
 	return m.Size()
 }
-func (m *Message) XXX_DiscardUnknown() {
-	xxx_messageInfo_Message.DiscardUnknown(m)
+func (m *NewView) XXX_DiscardUnknown() {
+	xxx_messageInfo_NewView.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Message proto.InternalMessageInfo
+var xxx_messageInfo_NewView proto.InternalMessageInfo
 
-type isMessage_Msg interface {
-	isMessage_Msg()
-	MarshalTo([]byte) (int, error)
-	Size() int
+func (m *NewView) GetType() ConsensusMessageType {
+	if m != nil {
+		return m.Type
+	}
+	return NextViewType
 }
 
-type Message_NextView struct {
-	NextView *NextView `protobuf:"bytes,1,opt,name=next_view,json=nextView,proto3,oneof" json:"next_view,omitempty"`
-}
-type Message_Prepare struct {
-	Prepare *Prepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof" json:"prepare,omitempty"`
-}
-type Message_PrepareVote struct {
-	PrepareVote *PrepareVote `protobuf:"bytes,3,opt,name=prepare_vote,json=prepareVote,proto3,oneof" json:"prepare_vote,omitempty"`
-}
-type Message_PreCommit struct {
-	PreCommit *PreCommit `protobuf:"bytes,4,opt,name=pre_commit,json=preCommit,proto3,oneof" json:"pre_commit,omitempty"`
-}
-type Message_PreCommitVote struct {
-	PreCommitVote *PreCommitVote `protobuf:"bytes,5,opt,name=pre_commit_vote,json=preCommitVote,proto3,oneof" json:"pre_commit_vote,omitempty"`
-}
-type Message_Commit struct {
-	Commit *Commit `protobuf:"bytes,6,opt,name=commit,proto3,oneof" json:"commit,omitempty"`
+func (m *NewView) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
 }
-type Message_CommitVote struct {
-	CommitVote *CommitVote `protobuf:"bytes,7,opt,name=commit_vote,json=commitVote,proto3,oneof" json:"commit_vote,omitempty"`
+
+func (m *NewView) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
 }
-type Message_Decide struct {
-	Decide *Decide `protobuf:"bytes,8,opt,name=decide,proto3,oneof" json:"decide,omitempty"`
+
+func (m *NewView) GetRound() int32 {
+	if m != nil {
+		return m.Round
+	}
+	return 0
 }
 
-func (*Message_NextView) isMessage_Msg()      {}
-func (*Message_Prepare) isMessage_Msg()       {}
-func (*Message_PrepareVote) isMessage_Msg()   {}
-func (*Message_PreCommit) isMessage_Msg()     {}
-func (*Message_PreCommitVote) isMessage_Msg() {}
-func (*Message_Commit) isMessage_Msg()        {}
-func (*Message_CommitVote) isMessage_Msg()    {}
-func (*Message_Decide) isMessage_Msg()        {}
+func (m *NewView) GetHighQCRound() int32 {
+	if m != nil {
+		return m.HighQCRound
+	}
+	return 0
+}
 
-func (m *Message) GetMsg() isMessage_Msg {
+func (m *NewView) GetHighQCBlock() *Block {
 	if m != nil {
-		return m.Msg
+		return m.HighQCBlock
 	}
 	return nil
 }
 
-func (m *Message) GetNextView() *NextView {
-	if x, ok := m.GetMsg().(*Message_NextView); ok {
-		return x.NextView
+func (m *NewView) GetHighQC() *PreCommit {
+	if m != nil {
+		return m.HighQC
 	}
 	return nil
 }
 
-func (m *Message) GetPrepare() *Prepare {
-	if x, ok := m.GetMsg().(*Message_Prepare); ok {
-		return x.Prepare
+func (m *NewView) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return nil
+	return time.Time{}
 }
 
-func (m *Message) GetPrepareVote() *PrepareVote {
-	if x, ok := m.GetMsg().(*Message_PrepareVote); ok {
-		return x.PrepareVote
+func (m *NewView) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
 	return nil
 }
 
-func (m *Message) GetPreCommit() *PreCommit {
-	if x, ok := m.GetMsg().(*Message_PreCommit); ok {
-		return x.PreCommit
+// QuorumCert 链式HotStuff里2/3以上验证者对view视图里height高度的区块的投票所聚合成的QC。
+type QuorumCert struct {
+	View               int64                        `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Height             int64                        `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash          []byte                       `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,4,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
+}
+
+func (m *QuorumCert) Reset()         { *m = QuorumCert{} }
+func (m *QuorumCert) String() string { return proto.CompactTextString(m) }
+func (*QuorumCert) ProtoMessage()    {}
+func (*QuorumCert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{9}
+}
+func (m *QuorumCert) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QuorumCert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QuorumCert.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return nil
+}
+func (m *QuorumCert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuorumCert.Merge(m, src)
+}
+func (m *QuorumCert) XXX_Size() int {
+	return m.Size()
+}
+func (m *QuorumCert) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuorumCert.DiscardUnknown(m)
 }
 
-func (m *Message) GetPreCommitVote() *PreCommitVote {
-	if x, ok := m.GetMsg().(*Message_PreCommitVote); ok {
-		return x.PreCommitVote
+var xxx_messageInfo_QuorumCert proto.InternalMessageInfo
+
+func (m *QuorumCert) GetView() int64 {
+	if m != nil {
+		return m.View
 	}
-	return nil
+	return 0
 }
 
-func (m *Message) GetCommit() *Commit {
-	if x, ok := m.GetMsg().(*Message_Commit); ok {
-		return x.Commit
+func (m *QuorumCert) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return nil
+	return 0
 }
 
-func (m *Message) GetCommitVote() *CommitVote {
-	if x, ok := m.GetMsg().(*Message_CommitVote); ok {
-		return x.CommitVote
+func (m *QuorumCert) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
 	}
 	return nil
 }
 
-func (m *Message) GetDecide() *Decide {
-	if x, ok := m.GetMsg().(*Message_Decide); ok {
-		return x.Decide
+func (m *QuorumCert) GetAggregateSignature() *pbcrypto.AggregateSignature {
+	if m != nil {
+		return m.AggregateSignature
 	}
 	return nil
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*Message) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*Message_NextView)(nil),
-		(*Message_Prepare)(nil),
-		(*Message_PrepareVote)(nil),
-		(*Message_PreCommit)(nil),
-		(*Message_PreCommitVote)(nil),
-		(*Message_Commit)(nil),
-		(*Message_CommitVote)(nil),
-		(*Message_Decide)(nil),
-	}
+// ChainedProposal 链式HotStuff里主节点在view视图提出的区块，justify是区块的父区块的QC。
+type ChainedProposal struct {
+	ID        string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	View      int64               `protobuf:"varint,2,opt,name=view,proto3" json:"view,omitempty"`
+	Block     *Block              `protobuf:"bytes,3,opt,name=block,proto3" json:"block,omitempty"`
+	Justify   *QuorumCert         `protobuf:"bytes,4,opt,name=justify,proto3" json:"justify,omitempty"`
+	Timestamp time.Time           `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature *pbcrypto.Signature `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func init() {
-	proto.RegisterEnum("pbtypes.ConsensusMessageType", ConsensusMessageType_name, ConsensusMessageType_value)
-	proto.RegisterEnum("pbtypes.VoteType", VoteType_name, VoteType_value)
-	proto.RegisterType((*NextView)(nil), "pbtypes.NextView")
-	proto.RegisterType((*Vote)(nil), "pbtypes.Vote")
-	proto.RegisterType((*Prepare)(nil), "pbtypes.Prepare")
-	proto.RegisterType((*PrepareVote)(nil), "pbtypes.PrepareVote")
-	proto.RegisterType((*PreCommit)(nil), "pbtypes.PreCommit")
-	proto.RegisterType((*PreCommitVote)(nil), "pbtypes.PreCommitVote")
-	proto.RegisterType((*Commit)(nil), "pbtypes.Commit")
-	proto.RegisterType((*CommitVote)(nil), "pbtypes.CommitVote")
-	proto.RegisterType((*Decide)(nil), "pbtypes.Decide")
-	proto.RegisterType((*Message)(nil), "pbtypes.Message")
+func (m *ChainedProposal) Reset()         { *m = ChainedProposal{} }
+func (m *ChainedProposal) String() string { return proto.CompactTextString(m) }
+func (*ChainedProposal) ProtoMessage()    {}
+func (*ChainedProposal) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{10}
+}
+func (m *ChainedProposal) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainedProposal) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainedProposal.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *ChainedProposal) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainedProposal.Merge(m, src)
+}
+func (m *ChainedProposal) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainedProposal) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainedProposal.DiscardUnknown(m)
 }
 
-func init() { proto.RegisterFile("consensus.proto", fileDescriptor_56f0f2c53b3de771) }
+var xxx_messageInfo_ChainedProposal proto.InternalMessageInfo
 
-var fileDescriptor_56f0f2c53b3de771 = []byte{
-	// 860 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x96, 0xcf, 0x6f, 0xe2, 0x46,
-	0x14, 0xc7, 0x6d, 0x43, 0x00, 0x3f, 0x12, 0x48, 0x26, 0x69, 0x44, 0xad, 0xae, 0xf1, 0xa2, 0xa8,
-	0xa2, 0x51, 0x6b, 0x36, 0xa4, 0xaa, 0xda, 0x5b, 0x17, 0xb0, 0x04, 0x07, 0x76, 0x91, 0x8b, 0x68,
-	0x7b, 0xb2, 0x0c, 0x4c, 0x8d, 0xd5, 0x80, 0x2d, 0xdb, 0xb0, 0x9b, 0xff, 0xa0, 0xe5, 0xb4, 0xf7,
-	0xca, 0x52, 0xa5, 0xf6, 0xd0, 0x63, 0xff, 0x8c, 0x3d, 0xae, 0xd4, 0x4b, 0x4f, 0xdb, 0x8a, 0xf4,
-	0x0f, 0xa9, 0x18, 0x8f, 0x7f, 0x91, 0x6d, 0xb5, 0xad, 0x56, 0x7b, 0xca, 0x6d, 0x7e, 0x7c, 0xde,
-	0x7c, 0x67, 0xbe, 0xef, 0xf1, 0x0c, 0x94, 0x27, 0xd6, 0xc2, 0xc5, 0x0b, 0x77, 0xe9, 0xca, 0xb6,
-	0x63, 0x79, 0x16, 0xca, 0xdb, 0x63, 0xef, 0xda, 0xc6, 0xae, 0x20, 0x92, 0x79, 0xc3, 0x1e, 0x4f,
-	0x9c, 0x6b, 0xdb, 0xb3, 0x1a, 0xae, 0x69, 0x2c, 0x74, 0x6f, 0xe9, 0xe0, 0x00, 0x14, 0xde, 0x0d,
-	0xf7, 0x09, 0xde, 0x18, 0x5f, 0x59, 0x93, 0x6f, 0xe9, 0xd6, 0xa7, 0x86, 0x65, 0x58, 0x64, 0xf8,
-	0xd1, 0x85, 0xfc, 0xb1, 0x7c, 0xd9, 0x20, 0xe3, 0xf1, 0xf2, 0x9b, 0x86, 0x61, 0x59, 0xc6, 0x15,
-	0x8e, 0xe7, 0x9e, 0x39, 0xc7, 0xae, 0xa7, 0xcf, 0x6d, 0x1a, 0x79, 0xb6, 0x1b, 0x19, 0xcd, 0xc9,
-	0x28, 0xa0, 0x6a, 0x73, 0x28, 0x3c, 0xc2, 0x4f, 0xbd, 0x91, 0x89, 0x9f, 0xa0, 0x0b, 0xc8, 0x6e,
-	0x2f, 0x50, 0x61, 0x25, 0xb6, 0x5e, 0x6a, 0xde, 0x93, 0xe9, 0x7d, 0xe4, 0x76, 0xf8, 0xae, 0x3e,
-	0x76, 0x5d, 0xdd, 0xc0, 0xc3, 0x6b, 0x1b, 0xab, 0x04, 0x45, 0xa7, 0xc0, 0x99, 0xd3, 0x0a, 0x27,
-	0xb1, 0x75, 0xbe, 0x95, 0xdb, 0xbc, 0xac, 0x72, 0xbd, 0x8e, 0xca, 0x99, 0x53, 0x74, 0x0a, 0xb9,
-	0x19, 0x36, 0x8d, 0x99, 0x57, 0xc9, 0x48, 0x6c, 0x3d, 0xa3, 0xd2, 0x59, 0xed, 0x2f, 0x16, 0xb2,
-	0x23, 0xcb, 0xc3, 0x48, 0x06, 0x7e, 0x65, 0x79, 0x58, 0x4b, 0x08, 0x1e, 0x45, 0x82, 0x5b, 0x82,
-	0x88, 0x14, 0x56, 0x74, 0x94, 0x38, 0x90, 0x4b, 0x1e, 0x88, 0xee, 0x01, 0xac, 0xf4, 0xab, 0x25,
-	0xd6, 0x66, 0xba, 0x3b, 0x23, 0x62, 0xfb, 0x2a, 0x4f, 0x56, 0xba, 0xba, 0x3b, 0x43, 0x2d, 0xe0,
-	0x23, 0x5f, 0x2a, 0x59, 0x89, 0xad, 0x17, 0x9b, 0x82, 0x1c, 0x38, 0x27, 0x87, 0xce, 0xc9, 0xc3,
-	0x90, 0x68, 0x15, 0x9e, 0xbf, 0xac, 0x32, 0xcf, 0xfe, 0xa8, 0xb2, 0x6a, 0x1c, 0x86, 0x2e, 0x80,
-	0x8f, 0x12, 0x56, 0xd9, 0x23, 0x67, 0x1c, 0xcb, 0x61, 0x2e, 0xe5, 0x2f, 0xc2, 0x2d, 0x35, 0xa6,
-	0x6a, 0xdf, 0x73, 0x90, 0x1f, 0x38, 0xd8, 0xd6, 0x1d, 0xfc, 0x16, 0x5c, 0x45, 0x67, 0xb0, 0x47,
-	0x6a, 0x86, 0xbe, 0xb0, 0x14, 0x69, 0xb4, 0xb6, 0xab, 0x6a, 0xb0, 0x99, 0xf6, 0x62, 0xef, 0x0d,
-	0x78, 0x91, 0x7b, 0x2d, 0x2f, 0x1e, 0x40, 0x91, 0x5a, 0x41, 0x12, 0x7f, 0x1f, 0xb2, 0xdb, 0xa4,
-	0x12, 0x3b, 0x8a, 0xcd, 0x83, 0x54, 0xce, 0x55, 0xb2, 0x55, 0xfb, 0x91, 0x03, 0x7e, 0xe0, 0xe0,
-	0xb6, 0x35, 0x9f, 0x9b, 0xde, 0xdb, 0xf0, 0x2f, 0x5d, 0x44, 0xd9, 0x7f, 0x2d, 0xa2, 0xff, 0x69,
-	0x5c, 0x1f, 0x8e, 0x75, 0xc3, 0x70, 0xb0, 0xa1, 0x7b, 0x58, 0xdb, 0xb5, 0xf0, 0xbd, 0xd8, 0xc2,
-	0x87, 0x21, 0x14, 0x7b, 0x89, 0xf4, 0x5b, 0x6b, 0xb5, 0x26, 0x1c, 0x44, 0x0e, 0xbd, 0xae, 0xad,
-	0x3e, 0x07, 0xb9, 0x3b, 0x4f, 0xff, 0xd9, 0xd3, 0x06, 0xc0, 0x7f, 0x37, 0xb4, 0x83, 0x27, 0xe6,
-	0x14, 0xdf, 0x19, 0xfa, 0x2a, 0x43, 0x7f, 0xcd, 0x40, 0x9e, 0xbe, 0x1b, 0x3d, 0x00, 0x7e, 0x81,
-	0x9f, 0x7a, 0xda, 0xca, 0xc4, 0x4f, 0xa8, 0xa7, 0x71, 0xbf, 0x0f, 0xbf, 0x40, 0x5d, 0x46, 0x2d,
-	0x2c, 0xc2, 0xaf, 0xd1, 0x87, 0x90, 0xb7, 0x83, 0xbe, 0x41, 0x4c, 0x2a, 0x36, 0x0f, 0x23, 0x9e,
-	0xf6, 0x93, 0x2e, 0xa3, 0x86, 0x08, 0xfa, 0x0c, 0xf6, 0xe9, 0x50, 0x23, 0x69, 0xcb, 0x90, 0x90,
-	0x93, 0xdd, 0x90, 0x6d, 0xf6, 0xba, 0x8c, 0x5a, 0xb4, 0x13, 0x1d, 0xe9, 0x12, 0xc0, 0x76, 0xb0,
-	0x36, 0x21, 0xb9, 0xa7, 0x2d, 0x14, 0x25, 0x03, 0x83, 0xaa, 0xe8, 0x32, 0x2a, 0x6f, 0x47, 0x5d,
-	0xe9, 0x73, 0x28, 0xc7, 0x41, 0x81, 0x64, 0x60, 0xfa, 0xe9, 0xed, 0x48, 0x2a, 0x7a, 0x60, 0xa7,
-	0x7e, 0xb1, 0x1f, 0x40, 0x8e, 0x4a, 0x06, 0xfe, 0x96, 0x13, 0x45, 0x43, 0xf5, 0x28, 0x80, 0x3e,
-	0x81, 0x62, 0x52, 0x28, 0x1f, 0xf5, 0xdd, 0x24, 0x4f, 0x55, 0x60, 0x92, 0x92, 0x98, 0x92, 0xfa,
-	0xac, 0x14, 0x76, 0x24, 0x82, 0xb2, 0xdd, 0x4a, 0x04, 0x40, 0x6b, 0x0f, 0x32, 0x7d, 0xd7, 0x38,
-	0xff, 0x8d, 0x85, 0x93, 0x57, 0xd5, 0x2c, 0x3a, 0x83, 0xd2, 0x23, 0xe5, 0xab, 0xa1, 0x36, 0xea,
-	0x29, 0x5f, 0x6a, 0xc3, 0xaf, 0x07, 0xca, 0x21, 0x23, 0x1c, 0xae, 0x7d, 0x69, 0x3f, 0xcc, 0x1d,
-	0xa1, 0xee, 0xc3, 0xfe, 0x40, 0x55, 0x06, 0x0f, 0x55, 0x25, 0x60, 0x58, 0xa1, 0xbc, 0xf6, 0xa5,
-	0xb0, 0xff, 0x13, 0xe4, 0x7d, 0x28, 0x0f, 0x54, 0x45, 0x6b, 0x3f, 0xee, 0xf7, 0x7b, 0xc3, 0x80,
-	0xe2, 0x84, 0xa3, 0xb5, 0x2f, 0xc5, 0x0d, 0x8d, 0x70, 0x55, 0x28, 0x26, 0x99, 0x8c, 0x50, 0x5a,
-	0xfb, 0x12, 0xa4, 0x81, 0x8e, 0xd2, 0xee, 0x75, 0xa8, 0x54, 0x36, 0x00, 0x82, 0x87, 0x6d, 0x01,
-	0xa1, 0xf0, 0xdd, 0x4f, 0x22, 0xf3, 0xcb, 0xcf, 0x22, 0x7b, 0xfe, 0x03, 0x0b, 0x85, 0xf0, 0x3f,
-	0x05, 0x3a, 0x87, 0xa3, 0xf0, 0x8e, 0xa3, 0xc7, 0x43, 0x25, 0x7c, 0xcc, 0xf1, 0xda, 0x97, 0xca,
-	0x89, 0x2a, 0x21, 0x6c, 0x03, 0x4e, 0x12, 0x97, 0x8d, 0x71, 0x56, 0x78, 0x67, 0xed, 0x4b, 0x47,
-	0xa9, 0x0c, 0x93, 0x80, 0x3a, 0x1c, 0xde, 0x82, 0x39, 0x01, 0xad, 0x7d, 0xa9, 0x94, 0x26, 0xe3,
-	0xdb, 0xb5, 0x2a, 0xcf, 0x37, 0x22, 0xfb, 0x62, 0x23, 0xb2, 0x7f, 0x6e, 0x44, 0xf6, 0xd9, 0x8d,
-	0xc8, 0xbc, 0xb8, 0x11, 0x99, 0xdf, 0x6f, 0x44, 0x66, 0x9c, 0x23, 0x3f, 0xdc, 0xcb, 0xbf, 0x03,
-	0x00, 0x00, 0xff, 0xff, 0x59, 0xaf, 0xc5, 0x4a, 0x5a, 0x0a, 0x00, 0x00,
+func (m *ChainedProposal) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
 }
 
-func (m *NextView) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ChainedProposal) GetView() int64 {
+	if m != nil {
+		return m.View
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *NextView) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ChainedProposal) GetBlock() *Block {
+	if m != nil {
+		return m.Block
+	}
+	return nil
 }
 
-func (m *NextView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
+func (m *ChainedProposal) GetJustify() *QuorumCert {
+	if m != nil {
+		return m.Justify
 	}
-	if m.Type != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+	return nil
+}
+
+func (m *ChainedProposal) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
+	return time.Time{}
 }
 
-func (m *Vote) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ChainedProposal) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Vote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ChainedVote 链式HotStuff里验证者对view视图里的区块的投票，投票会被发送给下一个视图的主节点。
+type ChainedVote struct {
+	View      int64               `protobuf:"varint,1,opt,name=view,proto3" json:"view,omitempty"`
+	Height    int64               `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash []byte              `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	ValueHash []byte              `protobuf:"bytes,4,opt,name=value_hash,json=valueHash,proto3" json:"value_hash,omitempty"`
+	Timestamp time.Time           `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature *pbcrypto.Signature `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *Vote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Signature != nil {
-		{
-			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *ChainedVote) Reset()         { *m = ChainedVote{} }
+func (m *ChainedVote) String() string { return proto.CompactTextString(m) }
+func (*ChainedVote) ProtoMessage()    {}
+func (*ChainedVote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{11}
+}
+func (m *ChainedVote) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainedVote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainedVote.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x2a
-	}
-	n2, err2 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err2 != nil {
-		return 0, err2
-	}
-	i -= n2
-	i = encodeVarintConsensus(dAtA, i, uint64(n2))
-	i--
-	dAtA[i] = 0x22
-	if len(m.ValueHash) > 0 {
-		i -= len(m.ValueHash)
-		copy(dAtA[i:], m.ValueHash)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x10
-	}
-	if m.VoteType != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.VoteType))
-		i--
-		dAtA[i] = 0x8
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ChainedVote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainedVote.Merge(m, src)
+}
+func (m *ChainedVote) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainedVote) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainedVote.DiscardUnknown(m)
 }
 
-func (m *Prepare) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ChainedVote proto.InternalMessageInfo
+
+func (m *ChainedVote) GetView() int64 {
+	if m != nil {
+		return m.View
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Prepare) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ChainedVote) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
 }
 
-func (m *Prepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Signature != nil {
-		{
-			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	n4, err4 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err4 != nil {
-		return 0, err4
-	}
-	i -= n4
-	i = encodeVarintConsensus(dAtA, i, uint64(n4))
-	i--
-	dAtA[i] = 0x2a
-	if m.Block != nil {
-		{
-			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
+func (m *ChainedVote) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
 	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
+	return nil
+}
+
+func (m *ChainedVote) GetValueHash() []byte {
+	if m != nil {
+		return m.ValueHash
 	}
-	if m.Type != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+	return nil
+}
+
+func (m *ChainedVote) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
+	return time.Time{}
 }
 
-func (m *PrepareVote) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ChainedVote) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PrepareVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// ChainedNewView 链式HotStuff里验证者在视图超时后发送给view视图主节点的消息，携带自己所知道的最高QC。
+type ChainedNewView struct {
+	ID        string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	View      int64               `protobuf:"varint,2,opt,name=view,proto3" json:"view,omitempty"`
+	HighQC    *QuorumCert         `protobuf:"bytes,3,opt,name=high_qc,json=highQc,proto3" json:"high_qc,omitempty"`
+	Timestamp time.Time           `protobuf:"bytes,4,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature *pbcrypto.Signature `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *PrepareVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Vote != nil {
-		{
-			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *ChainedNewView) Reset()         { *m = ChainedNewView{} }
+func (m *ChainedNewView) String() string { return proto.CompactTextString(m) }
+func (*ChainedNewView) ProtoMessage()    {}
+func (*ChainedNewView) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{12}
+}
+func (m *ChainedNewView) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *ChainedNewView) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_ChainedNewView.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *ChainedNewView) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ChainedNewView.Merge(m, src)
+}
+func (m *ChainedNewView) XXX_Size() int {
+	return m.Size()
+}
+func (m *ChainedNewView) XXX_DiscardUnknown() {
+	xxx_messageInfo_ChainedNewView.DiscardUnknown(m)
 }
 
-func (m *PreCommit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_ChainedNewView proto.InternalMessageInfo
+
+func (m *ChainedNewView) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *PreCommit) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *ChainedNewView) GetView() int64 {
+	if m != nil {
+		return m.View
+	}
+	return 0
 }
 
-func (m *PreCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.AggregateSignature != nil {
-		{
-			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	n8, err8 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err8 != nil {
-		return 0, err8
-	}
-	i -= n8
-	i = encodeVarintConsensus(dAtA, i, uint64(n8))
-	i--
-	dAtA[i] = 0x2a
-	if len(m.ValueHash) > 0 {
-		i -= len(m.ValueHash)
-		copy(dAtA[i:], m.ValueHash)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
+func (m *ChainedNewView) GetHighQC() *QuorumCert {
+	if m != nil {
+		return m.HighQC
 	}
-	if m.Type != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+	return nil
+}
+
+func (m *ChainedNewView) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
+	return time.Time{}
 }
 
-func (m *PreCommitVote) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *ChainedNewView) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *PreCommitVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Timeout 两阶段共识里验证者在规定时间内没有确认区块时广播的超时消息，签名只覆盖高度、轮次和highQC所在的轮次，
+// 这样不同验证者的签名可以被聚合成超时证书。
+type Timeout struct {
+	ID          string              `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Height      int64               `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	Round       int32               `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	HighQC      *PreCommit          `protobuf:"bytes,4,opt,name=high_qc,json=highQc,proto3" json:"high_qc,omitempty"`
+	HighQCBlock *Block              `protobuf:"bytes,5,opt,name=high_qc_block,json=highQcBlock,proto3" json:"high_qc_block,omitempty"`
+	Timestamp   time.Time           `protobuf:"bytes,6,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature   *pbcrypto.Signature `protobuf:"bytes,7,opt,name=signature,proto3" json:"signature,omitempty"`
 }
 
-func (m *PreCommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Vote != nil {
-		{
-			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *Timeout) Reset()         { *m = Timeout{} }
+func (m *Timeout) String() string { return proto.CompactTextString(m) }
+func (*Timeout) ProtoMessage()    {}
+func (*Timeout) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{13}
+}
+func (m *Timeout) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Timeout) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Timeout.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
+}
+func (m *Timeout) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Timeout.Merge(m, src)
+}
+func (m *Timeout) XXX_Size() int {
+	return m.Size()
+}
+func (m *Timeout) XXX_DiscardUnknown() {
+	xxx_messageInfo_Timeout.DiscardUnknown(m)
 }
 
-func (m *Commit) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_Timeout proto.InternalMessageInfo
+
+func (m *Timeout) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return dAtA[:n], nil
+	return ""
 }
 
-func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Timeout) GetHeight() int64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
 }
 
-func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.AggregateSignature != nil {
-		{
-			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
-	}
-	n11, err11 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err11 != nil {
-		return 0, err11
-	}
-	i -= n11
-	i = encodeVarintConsensus(dAtA, i, uint64(n11))
-	i--
-	dAtA[i] = 0x2a
-	if len(m.ValueHash) > 0 {
-		i -= len(m.ValueHash)
-		copy(dAtA[i:], m.ValueHash)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+func (m *Timeout) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *CommitVote) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Timeout) GetHighQC() *PreCommit {
+	if m != nil {
+		return m.HighQC
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *CommitVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *Timeout) GetHighQCBlock() *Block {
+	if m != nil {
+		return m.HighQCBlock
+	}
+	return nil
 }
 
-func (m *CommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Vote != nil {
-		{
-			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0xa
+func (m *Timeout) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
+	return time.Time{}
 }
 
-func (m *Decide) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *Timeout) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
-	return dAtA[:n], nil
+	return nil
 }
 
-func (m *Decide) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// TimeoutCert 超时证书（TC），由2/3以上验证者在同一轮次的超时消息聚合而成。
+type TimeoutCert struct {
+	Height             int64                        `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round              int32                        `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Entries            []*TimeoutCert_Entry         `protobuf:"bytes,3,rep,name=entries,proto3" json:"entries,omitempty"`
+	AggregateSignature *pbcrypto.AggregateSignature `protobuf:"bytes,4,opt,name=aggregate_signature,json=aggregateSignature,proto3" json:"aggregate_signature,omitempty"`
 }
 
-func (m *Decide) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.AggregateSignature != nil {
-		{
-			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *TimeoutCert) Reset()         { *m = TimeoutCert{} }
+func (m *TimeoutCert) String() string { return proto.CompactTextString(m) }
+func (*TimeoutCert) ProtoMessage()    {}
+func (*TimeoutCert) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{14}
+}
+func (m *TimeoutCert) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutCert) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutCert.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x32
-	}
-	n14, err14 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
-	if err14 != nil {
-		return 0, err14
-	}
-	i -= n14
-	i = encodeVarintConsensus(dAtA, i, uint64(n14))
-	i--
-	dAtA[i] = 0x2a
-	if len(m.ValueHash) > 0 {
-		i -= len(m.ValueHash)
-		copy(dAtA[i:], m.ValueHash)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.Height != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
-		i--
-		dAtA[i] = 0x18
-	}
-	if len(m.ID) > 0 {
-		i -= len(m.ID)
-		copy(dAtA[i:], m.ID)
-		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
-		i--
-		dAtA[i] = 0x12
+		return b[:n], nil
 	}
-	if m.Type != 0 {
-		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
+}
+func (m *TimeoutCert) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutCert.Merge(m, src)
+}
+func (m *TimeoutCert) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutCert) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutCert.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeoutCert proto.InternalMessageInfo
+
+func (m *TimeoutCert) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
 
-func (m *Message) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (m *TimeoutCert) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return dAtA[:n], nil
+	return 0
 }
 
-func (m *Message) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *TimeoutCert) GetEntries() []*TimeoutCert_Entry {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
 }
 
-func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Msg != nil {
-		{
-			size := m.Msg.Size()
-			i -= size
-			if _, err := m.Msg.MarshalTo(dAtA[i:]); err != nil {
-				return 0, err
-			}
-		}
+func (m *TimeoutCert) GetAggregateSignature() *pbcrypto.AggregateSignature {
+	if m != nil {
+		return m.AggregateSignature
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
 
-func (m *Message_NextView) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Entry 签名者以及签名者的highQC所在的轮次，按照签名者的ID排序
+type TimeoutCert_Entry struct {
+	ID          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	HighQCRound int32  `protobuf:"varint,2,opt,name=high_qc_round,json=highQcRound,proto3" json:"high_qc_round,omitempty"`
 }
 
-func (m *Message_NextView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.NextView != nil {
-		{
-			size, err := m.NextView.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *TimeoutCert_Entry) Reset()         { *m = TimeoutCert_Entry{} }
+func (m *TimeoutCert_Entry) String() string { return proto.CompactTextString(m) }
+func (*TimeoutCert_Entry) ProtoMessage()    {}
+func (*TimeoutCert_Entry) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{14, 0}
+}
+func (m *TimeoutCert_Entry) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeoutCert_Entry) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeoutCert_Entry.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0xa
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Message_Prepare) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *TimeoutCert_Entry) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeoutCert_Entry.Merge(m, src)
+}
+func (m *TimeoutCert_Entry) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeoutCert_Entry) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeoutCert_Entry.DiscardUnknown(m)
 }
 
-func (m *Message_Prepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Prepare != nil {
-		{
-			size, err := m.Prepare.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+var xxx_messageInfo_TimeoutCert_Entry proto.InternalMessageInfo
+
+func (m *TimeoutCert_Entry) GetID() string {
+	if m != nil {
+		return m.ID
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Message_PrepareVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return ""
 }
 
-func (m *Message_PrepareVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.PrepareVote != nil {
-		{
-			size, err := m.PrepareVote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
+func (m *TimeoutCert_Entry) GetHighQCRound() int32 {
+	if m != nil {
+		return m.HighQCRound
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Message_PreCommit) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+// CompactPrepare 紧凑模式下主节点发送的Prepare消息，除了区块被换成紧凑区块以外，其他字段与Prepare相同。
+type CompactPrepare struct {
+	Type      ConsensusMessageType `protobuf:"varint,1,opt,name=type,proto3,enum=pbtypes.ConsensusMessageType" json:"type,omitempty"`
+	ID        string               `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Height    int64                `protobuf:"varint,3,opt,name=height,proto3" json:"height,omitempty"`
+	Block     *CompactBlock        `protobuf:"bytes,4,opt,name=block,proto3" json:"block,omitempty"`
+	Timestamp time.Time            `protobuf:"bytes,5,opt,name=timestamp,proto3,stdtime" json:"timestamp"`
+	Signature *pbcrypto.Signature  `protobuf:"bytes,6,opt,name=signature,proto3" json:"signature,omitempty"`
+	Round     int32                `protobuf:"varint,7,opt,name=round,proto3" json:"round,omitempty"`
+	HighQC    *PreCommit           `protobuf:"bytes,8,opt,name=high_qc,json=highQc,proto3" json:"high_qc,omitempty"`
+	TC        *TimeoutCert         `protobuf:"bytes,9,opt,name=tc,proto3" json:"tc,omitempty"`
 }
 
-func (m *Message_PreCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.PreCommit != nil {
-		{
-			size, err := m.PreCommit.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
+func (m *CompactPrepare) Reset()         { *m = CompactPrepare{} }
+func (m *CompactPrepare) String() string { return proto.CompactTextString(m) }
+func (*CompactPrepare) ProtoMessage()    {}
+func (*CompactPrepare) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{15}
+}
+func (m *CompactPrepare) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CompactPrepare) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CompactPrepare.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
-		i--
-		dAtA[i] = 0x22
+		return b[:n], nil
 	}
-	return len(dAtA) - i, nil
 }
-func (m *Message_PreCommitVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (m *CompactPrepare) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompactPrepare.Merge(m, src)
+}
+func (m *CompactPrepare) XXX_Size() int {
+	return m.Size()
+}
+func (m *CompactPrepare) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompactPrepare.DiscardUnknown(m)
 }
 
-func (m *Message_PreCommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.PreCommitVote != nil {
-		{
-			size, err := m.PreCommitVote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x2a
+var xxx_messageInfo_CompactPrepare proto.InternalMessageInfo
+
+func (m *CompactPrepare) GetType() ConsensusMessageType {
+	if m != nil {
+		return m.Type
 	}
-	return len(dAtA) - i, nil
+	return NextViewType
 }
-func (m *Message_Commit) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *CompactPrepare) GetID() string {
+	if m != nil {
+		return m.ID
+	}
+	return ""
 }
 
-func (m *Message_Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Commit != nil {
-		{
-			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x32
+func (m *CompactPrepare) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return len(dAtA) - i, nil
+	return 0
 }
-func (m *Message_CommitVote) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+
+func (m *CompactPrepare) GetBlock() *CompactBlock {
+	if m != nil {
+		return m.Block
+	}
+	return nil
 }
 
-func (m *Message_CommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.CommitVote != nil {
-		{
-			size, err := m.CommitVote.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x3a
+func (m *CompactPrepare) GetTimestamp() time.Time {
+	if m != nil {
+		return m.Timestamp
 	}
-	return len(dAtA) - i, nil
-}
-func (m *Message_Decide) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return time.Time{}
 }
 
-func (m *Message_Decide) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	if m.Decide != nil {
-		{
-			size, err := m.Decide.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintConsensus(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x42
+func (m *CompactPrepare) GetSignature() *pbcrypto.Signature {
+	if m != nil {
+		return m.Signature
 	}
-	return len(dAtA) - i, nil
+	return nil
 }
-func encodeVarintConsensus(dAtA []byte, offset int, v uint64) int {
-	offset -= sovConsensus(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+
+func (m *CompactPrepare) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return 0
 }
-func (m *NextView) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovConsensus(uint64(m.Type))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
+
+func (m *CompactPrepare) GetHighQC() *PreCommit {
+	if m != nil {
+		return m.HighQC
 	}
-	return n
+	return nil
 }
 
-func (m *Vote) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.VoteType != 0 {
-		n += 1 + sovConsensus(uint64(m.VoteType))
-	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
-	}
-	l = len(m.ValueHash)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovConsensus(uint64(l))
-	if m.Signature != nil {
-		l = m.Signature.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+func (m *CompactPrepare) GetTC() *TimeoutCert {
+	if m != nil {
+		return m.TC
 	}
-	return n
+	return nil
 }
 
-func (m *Prepare) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovConsensus(uint64(m.Type))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
+// BlockTxsRequest 副本节点还原紧凑区块时，向主节点索要自己交易池里缺少的交易，indexes是这些交易在区块里的位置。
+type BlockTxsRequest struct {
+	Height    int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round     int32    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash []byte   `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Indexes   []uint32 `protobuf:"varint,4,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+}
+
+func (m *BlockTxsRequest) Reset()         { *m = BlockTxsRequest{} }
+func (m *BlockTxsRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockTxsRequest) ProtoMessage()    {}
+func (*BlockTxsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{16}
+}
+func (m *BlockTxsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockTxsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockTxsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
+}
+func (m *BlockTxsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockTxsRequest.Merge(m, src)
+}
+func (m *BlockTxsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockTxsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockTxsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockTxsRequest proto.InternalMessageInfo
+
+func (m *BlockTxsRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	if m.Block != nil {
-		l = m.Block.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return 0
+}
+
+func (m *BlockTxsRequest) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovConsensus(uint64(l))
-	if m.Signature != nil {
-		l = m.Signature.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return 0
+}
+
+func (m *BlockTxsRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
 	}
-	return n
+	return nil
 }
 
-func (m *PrepareVote) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BlockTxsRequest) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
 	}
-	var l int
-	_ = l
-	if m.Vote != nil {
-		l = m.Vote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+// BlockTxsResponse 主节点对BlockTxsRequest的回复，txs与indexes一一对应。
+type BlockTxsResponse struct {
+	Height    int64    `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round     int32    `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	BlockHash []byte   `protobuf:"bytes,3,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	Indexes   []uint32 `protobuf:"varint,4,rep,packed,name=indexes,proto3" json:"indexes,omitempty"`
+	Txs       [][]byte `protobuf:"bytes,5,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *BlockTxsResponse) Reset()         { *m = BlockTxsResponse{} }
+func (m *BlockTxsResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockTxsResponse) ProtoMessage()    {}
+func (*BlockTxsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{17}
+}
+func (m *BlockTxsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockTxsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockTxsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *BlockTxsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockTxsResponse.Merge(m, src)
+}
+func (m *BlockTxsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockTxsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockTxsResponse.DiscardUnknown(m)
 }
 
-func (m *PreCommit) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_BlockTxsResponse proto.InternalMessageInfo
+
+func (m *BlockTxsResponse) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovConsensus(uint64(m.Type))
+	return 0
+}
+
+func (m *BlockTxsResponse) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
+	return 0
+}
+
+func (m *BlockTxsResponse) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
 	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
+	return nil
+}
+
+func (m *BlockTxsResponse) GetIndexes() []uint32 {
+	if m != nil {
+		return m.Indexes
 	}
-	l = len(m.ValueHash)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+func (m *BlockTxsResponse) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
 	}
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovConsensus(uint64(l))
-	if m.AggregateSignature != nil {
-		l = m.AggregateSignature.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+// BlockPartSetHeader 主节点把Prepare消息切分成分片后，先把分片集合的头部发送给其他节点。
+type BlockPartSetHeader struct {
+	Height int64         `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32         `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Header PartSetHeader `protobuf:"bytes,3,opt,name=header,proto3" json:"header"`
+}
+
+func (m *BlockPartSetHeader) Reset()         { *m = BlockPartSetHeader{} }
+func (m *BlockPartSetHeader) String() string { return proto.CompactTextString(m) }
+func (*BlockPartSetHeader) ProtoMessage()    {}
+func (*BlockPartSetHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{18}
+}
+func (m *BlockPartSetHeader) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockPartSetHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockPartSetHeader.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *BlockPartSetHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockPartSetHeader.Merge(m, src)
+}
+func (m *BlockPartSetHeader) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockPartSetHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockPartSetHeader.DiscardUnknown(m)
 }
 
-func (m *PreCommitVote) Size() (n int) {
-	if m == nil {
-		return 0
+var xxx_messageInfo_BlockPartSetHeader proto.InternalMessageInfo
+
+func (m *BlockPartSetHeader) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	var l int
-	_ = l
-	if m.Vote != nil {
-		l = m.Vote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return 0
+}
+
+func (m *BlockPartSetHeader) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return n
+	return 0
 }
 
-func (m *Commit) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BlockPartSetHeader) GetHeader() PartSetHeader {
+	if m != nil {
+		return m.Header
 	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovConsensus(uint64(m.Type))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
-	}
-	l = len(m.ValueHash)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovConsensus(uint64(l))
-	if m.AggregateSignature != nil {
-		l = m.AggregateSignature.Size()
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	return n
+	return PartSetHeader{}
 }
 
-func (m *CommitVote) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Vote != nil {
-		l = m.Vote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	return n
+// BlockPart Prepare消息的一个分片，任何拥有该分片的节点都可以把它转发给其他节点。
+type BlockPart struct {
+	Height int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Hash   []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Part   *Part  `protobuf:"bytes,4,opt,name=part,proto3" json:"part,omitempty"`
 }
 
-func (m *Decide) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovConsensus(uint64(m.Type))
-	}
-	l = len(m.ID)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	if m.Height != 0 {
-		n += 1 + sovConsensus(uint64(m.Height))
-	}
-	l = len(m.ValueHash)
-	if l > 0 {
-		n += 1 + l + sovConsensus(uint64(l))
-	}
-	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
-	n += 1 + l + sovConsensus(uint64(l))
-	if m.AggregateSignature != nil {
-		l = m.AggregateSignature.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+func (m *BlockPart) Reset()         { *m = BlockPart{} }
+func (m *BlockPart) String() string { return proto.CompactTextString(m) }
+func (*BlockPart) ProtoMessage()    {}
+func (*BlockPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{19}
+}
+func (m *BlockPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
+}
+func (m *BlockPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockPart.Merge(m, src)
+}
+func (m *BlockPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockPart.DiscardUnknown(m)
 }
 
-func (m *Message) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Msg != nil {
-		n += m.Msg.Size()
+var xxx_messageInfo_BlockPart proto.InternalMessageInfo
+
+func (m *BlockPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return n
+	return 0
 }
 
-func (m *Message_NextView) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.NextView != nil {
-		l = m.NextView.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+func (m *BlockPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return n
+	return 0
 }
-func (m *Message_Prepare) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Prepare != nil {
-		l = m.Prepare.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+
+func (m *BlockPart) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	return n
+	return nil
 }
-func (m *Message_PrepareVote) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *BlockPart) GetPart() *Part {
+	if m != nil {
+		return m.Part
 	}
-	var l int
-	_ = l
-	if m.PrepareVote != nil {
-		l = m.PrepareVote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+// HasBlockPart 节点收到一个新的分片后告诉其他节点，其他节点就不会再向它发送这个分片。
+type HasBlockPart struct {
+	Height int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Round  int32  `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
+	Hash   []byte `protobuf:"bytes,3,opt,name=hash,proto3" json:"hash,omitempty"`
+	Index  uint32 `protobuf:"varint,4,opt,name=index,proto3" json:"index,omitempty"`
+}
+
+func (m *HasBlockPart) Reset()         { *m = HasBlockPart{} }
+func (m *HasBlockPart) String() string { return proto.CompactTextString(m) }
+func (*HasBlockPart) ProtoMessage()    {}
+func (*HasBlockPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{20}
+}
+func (m *HasBlockPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HasBlockPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HasBlockPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *Message_PreCommit) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *HasBlockPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HasBlockPart.Merge(m, src)
+}
+func (m *HasBlockPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *HasBlockPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_HasBlockPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HasBlockPart proto.InternalMessageInfo
+
+func (m *HasBlockPart) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	var l int
-	_ = l
-	if m.PreCommit != nil {
-		l = m.PreCommit.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return 0
+}
+
+func (m *HasBlockPart) GetRound() int32 {
+	if m != nil {
+		return m.Round
 	}
-	return n
+	return 0
 }
-func (m *Message_PreCommitVote) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *HasBlockPart) GetHash() []byte {
+	if m != nil {
+		return m.Hash
 	}
-	var l int
-	_ = l
-	if m.PreCommitVote != nil {
-		l = m.PreCommitVote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+func (m *HasBlockPart) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
 	}
-	return n
+	return 0
 }
-func (m *Message_Commit) Size() (n int) {
-	if m == nil {
-		return 0
+
+// BlockRequest 副本节点错过了Prepare消息，却收到了认证该区块的QC时，向其他节点索要变色龙哈希为block_hash的区块。
+type BlockRequest struct {
+	Height    int64  `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	BlockHash []byte `protobuf:"bytes,2,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+}
+
+func (m *BlockRequest) Reset()         { *m = BlockRequest{} }
+func (m *BlockRequest) String() string { return proto.CompactTextString(m) }
+func (*BlockRequest) ProtoMessage()    {}
+func (*BlockRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{21}
+}
+func (m *BlockRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	var l int
-	_ = l
-	if m.Commit != nil {
-		l = m.Commit.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+}
+func (m *BlockRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockRequest.Merge(m, src)
+}
+func (m *BlockRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockRequest proto.InternalMessageInfo
+
+func (m *BlockRequest) GetHeight() int64 {
+	if m != nil {
+		return m.Height
 	}
-	return n
+	return 0
 }
-func (m *Message_CommitVote) Size() (n int) {
-	if m == nil {
-		return 0
+
+func (m *BlockRequest) GetBlockHash() []byte {
+	if m != nil {
+		return m.BlockHash
 	}
-	var l int
-	_ = l
-	if m.CommitVote != nil {
-		l = m.CommitVote.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+// BlockResponse 对BlockRequest的回复，区块是否正确由索要区块的节点用QC验证。
+type BlockResponse struct {
+	Block *Block `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+}
+
+func (m *BlockResponse) Reset()         { *m = BlockResponse{} }
+func (m *BlockResponse) String() string { return proto.CompactTextString(m) }
+func (*BlockResponse) ProtoMessage()    {}
+func (*BlockResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{22}
+}
+func (m *BlockResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return n
 }
-func (m *Message_Decide) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *BlockResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockResponse.Merge(m, src)
+}
+func (m *BlockResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockResponse proto.InternalMessageInfo
+
+func (m *BlockResponse) GetBlock() *Block {
+	if m != nil {
+		return m.Block
 	}
-	var l int
-	_ = l
-	if m.Decide != nil {
-		l = m.Decide.Size()
-		n += 1 + l + sovConsensus(uint64(l))
+	return nil
+}
+
+type Message struct {
+	// Types that are valid to be assigned to Msg:
+	//	*Message_NextView
+	//	*Message_Prepare
+	//	*Message_PrepareVote
+	//	*Message_PreCommit
+	//	*Message_PreCommitVote
+	//	*Message_Commit
+	//	*Message_CommitVote
+	//	*Message_Decide
+	//	*Message_NewView
+	//	*Message_ChainedProposal
+	//	*Message_ChainedVote
+	//	*Message_ChainedNewView
+	//	*Message_Timeout
+	//	*Message_CompactPrepare
+	//	*Message_BlockTxsRequest
+	//	*Message_BlockTxsResponse
+	//	*Message_BlockPartSetHeader
+	//	*Message_BlockPart
+	//	*Message_HasBlockPart
+	//	*Message_BlockRequest
+	//	*Message_BlockResponse
+	//	*Message_AggregateVote
+	Msg isMessage_Msg `protobuf_oneof:"Msg"`
+}
+
+func (m *Message) Reset()         { *m = Message{} }
+func (m *Message) String() string { return proto.CompactTextString(m) }
+func (*Message) ProtoMessage()    {}
+func (*Message) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56f0f2c53b3de771, []int{23}
+}
+func (m *Message) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Message) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Message.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Message) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Message.Merge(m, src)
+}
+func (m *Message) XXX_Size() int {
+	return m.Size()
+}
+func (m *Message) XXX_DiscardUnknown() {
+	xxx_messageInfo_Message.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Message proto.InternalMessageInfo
+
+type isMessage_Msg interface {
+	isMessage_Msg()
+	MarshalTo([]byte) (int, error)
+	Size() int
+}
+
+type Message_NextView struct {
+	NextView *NextView `protobuf:"bytes,1,opt,name=next_view,json=nextView,proto3,oneof" json:"next_view,omitempty"`
+}
+type Message_Prepare struct {
+	Prepare *Prepare `protobuf:"bytes,2,opt,name=prepare,proto3,oneof" json:"prepare,omitempty"`
+}
+type Message_PrepareVote struct {
+	PrepareVote *PrepareVote `protobuf:"bytes,3,opt,name=prepare_vote,json=prepareVote,proto3,oneof" json:"prepare_vote,omitempty"`
+}
+type Message_PreCommit struct {
+	PreCommit *PreCommit `protobuf:"bytes,4,opt,name=pre_commit,json=preCommit,proto3,oneof" json:"pre_commit,omitempty"`
+}
+type Message_PreCommitVote struct {
+	PreCommitVote *PreCommitVote `protobuf:"bytes,5,opt,name=pre_commit_vote,json=preCommitVote,proto3,oneof" json:"pre_commit_vote,omitempty"`
+}
+type Message_Commit struct {
+	Commit *Commit `protobuf:"bytes,6,opt,name=commit,proto3,oneof" json:"commit,omitempty"`
+}
+type Message_CommitVote struct {
+	CommitVote *CommitVote `protobuf:"bytes,7,opt,name=commit_vote,json=commitVote,proto3,oneof" json:"commit_vote,omitempty"`
+}
+type Message_Decide struct {
+	Decide *Decide `protobuf:"bytes,8,opt,name=decide,proto3,oneof" json:"decide,omitempty"`
+}
+type Message_NewView struct {
+	NewView *NewView `protobuf:"bytes,9,opt,name=new_view,json=newView,proto3,oneof" json:"new_view,omitempty"`
+}
+type Message_ChainedProposal struct {
+	ChainedProposal *ChainedProposal `protobuf:"bytes,10,opt,name=chained_proposal,json=chainedProposal,proto3,oneof" json:"chained_proposal,omitempty"`
+}
+type Message_ChainedVote struct {
+	ChainedVote *ChainedVote `protobuf:"bytes,11,opt,name=chained_vote,json=chainedVote,proto3,oneof" json:"chained_vote,omitempty"`
+}
+type Message_ChainedNewView struct {
+	ChainedNewView *ChainedNewView `protobuf:"bytes,12,opt,name=chained_new_view,json=chainedNewView,proto3,oneof" json:"chained_new_view,omitempty"`
+}
+type Message_Timeout struct {
+	Timeout *Timeout `protobuf:"bytes,13,opt,name=timeout,proto3,oneof" json:"timeout,omitempty"`
+}
+type Message_CompactPrepare struct {
+	CompactPrepare *CompactPrepare `protobuf:"bytes,14,opt,name=compact_prepare,json=compactPrepare,proto3,oneof" json:"compact_prepare,omitempty"`
+}
+type Message_BlockTxsRequest struct {
+	BlockTxsRequest *BlockTxsRequest `protobuf:"bytes,15,opt,name=block_txs_request,json=blockTxsRequest,proto3,oneof" json:"block_txs_request,omitempty"`
+}
+type Message_BlockTxsResponse struct {
+	BlockTxsResponse *BlockTxsResponse `protobuf:"bytes,16,opt,name=block_txs_response,json=blockTxsResponse,proto3,oneof" json:"block_txs_response,omitempty"`
+}
+type Message_BlockPartSetHeader struct {
+	BlockPartSetHeader *BlockPartSetHeader `protobuf:"bytes,17,opt,name=block_part_set_header,json=blockPartSetHeader,proto3,oneof" json:"block_part_set_header,omitempty"`
+}
+type Message_BlockPart struct {
+	BlockPart *BlockPart `protobuf:"bytes,18,opt,name=block_part,json=blockPart,proto3,oneof" json:"block_part,omitempty"`
+}
+type Message_HasBlockPart struct {
+	HasBlockPart *HasBlockPart `protobuf:"bytes,19,opt,name=has_block_part,json=hasBlockPart,proto3,oneof" json:"has_block_part,omitempty"`
+}
+type Message_BlockRequest struct {
+	BlockRequest *BlockRequest `protobuf:"bytes,20,opt,name=block_request,json=blockRequest,proto3,oneof" json:"block_request,omitempty"`
+}
+type Message_BlockResponse struct {
+	BlockResponse *BlockResponse `protobuf:"bytes,21,opt,name=block_response,json=blockResponse,proto3,oneof" json:"block_response,omitempty"`
+}
+type Message_AggregateVote struct {
+	AggregateVote *AggregateVote `protobuf:"bytes,22,opt,name=aggregate_vote,json=aggregateVote,proto3,oneof" json:"aggregate_vote,omitempty"`
+}
+
+func (*Message_NextView) isMessage_Msg()           {}
+func (*Message_Prepare) isMessage_Msg()            {}
+func (*Message_PrepareVote) isMessage_Msg()        {}
+func (*Message_PreCommit) isMessage_Msg()          {}
+func (*Message_PreCommitVote) isMessage_Msg()      {}
+func (*Message_Commit) isMessage_Msg()             {}
+func (*Message_CommitVote) isMessage_Msg()         {}
+func (*Message_Decide) isMessage_Msg()             {}
+func (*Message_NewView) isMessage_Msg()            {}
+func (*Message_ChainedProposal) isMessage_Msg()    {}
+func (*Message_ChainedVote) isMessage_Msg()        {}
+func (*Message_ChainedNewView) isMessage_Msg()     {}
+func (*Message_Timeout) isMessage_Msg()            {}
+func (*Message_CompactPrepare) isMessage_Msg()     {}
+func (*Message_BlockTxsRequest) isMessage_Msg()    {}
+func (*Message_BlockTxsResponse) isMessage_Msg()   {}
+func (*Message_BlockPartSetHeader) isMessage_Msg() {}
+func (*Message_BlockPart) isMessage_Msg()          {}
+func (*Message_HasBlockPart) isMessage_Msg()       {}
+func (*Message_BlockRequest) isMessage_Msg()       {}
+func (*Message_BlockResponse) isMessage_Msg()      {}
+func (*Message_AggregateVote) isMessage_Msg()      {}
+
+func (m *Message) GetMsg() isMessage_Msg {
+	if m != nil {
+		return m.Msg
+	}
+	return nil
+}
+
+func (m *Message) GetNextView() *NextView {
+	if x, ok := m.GetMsg().(*Message_NextView); ok {
+		return x.NextView
+	}
+	return nil
+}
+
+func (m *Message) GetPrepare() *Prepare {
+	if x, ok := m.GetMsg().(*Message_Prepare); ok {
+		return x.Prepare
+	}
+	return nil
+}
+
+func (m *Message) GetPrepareVote() *PrepareVote {
+	if x, ok := m.GetMsg().(*Message_PrepareVote); ok {
+		return x.PrepareVote
+	}
+	return nil
+}
+
+func (m *Message) GetPreCommit() *PreCommit {
+	if x, ok := m.GetMsg().(*Message_PreCommit); ok {
+		return x.PreCommit
+	}
+	return nil
+}
+
+func (m *Message) GetPreCommitVote() *PreCommitVote {
+	if x, ok := m.GetMsg().(*Message_PreCommitVote); ok {
+		return x.PreCommitVote
+	}
+	return nil
+}
+
+func (m *Message) GetCommit() *Commit {
+	if x, ok := m.GetMsg().(*Message_Commit); ok {
+		return x.Commit
+	}
+	return nil
+}
+
+func (m *Message) GetCommitVote() *CommitVote {
+	if x, ok := m.GetMsg().(*Message_CommitVote); ok {
+		return x.CommitVote
+	}
+	return nil
+}
+
+func (m *Message) GetDecide() *Decide {
+	if x, ok := m.GetMsg().(*Message_Decide); ok {
+		return x.Decide
+	}
+	return nil
+}
+
+func (m *Message) GetNewView() *NewView {
+	if x, ok := m.GetMsg().(*Message_NewView); ok {
+		return x.NewView
+	}
+	return nil
+}
+
+func (m *Message) GetChainedProposal() *ChainedProposal {
+	if x, ok := m.GetMsg().(*Message_ChainedProposal); ok {
+		return x.ChainedProposal
+	}
+	return nil
+}
+
+func (m *Message) GetChainedVote() *ChainedVote {
+	if x, ok := m.GetMsg().(*Message_ChainedVote); ok {
+		return x.ChainedVote
+	}
+	return nil
+}
+
+func (m *Message) GetChainedNewView() *ChainedNewView {
+	if x, ok := m.GetMsg().(*Message_ChainedNewView); ok {
+		return x.ChainedNewView
+	}
+	return nil
+}
+
+func (m *Message) GetTimeout() *Timeout {
+	if x, ok := m.GetMsg().(*Message_Timeout); ok {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (m *Message) GetCompactPrepare() *CompactPrepare {
+	if x, ok := m.GetMsg().(*Message_CompactPrepare); ok {
+		return x.CompactPrepare
+	}
+	return nil
+}
+
+func (m *Message) GetBlockTxsRequest() *BlockTxsRequest {
+	if x, ok := m.GetMsg().(*Message_BlockTxsRequest); ok {
+		return x.BlockTxsRequest
+	}
+	return nil
+}
+
+func (m *Message) GetBlockTxsResponse() *BlockTxsResponse {
+	if x, ok := m.GetMsg().(*Message_BlockTxsResponse); ok {
+		return x.BlockTxsResponse
+	}
+	return nil
+}
+
+func (m *Message) GetBlockPartSetHeader() *BlockPartSetHeader {
+	if x, ok := m.GetMsg().(*Message_BlockPartSetHeader); ok {
+		return x.BlockPartSetHeader
+	}
+	return nil
+}
+
+func (m *Message) GetBlockPart() *BlockPart {
+	if x, ok := m.GetMsg().(*Message_BlockPart); ok {
+		return x.BlockPart
+	}
+	return nil
+}
+
+func (m *Message) GetHasBlockPart() *HasBlockPart {
+	if x, ok := m.GetMsg().(*Message_HasBlockPart); ok {
+		return x.HasBlockPart
+	}
+	return nil
+}
+
+func (m *Message) GetBlockRequest() *BlockRequest {
+	if x, ok := m.GetMsg().(*Message_BlockRequest); ok {
+		return x.BlockRequest
+	}
+	return nil
+}
+
+func (m *Message) GetBlockResponse() *BlockResponse {
+	if x, ok := m.GetMsg().(*Message_BlockResponse); ok {
+		return x.BlockResponse
+	}
+	return nil
+}
+
+func (m *Message) GetAggregateVote() *AggregateVote {
+	if x, ok := m.GetMsg().(*Message_AggregateVote); ok {
+		return x.AggregateVote
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*Message) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*Message_NextView)(nil),
+		(*Message_Prepare)(nil),
+		(*Message_PrepareVote)(nil),
+		(*Message_PreCommit)(nil),
+		(*Message_PreCommitVote)(nil),
+		(*Message_Commit)(nil),
+		(*Message_CommitVote)(nil),
+		(*Message_Decide)(nil),
+		(*Message_NewView)(nil),
+		(*Message_ChainedProposal)(nil),
+		(*Message_ChainedVote)(nil),
+		(*Message_ChainedNewView)(nil),
+		(*Message_Timeout)(nil),
+		(*Message_CompactPrepare)(nil),
+		(*Message_BlockTxsRequest)(nil),
+		(*Message_BlockTxsResponse)(nil),
+		(*Message_BlockPartSetHeader)(nil),
+		(*Message_BlockPart)(nil),
+		(*Message_HasBlockPart)(nil),
+		(*Message_BlockRequest)(nil),
+		(*Message_BlockResponse)(nil),
+		(*Message_AggregateVote)(nil),
+	}
+}
+
+func init() {
+	proto.RegisterEnum("pbtypes.ConsensusMessageType", ConsensusMessageType_name, ConsensusMessageType_value)
+	proto.RegisterType((*NextView)(nil), "pbtypes.NextView")
+	proto.RegisterType((*Prepare)(nil), "pbtypes.Prepare")
+	proto.RegisterType((*PrepareVote)(nil), "pbtypes.PrepareVote")
+	proto.RegisterType((*PreCommit)(nil), "pbtypes.PreCommit")
+	proto.RegisterType((*PreCommitVote)(nil), "pbtypes.PreCommitVote")
+	proto.RegisterType((*Commit)(nil), "pbtypes.Commit")
+	proto.RegisterType((*CommitVote)(nil), "pbtypes.CommitVote")
+	proto.RegisterType((*Decide)(nil), "pbtypes.Decide")
+	proto.RegisterType((*NewView)(nil), "pbtypes.NewView")
+	proto.RegisterType((*QuorumCert)(nil), "pbtypes.QuorumCert")
+	proto.RegisterType((*ChainedProposal)(nil), "pbtypes.ChainedProposal")
+	proto.RegisterType((*ChainedVote)(nil), "pbtypes.ChainedVote")
+	proto.RegisterType((*ChainedNewView)(nil), "pbtypes.ChainedNewView")
+	proto.RegisterType((*Timeout)(nil), "pbtypes.Timeout")
+	proto.RegisterType((*TimeoutCert)(nil), "pbtypes.TimeoutCert")
+	proto.RegisterType((*TimeoutCert_Entry)(nil), "pbtypes.TimeoutCert.Entry")
+	proto.RegisterType((*CompactPrepare)(nil), "pbtypes.CompactPrepare")
+	proto.RegisterType((*BlockTxsRequest)(nil), "pbtypes.BlockTxsRequest")
+	proto.RegisterType((*BlockTxsResponse)(nil), "pbtypes.BlockTxsResponse")
+	proto.RegisterType((*BlockPartSetHeader)(nil), "pbtypes.BlockPartSetHeader")
+	proto.RegisterType((*BlockPart)(nil), "pbtypes.BlockPart")
+	proto.RegisterType((*HasBlockPart)(nil), "pbtypes.HasBlockPart")
+	proto.RegisterType((*BlockRequest)(nil), "pbtypes.BlockRequest")
+	proto.RegisterType((*BlockResponse)(nil), "pbtypes.BlockResponse")
+	proto.RegisterType((*Message)(nil), "pbtypes.Message")
+}
+
+func init() { proto.RegisterFile("consensus.proto", fileDescriptor_56f0f2c53b3de771) }
+
+var fileDescriptor_56f0f2c53b3de771 = []byte{
+	// 1649 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x58, 0xcb, 0x6f, 0x1b, 0xc7,
+	0x19, 0xe7, 0xee, 0xf2, 0xf9, 0xf1, 0xa9, 0xb1, 0xac, 0xae, 0xd9, 0x9a, 0xa2, 0x17, 0x46, 0xa1,
+	0xb6, 0x36, 0x65, 0x4b, 0x6e, 0xeb, 0x02, 0x2d, 0x5a, 0x93, 0x62, 0x41, 0x1d, 0xa4, 0xd2, 0x63,
+	0xc2, 0x6e, 0x4f, 0x8b, 0x25, 0x39, 0x26, 0xd9, 0x4a, 0xdc, 0xf5, 0xee, 0xd2, 0xa2, 0xfe, 0x81,
+	0x22, 0x51, 0x2e, 0x3e, 0x04, 0xb9, 0xe9, 0x94, 0x20, 0xc8, 0x21, 0x97, 0xfc, 0x0f, 0x39, 0xf8,
+	0x68, 0x04, 0x08, 0x90, 0x93, 0x12, 0xc8, 0xe7, 0xe4, 0x9a, 0x6b, 0x30, 0x33, 0xfb, 0x96, 0x68,
+	0x49, 0x06, 0xa1, 0x1c, 0xe2, 0xdb, 0xcc, 0x37, 0xdf, 0x6b, 0xbe, 0xc7, 0x6f, 0xbe, 0x5d, 0x28,
+	0xf6, 0xf4, 0xb1, 0x45, 0xc6, 0xd6, 0xc4, 0xaa, 0x19, 0xa6, 0x6e, 0xeb, 0x28, 0x65, 0x74, 0xed,
+	0x7d, 0x83, 0x58, 0xe5, 0x0a, 0xdb, 0xaf, 0x1a, 0xdd, 0x9e, 0xb9, 0x6f, 0xd8, 0xfa, 0xaa, 0x35,
+	0x1a, 0x8c, 0x35, 0x7b, 0x62, 0x12, 0xce, 0x58, 0xbe, 0xe6, 0x9e, 0x33, 0xf6, 0xd5, 0xee, 0x8e,
+	0xde, 0xfb, 0x9f, 0x73, 0x24, 0x87, 0x8f, 0x9e, 0xeb, 0xb6, 0x2b, 0x74, 0x7f, 0xa0, 0x0f, 0x74,
+	0xb6, 0xbc, 0x7d, 0xb7, 0x76, 0xaf, 0xb6, 0xbe, 0xca, 0xd6, 0xdd, 0xc9, 0xd3, 0xd5, 0x81, 0xae,
+	0x0f, 0x76, 0x88, 0xbf, 0xb7, 0x47, 0xbb, 0xc4, 0xb2, 0xb5, 0x5d, 0xc3, 0x91, 0xbc, 0x19, 0x95,
+	0xf4, 0xf6, 0x6c, 0xc5, 0xb9, 0x94, 0x5d, 0x48, 0x6f, 0x93, 0xa9, 0xfd, 0x78, 0x44, 0xf6, 0xd0,
+	0x5d, 0x88, 0x53, 0xfb, 0xb2, 0x50, 0x15, 0x56, 0x0a, 0x6b, 0xd7, 0x6b, 0x8e, 0x3b, 0xb5, 0x86,
+	0x7b, 0xe3, 0x2d, 0x62, 0x59, 0xda, 0x80, 0x74, 0xf6, 0x0d, 0x82, 0x19, 0x2b, 0x5a, 0x02, 0x71,
+	0xd4, 0x97, 0xc5, 0xaa, 0xb0, 0x92, 0xa9, 0x27, 0x8f, 0x8f, 0x96, 0xc5, 0xcd, 0x0d, 0x2c, 0x8e,
+	0xfa, 0x68, 0x09, 0x92, 0x43, 0x32, 0x1a, 0x0c, 0x6d, 0x59, 0xaa, 0x0a, 0x2b, 0x12, 0x76, 0x76,
+	0xca, 0xff, 0x25, 0x48, 0xb5, 0x4d, 0x62, 0x68, 0x26, 0xb9, 0x04, 0x73, 0xe8, 0x26, 0x24, 0x58,
+	0x98, 0xe5, 0x78, 0x55, 0x58, 0xc9, 0xae, 0x15, 0x3c, 0x1b, 0x75, 0x4a, 0xc5, 0xfc, 0x10, 0xd5,
+	0x21, 0xe3, 0x05, 0x4f, 0x4e, 0x30, 0xce, 0x72, 0x8d, 0x87, 0xb7, 0xe6, 0x86, 0xb7, 0xd6, 0x71,
+	0x39, 0xea, 0xe9, 0x97, 0x47, 0xcb, 0xb1, 0x17, 0xdf, 0x2e, 0x0b, 0xd8, 0x17, 0x43, 0x77, 0x21,
+	0xe3, 0xe5, 0x5b, 0x4e, 0x32, 0x1d, 0x57, 0x6a, 0x6e, 0x29, 0xd4, 0x1e, 0xb9, 0x47, 0xd8, 0xe7,
+	0x42, 0x8b, 0x90, 0x30, 0xf5, 0xc9, 0xb8, 0x2f, 0xa7, 0xaa, 0xc2, 0x4a, 0x02, 0xf3, 0x0d, 0xfa,
+	0x33, 0xa4, 0x86, 0xa3, 0xc1, 0x50, 0x7d, 0xd6, 0x93, 0xd3, 0x4c, 0x0d, 0xf2, 0x9c, 0x6e, 0x9b,
+	0xa4, 0xa1, 0xef, 0xee, 0x8e, 0xec, 0x3a, 0x1c, 0x1f, 0x2d, 0x27, 0x5b, 0xa3, 0xc1, 0xf0, 0x61,
+	0x03, 0x27, 0x29, 0xfb, 0xc3, 0x1e, 0xba, 0x05, 0xa2, 0xdd, 0x93, 0x33, 0x4c, 0x66, 0xd1, 0x93,
+	0xa1, 0x6e, 0xeb, 0x13, 0xbb, 0x41, 0x4c, 0x9b, 0x47, 0xac, 0xd3, 0xc0, 0xa2, 0xdd, 0x53, 0xee,
+	0x40, 0xd6, 0xc9, 0xc3, 0x63, 0xdd, 0x26, 0xe8, 0x06, 0xc4, 0x69, 0xd1, 0xb1, 0x5c, 0x64, 0xd7,
+	0xf2, 0x9e, 0x38, 0x3d, 0xc4, 0xec, 0x48, 0xf9, 0x5a, 0x84, 0x8c, 0xe7, 0xc1, 0x65, 0x24, 0xef,
+	0x3a, 0xc0, 0x73, 0x6d, 0x67, 0x42, 0xd4, 0xa1, 0x66, 0x0d, 0x59, 0x06, 0x73, 0x38, 0xc3, 0x28,
+	0x2d, 0xcd, 0x1a, 0xce, 0x25, 0x6b, 0x5b, 0x70, 0x45, 0x1b, 0x0c, 0x4c, 0x32, 0xd0, 0x6c, 0xa2,
+	0x46, 0xf3, 0xf7, 0x1b, 0x3f, 0x7f, 0x0f, 0x5c, 0x26, 0x3f, 0x91, 0x48, 0x3b, 0x41, 0x9b, 0x91,
+	0xd1, 0xeb, 0x00, 0xac, 0xce, 0xf8, 0x3d, 0xd2, 0xfc, 0x1e, 0x8c, 0x42, 0xef, 0xa1, 0xac, 0x41,
+	0xde, 0x0b, 0xeb, 0x79, 0x73, 0xf1, 0x95, 0x08, 0xc9, 0x77, 0x89, 0x98, 0x73, 0x22, 0x56, 0x01,
+	0x2e, 0x9e, 0x85, 0x0d, 0xd2, 0x1b, 0xf5, 0xc9, 0xbb, 0x2c, 0xcc, 0x2d, 0x0b, 0x9f, 0x4b, 0x90,
+	0xda, 0x26, 0x7b, 0x97, 0xf4, 0x20, 0xf9, 0x3e, 0xc6, 0x83, 0x3e, 0xae, 0x43, 0xde, 0x01, 0x61,
+	0x95, 0x9f, 0xd2, 0x80, 0x26, 0xea, 0xc5, 0xe3, 0xa3, 0xe5, 0xac, 0x03, 0xbb, 0x94, 0x8c, 0xb3,
+	0x1c, 0x7b, 0xd9, 0x06, 0x35, 0x7c, 0x21, 0xfe, 0xe8, 0x24, 0x4f, 0x7b, 0x74, 0x82, 0x4a, 0x18,
+	0xc1, 0x55, 0xc2, 0x36, 0x41, 0xf8, 0x4f, 0x5d, 0x08, 0xfe, 0x43, 0xf9, 0x4f, 0xcf, 0xe1, 0x11,
+	0xcb, 0x9c, 0xe7, 0x11, 0x53, 0x3e, 0x15, 0x00, 0x1e, 0x4e, 0x74, 0x73, 0xb2, 0x4b, 0x9f, 0x18,
+	0x84, 0x20, 0xfe, 0x7c, 0x44, 0xf6, 0x58, 0xc6, 0x24, 0xcc, 0xd6, 0x81, 0xd0, 0x8b, 0xd1, 0x82,
+	0x0e, 0x14, 0x82, 0x14, 0x29, 0x84, 0x59, 0xc5, 0x18, 0x7f, 0xbb, 0x62, 0x54, 0xde, 0x17, 0xa1,
+	0xd8, 0x18, 0x6a, 0xa3, 0x31, 0xe9, 0xb7, 0x4d, 0xdd, 0xd0, 0x2d, 0x6d, 0xc7, 0x29, 0x16, 0xe1,
+	0x44, 0xb1, 0xb8, 0xb7, 0x10, 0x03, 0xb7, 0xf0, 0x46, 0x09, 0xe9, 0x4d, 0xa3, 0xc4, 0x6d, 0x48,
+	0xfd, 0x77, 0x62, 0xd9, 0xa3, 0xa7, 0xfb, 0x72, 0xdc, 0x8b, 0x1f, 0xe7, 0xf3, 0xa3, 0x84, 0x5d,
+	0x9e, 0x9f, 0x69, 0xf2, 0x50, 0x7e, 0x10, 0x20, 0xeb, 0xc4, 0x82, 0x61, 0xdd, 0x1c, 0xb3, 0x76,
+	0x09, 0x28, 0xf5, 0x16, 0x17, 0xfe, 0x5e, 0x80, 0x82, 0x73, 0x61, 0x17, 0x5b, 0x2e, 0x92, 0xfb,
+	0xfb, 0x7e, 0x53, 0x4a, 0x33, 0xb3, 0x7a, 0x76, 0x57, 0xc6, 0xe7, 0x70, 0xdf, 0xc4, 0xb9, 0xee,
+	0xfb, 0xa5, 0x08, 0x29, 0x67, 0xf2, 0x9b, 0x79, 0xd1, 0x59, 0x09, 0xf6, 0x10, 0x51, 0x9a, 0x31,
+	0x96, 0xc6, 0x2f, 0x84, 0x4b, 0x27, 0x50, 0x31, 0xf1, 0x16, 0xa8, 0x18, 0x0a, 0x63, 0x72, 0x0e,
+	0x61, 0x4c, 0x9d, 0x2b, 0x8c, 0x1f, 0x89, 0x90, 0x0d, 0x0c, 0xd0, 0x81, 0x90, 0x09, 0xa7, 0x87,
+	0x4c, 0x0c, 0x86, 0xec, 0x1e, 0xa4, 0xc8, 0xd8, 0x36, 0x47, 0xc4, 0x92, 0xa5, 0xaa, 0xc4, 0x5c,
+	0x3e, 0x65, 0x2a, 0xaf, 0x35, 0xc7, 0xb6, 0xb9, 0x8f, 0x5d, 0xd6, 0x39, 0xc3, 0x5e, 0xb9, 0x03,
+	0x09, 0x66, 0x60, 0x66, 0x19, 0x9c, 0x78, 0xea, 0xc4, 0xb3, 0x9f, 0x3a, 0xe5, 0x43, 0x09, 0x0a,
+	0x0d, 0x7d, 0xd7, 0xd0, 0x7a, 0xf6, 0x25, 0x7e, 0xcd, 0xfd, 0x21, 0xfc, 0x35, 0x77, 0x35, 0x60,
+	0x83, 0xb9, 0xf2, 0x8b, 0xff, 0xa8, 0x9b, 0x42, 0x91, 0xc5, 0xa0, 0x33, 0xb5, 0x30, 0x79, 0x36,
+	0x21, 0xd6, 0x45, 0x4b, 0xf6, 0x0c, 0x70, 0x97, 0x21, 0x35, 0x1a, 0xf7, 0xc9, 0x94, 0x58, 0x72,
+	0xbc, 0x2a, 0xad, 0xe4, 0xb1, 0xbb, 0x55, 0x3e, 0x10, 0xa0, 0xe4, 0x9b, 0xb6, 0x0c, 0x9a, 0xf4,
+	0x4b, 0xb2, 0x8d, 0x4a, 0x20, 0xd9, 0x53, 0x4b, 0x4e, 0x54, 0xa5, 0x95, 0x1c, 0xa6, 0x4b, 0x65,
+	0x0a, 0x88, 0x39, 0xd3, 0xd6, 0x4c, 0xfb, 0x11, 0xb1, 0x5b, 0x44, 0xeb, 0x13, 0xf3, 0xc2, 0xdd,
+	0x9b, 0x1c, 0x32, 0x39, 0x07, 0xf2, 0x97, 0xfc, 0x8c, 0x05, 0xb5, 0xd6, 0xe3, 0xb4, 0x70, 0xb0,
+	0xc3, 0xab, 0x18, 0x90, 0xf1, 0x2c, 0x5f, 0xd0, 0x20, 0x82, 0x78, 0xe0, 0xe6, 0x6c, 0x4d, 0x3f,
+	0x42, 0x0c, 0xcd, 0xb4, 0xe5, 0x78, 0xe4, 0x23, 0x84, 0xaa, 0xc7, 0xec, 0x48, 0x79, 0x0a, 0xb9,
+	0x96, 0x66, 0xcd, 0xd3, 0xe8, 0x22, 0x24, 0x58, 0x68, 0x99, 0xd5, 0x3c, 0xe6, 0x1b, 0xa5, 0x09,
+	0x39, 0xde, 0x5f, 0x67, 0x14, 0x56, 0x38, 0x8d, 0x62, 0x74, 0xbc, 0xff, 0x23, 0xe4, 0x1d, 0x35,
+	0x4e, 0x91, 0x78, 0x73, 0x95, 0xf0, 0x86, 0xb9, 0x4a, 0xf9, 0x02, 0x20, 0xe5, 0x00, 0x08, 0xba,
+	0x03, 0x99, 0x31, 0x99, 0xda, 0xaa, 0x37, 0xb2, 0x64, 0xd7, 0x16, 0x3c, 0x29, 0xf7, 0x67, 0x56,
+	0x2b, 0x86, 0xd3, 0x63, 0x67, 0x8d, 0x6e, 0x41, 0xca, 0xe0, 0x30, 0xc5, 0x1c, 0xca, 0xae, 0x95,
+	0x82, 0xed, 0x47, 0xe9, 0xad, 0x18, 0x76, 0x59, 0xd0, 0x5f, 0x20, 0xe7, 0x2c, 0x55, 0xf6, 0x05,
+	0x28, 0x45, 0xba, 0x2f, 0xf0, 0xdf, 0xa4, 0x15, 0xc3, 0x59, 0xc3, 0xdf, 0xa2, 0x75, 0x00, 0xc3,
+	0x24, 0x6a, 0x8f, 0x35, 0xf4, 0xec, 0x87, 0xb2, 0x15, 0xc3, 0x19, 0xc3, 0xdd, 0xa0, 0x7f, 0x40,
+	0xd1, 0x17, 0xe2, 0x26, 0x13, 0xd1, 0x92, 0x0b, 0xfe, 0x20, 0x68, 0xc5, 0x70, 0xde, 0x08, 0x12,
+	0xd0, 0xef, 0x20, 0xe9, 0x98, 0xe4, 0x20, 0x55, 0x0c, 0x22, 0x23, 0xb7, 0xe7, 0x30, 0xa0, 0x3f,
+	0x41, 0x36, 0x68, 0x28, 0x15, 0x19, 0x67, 0x42, 0x56, 0xa0, 0x17, 0x32, 0xd1, 0x67, 0x9f, 0xba,
+	0x72, 0x3a, 0x62, 0x82, 0x7f, 0x01, 0x53, 0x13, 0x9c, 0x01, 0xdd, 0x86, 0xf4, 0x98, 0xec, 0xf1,
+	0xf4, 0x64, 0x22, 0xe1, 0x76, 0xa6, 0x2f, 0x1a, 0xee, 0x31, 0x5f, 0xa2, 0x26, 0x94, 0x7a, 0x7c,
+	0x34, 0x53, 0x0d, 0x67, 0x30, 0x97, 0x81, 0x89, 0xc9, 0xbe, 0x5b, 0xe1, 0xc1, 0xbd, 0x15, 0xc3,
+	0xc5, 0x5e, 0x98, 0x44, 0xb3, 0xe6, 0xaa, 0x61, 0x37, 0xcb, 0x46, 0xb2, 0x16, 0x98, 0x77, 0x69,
+	0xd6, 0x7a, 0xfe, 0x16, 0x35, 0x7c, 0x0f, 0x3c, 0xc7, 0x73, 0x4c, 0xfc, 0x57, 0x51, 0x71, 0xdf,
+	0xff, 0x42, 0x2f, 0x44, 0xa1, 0x35, 0x66, 0x73, 0x58, 0x96, 0xf3, 0x91, 0x4b, 0x3b, 0x70, 0x4d,
+	0x2f, 0xed, 0xb0, 0xa0, 0x3a, 0xfd, 0x8f, 0xcc, 0x1e, 0x2d, 0xd5, 0xad, 0xcc, 0x42, 0xd4, 0x62,
+	0xe8, 0x7d, 0x65, 0x16, 0x43, 0x14, 0xf4, 0x4f, 0x58, 0xe0, 0x9d, 0x66, 0x4f, 0x2d, 0xd5, 0xe4,
+	0x6d, 0x29, 0x17, 0x23, 0x91, 0x8b, 0xbc, 0x07, 0x34, 0x72, 0xdd, 0x30, 0x09, 0x6d, 0x02, 0x0a,
+	0xea, 0xe1, 0x7d, 0x29, 0x97, 0x98, 0xa2, 0x6b, 0xa7, 0x28, 0xe2, 0x0c, 0xad, 0x18, 0x2e, 0x75,
+	0x23, 0x34, 0xd4, 0x86, 0xab, 0x5c, 0x15, 0x85, 0x26, 0xd5, 0x22, 0xb6, 0xea, 0x60, 0xe8, 0x02,
+	0xd3, 0xf6, 0xeb, 0xb0, 0xb6, 0x10, 0x90, 0xb6, 0x62, 0x18, 0x75, 0x4f, 0x50, 0x69, 0x47, 0xf9,
+	0x1a, 0x65, 0x14, 0xe9, 0x28, 0x4f, 0x0d, 0xed, 0x28, 0x4f, 0x1a, 0xfd, 0x0d, 0x0a, 0x43, 0xcd,
+	0x52, 0x03, 0x82, 0x57, 0x22, 0x13, 0x43, 0x10, 0x32, 0x5b, 0x31, 0x9c, 0x1b, 0x06, 0xf6, 0xe8,
+	0xaf, 0x90, 0xe7, 0xa2, 0x6e, 0x50, 0x17, 0x23, 0xd2, 0x41, 0x20, 0xa4, 0xd2, 0xdd, 0xc0, 0x1e,
+	0xfd, 0x1d, 0x0a, 0xae, 0xb4, 0x13, 0xca, 0xab, 0x91, 0x6e, 0x0e, 0x01, 0x20, 0xed, 0xe6, 0x6e,
+	0x90, 0x40, 0x15, 0xf8, 0x13, 0x20, 0xab, 0xe5, 0xa5, 0x88, 0x02, 0x6f, 0xf6, 0x73, 0xe1, 0x40,
+	0x0b, 0x12, 0xea, 0x09, 0x90, 0xb6, 0xac, 0xc1, 0xef, 0x7f, 0x14, 0x60, 0xf1, 0xb4, 0xe9, 0x0b,
+	0xdd, 0x84, 0xc2, 0x76, 0xf3, 0xdf, 0x1d, 0xf5, 0xf1, 0x66, 0xf3, 0x89, 0xda, 0xf9, 0x4f, 0xbb,
+	0x59, 0x8a, 0x95, 0x4b, 0x07, 0x87, 0xd5, 0x9c, 0x0b, 0x9e, 0x8c, 0xeb, 0x06, 0xe4, 0xda, 0xb8,
+	0xd9, 0x7e, 0x80, 0x9b, 0x9c, 0x47, 0x28, 0x17, 0x0f, 0x0e, 0xab, 0xee, 0x5f, 0x63, 0xc6, 0xf2,
+	0x5b, 0x28, 0xb6, 0x71, 0x53, 0x6d, 0xfc, 0x6b, 0x6b, 0x6b, 0xb3, 0xc3, 0xb9, 0xc4, 0xf2, 0xc2,
+	0xc1, 0x61, 0xd5, 0xff, 0xa3, 0xc9, 0xf8, 0x96, 0x21, 0x1b, 0xe4, 0x91, 0xca, 0x85, 0x83, 0xc3,
+	0x2a, 0x84, 0x19, 0x36, 0x9a, 0x8d, 0xcd, 0x0d, 0xc7, 0x54, 0x9c, 0x33, 0x70, 0x64, 0x61, 0x0c,
+	0x0a, 0xe4, 0xb7, 0x9b, 0x4f, 0x02, 0x1e, 0x27, 0xb8, 0x37, 0x4e, 0xf7, 0x51, 0x9e, 0x72, 0xfa,
+	0xbd, 0x8f, 0x2b, 0xb1, 0xcf, 0x3e, 0xa9, 0x08, 0x75, 0xf9, 0xe5, 0x71, 0x45, 0x78, 0x75, 0x5c,
+	0x11, 0xbe, 0x3b, 0xae, 0x08, 0x2f, 0x5e, 0x57, 0x62, 0xaf, 0x5e, 0x57, 0x62, 0xdf, 0xbc, 0xae,
+	0xc4, 0xba, 0x49, 0x36, 0xfa, 0xad, 0xff, 0x34, 0x00, 0x25, 0x4c, 0x80, 0x01, 0xc6, 0x19, 0x00,
+	0x00,
+}
+
+func (m *NextView) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NextView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NextView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Prepare) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Prepare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Prepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TC != nil {
+		{
+			size, err := m.TC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.HighQC != nil {
+		{
+			size, err := m.HighQC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n4, err4 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err4 != nil {
+		return 0, err4
+	}
+	i -= n4
+	i = encodeVarintConsensus(dAtA, i, uint64(n4))
+	i--
+	dAtA[i] = 0x2a
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PrepareVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PrepareVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PrepareVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PreCommit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PreCommit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PreCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AggregateSignature != nil {
+		{
+			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n8, err8 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err8 != nil {
+		return 0, err8
+	}
+	i -= n8
+	i = encodeVarintConsensus(dAtA, i, uint64(n8))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *PreCommitVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *PreCommitVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *PreCommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Commit) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Commit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AggregateSignature != nil {
+		{
+			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n11, err11 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err11 != nil {
+		return 0, err11
+	}
+	i -= n11
+	i = encodeVarintConsensus(dAtA, i, uint64(n11))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CommitVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CommitVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Vote != nil {
+		{
+			size, err := m.Vote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Decide) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Decide) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Decide) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.AggregateSignature != nil {
+		{
+			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n14, err14 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err14 != nil {
+		return 0, err14
+	}
+	i -= n14
+	i = encodeVarintConsensus(dAtA, i, uint64(n14))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *NewView) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *NewView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *NewView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	n16, err16 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err16 != nil {
+		return 0, err16
+	}
+	i -= n16
+	i = encodeVarintConsensus(dAtA, i, uint64(n16))
+	i--
+	dAtA[i] = 0x42
+	if m.HighQC != nil {
+		{
+			size, err := m.HighQC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.HighQCBlock != nil {
+		{
+			size, err := m.HighQCBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.HighQCRound != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.HighQCRound))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QuorumCert) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QuorumCert) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QuorumCert) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AggregateSignature != nil {
+		{
+			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.View != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.View))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChainedProposal) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainedProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChainedProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n21, err21 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err21 != nil {
+		return 0, err21
+	}
+	i -= n21
+	i = encodeVarintConsensus(dAtA, i, uint64(n21))
+	i--
+	dAtA[i] = 0x2a
+	if m.Justify != nil {
+		{
+			size, err := m.Justify.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.View != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.View))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChainedVote) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainedVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChainedVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n25, err25 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err25 != nil {
+		return 0, err25
+	}
+	i -= n25
+	i = encodeVarintConsensus(dAtA, i, uint64(n25))
+	i--
+	dAtA[i] = 0x2a
+	if len(m.ValueHash) > 0 {
+		i -= len(m.ValueHash)
+		copy(dAtA[i:], m.ValueHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ValueHash)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.View != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.View))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ChainedNewView) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ChainedNewView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ChainedNewView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	n27, err27 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err27 != nil {
+		return 0, err27
+	}
+	i -= n27
+	i = encodeVarintConsensus(dAtA, i, uint64(n27))
+	i--
+	dAtA[i] = 0x22
+	if m.HighQC != nil {
+		{
+			size, err := m.HighQC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.View != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.View))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Timeout) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Timeout) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Timeout) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	n30, err30 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err30 != nil {
+		return 0, err30
+	}
+	i -= n30
+	i = encodeVarintConsensus(dAtA, i, uint64(n30))
+	i--
+	dAtA[i] = 0x32
+	if m.HighQCBlock != nil {
+		{
+			size, err := m.HighQCBlock.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	if m.HighQC != nil {
+		{
+			size, err := m.HighQC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeoutCert) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeoutCert) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeoutCert) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.AggregateSignature != nil {
+		{
+			size, err := m.AggregateSignature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Entries) > 0 {
+		for iNdEx := len(m.Entries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Entries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintConsensus(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeoutCert_Entry) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeoutCert_Entry) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeoutCert_Entry) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HighQCRound != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.HighQCRound))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CompactPrepare) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CompactPrepare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CompactPrepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.TC != nil {
+		{
+			size, err := m.TC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	if m.HighQC != nil {
+		{
+			size, err := m.HighQC.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.Signature != nil {
+		{
+			size, err := m.Signature.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	n37, err37 := github_com_cosmos_gogoproto_types.StdTimeMarshalTo(m.Timestamp, dAtA[i-github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp):])
+	if err37 != nil {
+		return 0, err37
+	}
+	i -= n37
+	i = encodeVarintConsensus(dAtA, i, uint64(n37))
+	i--
+	dAtA[i] = 0x2a
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.ID) > 0 {
+		i -= len(m.ID)
+		copy(dAtA[i:], m.ID)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.ID)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockTxsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Indexes) > 0 {
+		dAtA40 := make([]byte, len(m.Indexes)*10)
+		var j39 int
+		for _, num := range m.Indexes {
+			for num >= 1<<7 {
+				dAtA40[j39] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j39++
+			}
+			dAtA40[j39] = uint8(num)
+			j39++
+		}
+		i -= j39
+		copy(dAtA[i:], dAtA40[:j39])
+		i = encodeVarintConsensus(dAtA, i, uint64(j39))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockTxsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockTxsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockTxsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintConsensus(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
+	}
+	if len(m.Indexes) > 0 {
+		dAtA42 := make([]byte, len(m.Indexes)*10)
+		var j41 int
+		for _, num := range m.Indexes {
+			for num >= 1<<7 {
+				dAtA42[j41] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j41++
+			}
+			dAtA42[j41] = uint8(num)
+			j41++
+		}
+		i -= j41
+		copy(dAtA[i:], dAtA42[:j41])
+		i = encodeVarintConsensus(dAtA, i, uint64(j41))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockPartSetHeader) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockPartSetHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockPartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintConsensus(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Part != nil {
+		{
+			size, err := m.Part.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *HasBlockPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *HasBlockPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HasBlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Index != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.Round != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Round))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.BlockHash) > 0 {
+		i -= len(m.BlockHash)
+		copy(dAtA[i:], m.BlockHash)
+		i = encodeVarintConsensus(dAtA, i, uint64(len(m.BlockHash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintConsensus(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Block != nil {
+		{
+			size, err := m.Block.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Message) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Msg != nil {
+		{
+			size := m.Msg.Size()
+			i -= size
+			if _, err := m.Msg.MarshalTo(dAtA[i:]); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Message_NextView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_NextView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NextView != nil {
+		{
+			size, err := m.NextView.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_Prepare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_Prepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Prepare != nil {
+		{
+			size, err := m.Prepare.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PrepareVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PrepareVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PrepareVote != nil {
+		{
+			size, err := m.PrepareVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PreCommit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PreCommit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PreCommit != nil {
+		{
+			size, err := m.PreCommit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_PreCommitVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_PreCommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.PreCommitVote != nil {
+		{
+			size, err := m.PreCommitVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x2a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_Commit) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Commit != nil {
+		{
+			size, err := m.Commit.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x32
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CommitVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CommitVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CommitVote != nil {
+		{
+			size, err := m.CommitVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_Decide) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_Decide) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Decide != nil {
+		{
+			size, err := m.Decide.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x42
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_NewView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_NewView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.NewView != nil {
+		{
+			size, err := m.NewView.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x4a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_ChainedProposal) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ChainedProposal) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ChainedProposal != nil {
+		{
+			size, err := m.ChainedProposal.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x52
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_ChainedVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ChainedVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ChainedVote != nil {
+		{
+			size, err := m.ChainedVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x5a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_ChainedNewView) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_ChainedNewView) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.ChainedNewView != nil {
+		{
+			size, err := m.ChainedNewView.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_Timeout) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_Timeout) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.Timeout != nil {
+		{
+			size, err := m.Timeout.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_CompactPrepare) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_CompactPrepare) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.CompactPrepare != nil {
+		{
+			size, err := m.CompactPrepare.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockTxsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockTxsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockTxsRequest != nil {
+		{
+			size, err := m.BlockTxsRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockTxsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockTxsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockTxsResponse != nil {
+		{
+			size, err := m.BlockTxsResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockPartSetHeader) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockPartSetHeader) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockPartSetHeader != nil {
+		{
+			size, err := m.BlockPartSetHeader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockPart != nil {
+		{
+			size, err := m.BlockPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_HasBlockPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_HasBlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.HasBlockPart != nil {
+		{
+			size, err := m.HasBlockPart.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockRequest != nil {
+		{
+			size, err := m.BlockRequest.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_BlockResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_BlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.BlockResponse != nil {
+		{
+			size, err := m.BlockResponse.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
+	}
+	return len(dAtA) - i, nil
+}
+func (m *Message_AggregateVote) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Message_AggregateVote) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	if m.AggregateVote != nil {
+		{
+			size, err := m.AggregateVote.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintConsensus(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb2
+	}
+	return len(dAtA) - i, nil
+}
+func encodeVarintConsensus(dAtA []byte, offset int, v uint64) int {
+	offset -= sovConsensus(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *NextView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	return n
+}
+
+func (m *Prepare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	if m.HighQC != nil {
+		l = m.HighQC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.TC != nil {
+		l = m.TC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *PrepareVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *PreCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.AggregateSignature != nil {
+		l = m.AggregateSignature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *PreCommitVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.AggregateSignature != nil {
+		l = m.AggregateSignature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *CommitVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Vote != nil {
+		l = m.Vote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *Decide) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.AggregateSignature != nil {
+		l = m.AggregateSignature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *NewView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	if m.HighQCRound != 0 {
+		n += 1 + sovConsensus(uint64(m.HighQCRound))
+	}
+	if m.HighQCBlock != nil {
+		l = m.HighQCBlock.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.HighQC != nil {
+		l = m.HighQC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *QuorumCert) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.View != 0 {
+		n += 1 + sovConsensus(uint64(m.View))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.AggregateSignature != nil {
+		l = m.AggregateSignature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *ChainedProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.View != 0 {
+		n += 1 + sovConsensus(uint64(m.View))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Justify != nil {
+		l = m.Justify.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *ChainedVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.View != 0 {
+		n += 1 + sovConsensus(uint64(m.View))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = len(m.ValueHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *ChainedNewView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.View != 0 {
+		n += 1 + sovConsensus(uint64(m.View))
+	}
+	if m.HighQC != nil {
+		l = m.HighQC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *Timeout) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	if m.HighQC != nil {
+		l = m.HighQC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.HighQCBlock != nil {
+		l = m.HighQCBlock.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *TimeoutCert) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	if len(m.Entries) > 0 {
+		for _, e := range m.Entries {
+			l = e.Size()
+			n += 1 + l + sovConsensus(uint64(l))
+		}
+	}
+	if m.AggregateSignature != nil {
+		l = m.AggregateSignature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *TimeoutCert_Entry) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.HighQCRound != 0 {
+		n += 1 + sovConsensus(uint64(m.HighQCRound))
+	}
+	return n
+}
+
+func (m *CompactPrepare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovConsensus(uint64(m.Type))
+	}
+	l = len(m.ID)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	l = github_com_cosmos_gogoproto_types.SizeOfStdTime(m.Timestamp)
+	n += 1 + l + sovConsensus(uint64(l))
+	if m.Signature != nil {
+		l = m.Signature.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	if m.HighQC != nil {
+		l = m.HighQC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.TC != nil {
+		l = m.TC.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *BlockTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sovConsensus(uint64(e))
+		}
+		n += 1 + sovConsensus(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *BlockTxsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if len(m.Indexes) > 0 {
+		l = 0
+		for _, e := range m.Indexes {
+			l += sovConsensus(uint64(e))
+		}
+		n += 1 + sovConsensus(uint64(l)) + l
+	}
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 1 + l + sovConsensus(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BlockPartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = m.Header.Size()
+	n += 1 + l + sovConsensus(uint64(l))
+	return n
+}
+
+func (m *BlockPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Part != nil {
+		l = m.Part.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *HasBlockPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	if m.Round != 0 {
+		n += 1 + sovConsensus(uint64(m.Round))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 1 + sovConsensus(uint64(m.Index))
+	}
+	return n
+}
+
+func (m *BlockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovConsensus(uint64(m.Height))
+	}
+	l = len(m.BlockHash)
+	if l > 0 {
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *BlockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != nil {
+		l = m.Block.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func (m *Message) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Msg != nil {
+		n += m.Msg.Size()
+	}
+	return n
+}
+
+func (m *Message_NextView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NextView != nil {
+		l = m.NextView.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_Prepare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Prepare != nil {
+		l = m.Prepare.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_PrepareVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PrepareVote != nil {
+		l = m.PrepareVote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_PreCommit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PreCommit != nil {
+		l = m.PreCommit.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_PreCommitVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.PreCommitVote != nil {
+		l = m.PreCommitVote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_Commit) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Commit != nil {
+		l = m.Commit.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_CommitVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CommitVote != nil {
+		l = m.CommitVote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_Decide) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Decide != nil {
+		l = m.Decide.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_NewView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.NewView != nil {
+		l = m.NewView.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_ChainedProposal) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChainedProposal != nil {
+		l = m.ChainedProposal.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_ChainedVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChainedVote != nil {
+		l = m.ChainedVote.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_ChainedNewView) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ChainedNewView != nil {
+		l = m.ChainedNewView.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_Timeout) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Timeout != nil {
+		l = m.Timeout.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_CompactPrepare) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.CompactPrepare != nil {
+		l = m.CompactPrepare.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockTxsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockTxsRequest != nil {
+		l = m.BlockTxsRequest.Size()
+		n += 1 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockTxsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockTxsResponse != nil {
+		l = m.BlockTxsResponse.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockPartSetHeader) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockPartSetHeader != nil {
+		l = m.BlockPartSetHeader.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockPart != nil {
+		l = m.BlockPart.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_HasBlockPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.HasBlockPart != nil {
+		l = m.HasBlockPart.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockRequest != nil {
+		l = m.BlockRequest.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_BlockResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.BlockResponse != nil {
+		l = m.BlockResponse.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+func (m *Message_AggregateVote) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.AggregateVote != nil {
+		l = m.AggregateVote.Size()
+		n += 2 + l + sovConsensus(uint64(l))
+	}
+	return n
+}
+
+func sovConsensus(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozConsensus(x uint64) (n int) {
+	return sovConsensus(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *NextView) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NextView: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NextView: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Prepare) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Prepare: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Prepare: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQC == nil {
+				m.HighQC = &PreCommit{}
+			}
+			if err := m.HighQC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TC == nil {
+				m.TC = &TimeoutCert{}
+			}
+			if err := m.TC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PrepareVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PrepareVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PrepareVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PreCommit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PreCommit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PreCommit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = &pbcrypto.AggregateSignature{}
+			}
+			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *PreCommitVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: PreCommitVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: PreCommitVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Commit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = &pbcrypto.AggregateSignature{}
+			}
+			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CommitVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CommitVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CommitVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Vote == nil {
+				m.Vote = &Vote{}
+			}
+			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Decide) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Decide: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Decide: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = &pbcrypto.AggregateSignature{}
+			}
+			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *NewView) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: NewView: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: NewView: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQCRound", wireType)
+			}
+			m.HighQCRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HighQCRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQCBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQCBlock == nil {
+				m.HighQCBlock = &Block{}
+			}
+			if err := m.HighQCBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQC == nil {
+				m.HighQC = &PreCommit{}
+			}
+			if err := m.HighQC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QuorumCert) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QuorumCert: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QuorumCert: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			m.View = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.View |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = &pbcrypto.AggregateSignature{}
+			}
+			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainedProposal) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainedProposal: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainedProposal: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			m.View = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.View |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Justify", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Justify == nil {
+				m.Justify = &QuorumCert{}
+			}
+			if err := m.Justify.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainedVote) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainedVote: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainedVote: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			m.View = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.View |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValueHash == nil {
+				m.ValueHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ChainedNewView) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ChainedNewView: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ChainedNewView: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field View", wireType)
+			}
+			m.View = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.View |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQC == nil {
+				m.HighQC = &QuorumCert{}
+			}
+			if err := m.HighQC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Timeout) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Timeout: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Timeout: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQC == nil {
+				m.HighQC = &PreCommit{}
+			}
+			if err := m.HighQC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQCBlock", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQCBlock == nil {
+				m.HighQCBlock = &Block{}
+			}
+			if err := m.HighQCBlock.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimeoutCert) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: TimeoutCert: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: TimeoutCert: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Entries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Entries = append(m.Entries, &TimeoutCert_Entry{})
+			if err := m.Entries[len(m.Entries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregateSignature == nil {
+				m.AggregateSignature = &pbcrypto.AggregateSignature{}
+			}
+			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *TimeoutCert_Entry) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Entry: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Entry: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQCRound", wireType)
+			}
+			m.HighQCRound = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HighQCRound |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CompactPrepare) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CompactPrepare: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CompactPrepare: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			}
+			m.Type = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ID = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &CompactBlock{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Signature == nil {
+				m.Signature = &pbcrypto.Signature{}
+			}
+			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HighQC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.HighQC == nil {
+				m.HighQC = &PreCommit{}
+			}
+			if err := m.HighQC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TC", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.TC == nil {
+				m.TC = &TimeoutCert{}
+			}
+			if err := m.TC.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
 	}
-	return n
-}
 
-func sovConsensus(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
-}
-func sozConsensus(x uint64) (n int) {
-	return sovConsensus(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
 }
-func (m *NextView) Unmarshal(dAtA []byte) error {
+func (m *BlockTxsRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1958,17 +8726,17 @@ func (m *NextView) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: NextView: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockTxsRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: NextView: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockTxsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.Type = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -1978,16 +8746,16 @@ func (m *NextView) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			var stringLen uint64
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -1997,29 +8765,16 @@ func (m *NextView) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
 			}
-			m.Height = 0
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2029,11 +8784,102 @@ func (m *NextView) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConsensus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indexes = append(m.Indexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConsensus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthConsensus
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthConsensus
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indexes) == 0 {
+					m.Indexes = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConsensus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indexes = append(m.Indexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsensus(dAtA[iNdEx:])
@@ -2055,7 +8901,7 @@ func (m *NextView) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Vote) Unmarshal(dAtA []byte) error {
+func (m *BlockTxsResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2078,17 +8924,17 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Vote: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockTxsResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Vote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockTxsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field VoteType", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
-			m.VoteType = 0
+			m.Height = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2098,16 +8944,16 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.VoteType |= VoteType(b&0x7F) << shift
+				m.Height |= int64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 2:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			m.Height = 0
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2117,14 +8963,14 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
 		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2151,16 +8997,193 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.ValueHash == nil {
-				m.ValueHash = []byte{}
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConsensus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Indexes = append(m.Indexes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowConsensus
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthConsensus
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthConsensus
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Indexes) == 0 {
+					m.Indexes = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowConsensus
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Indexes = append(m.Indexes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Indexes", wireType)
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipConsensus(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *BlockPartSetHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowConsensus
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockPartSetHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockPartSetHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			iNdEx = postIndex
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			var msglen int
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2170,28 +9193,14 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2218,10 +9227,7 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Signature == nil {
-				m.Signature = &pbcrypto.Signature{}
-			}
-			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2246,7 +9252,7 @@ func (m *Vote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Prepare) Unmarshal(dAtA []byte) error {
+func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2269,64 +9275,13 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Prepare: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Prepare: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= ConsensusMessageType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
@@ -2345,11 +9300,11 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 4:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
 			}
-			var msglen int
+			m.Round = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2359,33 +9314,16 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.Round |= int32(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.Block == nil {
-				m.Block = &Block{}
-			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 5:
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2395,28 +9333,29 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
-		case 6:
+		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Part", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2443,10 +9382,10 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Signature == nil {
-				m.Signature = &pbcrypto.Signature{}
+			if m.Part == nil {
+				m.Part = &Part{}
 			}
-			if err := m.Signature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Part.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2471,7 +9410,7 @@ func (m *Prepare) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PrepareVote) Unmarshal(dAtA []byte) error {
+func (m *HasBlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2494,17 +9433,55 @@ func (m *PrepareVote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PrepareVote: wiretype end group for non-group")
+			return fmt.Errorf("proto: HasBlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PrepareVote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HasBlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Round", wireType)
+			}
+			m.Round = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Round |= int32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2514,28 +9491,45 @@ func (m *PrepareVote) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &Vote{}
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipConsensus(dAtA[iNdEx:])
@@ -2557,7 +9551,7 @@ func (m *PrepareVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PreCommit) Unmarshal(dAtA []byte) error {
+func (m *BlockRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2573,71 +9567,20 @@ func (m *PreCommit) Unmarshal(dAtA []byte) error {
 			b := dAtA[iNdEx]
 			iNdEx++
 			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: PreCommit: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PreCommit: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
-			}
-			m.Type = 0
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				m.Type |= ConsensusMessageType(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			if b < 0x80 {
+				break
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 3:
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 0 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
 			}
@@ -2656,9 +9599,9 @@ func (m *PreCommit) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
-		case 4:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2685,78 +9628,9 @@ func (m *PreCommit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.ValueHash == nil {
-				m.ValueHash = []byte{}
-			}
-			iNdEx = postIndex
-		case 5:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 6:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowConsensus
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.AggregateSignature == nil {
-				m.AggregateSignature = &pbcrypto.AggregateSignature{}
-			}
-			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.BlockHash = append(m.BlockHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlockHash == nil {
+				m.BlockHash = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -2780,7 +9654,7 @@ func (m *PreCommit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *PreCommitVote) Unmarshal(dAtA []byte) error {
+func (m *BlockResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2803,15 +9677,15 @@ func (m *PreCommitVote) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: PreCommitVote: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: PreCommitVote: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -2838,10 +9712,10 @@ func (m *PreCommitVote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &Vote{}
+			if m.Block == nil {
+				m.Block = &Block{}
 			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -2866,7 +9740,7 @@ func (m *PreCommitVote) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Commit) Unmarshal(dAtA []byte) error {
+func (m *Message) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2889,17 +9763,17 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+			return fmt.Errorf("proto: Message: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field NextView", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2909,16 +9783,32 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &NextView{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_NextView{v}
+			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Prepare", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2928,29 +9818,32 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			v := &Prepare{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_Prepare{v}
 			iNdEx = postIndex
 		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PrepareVote", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2960,16 +9853,32 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &PrepareVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_PrepareVote{v}
+			iNdEx = postIndex
 		case 4:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PreCommit", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -2979,29 +9888,30 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.ValueHash == nil {
-				m.ValueHash = []byte{}
+			v := &PreCommit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			m.Msg = &Message_PreCommit{v}
 			iNdEx = postIndex
 		case 5:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PreCommitVote", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3028,13 +9938,15 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+			v := &PreCommitVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Msg = &Message_PreCommitVote{v}
 			iNdEx = postIndex
 		case 6:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3059,68 +9971,17 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.AggregateSignature == nil {
-				m.AggregateSignature = &pbcrypto.AggregateSignature{}
-			}
-			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipConsensus(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *CommitVote) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowConsensus
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: CommitVote: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: CommitVote: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+				return io.ErrUnexpectedEOF
+			}
+			v := &Commit{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_Commit{v}
+			iNdEx = postIndex
+		case 7:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Vote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field CommitVote", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3147,68 +10008,17 @@ func (m *CommitVote) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Vote == nil {
-				m.Vote = &Vote{}
-			}
-			if err := m.Vote.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &CommitVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Msg = &Message_CommitVote{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipConsensus(dAtA[iNdEx:])
-			if err != nil {
-				return err
-			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthConsensus
-			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
-			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Decide) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowConsensus
-			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
-			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
-			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Decide: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Decide: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Type", wireType)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Decide", wireType)
 			}
-			m.Type = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -3218,16 +10028,32 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Type |= ConsensusMessageType(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &Decide{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_Decide{v}
+			iNdEx = postIndex
+		case 9:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field NewView", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -3237,29 +10063,32 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ID = string(dAtA[iNdEx:postIndex])
+			v := &NewView{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_NewView{v}
 			iNdEx = postIndex
-		case 3:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainedProposal", wireType)
 			}
-			m.Height = 0
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -3269,16 +10098,32 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Height |= int64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 4:
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			v := &ChainedProposal{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			m.Msg = &Message_ChainedProposal{v}
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ValueHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainedVote", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowConsensus
@@ -3288,29 +10133,30 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthConsensus
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthConsensus
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ValueHash = append(m.ValueHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.ValueHash == nil {
-				m.ValueHash = []byte{}
+			v := &ChainedVote{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
+			m.Msg = &Message_ChainedVote{v}
 			iNdEx = postIndex
-		case 5:
+		case 12:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainedNewView", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3337,13 +10183,15 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if err := github_com_cosmos_gogoproto_types.StdTimeUnmarshal(&m.Timestamp, dAtA[iNdEx:postIndex]); err != nil {
+			v := &ChainedNewView{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Msg = &Message_ChainedNewView{v}
 			iNdEx = postIndex
-		case 6:
+		case 13:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AggregateSignature", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Timeout", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3370,66 +10218,50 @@ func (m *Decide) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.AggregateSignature == nil {
-				m.AggregateSignature = &pbcrypto.AggregateSignature{}
-			}
-			if err := m.AggregateSignature.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			v := &Timeout{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
+			m.Msg = &Message_Timeout{v}
 			iNdEx = postIndex
-		default:
-			iNdEx = preIndex
-			skippy, err := skipConsensus(dAtA[iNdEx:])
-			if err != nil {
-				return err
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactPrepare", wireType)
 			}
-			if (skippy < 0) || (iNdEx+skippy) < 0 {
-				return ErrInvalidLengthConsensus
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowConsensus
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
 			}
-			if (iNdEx + skippy) > l {
-				return io.ErrUnexpectedEOF
+			if msglen < 0 {
+				return ErrInvalidLengthConsensus
 			}
-			iNdEx += skippy
-		}
-	}
-
-	if iNdEx > l {
-		return io.ErrUnexpectedEOF
-	}
-	return nil
-}
-func (m *Message) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowConsensus
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthConsensus
 			}
-			if iNdEx >= l {
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			v := &CompactPrepare{}
+			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: Message: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Message: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
+			m.Msg = &Message_CompactPrepare{v}
+			iNdEx = postIndex
+		case 15:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field NextView", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTxsRequest", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3456,15 +10288,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &NextView{}
+			v := &BlockTxsRequest{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_NextView{v}
+			m.Msg = &Message_BlockTxsRequest{v}
 			iNdEx = postIndex
-		case 2:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Prepare", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockTxsResponse", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3491,15 +10323,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Prepare{}
+			v := &BlockTxsResponse{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_Prepare{v}
+			m.Msg = &Message_BlockTxsResponse{v}
 			iNdEx = postIndex
-		case 3:
+		case 17:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PrepareVote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPartSetHeader", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3526,15 +10358,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PrepareVote{}
+			v := &BlockPartSetHeader{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_PrepareVote{v}
+			m.Msg = &Message_BlockPartSetHeader{v}
 			iNdEx = postIndex
-		case 4:
+		case 18:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreCommit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockPart", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3561,15 +10393,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PreCommit{}
+			v := &BlockPart{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_PreCommit{v}
+			m.Msg = &Message_BlockPart{v}
 			iNdEx = postIndex
-		case 5:
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field PreCommitVote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field HasBlockPart", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3596,15 +10428,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &PreCommitVote{}
+			v := &HasBlockPart{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_PreCommitVote{v}
+			m.Msg = &Message_HasBlockPart{v}
 			iNdEx = postIndex
-		case 6:
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockRequest", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3631,15 +10463,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Commit{}
+			v := &BlockRequest{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_Commit{v}
+			m.Msg = &Message_BlockRequest{v}
 			iNdEx = postIndex
-		case 7:
+		case 21:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field CommitVote", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlockResponse", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3666,15 +10498,15 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &CommitVote{}
+			v := &BlockResponse{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_CommitVote{v}
+			m.Msg = &Message_BlockResponse{v}
 			iNdEx = postIndex
-		case 8:
+		case 22:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Decide", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregateVote", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -3701,11 +10533,11 @@ func (m *Message) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			v := &Decide{}
+			v := &AggregateVote{}
 			if err := v.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
-			m.Msg = &Message_Decide{v}
+			m.Msg = &Message_AggregateVote{v}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
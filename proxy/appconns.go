@@ -10,6 +10,7 @@ type AppConns struct {
 	service.BaseService
 	consensus   *AppConnConsensus
 	txspool     *AppConnTxsPool
+	snapshot    *AppConnSnapshot
 	application abci.Application
 }
 
@@ -28,8 +29,13 @@ func (conns *AppConns) TxsPool() *AppConnTxsPool {
 	return conns.txspool
 }
 
+func (conns *AppConns) Snapshot() *AppConnSnapshot {
+	return conns.snapshot
+}
+
 func (conns *AppConns) Start() error {
 	conns.consensus = NewAppConnConsensus(conns.application)
 	conns.txspool = NewAppConnTxsPool(conns.application, conns.Logger.New("module", "ProxyAppTxsPool"))
+	conns.snapshot = NewAppConnSnapshot(conns.application)
 	return conns.BaseService.Start()
 }
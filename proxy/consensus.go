@@ -18,10 +18,22 @@ func NewAppConnConsensus(app abci.Application) *AppConnConsensus {
 	}
 }
 
+func (app *AppConnConsensus) Info(req pbabci.RequestInfo) pbabci.ResponseInfo {
+	return app.application.Info(req)
+}
+
 func (app *AppConnConsensus) InitChain(req pbabci.RequestInitChain) pbabci.ResponseInitChain {
 	return app.application.InitChain(req)
 }
 
+func (app *AppConnConsensus) PrepareProposal(req pbabci.RequestPrepareProposal) pbabci.ResponsePrepareProposal {
+	return app.application.PrepareProposal(req)
+}
+
+func (app *AppConnConsensus) ProcessProposal(req pbabci.RequestProcessProposal) pbabci.ResponseProcessProposal {
+	return app.application.ProcessProposal(req)
+}
+
 func (app *AppConnConsensus) BeginBlock(req pbabci.RequestBeginBlock) pbabci.ResponseBeginBlock {
 	return app.application.BeginBlock(req)
 }
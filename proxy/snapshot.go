@@ -0,0 +1,52 @@
+package proxy
+
+import (
+	"github.com/232425wxy/meta--/abci"
+	"github.com/232425wxy/meta--/common/service"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"sync"
+)
+
+// AppConnSnapshot 状态同步使用的应用连接，既为其他节点提供本地的快照，也用其他节点的快照恢复本地应用的状态。
+type AppConnSnapshot struct {
+	service.BaseService
+	mu  sync.Mutex
+	app abci.Application
+}
+
+func NewAppConnSnapshot(app abci.Application) *AppConnSnapshot {
+	return &AppConnSnapshot{
+		BaseService: *service.NewBaseService(nil, "AppConn_Snapshot"),
+		app:         app,
+	}
+}
+
+func (ac *AppConnSnapshot) Info(req pbabci.RequestInfo) pbabci.ResponseInfo {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.app.Info(req)
+}
+
+func (ac *AppConnSnapshot) ListSnapshots(req pbabci.RequestListSnapshots) pbabci.ResponseListSnapshots {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.app.ListSnapshots(req)
+}
+
+func (ac *AppConnSnapshot) OfferSnapshot(req pbabci.RequestOfferSnapshot) pbabci.ResponseOfferSnapshot {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.app.OfferSnapshot(req)
+}
+
+func (ac *AppConnSnapshot) LoadSnapshotChunk(req pbabci.RequestLoadSnapshotChunk) pbabci.ResponseLoadSnapshotChunk {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.app.LoadSnapshotChunk(req)
+}
+
+func (ac *AppConnSnapshot) ApplySnapshotChunk(req pbabci.RequestApplySnapshotChunk) pbabci.ResponseApplySnapshotChunk {
+	ac.mu.Lock()
+	defer ac.mu.Unlock()
+	return ac.app.ApplySnapshotChunk(req)
+}
@@ -0,0 +1,101 @@
+package statesync
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"sync"
+)
+
+type chunk struct {
+	index  uint32
+	data   []byte
+	sender crypto.ID
+}
+
+// chunkQueue 记录一个快照的分块的获取情况：多个协程同时向其他节点索要不同的分块，收到的分块再按照顺序交给应用。
+type chunkQueue struct {
+	mu       sync.Mutex
+	snapshot *snapshot
+	chunks   map[uint32]*chunk
+	fetching map[uint32]chan struct{} // 正在获取的分块，收到分块或者放弃获取时关闭对应的通道
+	updated  chan struct{}            // 每收到一个分块就通知一次等待分块的应用协程
+}
+
+func newChunkQueue(s *snapshot) *chunkQueue {
+	return &chunkQueue{
+		snapshot: s,
+		chunks:   make(map[uint32]*chunk),
+		fetching: make(map[uint32]chan struct{}),
+		updated:  make(chan struct{}, 1),
+	}
+}
+
+// allocate 分配一个既没有收到也没有在获取的分块，返回分块的序号，以及在收到分块或者放弃获取时被关闭的通道，所有分块都
+// 已经收到或者正在获取时返回false。
+func (q *chunkQueue) allocate() (uint32, <-chan struct{}, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for index := uint32(0); index < q.snapshot.Chunks; index++ {
+		if _, ok := q.chunks[index]; ok {
+			continue
+		}
+		if _, ok := q.fetching[index]; ok {
+			continue
+		}
+		ch := make(chan struct{})
+		q.fetching[index] = ch
+		return index, ch, true
+	}
+	return 0, nil, false
+}
+
+// add 保存收到的分块，只接收正在获取的分块，分块被保存时返回true。
+func (q *chunkQueue) add(c *chunk) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	ch, ok := q.fetching[c.index]
+	if !ok {
+		return false
+	}
+	q.chunks[c.index] = c
+	close(ch)
+	delete(q.fetching, c.index)
+	select {
+	case q.updated <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// release 放弃获取某个分块，之后会有协程重新获取它。
+func (q *chunkQueue) release(index uint32) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if ch, ok := q.fetching[index]; ok {
+		close(ch)
+		delete(q.fetching, index)
+	}
+}
+
+func (q *chunkQueue) get(index uint32) *chunk {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.chunks[index]
+}
+
+// discard 丢弃已经收到的分块，之后会有协程重新获取它。
+func (q *chunkQueue) discard(index uint32) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	delete(q.chunks, index)
+}
+
+// discardSender 丢弃从sender那里收到的、序号不小于from的分块，即还没有交给应用的分块。
+func (q *chunkQueue) discardSender(sender crypto.ID, from uint32) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for index, c := range q.chunks {
+		if c.sender == sender && index >= from {
+			delete(q.chunks, index)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package statesync
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/proto/pbstatesync"
+	"github.com/cosmos/gogoproto/proto"
+)
+
+func EncodeMsg(pb proto.Message) ([]byte, error) {
+	msg := &pbstatesync.Message{}
+
+	switch pb := pb.(type) {
+	case *pbstatesync.SnapshotsRequest:
+		msg.Sum = &pbstatesync.Message_SnapshotsRequest{SnapshotsRequest: pb}
+	case *pbstatesync.SnapshotsResponse:
+		msg.Sum = &pbstatesync.Message_SnapshotsResponse{SnapshotsResponse: pb}
+	case *pbstatesync.ChunkRequest:
+		msg.Sum = &pbstatesync.Message_ChunkRequest{ChunkRequest: pb}
+	case *pbstatesync.ChunkResponse:
+		msg.Sum = &pbstatesync.Message_ChunkResponse{ChunkResponse: pb}
+	case *pbstatesync.LightBlockRequest:
+		msg.Sum = &pbstatesync.Message_LightBlockRequest{LightBlockRequest: pb}
+	case *pbstatesync.LightBlockResponse:
+		msg.Sum = &pbstatesync.Message_LightBlockResponse{LightBlockResponse: pb}
+	default:
+		return nil, fmt.Errorf("unknown message type: %T", pb)
+	}
+	bz, err := proto.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+func DecodeMsg(bz []byte) (proto.Message, error) {
+	pb := &pbstatesync.Message{}
+	err := proto.Unmarshal(bz, pb)
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg := pb.Sum.(type) {
+	case *pbstatesync.Message_SnapshotsRequest:
+		return msg.SnapshotsRequest, nil
+	case *pbstatesync.Message_SnapshotsResponse:
+		return msg.SnapshotsResponse, nil
+	case *pbstatesync.Message_ChunkRequest:
+		return msg.ChunkRequest, nil
+	case *pbstatesync.Message_ChunkResponse:
+		return msg.ChunkResponse, nil
+	case *pbstatesync.Message_LightBlockRequest:
+		return msg.LightBlockRequest, nil
+	case *pbstatesync.Message_LightBlockResponse:
+		return msg.LightBlockResponse, nil
+	default:
+		return nil, fmt.Errorf("unknown message type: %T", msg)
+	}
+}
@@ -0,0 +1,218 @@
+package statesync
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/config"
+	state2 "github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/log"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proto/pbstatesync"
+	"github.com/232425wxy/meta--/proxy"
+	"github.com/232425wxy/meta--/store"
+	"sort"
+	"sync"
+)
+
+// recentSnapshots 回应其他节点的询问时，最多告诉对方最近的几个快照
+const recentSnapshots = 10
+
+// Reactor 状态同步：一方面把本地应用的快照、快照的分块以及快照高度附近的区块提供给其他节点，另一方面在新节点启动时，
+// 从其他节点那里获取快照来恢复本地应用的状态，之后再交给区块同步和共识。
+type Reactor struct {
+	p2p.BaseReactor
+	cfg        *config.StateSyncConfig
+	conn       *proxy.AppConnSnapshot
+	stateStore *state2.StoreState
+	blockStore *store.BlockStore
+
+	mu           sync.Mutex
+	pool         *snapshotPool // 只在状态同步期间不为nil
+	queue        *chunkQueue   // 正在获取分块的快照
+	lightBlockCh chan lightBlockResponse
+}
+
+type lightBlockResponse struct {
+	peer *p2p.Peer
+	msg  *pbstatesync.LightBlockResponse
+}
+
+func NewReactor(cfg *config.StateSyncConfig, conn *proxy.AppConnSnapshot, stateStore *state2.StoreState, blockStore *store.BlockStore, logger log.Logger) *Reactor {
+	r := &Reactor{
+		BaseReactor: *p2p.NewBaseReactor("StateSync"),
+		cfg:         cfg,
+		conn:        conn,
+		stateStore:  stateStore,
+		blockStore:  blockStore,
+	}
+	r.SetLogger(logger)
+	return r
+}
+
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  p2p.SnapshotChannel,
+			Priority:            6,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  1024 * 1024,
+			RecvMessageCapacity: 1024 * 1024 * 16,
+		},
+		{
+			ID:                  p2p.ChunkChannel,
+			Priority:            3,
+			SendQueueCapacity:   4,
+			RecvBufferCapacity:  1024 * 1024,
+			RecvMessageCapacity: 1024 * 1024 * 16,
+		},
+	}
+}
+
+// AddPeer 状态同步期间向新连接的节点询问它的快照。
+func (r *Reactor) AddPeer(p *p2p.Peer) {
+	if r.currentPool() == nil {
+		return
+	}
+	bz, err := EncodeMsg(&pbstatesync.SnapshotsRequest{})
+	if err != nil {
+		r.Logger.Error("failed to encode SnapshotsRequest message", "err", err)
+		return
+	}
+	p.Send(p2p.SnapshotChannel, bz)
+}
+
+func (r *Reactor) RemovePeer(p *p2p.Peer, reason error) {
+	if pool := r.currentPool(); pool != nil {
+		pool.removePeer(p.NodeID())
+	}
+}
+
+func (r *Reactor) Receive(chID byte, src *p2p.Peer, msgBytes []byte) {
+	msg, err := DecodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("failed to decode message", "sender_id", src.NodeID(), "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	switch msg := msg.(type) {
+	case *pbstatesync.SnapshotsRequest:
+		r.respondSnapshots(src)
+	case *pbstatesync.SnapshotsResponse:
+		pool := r.currentPool()
+		if pool == nil {
+			return
+		}
+		if msg.Chunks == 0 || msg.Chunks > maxSnapshotChunks {
+			r.Logger.Warn("ignore snapshot with invalid chunks", "peer_id", src.NodeID(), "height", msg.Height, "chunks", msg.Chunks)
+			return
+		}
+		s := &snapshot{Height: msg.Height, Format: msg.Format, Chunks: msg.Chunks, Hash: msg.Hash, Metadata: msg.Metadata}
+		if pool.add(src.NodeID(), s) {
+			r.Logger.Info("discovered new snapshot", "peer_id", src.NodeID(), "height", s.Height, "format", s.Format, "hash", fmt.Sprintf("%X", s.Hash))
+		}
+	case *pbstatesync.ChunkRequest:
+		r.respondChunk(msg, src)
+	case *pbstatesync.ChunkResponse:
+		q := r.currentQueue()
+		if q == nil || q.snapshot.Height != msg.Height || q.snapshot.Format != msg.Format {
+			return
+		}
+		if msg.Missing {
+			r.Logger.Debug("peer does not have the chunk", "peer_id", src.NodeID(), "height", msg.Height, "index", msg.Index)
+			q.release(msg.Index)
+			return
+		}
+		q.add(&chunk{index: msg.Index, data: msg.Chunk, sender: src.NodeID()})
+	case *pbstatesync.LightBlockRequest:
+		r.respondLightBlock(msg, src)
+	case *pbstatesync.LightBlockResponse:
+		r.mu.Lock()
+		ch := r.lightBlockCh
+		r.mu.Unlock()
+		if ch == nil {
+			return
+		}
+		select {
+		case ch <- lightBlockResponse{peer: src, msg: msg}:
+		default:
+			r.Logger.Warn("drop light block response", "peer_id", src.NodeID(), "height", msg.Height)
+		}
+	default:
+		r.Logger.Warn(fmt.Sprintf("unknown message type: %T", msg))
+	}
+}
+
+// respondSnapshots 把本地应用最近的快照告诉对方。
+func (r *Reactor) respondSnapshots(src *p2p.Peer) {
+	res := r.conn.ListSnapshots(pbabci.RequestListSnapshots{})
+	snapshots := res.Snapshots
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height > snapshots[j].Height
+	})
+	if len(snapshots) > recentSnapshots {
+		snapshots = snapshots[:recentSnapshots]
+	}
+	for _, s := range snapshots {
+		bz, err := EncodeMsg(&pbstatesync.SnapshotsResponse{Height: s.Height, Format: s.Format, Chunks: s.Chunks, Hash: s.Hash, Metadata: s.Metadata})
+		if err != nil {
+			r.Logger.Error("failed to encode SnapshotsResponse message", "err", err)
+			return
+		}
+		src.Send(p2p.SnapshotChannel, bz)
+	}
+}
+
+func (r *Reactor) respondChunk(req *pbstatesync.ChunkRequest, src *p2p.Peer) {
+	res := r.conn.LoadSnapshotChunk(pbabci.RequestLoadSnapshotChunk{Height: req.Height, Format: req.Format, Chunk: req.Index})
+	bz, err := EncodeMsg(&pbstatesync.ChunkResponse{
+		Height:  req.Height,
+		Format:  req.Format,
+		Index:   req.Index,
+		Chunk:   res.Chunk,
+		Missing: res.Chunk == nil,
+	})
+	if err != nil {
+		r.Logger.Error("failed to encode ChunkResponse message", "err", err)
+		return
+	}
+	src.Send(p2p.ChunkChannel, bz)
+}
+
+// respondLightBlock 把指定高度的区块、提交证明、为它投票的验证者集合以及它使用的共识参数提供给对方，本地没有时
+// 只回复高度。
+func (r *Reactor) respondLightBlock(req *pbstatesync.LightBlockRequest, src *p2p.Peer) {
+	res := &pbstatesync.LightBlockResponse{Height: req.Height}
+	block := r.blockStore.LoadBlockByHeight(req.Height)
+	commit := r.blockStore.LoadCommit(req.Height)
+	validators, err := r.stateStore.LoadValidators(req.Height)
+	if block != nil && commit != nil && err == nil {
+		res.Block = block.ToProto()
+		res.Commit = commit.ToProto()
+		res.Validators = validators.ToProto()
+		// 初始高度的共识参数没有被单独保存下来
+		if params, err := r.stateStore.LoadConsensusParams(req.Height); err == nil {
+			res.ConsensusParams = params.ToProto()
+		}
+	} else {
+		r.Logger.Warn("peer asked for a light block that we don't have", "peer_id", src.NodeID(), "height", req.Height)
+	}
+	bz, err := EncodeMsg(res)
+	if err != nil {
+		r.Logger.Error("failed to encode LightBlockResponse message", "err", err)
+		return
+	}
+	src.Send(p2p.SnapshotChannel, bz)
+}
+
+func (r *Reactor) currentPool() *snapshotPool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.pool
+}
+
+func (r *Reactor) currentQueue() *chunkQueue {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.queue
+}
@@ -0,0 +1,156 @@
+package statesync
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"math/rand"
+	"sort"
+	"sync"
+)
+
+// maxSnapshotChunks 快照最多能被切分成多少个分块，超过这个数量的快照会被直接忽略
+const maxSnapshotChunks = 100000
+
+type snapshot struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
+// key 同一高度、同一格式、哈希值也相同的快照被认为是同一个快照，无论它来自哪个节点。
+func (s *snapshot) key() string {
+	return fmt.Sprintf("%d:%d:%X", s.Height, s.Format, s.Hash)
+}
+
+func (s *snapshot) toABCI() *pbabci.Snapshot {
+	return &pbabci.Snapshot{
+		Height:   s.Height,
+		Format:   s.Format,
+		Chunks:   s.Chunks,
+		Hash:     s.Hash,
+		Metadata: s.Metadata,
+	}
+}
+
+// snapshotPool 记录其他节点提供的快照，以及每个快照可以从哪些节点那里获取，被拒绝的快照、快照格式和节点不会再被使用。
+type snapshotPool struct {
+	mu              sync.Mutex
+	snapshots       map[string]*snapshot
+	peers           map[string]map[crypto.ID]struct{}
+	rejected        map[string]struct{}
+	rejectedFormats map[uint32]struct{}
+	rejectedPeers   map[crypto.ID]struct{}
+}
+
+func newSnapshotPool() *snapshotPool {
+	return &snapshotPool{
+		snapshots:       make(map[string]*snapshot),
+		peers:           make(map[string]map[crypto.ID]struct{}),
+		rejected:        make(map[string]struct{}),
+		rejectedFormats: make(map[uint32]struct{}),
+		rejectedPeers:   make(map[crypto.ID]struct{}),
+	}
+}
+
+// add 记录peerID提供的快照，第一次见到该快照时返回true。
+func (p *snapshotPool) add(peerID crypto.ID, s *snapshot) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key := s.key()
+	if _, ok := p.peers[key]; !ok {
+		p.peers[key] = make(map[crypto.ID]struct{})
+	}
+	p.peers[key][peerID] = struct{}{}
+	if _, ok := p.snapshots[key]; ok {
+		return false
+	}
+	p.snapshots[key] = s
+	return true
+}
+
+// best 挑选最合适的快照：优先选择高度最高的快照，高度相同时选择提供它的节点最多的快照，没有可用的快照时返回nil。
+func (p *snapshotPool) best() *snapshot {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var candidates []*snapshot
+	for key, s := range p.snapshots {
+		if _, ok := p.rejected[key]; ok {
+			continue
+		}
+		if _, ok := p.rejectedFormats[s.Format]; ok {
+			continue
+		}
+		if len(p.availablePeers(key)) == 0 {
+			continue
+		}
+		candidates = append(candidates, s)
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Height != b.Height {
+			return a.Height > b.Height
+		}
+		if na, nb := len(p.availablePeers(a.key())), len(p.availablePeers(b.key())); na != nb {
+			return na > nb
+		}
+		if a.Format != b.Format {
+			return a.Format > b.Format
+		}
+		return a.key() < b.key()
+	})
+	return candidates[0]
+}
+
+// getPeers 以随机的顺序返回可以提供该快照的节点。
+func (p *snapshotPool) getPeers(s *snapshot) []crypto.ID {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	peers := p.availablePeers(s.key())
+	rand.Shuffle(len(peers), func(i, j int) {
+		peers[i], peers[j] = peers[j], peers[i]
+	})
+	return peers
+}
+
+func (p *snapshotPool) availablePeers(key string) []crypto.ID {
+	peers := make([]crypto.ID, 0, len(p.peers[key]))
+	for id := range p.peers[key] {
+		if _, ok := p.rejectedPeers[id]; !ok {
+			peers = append(peers, id)
+		}
+	}
+	return peers
+}
+
+func (p *snapshotPool) reject(s *snapshot) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rejected[s.key()] = struct{}{}
+}
+
+func (p *snapshotPool) rejectFormat(format uint32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rejectedFormats[format] = struct{}{}
+}
+
+func (p *snapshotPool) rejectPeer(peerID crypto.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.rejectedPeers[peerID] = struct{}{}
+}
+
+// removePeer 节点断开连接后，不再从它那里获取快照。
+func (p *snapshotPool) removePeer(peerID crypto.ID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, peers := range p.peers {
+		delete(peers, peerID)
+	}
+}
@@ -0,0 +1,400 @@
+package statesync
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	state2 "github.com/232425wxy/meta--/consensus/state"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/p2p"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proto/pbstatesync"
+	"github.com/232425wxy/meta--/types"
+	"time"
+)
+
+var (
+	// errAbort 应用要求中止状态同步
+	errAbort = errors.New("state sync aborted by application")
+	// errRetrySnapshot 应用要求从头恢复同一个快照
+	errRetrySnapshot = errors.New("application asked to retry the snapshot")
+	// errRejectSnapshot 应用拒绝了这个快照
+	errRejectSnapshot = errors.New("snapshot was rejected by application")
+	// errRejectFormat 应用不支持这种格式的快照
+	errRejectFormat = errors.New("snapshot format was rejected by application")
+	// errNoSnapshotPeers 已经没有可以提供该快照的节点了
+	errNoSnapshotPeers = errors.New("no peers have the snapshot")
+	// errAppMismatch 应用恢复出的状态与轻区块证明的状态不一致，此时应用的数据已经被改写，无法再尝试其他快照
+	errAppMismatch = errors.New("restored application state does not match the verified block")
+	errStopped     = errors.New("state sync reactor stopped")
+)
+
+// lightBlock 其他节点提供的区块、区块的提交证明、为它投票的验证者集合以及它使用的共识参数。
+type lightBlock struct {
+	block      *types.Block
+	commit     *types.CommitBlock
+	validators *types.ValidatorSet
+	params     *types.ConsensusParams
+}
+
+func lightBlockFromProto(pb *pbstatesync.LightBlockResponse) (*lightBlock, error) {
+	if pb.Block == nil || pb.Commit == nil || pb.Validators == nil {
+		return nil, fmt.Errorf("peer does not have light block at height %d", pb.Height)
+	}
+	lb := &lightBlock{
+		block:      types.BlockFromProto(pb.Block),
+		commit:     types.CommitBlockFromProto(pb.Commit),
+		validators: types.ValidatorSetFromProto(pb.Validators),
+		params:     types.ConsensusParamsFromProto(pb.ConsensusParams),
+	}
+	if lb.block.Header == nil || lb.block.ChameleonHash == nil {
+		return nil, fmt.Errorf("incomplete block at height %d", pb.Height)
+	}
+	if lb.block.Header.Height != pb.Height {
+		return nil, fmt.Errorf("asked for block at height %d, but got block at height %d", pb.Height, lb.block.Header.Height)
+	}
+	return lb, nil
+}
+
+// Sync 用其他节点的快照恢复本地应用的状态，返回快照高度的状态，并且把该状态和快照高度的区块保存下来。
+//
+// stat是节点的创世状态，它的验证者集合是状态同步唯一信任的验证者集合：快照高度的区块和它的下一个区块都必须由与它
+// 成员和投票权完全相同的验证者集合确认，因此只有验证者集合自创世以来没有变化的链才能通过状态同步启动新节点。下一个
+// 区块的区块头记录了执行完快照高度的区块后应用状态的哈希值，应用恢复出的状态必须与它一致。
+func (r *Reactor) Sync(stat *state2.State) (*state2.State, error) {
+	r.mu.Lock()
+	if r.pool != nil {
+		r.mu.Unlock()
+		return nil, errors.New("a state sync is already in progress")
+	}
+	r.pool = newSnapshotPool()
+	r.lightBlockCh = make(chan lightBlockResponse, 4)
+	pool := r.pool
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.pool, r.lightBlockCh = nil, nil
+		r.mu.Unlock()
+	}()
+
+	r.Logger.Info("start state sync, discovering snapshots", "discovery_time", r.cfg.DiscoveryTime)
+	r.requestSnapshots()
+	for {
+		select {
+		case <-time.After(r.cfg.DiscoveryTime):
+		case <-r.WaitStop():
+			return nil, errStopped
+		}
+		for s := pool.best(); s != nil; s = pool.best() {
+			newState, err := r.syncSnapshot(stat, s)
+			switch {
+			case err == nil:
+				return newState, nil
+			case errors.Is(err, errAbort), errors.Is(err, errAppMismatch), errors.Is(err, errStopped):
+				return nil, err
+			case errors.Is(err, errRetrySnapshot):
+				r.Logger.Info("retry snapshot", "height", s.Height, "format", s.Format)
+			case errors.Is(err, errRejectFormat):
+				r.Logger.Info("snapshot format rejected", "format", s.Format)
+				pool.rejectFormat(s.Format)
+			default:
+				r.Logger.Info("snapshot rejected", "height", s.Height, "format", s.Format, "hash", fmt.Sprintf("%X", s.Hash), "err", err)
+				pool.reject(s)
+			}
+		}
+		r.Logger.Info("no available snapshots, discovering again", "discovery_time", r.cfg.DiscoveryTime)
+		r.requestSnapshots()
+	}
+}
+
+func (r *Reactor) requestSnapshots() {
+	bz, err := EncodeMsg(&pbstatesync.SnapshotsRequest{})
+	if err != nil {
+		r.Logger.Error("failed to encode SnapshotsRequest message", "err", err)
+		return
+	}
+	r.Switch.Broadcast(p2p.SnapshotChannel, bz)
+}
+
+// syncSnapshot 验证快照高度的区块和它的下一个区块，然后把快照交给应用，并行地从其他节点那里获取分块，按照顺序交给
+// 应用恢复状态，最后检查应用恢复出的状态，并保存新的状态和快照高度的区块。
+func (r *Reactor) syncSnapshot(stat *state2.State, s *snapshot) (*state2.State, error) {
+	r.Logger.Info("try to restore snapshot", "height", s.Height, "format", s.Format, "chunks", s.Chunks, "hash", fmt.Sprintf("%X", s.Hash))
+	lb, err := r.fetchLightBlock(s, s.Height)
+	if err != nil {
+		return nil, err
+	}
+	next, err := r.fetchLightBlock(s, s.Height+1)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyLightBlocks(stat.Validators, lb, next); err != nil {
+		return nil, err
+	}
+	appHash := next.block.Header.AppHash
+
+	res := r.conn.OfferSnapshot(pbabci.RequestOfferSnapshot{Snapshot: s.toABCI(), AppHash: appHash})
+	switch res.Result {
+	case pbabci.ResponseOfferSnapshot_ACCEPT:
+	case pbabci.ResponseOfferSnapshot_ABORT:
+		return nil, errAbort
+	case pbabci.ResponseOfferSnapshot_REJECT:
+		return nil, errRejectSnapshot
+	case pbabci.ResponseOfferSnapshot_REJECT_FORMAT:
+		return nil, errRejectFormat
+	default:
+		return nil, fmt.Errorf("unknown OfferSnapshot result %v", res.Result)
+	}
+
+	q := newChunkQueue(s)
+	done := make(chan struct{})
+	r.mu.Lock()
+	r.queue = q
+	r.mu.Unlock()
+	defer func() {
+		close(done)
+		r.mu.Lock()
+		r.queue = nil
+		r.mu.Unlock()
+	}()
+	for i := 0; i < r.cfg.ChunkFetchers; i++ {
+		go r.fetchChunks(s, q, done)
+	}
+	if err = r.applyChunks(s, q); err != nil {
+		return nil, err
+	}
+
+	info := r.conn.Info(pbabci.RequestInfo{})
+	if info.LastBlockHeight != s.Height || !bytes.Equal(info.LastBlockAppHash, appHash) {
+		return nil, fmt.Errorf("%w: application has height %d and app hash %X, expected height %d and app hash %X",
+			errAppMismatch, info.LastBlockHeight, info.LastBlockAppHash, s.Height, appHash)
+	}
+
+	newState := stat.Copy()
+	newState.SetChameleon(stat.Chameleon)
+	newState.SetBlockStore(stat.BlockStore)
+	newState.LastBlockHeight = lb.block.Header.Height
+	newState.PreviousBlock = lb.block
+	newState.LastBlockTime = lb.block.Header.Timestamp
+	newState.Validators = next.validators
+	newState.AppHash = appHash
+	if next.params != nil {
+		newState.ConsensusParams = next.params
+	}
+	r.blockStore.SaveBlock(lb.block, lb.commit)
+	if err = r.stateStore.SaveValidators(lb.block.Header.Height, lb.validators); err != nil {
+		return nil, err
+	}
+	if err = r.stateStore.Bootstrap(newState); err != nil {
+		return nil, err
+	}
+	r.Logger.Info("snapshot restored", "height", newState.LastBlockHeight, "app_hash", fmt.Sprintf("%X", appHash))
+	return newState, nil
+}
+
+// fetchLightBlock 依次向提供该快照的节点索要指定高度的轻区块，直到有节点提供为止。
+func (r *Reactor) fetchLightBlock(s *snapshot, height int64) (*lightBlock, error) {
+	bz, err := EncodeMsg(&pbstatesync.LightBlockRequest{Height: height})
+	if err != nil {
+		return nil, err
+	}
+	r.mu.Lock()
+	ch := r.lightBlockCh
+	r.mu.Unlock()
+	for _, id := range r.currentPool().getPeers(s) {
+		p := r.Switch.Peers().GetPeer(id)
+		if p == nil || !p.Send(p2p.SnapshotChannel, bz) {
+			continue
+		}
+		lb, err := r.waitLightBlock(ch, id, height)
+		if errors.Is(err, errStopped) {
+			return nil, err
+		}
+		if err != nil {
+			r.Logger.Warn("failed to fetch light block", "peer_id", id, "height", height, "err", err)
+			continue
+		}
+		return lb, nil
+	}
+	return nil, fmt.Errorf("%w: failed to fetch light block at height %d", errNoSnapshotPeers, height)
+}
+
+func (r *Reactor) waitLightBlock(ch chan lightBlockResponse, id crypto.ID, height int64) (*lightBlock, error) {
+	timer := time.NewTimer(r.cfg.ChunkRequestTimeout)
+	defer timer.Stop()
+	for {
+		select {
+		case res := <-ch:
+			if res.peer.NodeID() != id || res.msg.Height != height {
+				// 之前超时的请求迟到的回复
+				continue
+			}
+			return lightBlockFromProto(res.msg)
+		case <-timer.C:
+			return nil, errors.New("timed out waiting for light block")
+		case <-r.WaitStop():
+			return nil, errStopped
+		}
+	}
+}
+
+// verifyLightBlocks 用信任的验证者集合验证快照高度的区块和它的下一个区块：两个区块的提交证明都必须是合法的，下一个
+// 区块必须引用快照高度的区块，并且下一个区块的区块头必须记录了应用状态哈希。两个轻区块里的验证者集合被替换成信任的
+// 验证者集合，只保留其他节点提供的主节点优先级。
+func verifyLightBlocks(trusted *types.ValidatorSet, lb, next *lightBlock) error {
+	height := lb.block.Header.Height
+	if next.block.Header.Height != height+1 {
+		return fmt.Errorf("expected block at height %d, but got block at height %d", height+1, next.block.Header.Height)
+	}
+	for _, b := range []*lightBlock{lb, next} {
+		validators, err := trustValidators(trusted, b.validators)
+		if err != nil {
+			return fmt.Errorf("untrusted validators at height %d: %w", b.block.Header.Height, err)
+		}
+		if err = b.commit.VerifyCommit(b.block, validators); err != nil {
+			return fmt.Errorf("invalid commit at height %d: %w", b.block.Header.Height, err)
+		}
+		b.validators = validators
+		if b.params != nil {
+			if err = b.params.ValidateBasic(); err != nil {
+				return fmt.Errorf("invalid consensus params at height %d: %w", b.block.Header.Height, err)
+			}
+		}
+	}
+	if !bytes.Equal(next.block.Header.PreviousBlockHash, lb.block.ChameleonHash.Hash) {
+		return fmt.Errorf("block %d does not reference block %d", height+1, height)
+	}
+	if len(next.block.Header.AppHash) == 0 {
+		// 链式HotStuff里父区块未提交时区块头不携带应用状态哈希
+		return fmt.Errorf("block %d does not carry the app hash", height+1)
+	}
+	return nil
+}
+
+// trustValidators 检查其他节点提供的验证者集合与信任的验证者集合的成员和投票权完全相同，返回信任的验证者集合的副本，
+// 副本里每个验证者的主节点优先级以及主节点取自其他节点提供的验证者集合。
+func trustValidators(trusted, served *types.ValidatorSet) (*types.ValidatorSet, error) {
+	if len(served.Validators) != len(trusted.Validators) {
+		return nil, fmt.Errorf("expected %d validators, got %d", len(trusted.Validators), len(served.Validators))
+	}
+	validators := trusted.Copy()
+	for _, val := range served.Validators {
+		if val == nil {
+			return nil, errors.New("nil validator")
+		}
+		v := validators.GetValidatorByID(val.ID)
+		if v == nil {
+			return nil, fmt.Errorf("unknown validator %s", val.ID)
+		}
+		if v.VotingPower != val.VotingPower {
+			return nil, fmt.Errorf("validator %s has voting power %d, expected %d", val.ID, val.VotingPower, v.VotingPower)
+		}
+		v.LeaderPriority = val.LeaderPriority
+	}
+	if served.Leader != nil {
+		validators.Leader = validators.GetValidatorByID(served.Leader.ID)
+	}
+	return validators, nil
+}
+
+// fetchChunks 不断地从其他节点那里获取还没有收到的分块，直到done被关闭。
+func (r *Reactor) fetchChunks(s *snapshot, q *chunkQueue, done <-chan struct{}) {
+	for {
+		index, arrived, ok := q.allocate()
+		if !ok || !r.requestChunk(s, index) {
+			if ok {
+				q.release(index)
+			}
+			// 所有分块都已经收到或者正在获取，或者暂时没有节点可以提供分块
+			select {
+			case <-time.After(100 * time.Millisecond):
+				continue
+			case <-done:
+				return
+			}
+		}
+		timer := time.NewTimer(r.cfg.ChunkRequestTimeout)
+		select {
+		case <-arrived:
+		case <-timer.C:
+			r.Logger.Debug("timed out waiting for chunk, request it again", "height", s.Height, "index", index)
+			q.release(index)
+		case <-done:
+			timer.Stop()
+			return
+		}
+		timer.Stop()
+	}
+}
+
+// requestChunk 随机挑选一个提供该快照的节点，向它索要分块。
+func (r *Reactor) requestChunk(s *snapshot, index uint32) bool {
+	bz, err := EncodeMsg(&pbstatesync.ChunkRequest{Height: s.Height, Format: s.Format, Index: index})
+	if err != nil {
+		r.Logger.Error("failed to encode ChunkRequest message", "err", err)
+		return false
+	}
+	for _, id := range r.currentPool().getPeers(s) {
+		if p := r.Switch.Peers().GetPeer(id); p != nil && p.Send(p2p.ChunkChannel, bz) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyChunks 按照顺序把分块交给应用，应用可以要求重新获取某些分块，或者不再使用某些节点提供的分块。
+func (r *Reactor) applyChunks(s *snapshot, q *chunkQueue) error {
+	for index := uint32(0); index < s.Chunks; {
+		c, err := r.waitChunk(s, q, index)
+		if err != nil {
+			return err
+		}
+		res := r.conn.ApplySnapshotChunk(pbabci.RequestApplySnapshotChunk{Index: index, Chunk: c.data, Sender: string(c.sender)})
+		for _, sender := range res.RejectSenders {
+			r.Logger.Info("reject snapshot sender", "peer_id", sender)
+			r.currentPool().rejectPeer(crypto.ID(sender))
+			q.discardSender(crypto.ID(sender), index)
+		}
+		switch res.Result {
+		case pbabci.ResponseApplySnapshotChunk_ACCEPT:
+			index++
+		case pbabci.ResponseApplySnapshotChunk_RETRY:
+			q.discard(index)
+		case pbabci.ResponseApplySnapshotChunk_ABORT:
+			return errAbort
+		case pbabci.ResponseApplySnapshotChunk_RETRY_SNAPSHOT:
+			return errRetrySnapshot
+		case pbabci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT:
+			return errRejectSnapshot
+		default:
+			return fmt.Errorf("unknown ApplySnapshotChunk result %v", res.Result)
+		}
+		for _, i := range res.RefetchChunks {
+			q.discard(i)
+			if i < index {
+				index = i
+			}
+		}
+	}
+	return nil
+}
+
+// waitChunk 等待指定序号的分块，已经没有节点能够提供该快照时放弃等待。
+func (r *Reactor) waitChunk(s *snapshot, q *chunkQueue, index uint32) (*chunk, error) {
+	ticker := time.NewTicker(r.cfg.ChunkRequestTimeout)
+	defer ticker.Stop()
+	for {
+		if c := q.get(index); c != nil {
+			return c, nil
+		}
+		select {
+		case <-q.updated:
+		case <-ticker.C:
+			if len(r.currentPool().getPeers(s)) == 0 {
+				return nil, errNoSnapshotPeers
+			}
+		case <-r.WaitStop():
+			return nil, errStopped
+		}
+	}
+}
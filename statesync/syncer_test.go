@@ -0,0 +1,158 @@
+package statesync
+
+import (
+	"fmt"
+	"github.com/232425wxy/meta--/abci/apps"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/database"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/types"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSnapshotPool(t *testing.T) {
+	pool := newSnapshotPool()
+	s1 := &snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte("s1")}
+	s2 := &snapshot{Height: 20, Format: 1, Chunks: 1, Hash: []byte("s2")}
+	s3 := &snapshot{Height: 20, Format: 1, Chunks: 1, Hash: []byte("s3")}
+	assert.True(t, pool.add("peer1", s1))
+	assert.True(t, pool.add("peer1", s2))
+	assert.True(t, pool.add("peer2", s3))
+	assert.False(t, pool.add("peer3", &snapshot{Height: 20, Format: 1, Chunks: 1, Hash: []byte("s3")}))
+
+	// 高度相同时选择提供它的节点更多的快照
+	assert.Equal(t, s3.key(), pool.best().key())
+	assert.Len(t, pool.getPeers(s3), 2)
+
+	pool.reject(s3)
+	assert.Equal(t, s2.key(), pool.best().key())
+
+	// 被拒绝的节点提供的快照不再可用
+	pool.rejectPeer("peer1")
+	assert.Nil(t, pool.best())
+
+	pool.add("peer2", s1)
+	assert.Equal(t, s1.key(), pool.best().key())
+	pool.rejectFormat(1)
+	assert.Nil(t, pool.best())
+}
+
+func makeLightBlocks(t *testing.T, height int64) (*types.ValidatorSet, *lightBlock, *lightBlock) {
+	privateKeys := make([]*bls12.PrivateKey, 4)
+	validators := make([]*types.Validator, 4)
+	for i := 0; i < 4; i++ {
+		privateKeys[i], _ = bls12.GeneratePrivateKey()
+		validators[i] = types.NewValidator(privateKeys[i].PublicKey(), 10)
+	}
+	set := types.NewValidatorSet(validators)
+	commit := func(block *types.Block) *types.CommitBlock {
+		valueHash := types.GenerateDecideValueHash(block.Header.Height, 1, block.ChameleonHash.Hash)
+		sigs := make([]*bls12.Signature, 0)
+		for _, key := range privateKeys[:3] {
+			sig, err := key.Sign(valueHash)
+			assert.Nil(t, err)
+			sigs = append(sigs, sig)
+		}
+		agg, err := bls12.NewCryptoBLS12().CreateThresholdSignature(sigs)
+		assert.Nil(t, err)
+		return types.NewCommitBlock(block, types.NewDecide(agg, valueHash, block.ChameleonHash.Hash, validators[0].ID, block.Header.Height, 1))
+	}
+	block := &types.Block{
+		Header:        &types.Header{Height: height},
+		Body:          &types.Data{},
+		ChameleonHash: &types.ChameleonHash{Hash: []byte(fmt.Sprintf("block %d", height))},
+	}
+	next := &types.Block{
+		Header:        &types.Header{Height: height + 1, PreviousBlockHash: block.ChameleonHash.Hash, AppHash: []byte("app hash")},
+		Body:          &types.Data{},
+		ChameleonHash: &types.ChameleonHash{Hash: []byte(fmt.Sprintf("block %d", height+1))},
+	}
+	lb := &lightBlock{block: block, commit: commit(block), validators: set.Copy(), params: types.DefaultConsensusParams()}
+	nlb := &lightBlock{block: next, commit: commit(next), validators: set.Copy(), params: types.DefaultConsensusParams()}
+	return set, lb, nlb
+}
+
+func TestVerifyLightBlocks(t *testing.T) {
+	trusted, lb, next := makeLightBlocks(t, 5)
+	assert.Nil(t, verifyLightBlocks(trusted, lb, next))
+
+	// 下一个区块没有引用快照高度的区块
+	trusted, lb, next = makeLightBlocks(t, 5)
+	next.block.Header.PreviousBlockHash = []byte("other block")
+	assert.NotNil(t, verifyLightBlocks(trusted, lb, next))
+
+	// 下一个区块没有携带应用状态哈希
+	trusted, lb, next = makeLightBlocks(t, 5)
+	next.block.Header.AppHash = nil
+	assert.NotNil(t, verifyLightBlocks(trusted, lb, next))
+
+	// 其他节点提供的验证者集合与信任的验证者集合不同
+	trusted, lb, next = makeLightBlocks(t, 5)
+	next.validators.Validators[0].VotingPower = 100
+	assert.NotNil(t, verifyLightBlocks(trusted, lb, next))
+
+	// 提交证明不是由信任的验证者集合签署的
+	_, lb, next = makeLightBlocks(t, 5)
+	other, _, _ := makeLightBlocks(t, 5)
+	assert.NotNil(t, verifyLightBlocks(other, lb, next))
+}
+
+func TestRestoreKVStoreSnapshot(t *testing.T) {
+	source := apps.NewKVStoreApp("source", "", database.MemDBBackend)
+	source.SetSnapshotInterval(2, 64)
+	for height := int64(1); height <= 4; height++ {
+		source.BeginBlock(pbabci.RequestBeginBlock{Height: height})
+		for i := 0; i < 5; i++ {
+			source.DeliverTx(pbabci.RequestDeliverTx{Tx: []byte(fmt.Sprintf("key%d-%d=value%d-%d", height, i, height, i))})
+		}
+		source.EndBlock(pbabci.RequestEndBlock{})
+		source.Commit(pbabci.RequestCommit{})
+	}
+	snapshots := source.ListSnapshots(pbabci.RequestListSnapshots{}).Snapshots
+	assert.Len(t, snapshots, 2)
+	s := snapshots[1]
+	assert.Equal(t, int64(4), s.Height)
+	assert.True(t, s.Chunks > 1)
+	appHash := source.Info(pbabci.RequestInfo{}).LastBlockAppHash
+
+	target := apps.NewKVStoreApp("target", "", database.MemDBBackend)
+	// 不支持的快照格式
+	assert.Equal(t, pbabci.ResponseOfferSnapshot_REJECT_FORMAT, target.OfferSnapshot(pbabci.RequestOfferSnapshot{Snapshot: &pbabci.Snapshot{Format: 2}, AppHash: appHash}).Result)
+	assert.Equal(t, pbabci.ResponseOfferSnapshot_ACCEPT, target.OfferSnapshot(pbabci.RequestOfferSnapshot{Snapshot: s, AppHash: appHash}).Result)
+	for index := uint32(0); index < s.Chunks; index++ {
+		chunk := source.LoadSnapshotChunk(pbabci.RequestLoadSnapshotChunk{Height: s.Height, Format: s.Format, Chunk: index}).Chunk
+		assert.NotNil(t, chunk)
+		if index == 1 {
+			// 被篡改的分块需要从其他节点那里重新获取
+			res := target.ApplySnapshotChunk(pbabci.RequestApplySnapshotChunk{Index: index, Chunk: []byte("corrupted"), Sender: "bad peer"})
+			assert.Equal(t, pbabci.ResponseApplySnapshotChunk_RETRY, res.Result)
+			assert.Equal(t, []uint32{index}, res.RefetchChunks)
+			assert.Equal(t, []string{"bad peer"}, res.RejectSenders)
+		}
+		res := target.ApplySnapshotChunk(pbabci.RequestApplySnapshotChunk{Index: index, Chunk: chunk, Sender: "good peer"})
+		assert.Equal(t, pbabci.ResponseApplySnapshotChunk_ACCEPT, res.Result)
+	}
+	info := target.Info(pbabci.RequestInfo{})
+	assert.Equal(t, int64(4), info.LastBlockHeight)
+	assert.Equal(t, appHash, info.LastBlockAppHash)
+
+	// 恢复之后的应用与原来的应用执行相同的区块得到相同的应用状态哈希
+	for _, app := range []*apps.KVStoreApp{source, target} {
+		app.BeginBlock(pbabci.RequestBeginBlock{Height: 5})
+		app.DeliverTx(pbabci.RequestDeliverTx{Tx: []byte("key5=value5")})
+		app.EndBlock(pbabci.RequestEndBlock{})
+		app.Commit(pbabci.RequestCommit{})
+	}
+	assert.Equal(t, source.Info(pbabci.RequestInfo{}).LastBlockAppHash, target.Info(pbabci.RequestInfo{}).LastBlockAppHash)
+
+	// 用错误的应用状态哈希恢复快照
+	other := apps.NewKVStoreApp("other", "", database.MemDBBackend)
+	assert.Equal(t, pbabci.ResponseOfferSnapshot_ACCEPT, other.OfferSnapshot(pbabci.RequestOfferSnapshot{Snapshot: s, AppHash: []byte("wrong app hash")}).Result)
+	var result pbabci.ResponseApplySnapshotChunk_Result
+	for index := uint32(0); index < s.Chunks; index++ {
+		chunk := source.LoadSnapshotChunk(pbabci.RequestLoadSnapshotChunk{Height: s.Height, Format: s.Format, Chunk: index}).Chunk
+		result = other.ApplySnapshotChunk(pbabci.RequestApplySnapshotChunk{Index: index, Chunk: chunk}).Result
+	}
+	assert.Equal(t, pbabci.ResponseApplySnapshotChunk_REJECT_SNAPSHOT, result)
+}
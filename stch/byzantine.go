@@ -0,0 +1,29 @@
+package stch
+
+import (
+	"github.com/232425wxy/meta--/config"
+	"math/big"
+)
+
+// SetByzantineConfig 让节点按照配置在指定的高度发送错误的密钥份额或者签名片段，只能用于测试网。
+func (ch *Chameleon) SetByzantineConfig(cfg *config.ByzantineConfig) {
+	ch.byzantine = cfg
+}
+
+func (ch *Chameleon) misbehave(behaviour string, height int64) bool {
+	return ch.byzantine.Misbehave(behaviour, height)
+}
+
+// dkgHeight 分布式密钥生成发生在节点启动之后、参与共识之前，用区块存储里的最新高度来判断是否作恶，全新的节点是高度0。
+func (ch *Chameleon) dkgHeight() int64 {
+	if ch.blockStore == nil {
+		return 0
+	}
+	return ch.blockStore.Height()
+}
+
+// corrupt 返回一个与v不同的值，用来伪造错误的份额。
+func corrupt(v *big.Int) *big.Int {
+	res := new(big.Int).Add(v, big.NewInt(1))
+	return res.Mod(res, q)
+}
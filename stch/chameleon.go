@@ -1,11 +1,12 @@
 package stch
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"github.com/232425wxy/meta--/config"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/sha256"
-	"github.com/232425wxy/meta--/p2p"
 	"github.com/232425wxy/meta--/store"
 	"github.com/232425wxy/meta--/types"
 	"math/big"
@@ -53,6 +54,7 @@ type Chameleon struct {
 	redactAvailable bool
 	blockStore      *store.BlockStore
 	redactSteps     *stepInfo
+	byzantine       *config.ByzantineConfig // 测试网里让节点在指定的高度故意发送错误的份额，为nil时节点总是诚实的
 	mu              sync.Mutex
 }
 
@@ -73,6 +75,14 @@ func NewChameleon(id crypto.ID, n int) *Chameleon {
 	return ch
 }
 
+// NewChameleonWithAlpha 直接用给定的公共参数alpha创建变色龙哈希实例，跳过成员之间的分布式密钥生成。这样得到的实例可以
+// 计算和验证区块的变色龙哈希值，但是没有陷门份额，不能参与区块的修订，只用于单进程里的仿真测试。
+func NewChameleonWithAlpha(id crypto.ID, n int, alpha *big.Int) *Chameleon {
+	ch := NewChameleon(id, n)
+	ch.alpha = new(big.Int).Set(alpha)
+	return ch
+}
+
 func (ch *Chameleon) Init(kp *KeyPoly) {
 	ch.k = new(big.Int).Set(kp.K)
 	ch.x = new(big.Int).Exp(g, ch.k, q)
@@ -99,6 +109,9 @@ func (ch *Chameleon) GetX() *big.Int {
 func (ch *Chameleon) calculateFnXForPeer(identity *IdentityX, myID crypto.ID, peerID crypto.ID) *FnX {
 	res := &FnX{}
 	res.Data = ch.fn.calculate(identity.X, q)
+	if ch.misbehave(config.CorruptDKGShares, ch.dkgHeight()) {
+		res.Data = corrupt(res.Data)
+	}
 	res.From = myID
 	res.X = ch.x
 	ch.mu.Lock()
@@ -107,26 +120,25 @@ func (ch *Chameleon) calculateFnXForPeer(identity *IdentityX, myID crypto.ID, pe
 	return res
 }
 
-func (ch *Chameleon) handleIdentityX(peer *p2p.Peer, identityX *IdentityX) error {
-	if peer.NodeID() != identityX.ID {
-		return fmt.Errorf("identity mismatch, from %s, but identity is %s", peer.NodeID(), identityX.ID)
+func (ch *Chameleon) handleIdentityX(peerID crypto.ID, identityX *IdentityX) error {
+	if peerID != identityX.ID {
+		return fmt.Errorf("identity mismatch, from %s, but identity is %s", peerID, identityX.ID)
 	}
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 	participant := &Participant{
-		x:    identityX.X,
-		fnX:  nil,
-		pk:   nil,
-		peer: peer,
+		x:   identityX.X,
+		fnX: nil,
+		pk:  nil,
 	}
-	ch.participants.ps[peer.NodeID()] = participant
+	ch.participants.ps[peerID] = participant
 	return nil
 }
 
-func (ch *Chameleon) handleFnX(peer *p2p.Peer, fnX *FnX) bool {
+func (ch *Chameleon) handleFnX(peerID crypto.ID, fnX *FnX) bool {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
-	if peer.NodeID() != fnX.From {
+	if peerID != fnX.From {
 		return false
 	}
 	if _, ok := ch.participants.ps[fnX.From]; !ok {
@@ -135,7 +147,6 @@ func (ch *Chameleon) handleFnX(peer *p2p.Peer, fnX *FnX) bool {
 			fnX:      ch.fn.calculate(fnX.X, q),
 			fnXForMe: nil,
 			pk:       nil,
-			peer:     peer,
 		}
 		ch.participants.ps[fnX.From] = participant
 	}
@@ -167,9 +178,9 @@ func (ch *Chameleon) calculateSK(g, q *big.Int) {
 	ch.pk = new(big.Int).Exp(g, ch.sk, q)
 }
 
-func (ch *Chameleon) handlePublicKeySeg(peer *p2p.Peer, key *PublicKeySeg) bool {
-	if peer.NodeID() != key.From {
-		panic(fmt.Sprintf("identity mismatch, peer is %s, but key is from %s", peer.NodeID(), key.From))
+func (ch *Chameleon) handlePublicKeySeg(peerID crypto.ID, key *PublicKeySeg) bool {
+	if peerID != key.From {
+		panic(fmt.Sprintf("identity mismatch, peer is %s, but key is from %s", peerID, key.From))
 	}
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
@@ -209,19 +220,56 @@ func (ch *Chameleon) calculateHKAndCID(q *big.Int) {
 	ch.Alpha.Mul(ch.Alpha, ch.alphaExpK)
 }
 
-func (ch *Chameleon) handleAlphaExpKAndHK(ah *AlphaExpKAndHK, peer *p2p.Peer) error {
+func (ch *Chameleon) handleAlphaExpKAndHK(ah *AlphaExpKAndHK, peerID crypto.ID) error {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
-	if ch.hk.Int64() != 1 {
-		if ch.hk.Cmp(ah.HK) != 0 {
-			return fmt.Errorf("peer %s generate different hk from mine", peer.NodeID())
+	participant := ch.participants.ps[peerID]
+	participant.hk = new(big.Int).Set(ah.HK)
+	participant.alphaExpK = new(big.Int).Set(ah.AlphaExpK)
+	ch.Alpha.Mul(ch.Alpha, ah.AlphaExpK)
+	if ch.alpha != nil && ch.hk.Cmp(ah.HK) != 0 {
+		return fmt.Errorf("peer %s generate different hk from mine", peerID)
+	}
+	return nil
+}
+
+// verifyHK 自己算出变色龙哈希函数的公钥之后，检查在此之前收到的其他成员的公钥是否与自己的一致。发送错误密钥份额的成员
+// 算出的公钥与其他成员的不同，会在这里被发现。
+func (ch *Chameleon) verifyHK() error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+	var mismatched []crypto.ID
+	for id, participant := range ch.participants.ps {
+		if participant.hk != nil && participant.hk.Cmp(ch.hk) != 0 {
+			mismatched = append(mismatched, id)
 		}
 	}
-	ch.participants.ps[peer.NodeID()].alphaExpK = new(big.Int).Set(ah.AlphaExpK)
-	ch.Alpha.Mul(ch.Alpha, ah.AlphaExpK)
+	if len(mismatched) > 0 {
+		return fmt.Errorf("peers %v generate different hk from mine", mismatched)
+	}
 	return nil
 }
 
+// publicKeySeg 返回需要广播给其他成员的自己的公钥。
+func (ch *Chameleon) publicKeySeg() *PublicKeySeg {
+	pks := &PublicKeySeg{
+		From:      ch.id,
+		PublicKey: ch.pk,
+	}
+	if ch.misbehave(config.CorruptDKGShares, ch.dkgHeight()) {
+		pks.PublicKey = corrupt(pks.PublicKey)
+	}
+	return pks
+}
+
+// alphaExpKAndHK 返回需要广播给其他成员的alpha^k和变色龙哈希函数的公钥。
+func (ch *Chameleon) alphaExpKAndHK() *AlphaExpKAndHK {
+	return &AlphaExpKAndHK{
+		AlphaExpK: new(big.Int).Set(ch.alphaExpK),
+		HK:        new(big.Int).Set(ch.hk),
+	}
+}
+
 func (ch *Chameleon) Hash(block *types.Block) {
 	blockDataHash := block.BlockDataHash()
 	if block.ChameleonHash == nil {
@@ -236,6 +284,25 @@ func (ch *Chameleon) Hash(block *types.Block) {
 	block.ChameleonHash.Hash = h.Bytes()
 }
 
+// VerifyHash 根据区块内容重新计算变色龙哈希，检查区块携带的变色龙哈希是否与区块内容相符，并且是用本组成员共同的
+// 公钥生成的。修改区块内容后，只有持有陷门的成员合作生成新的随机数，才能使哈希值保持不变。
+func (ch *Chameleon) VerifyHash(block *types.Block) error {
+	cHash := block.ChameleonHash
+	if cHash == nil || cHash.R1 == nil || cHash.Alpha == nil || len(cHash.Hash) == 0 {
+		return errors.New("incomplete chameleon hash")
+	}
+	if ch != nil && ch.alpha != nil && ch.alpha.Cmp(cHash.Alpha) != 0 {
+		return errors.New("chameleon hash is not generated with our public key")
+	}
+	sigma := new(big.Int).SetBytes(block.ComputeBlockDataHash())
+	h := new(big.Int).Mul(cHash.R1, new(big.Int).Exp(cHash.Alpha, sigma, q))
+	h.Mod(h, q)
+	if !bytes.Equal(h.Bytes(), cHash.Hash) {
+		return fmt.Errorf("chameleon hash does not match block content, expected %X, got %X", h.Bytes(), cHash.Hash)
+	}
+	return nil
+}
+
 func (ch *Chameleon) AppendRedactTask(task *Task) {
 	select {
 	case ch.redactTaskChan <- task:
@@ -250,6 +317,9 @@ func (ch *Chameleon) AppendRedactTask(task *Task) {
 
 func (ch *Chameleon) handleRedactTask(task *Task, myID crypto.ID) ([]byte, error) {
 	block := ch.blockStore.LoadBlockByHeight(task.BlockHeight)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d does not exist or has been pruned", task.BlockHeight)
+	}
 	redactBlock := block.Copy()
 	old_msg := redactBlock.BlockDataHash()
 	if task.TxIndex >= len(redactBlock.Body.Txs) {
@@ -288,7 +358,7 @@ func (ch *Chameleon) handleRedactTask(task *Task, myID crypto.ID) ([]byte, error
 	return bz, nil
 }
 
-func (ch *Chameleon) verifyLeaderSchnorrSig(lss *LeaderSchnorrSig, peer *p2p.Peer, myID crypto.ID) ([]byte, error) {
+func (ch *Chameleon) verifyLeaderSchnorrSig(lss *LeaderSchnorrSig, peerID crypto.ID, myID crypto.ID) ([]byte, error) {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
@@ -314,13 +384,13 @@ func (ch *Chameleon) verifyLeaderSchnorrSig(lss *LeaderSchnorrSig, peer *p2p.Pee
 		x_.Exp(g, lss.S, q)
 	}
 	if e.Cmp(new(big.Int).SetInt64(0)) < 0 {
-		_pk := calcInverseElem(ch.participants.ps[peer.NodeID()].pk, q)
+		_pk := calcInverseElem(ch.participants.ps[peerID].pk, q)
 		x_ = new(big.Int).Mul(x_, new(big.Int).Exp(_pk, _e, q))
 	} else {
-		x_ = new(big.Int).Mul(x_, new(big.Int).Exp(ch.participants.ps[peer.NodeID()].pk, e, q))
+		x_ = new(big.Int).Mul(x_, new(big.Int).Exp(ch.participants.ps[peerID].pk, e, q))
 	}
 	x_.Mod(x_, q)
-	if x_.Cmp(ch.participants.ps[peer.NodeID()].x) == 0 {
+	if x_.Cmp(ch.participants.ps[peerID].x) == 0 {
 		rss := &ReplicaSchnorrSig{}
 		s := new(big.Int).Add(new(big.Int).Mul(ch.sk, _e), ch.k)
 		d := new(big.Int)
@@ -339,8 +409,14 @@ func (ch *Chameleon) verifyLeaderSchnorrSig(lss *LeaderSchnorrSig, peer *p2p.Pee
 		rss.TxIndex = lss.TxIndex
 		rss.NewTx = lss.NewTx
 		bz := MustEncode(rss)
+		if ch.misbehave(config.CorruptRedactShares, lss.BlockHeight) {
+			// 自己保留正确的签名片段，只把错误的片段发送给其他节点
+			wrong := *rss
+			wrong.S = corrupt(rss.S)
+			bz = MustEncode(&wrong)
+		}
 
-		isFull, err := ch.redactSteps.addLeaderRedact(peer.NodeID(), lss, ch.n)
+		isFull, err := ch.redactSteps.addLeaderRedact(peerID, lss, ch.n)
 		if err != nil {
 			return nil, err
 		}
@@ -361,7 +437,7 @@ func (ch *Chameleon) verifyLeaderSchnorrSig(lss *LeaderSchnorrSig, peer *p2p.Pee
 		}
 		return bz, nil
 	} else {
-		return nil, fmt.Errorf("leader %s sent wrong segment", peer.NodeID())
+		return nil, fmt.Errorf("leader %s sent wrong segment", peerID)
 	}
 }
 
@@ -516,8 +592,15 @@ func (ch *Chameleon) doRedact() error {
 	}
 	v.Mod(v, q)
 	if v.Cmp(r2) == 0 {
-		ch.blockStore.SaveBlock(ch.redactSteps.redactBlock)
+		// 修改区块内容不会改变区块的变色龙哈希值，所以区块原有的提交证明依然有效
+		redactBlock := ch.redactSteps.redactBlock
 		ch.redactSteps.reset()
+		// 在保存提交证明之前存储的区块，或者已经被裁剪掉的区块，没有提交证明可以和修改后的区块一起保存
+		commit := ch.blockStore.LoadCommit(redactBlock.Header.Height)
+		if commit == nil {
+			return fmt.Errorf("no commit found for redacted block at height %d", redactBlock.Header.Height)
+		}
+		ch.blockStore.SaveBlock(redactBlock, commit)
 		return nil
 	} else {
 		return fmt.Errorf("can not verify randomness")
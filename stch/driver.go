@@ -0,0 +1,116 @@
+package stch
+
+import (
+	"fmt"
+
+	"github.com/232425wxy/meta--/crypto"
+)
+
+// Outbound 同步驱动Chameleon时，Chameleon产生的需要发送给其他成员的消息，To为空表示广播给除自己以外的所有成员。
+type Outbound struct {
+	To  crypto.ID
+	Msg Message
+}
+
+// Driver 在调用者的goroutine里同步地驱动Chameleon完成分布式密钥生成和区块修订，不会启动Reactor里的后台goroutine：
+// 其他成员发来的消息由调用者按照自己决定的顺序逐个交给Driver处理，Chameleon产生的消息则按照Reactor的路由规则返回给
+// 调用者。确定性的仿真测试依靠它在一个进程里运行变色龙哈希的全部流程。
+type Driver struct {
+	ch        *Chameleon
+	hkPending bool // 已经收集齐了其他成员的公钥，但是自己的公钥还没有算出来
+}
+
+func NewDriver(ch *Chameleon) *Driver {
+	return &Driver{ch: ch}
+}
+
+// Hello 与Reactor.AddPeer一样，向其他成员发送自己的身份，开始分布式密钥生成。
+func (d *Driver) Hello() []Outbound {
+	return []Outbound{{Msg: &IdentityX{X: d.ch.GetX(), ID: d.ch.id}}}
+}
+
+// Redact 与Reactor处理修订任务的方式一样，由主节点发起对区块的修订。
+func (d *Driver) Redact(task *Task) ([]Outbound, error) {
+	bz, err := d.ch.handleRedactTask(task, d.ch.id)
+	if err != nil {
+		return nil, err
+	}
+	return d.drain([]Outbound{{Msg: MustDecode(bz)}})
+}
+
+// Receive 处理成员from发来的消息，返回需要发送给其他成员的消息。消息里的字段在处理过程中可能被修改，调用者不能把同一个
+// 消息对象交给多个成员处理。
+func (d *Driver) Receive(from crypto.ID, msg Message) ([]Outbound, error) {
+	var out []Outbound
+	switch msg := msg.(type) {
+	case *IdentityX:
+		if err := d.ch.handleIdentityX(from, msg); err != nil {
+			return nil, err
+		}
+		out = append(out, Outbound{To: from, Msg: d.ch.calculateFnXForPeer(msg, d.ch.id, from)})
+	case *FnX:
+		if ok := d.ch.handleFnX(from, msg); ok {
+			d.ch.calculateSK(g, q)
+			out = append(out, Outbound{Msg: d.ch.publicKeySeg()})
+			if d.hkPending {
+				return d.finishDKG(out)
+			}
+		}
+	case *PublicKeySeg:
+		if ok := d.ch.handlePublicKeySeg(from, msg); ok {
+			if d.ch.pk == nil {
+				d.hkPending = true
+				return out, nil
+			}
+			return d.finishDKG(out)
+		}
+	case *AlphaExpKAndHK:
+		if err := d.ch.handleAlphaExpKAndHK(msg, from); err != nil {
+			return nil, err
+		}
+	case *LeaderSchnorrSig:
+		bz, err := d.ch.verifyLeaderSchnorrSig(msg, from, d.ch.id)
+		if err != nil {
+			return nil, err
+		}
+		if len(bz) > 0 {
+			out = append(out, Outbound{Msg: MustDecode(bz)})
+		}
+	case *ReplicaSchnorrSig:
+		if err := d.ch.verifyReplicaSchnorrSig(msg, from); err != nil {
+			return nil, err
+		}
+	case *RandomVerification:
+		if err := d.ch.handleRandomVerification(msg, from); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown chameleon message type %T", msg)
+	}
+	return d.drain(out)
+}
+
+// finishDKG 算出变色龙哈希函数的公钥并广播出去，然后检查之前收到的其他成员的公钥是否与自己的一致。
+func (d *Driver) finishDKG(out []Outbound) ([]Outbound, error) {
+	d.hkPending = false
+	d.ch.calculateHKAndCID(q)
+	out = append(out, Outbound{Msg: d.ch.alphaExpKAndHK()})
+	return out, d.ch.verifyHK()
+}
+
+// drain 代替Reactor.processFormerRSS和Reactor.waitForFinalVer：处理在修订任务到达之前收到的签名片段，并广播新生成
+// 的随机数。
+func (d *Driver) drain(out []Outbound) ([]Outbound, error) {
+	for len(d.ch.redactSteps.redactMission) > 0 && len(d.ch.redactSteps.rssChan) > 0 {
+		rss := <-d.ch.redactSteps.rssChan
+		if err := d.ch.verifyReplicaSchnorrSig(rss.rss, rss.id); err != nil {
+			return out, err
+		}
+	}
+	select {
+	case rv := <-d.ch.redactSteps.randomChan:
+		out = append(out, Outbound{Msg: rv})
+	default:
+	}
+	return out, nil
+}
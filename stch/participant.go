@@ -2,7 +2,6 @@ package stch
 
 import (
 	"github.com/232425wxy/meta--/crypto"
-	"github.com/232425wxy/meta--/p2p"
 	"math/big"
 )
 
@@ -20,5 +19,5 @@ type Participant struct {
 	fnXForMe  *big.Int
 	pk        *big.Int // 节点的公钥
 	alphaExpK *big.Int
-	peer      *p2p.Peer
+	hk        *big.Int // 节点算出的变色龙哈希函数的公钥，应当与自己算出的一致
 }
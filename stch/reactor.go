@@ -1,7 +1,6 @@
 package stch
 
 import (
-	"math/big"
 	"time"
 
 	"github.com/232425wxy/meta--/p2p"
@@ -55,23 +54,24 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, bz []byte) {
 		msg := MustDecode(bz)
 		switch msg := msg.(type) {
 		case *IdentityX:
-			if err := r.ch.handleIdentityX(src, msg); err != nil {
+			if err := r.ch.handleIdentityX(src.NodeID(), msg); err != nil {
 				r.Logger.Error("Failed to handle IdentityX message", "err", err)
 				return
 			}
 			fnX := r.ch.calculateFnXForPeer(msg, r.Switch.NodeInfo().NodeID, src.NodeID())
 			r.sendFnXToPeer(fnX, src)
 		case *FnX:
-			if ok := r.ch.handleFnX(src, msg); ok {
+			if ok := r.ch.handleFnX(src.NodeID(), msg); ok {
 				r.ch.calculateSK(g, q)
 				r.broadcastPKToPeer()
 			}
 		case *PublicKeySeg:
-			if ok := r.ch.handlePublicKeySeg(src, msg); ok {
+			if ok := r.ch.handlePublicKeySeg(src.NodeID(), msg); ok {
 				// 收集齐了其他节点的公钥
 				if r.ch.pk != nil {
 					r.ch.calculateHKAndCID(q)
 					r.brodacastAlphaExpKAndHK()
+					r.verifyHK()
 					r.Logger.Info("Distributed chameleon hash function initialization complete", "hk", r.ch.hk.String()[:10], "cid", r.ch.cid.String()[:10], "alpha", r.ch.alpha.String())
 				} else {
 					// 自己的公钥还没制作出来的情况下，需要等待自己的公钥制作出来后再生成变色龙公钥
@@ -80,6 +80,7 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, bz []byte) {
 							if r.ch.pk != nil {
 								r.ch.calculateHKAndCID(q)
 								r.brodacastAlphaExpKAndHK()
+								r.verifyHK()
 								r.Logger.Error("Distributed chameleon hash function initialization complete", "hk", r.ch.hk.String()[:10], "cid", r.ch.cid.String()[:10], "alpha", r.ch.alpha.String())
 								return
 							}
@@ -89,12 +90,12 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, bz []byte) {
 				}
 			}
 		case *AlphaExpKAndHK:
-			if err := r.ch.handleAlphaExpKAndHK(msg, src); err != nil {
+			if err := r.ch.handleAlphaExpKAndHK(msg, src.NodeID()); err != nil {
 				r.Logger.Error("Failed to handle AlphaExpKAndHK message", "err", err)
 			}
 		case *LeaderSchnorrSig:
 			r.Logger.Debug("Receive new redact mission from leader", "leader", src.NodeID())
-			data, err := r.ch.verifyLeaderSchnorrSig(msg, src, r.Switch.NodeInfo().ID())
+			data, err := r.ch.verifyLeaderSchnorrSig(msg, src.NodeID(), r.Switch.NodeInfo().ID())
 			if len(data) > 0 && err == nil {
 				r.Switch.Broadcast(p2p.STCHChannel, data)
 			} else if err != nil {
@@ -130,11 +131,7 @@ func (r *Reactor) sendFnXToPeer(fnX *FnX, peer *p2p.Peer) {
 }
 
 func (r *Reactor) broadcastPKToPeer() {
-	pks := &PublicKeySeg{
-		From:      r.Switch.NodeInfo().ID(),
-		PublicKey: r.ch.pk,
-	}
-	bz := MustEncode(pks)
+	bz := MustEncode(r.ch.publicKeySeg())
 	r.Switch.Broadcast(p2p.STCHChannel, bz)
 }
 
@@ -143,14 +140,16 @@ func (r *Reactor) Chameleon() *Chameleon {
 }
 
 func (r *Reactor) brodacastAlphaExpKAndHK() {
-	ah := &AlphaExpKAndHK{
-		AlphaExpK: new(big.Int).Set(r.ch.alphaExpK),
-		HK:        new(big.Int).Set(r.ch.hk),
-	}
-	bz := MustEncode(ah)
+	bz := MustEncode(r.ch.alphaExpKAndHK())
 	r.Switch.Broadcast(p2p.STCHChannel, bz)
 }
 
+func (r *Reactor) verifyHK() {
+	if err := r.ch.verifyHK(); err != nil {
+		r.Logger.Error("Failed to verify hk of peers", "err", err)
+	}
+}
+
 func (r *Reactor) processRedactTaskRoutine() {
 	for {
 		if r.ch.redactAvailable {
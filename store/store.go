@@ -17,6 +17,7 @@ var StoreBlockKey = []byte("meta--/store-block")
 type BlockStore struct {
 	db     database.DB
 	mu     sync.RWMutex
+	base   int64 // 最低的区块高度，更低的区块已经被删除，没有区块时为0
 	height int64
 }
 
@@ -35,7 +36,18 @@ func NewStoreBlock(db database.DB) *BlockStore {
 	if err = proto.Unmarshal(bz, pb); err != nil {
 		panic(err)
 	}
-	return &BlockStore{db: db, height: pb.Height}
+	// 旧版本的区块存储没有记录最低的区块高度，它们从未删除过区块
+	if pb.Base == 0 && pb.Height > 0 {
+		pb.Base = 1
+	}
+	return &BlockStore{db: db, base: pb.Base, height: pb.Height}
+}
+
+// Base 返回区块存储里最低的区块高度，没有区块时返回0。
+func (sb *BlockStore) Base() int64 {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+	return sb.base
 }
 
 // Height
@@ -43,25 +55,20 @@ func NewStoreBlock(db database.DB) *BlockStore {
 // Height 反映当前区块链的高度和区块数量。
 func (sb *BlockStore) Height() int64 {
 	sb.mu.RLock()
-	sb.mu.RUnlock()
+	defer sb.mu.RUnlock()
 	return sb.height
 }
 
 func (sb *BlockStore) LoadBlockByHeight(height int64) *types.Block {
 	pb := &pbtypes.Block{}
 	bz, err := sb.db.Get(calcBlockHeightKey(height))
-	if err != nil {
+	if err != nil || len(bz) == 0 {
 		return nil
 	}
 	if err = proto.Unmarshal(bz, pb); err != nil {
 		return nil
 	}
-	block := &types.Block{
-		Header:        types.HeaderFromProto(pb.Header),
-		Body:          types.DataFromProto(pb.Body),
-		ChameleonHash: types.ChameleonHashFromProto(pb.ChameleonHash),
-	}
-	return block
+	return types.BlockFromProto(pb)
 }
 
 func (sb *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
@@ -76,10 +83,34 @@ func (sb *BlockStore) LoadBlockByHash(hash []byte) *types.Block {
 	return sb.LoadBlockByHeight(pb.Height)
 }
 
-func (sb *BlockStore) SaveBlock(block *types.Block) {
+// LoadCommit
+//
+// LoadCommit 返回指定高度区块的提交证明（commit QC），如果没有则返回nil。
+func (sb *BlockStore) LoadCommit(height int64) *types.CommitBlock {
+	pb := &pbtypes.CommitBlock{}
+	bz, err := sb.db.Get(calcBlockCommitKey(height))
+	if err != nil || len(bz) == 0 {
+		return nil
+	}
+	if err = proto.Unmarshal(bz, pb); err != nil {
+		return nil
+	}
+	return types.CommitBlockFromProto(pb)
+}
+
+// SaveBlock
+//
+// SaveBlock 保存区块以及证明该区块已经被2/3以上验证者确认的提交证明，并更新区块链的高度。
+func (sb *BlockStore) SaveBlock(block *types.Block, commit *types.CommitBlock) {
 	if block == nil {
 		panic("cannot save nil block")
 	}
+	if commit == nil {
+		panic("cannot save block without commit")
+	}
+	if commit.Height != block.Header.Height {
+		panic(fmt.Sprintf("commit height %d does not match block height %d", commit.Height, block.Header.Height))
+	}
 	pb := block.ToProto()
 	bz, err := proto.Marshal(pb)
 	if err != nil {
@@ -90,18 +121,100 @@ func (sb *BlockStore) SaveBlock(block *types.Block) {
 	if err != nil {
 		panic(err)
 	}
-	if err = sb.db.SetSync(calcBlockHashKey(block.ChameleonHash.Hash), bzh); err != nil {
+	bzc, err := proto.Marshal(commit.ToProto())
+	if err != nil {
+		panic(err)
+	}
+
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	// 区块、区块哈希索引、提交证明以及区块链的高度在同一个batch里原子地写入，崩溃后不会留下缺少索引或者提交证明的区块
+	batch := sb.db.NewBatch()
+	defer batch.Close()
+	if err = batch.Set(calcBlockHashKey(block.ChameleonHash.Hash), bzh); err != nil {
 		panic(err)
 	}
-	if err = sb.db.SetSync(calcBlockHeightKey(block.Header.Height), bz); err != nil {
+	if err = batch.Set(calcBlockHeightKey(block.Header.Height), bz); err != nil {
 		panic(err)
 	}
+	if err = batch.Set(calcBlockCommitKey(block.Header.Height), bzc); err != nil {
+		panic(err)
+	}
+	base, height := sb.base, sb.height
+	if base == 0 {
+		base = block.Header.Height
+	}
+	if block.Header.Height > height {
+		height = block.Header.Height
+		bzs, err := proto.Marshal(&pbstate.StoreBlock{Height: height, Base: base})
+		if err != nil {
+			panic(err)
+		}
+		if err = batch.Set(StoreBlockKey, bzs); err != nil {
+			panic(err)
+		}
+	}
+	if err = batch.WriteSync(); err != nil {
+		panic(err)
+	}
+	sb.base, sb.height = base, height
+}
+
+// PruneBlocks 删除高度低于retainHeight的区块、区块哈希索引以及提交证明，并把最低的区块高度更新为retainHeight，
+// 返回被删除的区块数量。
+func (sb *BlockStore) PruneBlocks(retainHeight int64) (int64, error) {
+	if retainHeight <= 0 {
+		return 0, fmt.Errorf("retain height must be greater than 0, got %d", retainHeight)
+	}
+	// 与SaveBlock互斥，保证读到的区块高度在删除期间不会变化，记录下来的区块高度也不会被旧的值覆盖
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+	base, height := sb.base, sb.height
+	if retainHeight > height {
+		return 0, fmt.Errorf("cannot prune beyond the latest height %d, got retain height %d", height, retainHeight)
+	}
+	if retainHeight <= base {
+		return 0, nil
+	}
+
+	batch := sb.db.NewBatch()
+	defer batch.Close()
+	for h := base; h < retainHeight; h++ {
+		if block := sb.LoadBlockByHeight(h); block != nil && block.ChameleonHash != nil {
+			if err := batch.Delete(calcBlockHashKey(block.ChameleonHash.Hash)); err != nil {
+				return 0, err
+			}
+		}
+		if err := batch.Delete(calcBlockHeightKey(h)); err != nil {
+			return 0, err
+		}
+		if err := batch.Delete(calcBlockCommitKey(h)); err != nil {
+			return 0, err
+		}
+	}
+
+	bz, err := proto.Marshal(&pbstate.StoreBlock{Height: height, Base: retainHeight})
+	if err != nil {
+		return 0, err
+	}
+	if err = batch.Set(StoreBlockKey, bz); err != nil {
+		return 0, err
+	}
+	if err = batch.WriteSync(); err != nil {
+		return 0, err
+	}
+	sb.base = retainHeight
+	return retainHeight - base, nil
 }
 
 func calcBlockHeightKey(height int64) []byte {
 	return append([]byte("block-height:"), fmt.Sprintf("%d", height)...)
 }
 
+func calcBlockCommitKey(height int64) []byte {
+	return append([]byte("block-commit:"), fmt.Sprintf("%d", height)...)
+}
+
 func calcBlockHashKey(hash []byte) []byte {
 	return append([]byte("block-hash:"), hash...)
 }
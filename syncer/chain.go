@@ -28,6 +28,7 @@ type requester struct {
 	peerID crypto.ID
 	height int64 // 要请求的区块的高度
 	block  *types.Block
+	commit *types.CommitBlock // 证明区块已经被确认的提交证明
 	gotCh  chan struct{}
 	redoCh chan crypto.ID
 	mu     sync.Mutex
@@ -37,6 +38,7 @@ type requester struct {
 type peer struct {
 	isTimeout  bool
 	pendingNum int32
+	base       int64 // 节点拥有的最低区块高度，更低的区块已经被它删除了
 	height     int64
 	chain      *Blockchain
 	id         crypto.ID
@@ -81,13 +83,20 @@ func (bc *Blockchain) Start() error {
 	return bc.BaseService.Start()
 }
 
+// SetHeight 设置开始同步的区块高度，状态同步结束后从快照的下一个高度开始同步区块，必须在Start之前调用。
+func (bc *Blockchain) SetHeight(height int64) {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	bc.height = height
+}
+
 func (bc *Blockchain) GetStatus() (height int64, pendingNum int32, requestersNum int) {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	return bc.height, atomic.LoadInt32(&bc.pendingNum), len(bc.requesters)
 }
 
-func (bc *Blockchain) AddBlock(peerID crypto.ID, block *types.Block) {
+func (bc *Blockchain) AddBlock(peerID crypto.ID, block *types.Block, commit *types.CommitBlock) {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	r := bc.requesters[block.Header.Height]
@@ -95,7 +104,7 @@ func (bc *Blockchain) AddBlock(peerID crypto.ID, block *types.Block) {
 		bc.Logger.Info("peer sent us a block that we didn't expect", "peer_id", peerID, "current_height", bc.height, "block_height", block.Header.Height)
 		return
 	}
-	if r.setBlock(block, peerID) {
+	if r.setBlock(block, commit, peerID) {
 		atomic.AddInt32(&bc.pendingNum, -1)
 		p := bc.peers[peerID]
 		if p != nil {
@@ -122,6 +131,17 @@ func (bc *Blockchain) PickTwoBlocks() (first *types.Block, second *types.Block)
 	return first, second
 }
 
+// FirstCommit 返回当前高度区块的提交证明。
+func (bc *Blockchain) FirstCommit() *types.CommitBlock {
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+
+	if r := bc.requesters[bc.height]; r != nil {
+		return r.getCommit()
+	}
+	return nil
+}
+
 func (bc *Blockchain) PopRequest() {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
@@ -141,16 +161,19 @@ func (bc *Blockchain) MaxPeerHeight() int64 {
 	return bc.maxPeerHeight
 }
 
-func (bc *Blockchain) SetPeerUpHeight(peerID crypto.ID, up int64) {
+// SetPeerRange 记录节点拥有的区块高度范围[base, up]。
+func (bc *Blockchain) SetPeerRange(peerID crypto.ID, base int64, up int64) {
 	bc.mu.Lock()
 	defer bc.mu.Unlock()
 	p := bc.peers[peerID]
 	if p != nil {
+		p.base = base
 		p.height = up
 	} else {
 		p = &peer{
 			isTimeout:  false,
 			pendingNum: 0,
+			base:       base,
 			height:     up,
 			chain:      bc,
 			id:         peerID,
@@ -293,7 +316,7 @@ func (bc *Blockchain) pickPeer(height int64) *peer {
 		if p.pendingNum > maxPendingNumPerPeer {
 			continue
 		}
-		if height > p.height {
+		if height < p.base || height > p.height {
 			continue
 		}
 		p.pendingNum++
@@ -402,17 +425,19 @@ func (r *requester) reset() {
 	}
 	r.peerID = ""
 	r.block = nil
+	r.commit = nil
 }
 
 // setBlock 当我们从requester.peerID处获得一个区块时，我们就将该区块保留下来，
 // 该方法的第二个参数peerID就是用来判断所得的区块是否来自requester.peerID。
-func (r *requester) setBlock(block *types.Block, peerID crypto.ID) bool {
+func (r *requester) setBlock(block *types.Block, commit *types.CommitBlock, peerID crypto.ID) bool {
 	r.mu.Lock()
 	if r.block != nil || r.peerID != peerID {
 		r.mu.Unlock()
 		return false // 我们想要的区块都是从指定的节点处获取的，并非是随便从哪个节点那里获取的
 	}
 	r.block = block
+	r.commit = commit
 	r.mu.Unlock()
 	select {
 	case r.gotCh <- struct{}{}:
@@ -427,6 +452,12 @@ func (r *requester) getBlock() *types.Block {
 	return r.block
 }
 
+func (r *requester) getCommit() *types.CommitBlock {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.commit
+}
+
 func (r *requester) getPeerID() crypto.ID {
 	r.mu.Lock()
 	defer r.mu.Unlock()
@@ -20,6 +20,7 @@ type Reactor struct {
 	chain         *Blockchain
 	requestsCh    chan BlockRequest
 	errorsCh      chan peerError
+	stateSync     bool // 等待状态同步完成后再开始同步区块
 }
 
 func NewReactor(stat *state2.State, blockExecutor *state2.BlockExecutor, blockStore *store.BlockStore, logger log.Logger) *Reactor {
@@ -53,10 +54,35 @@ func (r *Reactor) SetLogger(logger log.Logger) {
 }
 
 func (r *Reactor) Start() error {
+	if r.stateSync {
+		return nil
+	}
+	if err := r.chain.Start(); err != nil {
+		return err
+	}
+	go r.processRoutine()
+	return nil
+}
+
+// WaitForStateSync 启动时不开始同步区块，等到状态同步完成、SwitchToSyncer被调用后再开始，必须在Start之前调用。
+func (r *Reactor) WaitForStateSync() {
+	r.stateSync = true
+}
+
+// SwitchToSyncer 状态同步完成后，从恢复出的状态的下一个高度开始同步区块，追上其他节点后再切换到共识。
+func (r *Reactor) SwitchToSyncer(stat *state2.State) error {
+	if stat.LastBlockHeight != r.blockStore.Height() {
+		return fmt.Errorf("state height %d and store height %d mismatch", stat.LastBlockHeight, r.blockStore.Height())
+	}
+	r.Logger.Info("switch to syncer after state sync", "height", stat.LastBlockHeight)
+	r.initialState = stat
+	r.chain.SetHeight(stat.NextHeight())
 	if err := r.chain.Start(); err != nil {
 		return err
 	}
 	go r.processRoutine()
+	// 等待状态同步期间收到的节点高度可能已经过时了
+	r.BroadcastStatusRequest()
 	return nil
 }
 
@@ -80,7 +106,7 @@ func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 }
 
 func (r *Reactor) AddPeer(p *p2p.Peer) {
-	msgBytes, err := EncodeMsg(&pbsyncer.StatusResponse{Height: r.blockStore.Height()})
+	msgBytes, err := EncodeMsg(&pbsyncer.StatusResponse{Base: r.blockStore.Base(), Height: r.blockStore.Height()})
 	if err != nil {
 		panic(err)
 	}
@@ -105,18 +131,18 @@ func (r *Reactor) Receive(chID byte, src *p2p.Peer, msgBytes []byte) {
 		r.respondToPeer(msg, src)
 	case *pbsyncer.BlockResponse:
 		block := types.BlockFromProto(msg.Block)
-		r.chain.AddBlock(src.NodeID(), block)
+		r.chain.AddBlock(src.NodeID(), block, types.CommitBlockFromProto(msg.Commit))
 	case *pbsyncer.NoBlockResponse:
 		r.Logger.Warn("peer does not have expected block", "peer_id", src.NodeID(), "height", msg.Height)
 	case *pbsyncer.StatusRequest:
-		bz, err := EncodeMsg(&pbsyncer.StatusResponse{Height: r.blockStore.Height()})
+		bz, err := EncodeMsg(&pbsyncer.StatusResponse{Base: r.blockStore.Base(), Height: r.blockStore.Height()})
 		if err != nil {
 			r.Logger.Error("failed to encode StatusResponse message", "err", err)
 			return
 		}
 		src.TrySend(p2p.SyncerChannel, bz)
 	case *pbsyncer.StatusResponse:
-		r.chain.SetPeerUpHeight(src.NodeID(), msg.Height)
+		r.chain.SetPeerRange(src.NodeID(), msg.Base, msg.Height)
 	default:
 		r.Logger.Warn(fmt.Sprintf("unknown message type: %T", msg))
 	}
@@ -137,7 +163,8 @@ func (r *Reactor) respondToPeer(req *pbsyncer.BlockRequest, src *p2p.Peer) bool
 	block := r.blockStore.LoadBlockByHeight(req.Height)
 	if block != nil {
 		pbBlock := block.ToProto()
-		msgBytes, err := EncodeMsg(&pbsyncer.BlockResponse{Block: pbBlock})
+		pbCommit := r.blockStore.LoadCommit(req.Height).ToProto()
+		msgBytes, err := EncodeMsg(&pbsyncer.BlockResponse{Block: pbBlock, Commit: pbCommit})
 		if err != nil {
 			r.Logger.Error("could not marshal BlockResponse message", "err", err)
 			return false
@@ -235,13 +262,26 @@ LOOP:
 					r.Switch.StopPeerForError(p, fmt.Errorf("provide invalid block"))
 				}
 				continue LOOP
+			}
+			commit := r.chain.FirstCommit()
+			// 用为该区块投票的验证者集合验证它的提交证明
+			validators, err := r.blockExecutor.LoadValidators(stat, first.Header.Height)
+			if err != nil {
+				r.Logger.Error("failed to load validators", "height", first.Header.Height, "err", err)
+				continue LOOP
+			}
+			if err = commit.VerifyCommit(first, validators); err != nil {
+				r.Logger.Error("block's commit is invalid", "height", first.Header.Height, "err", err)
+				peerID := r.chain.RedoRequest(first.Header.Height)
+				if p := r.Switch.Peers().GetPeer(peerID); p != nil {
+					r.Switch.StopPeerForError(p, fmt.Errorf("provide block with invalid commit: %q", err))
+				}
+				continue LOOP
 			} else {
 				r.chain.PopRequest()
-				r.blockStore.SaveBlock(first)
-				var err error
-				stat, err = r.blockExecutor.ApplyBlock(stat, first)
+				stat, err = r.blockExecutor.ApplyBlock(stat, first, commit)
 				if err != nil {
-					panic("failed to apply committed block")
+					panic(fmt.Sprintf("failed to apply committed block at height %d: %v", first.Header.Height, err))
 				}
 			}
 			continue LOOP
@@ -157,21 +157,35 @@ func (p *TxsPool) TxsAvailable() chan struct{} {
 //
 // ReapMaxBytes 从交易池里获取最多maxBytes大小的交易数据集合。
 func (p *TxsPool) ReapMaxBytes(maxBytes int) types.Txs {
+	return p.ReapMaxBytesMaxTxs(maxBytes, -1)
+}
+
+// ReapMaxBytesMaxTxs 从交易池里获取最多maxBytes大小、最多maxTxs笔的交易数据集合，maxTxs为负数时不限制交易数量。
+func (p *TxsPool) ReapMaxBytesMaxTxs(maxBytes, maxTxs int) types.Txs {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
 	txs := make([]types.Tx, 0)
 	size := 0
 	for elem := p.txs.Head(); elem != nil; elem = elem.Next() {
 		ptx := elem.Value.(*poolTx)
-		txs = append(txs, ptx.tx)
-		size += len(ptx.tx)
-		if size >= maxBytes {
+		if size+len(ptx.tx) > maxBytes || (maxTxs >= 0 && len(txs) >= maxTxs) {
 			return txs
 		}
+		txs = append(txs, ptx.tx)
+		size += len(ptx.tx)
 	}
 	return txs
 }
 
+// GetTx 根据交易的sha256哈希值在交易池里查找交易，共识模块用它还原主节点发来的紧凑区块。
+func (p *TxsPool) GetTx(hash []byte) (types.Tx, bool) {
+	elem := p.txsMap.Get(hex.EncodeToString(hash))
+	if elem == nil {
+		return nil, false
+	}
+	return elem.(*clist.Element).Value.(*poolTx).tx, true
+}
+
 // Update ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
 //
 //	---------------------------------------------------------
@@ -216,12 +230,14 @@ func (p *TxsPool) notifyTxsAvailable() {
 	if p.TxsNumInPool() == 0 {
 		panic("notified txs available but txs pool is empty")
 	}
-	for p.notifiedAvailable {
+	if p.notifiedAvailable {
 		select {
 		case p.txsAvailable <- struct{}{}:
-			p.notifiedAvailable = false
 		default:
+			// 通道里还有一个没有被取走的提醒，共识模块取走它时就会知道交易池里有交易数据，不需要等待，否则一次
+			// 提交多个区块时，在同一个goroutine里连续调用Update会永远阻塞在这里
 		}
+		p.notifiedAvailable = false
 	}
 }
 
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/crypto"
@@ -8,6 +10,7 @@ import (
 	"github.com/232425wxy/meta--/crypto/merkle"
 	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/232425wxy/meta--/proto/pbtypes"
+	"io"
 	"math/big"
 	"time"
 )
@@ -52,6 +55,7 @@ type Block struct {
 	Header        *Header        `json:"header"`
 	Body          *Data          `json:"body"`
 	ChameleonHash *ChameleonHash `json:"chameleon_hash"`
+	Evidence      EvidenceList   `json:"evidence"`
 }
 
 func (b *Block) Copy() *Block {
@@ -65,6 +69,8 @@ func (b *Block) Copy() *Block {
 			Height:            b.Header.Height,
 			Timestamp:         b.Header.Timestamp,
 			Proposer:          b.Header.Proposer,
+			AppHash:           b.Header.AppHash,
+			AppHashLag:        b.Header.AppHashLag,
 		},
 		Body: &Data{
 			RootHash: b.Body.RootHash,
@@ -80,37 +86,94 @@ func (b *Block) Copy() *Block {
 	for i, tx := range b.Body.Txs {
 		cp.Body.Txs[i] = tx
 	}
+	if len(b.Evidence) > 0 {
+		cp.Evidence = make(EvidenceList, len(b.Evidence))
+		copy(cp.Evidence, b.Evidence)
+	}
 
 	return cp
 }
 
+// ValidateBasic 检查区块的格式，以及区块头里记录的默克尔根和区块数据哈希是否与区块内容相符。
 func (b *Block) ValidateBasic() error {
 	if b == nil {
 		return errors.New("nil block")
 	}
+	if b.Header == nil {
+		return errors.New("nil block header")
+	}
+	if b.Body == nil {
+		return errors.New("nil block body")
+	}
+	if b.Header.Height <= 0 {
+		return fmt.Errorf("non-positive block height: %d", b.Header.Height)
+	}
+	if len(b.Header.PreviousBlockHash) == 0 {
+		return errors.New("empty previous block hash")
+	}
+	if b.Header.AppHashLag < 0 || b.Header.AppHashLag >= b.Header.Height {
+		return fmt.Errorf("app hash lag %d out of range [0, %d)", b.Header.AppHashLag, b.Header.Height)
+	}
+	if b.Header.Proposer == "" {
+		return errors.New("empty block proposer")
+	}
 	if err := b.Body.ValidateBasic(); err != nil {
 		return err
 	}
+	if rootHash := b.Body.ComputeRootHash(); !bytes.Equal(rootHash, b.Body.RootHash) {
+		return fmt.Errorf("wrong root hash, expected %X, got %X", rootHash, b.Body.RootHash)
+	}
+	if dataHash := b.ComputeBlockDataHash(); !bytes.Equal(dataHash, b.Header.BlockDataHash) {
+		return fmt.Errorf("wrong block data hash, expected %X, got %X", dataHash, b.Header.BlockDataHash)
+	}
+	for _, ev := range b.Evidence {
+		if err := ev.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid evidence: %w", err)
+		}
+	}
 	return nil
 }
 
 // BlockDataHash
-// 计算区块的哈希值
+// 计算区块的哈希值，并将交易数据的默克尔根和区块数据的哈希值填入区块里
 // TODO 将来换成变色龙哈希
 func (b *Block) BlockDataHash() []byte {
+	b.Body.RootHash = b.Body.ComputeRootHash()
+	b.Header.BlockDataHash = b.ComputeBlockDataHash()
+	return b.Header.BlockDataHash
+}
+
+// ComputeBlockDataHash 根据区块内容计算区块数据的哈希值，与BlockDataHash不同，它不会修改区块。应用状态的哈希值、
+// 它落后的高度数以及区块里的证据也需要被变色龙哈希覆盖，否则副本节点无法确认它们来自主节点。每个字段无论是否为空都会
+// 以固定的顺序、带着长度前缀写入，不同的区块头不会得到相同的输入。
+func (b *Block) ComputeBlockDataHash() []byte {
 	h := sha256.New()
-	h.Write(b.Header.PreviousBlockHash)
-	h.Write([]byte(fmt.Sprintf("%d", b.Header.Height)))
-	//h.Write([]byte(b.Header.Timestamp.String()))
-	h.Write([]byte(b.Header.Proposer))
-	_txs := make([][]byte, len(b.Body.Txs))
-	for i, tx := range b.Body.Txs {
-		_txs[i] = tx
+	writeHashField(h, b.Header.PreviousBlockHash)
+	writeHashField(h, int64Bytes(b.Header.Height))
+	writeHashField(h, []byte(b.Header.Proposer))
+	writeHashField(h, b.Header.AppHash)
+	writeHashField(h, int64Bytes(b.Header.AppHashLag))
+	writeHashField(h, b.Body.ComputeRootHash())
+	var evidenceHash []byte
+	if len(b.Evidence) > 0 {
+		evidenceHash = b.Evidence.Hash()
 	}
-	b.Body.RootHash = merkle.ComputeMerkleRoot(_txs)
-	h.Write(b.Body.RootHash)
-	b.Header.BlockDataHash = h.Sum(nil)
-	return b.Header.BlockDataHash
+	writeHashField(h, evidenceHash)
+	return h.Sum(nil)
+}
+
+// writeHashField 先写入8个字节的长度前缀，再写入字段的内容。
+func writeHashField(w io.Writer, field []byte) {
+	var prefix [8]byte
+	binary.BigEndian.PutUint64(prefix[:], uint64(len(field)))
+	_, _ = w.Write(prefix[:])
+	_, _ = w.Write(field)
+}
+
+func int64Bytes(i int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(i))
+	return bz
 }
 
 func (b *Block) ToProto() *pbtypes.Block {
@@ -122,6 +185,7 @@ func (b *Block) ToProto() *pbtypes.Block {
 		Header:        b.Header.ToProto(),
 		Body:          b.Body.ToProto(),
 		ChameleonHash: b.ChameleonHash.ToProto(),
+		Evidence:      b.Evidence.ToProto(),
 	}
 	return pb
 }
@@ -130,10 +194,13 @@ func BlockFromProto(pb *pbtypes.Block) *Block {
 	if pb == nil {
 		return nil
 	}
+	// 无法解析的证据会被丢弃，这会导致区块的哈希值对不上，从而在后续的验证中被拒绝
+	evidence, _ := EvidenceListFromProto(pb.Evidence)
 	return &Block{
 		Header:        HeaderFromProto(pb.Header),
 		Body:          DataFromProto(pb.Body),
 		ChameleonHash: ChameleonHashFromProto(pb.ChameleonHash),
+		Evidence:      evidence,
 	}
 }
 
@@ -170,12 +237,139 @@ func BlockHeightFromProto(pb *pbtypes.BlockHeight) *BlockHeight {
 	return &BlockHeight{Height: pb.Height}
 }
 
+// CommitBlock 区块的提交证明（commit QC），它来自于主节点发布的Decide消息，其中的聚合签名证明了区块得到了2/3以上验证者
+// 的确认：
+//  1. Hash：被确认的区块的变色龙哈希值
+//  2. Round：区块被确认时所处的轮次
+//  3. ValueHash：被聚合签名签署的值，即 GenerateDecideValueHash(Height, Round, Hash)
+//  4. AggregateSignature：聚合签名，其中包含了参与签名的验证者集合
+//
+// 链式HotStuff里没有Decide消息，提交证明是认证该区块的QC，此时View为QC所在的视图，ValueHash为
+// GenerateChainedVoteValueHash(View, Height, Hash)。
+//
+// 两阶段共识里Decide消息携带的是对PreCommitVote的聚合签名，此时ValueHash为GenerateCommitValueHash(Height, Round, Hash)。
 type CommitBlock struct {
 	Height             int64                     `json:"height"`
 	Hash               []byte                    `json:"hash"`
+	Round              int16                     `json:"round"`
+	View               int64                     `json:"view"` // 链式HotStuff里认证区块的QC所在的视图，此时Round为0
+	ValueHash          []byte                    `json:"value_hash"`
 	AggregateSignature *bls12.AggregateSignature `json:"aggregate_signature"`
 }
 
+func NewCommitBlock(block *Block, decide *Decide) *CommitBlock {
+	hash := make([]byte, len(block.ChameleonHash.Hash))
+	copy(hash, block.ChameleonHash.Hash)
+	valueHash := make([]byte, len(decide.ValueHash))
+	copy(valueHash, decide.ValueHash)
+	return &CommitBlock{
+		Height:             block.Header.Height,
+		Hash:               hash,
+		Round:              decide.Round,
+		ValueHash:          valueHash,
+		AggregateSignature: decide.AggregateSignature,
+	}
+}
+
+// NewChainedCommitBlock 链式HotStuff里区块的提交证明，它是认证该区块的QC，区块在它之后连续两个视图的QC形成时被提交。
+func NewChainedCommitBlock(block *Block, qc *QuorumCert) *CommitBlock {
+	hash := make([]byte, len(block.ChameleonHash.Hash))
+	copy(hash, block.ChameleonHash.Hash)
+	return &CommitBlock{
+		Height:             block.Header.Height,
+		Hash:               hash,
+		View:               qc.View,
+		ValueHash:          qc.ValueHash(),
+		AggregateSignature: qc.AggregateSignature,
+	}
+}
+
+// Signers 返回参与聚合签名的验证者集合。
+func (cb *CommitBlock) Signers() *crypto.IDSet {
+	if cb == nil || cb.AggregateSignature == nil {
+		return crypto.NewIDSet(0)
+	}
+	return cb.AggregateSignature.Participants()
+}
+
+func (cb *CommitBlock) ValidateBasic() error {
+	if cb == nil {
+		return errors.New("nil commit")
+	}
+	if cb.Height < 0 {
+		return errors.New("negative height")
+	}
+	if len(cb.Hash) == 0 {
+		return errors.New("empty block hash")
+	}
+	if cb.View > 0 {
+		if !bytes.Equal(cb.ValueHash, GenerateChainedVoteValueHash(cb.View, cb.Height, cb.Hash)) {
+			return errors.New("value hash does not match block hash")
+		}
+	} else {
+		if cb.Round < 1 {
+			return errors.New("round must be greater than 0")
+		}
+		if !bytes.Equal(cb.ValueHash, GenerateDecideValueHash(cb.Height, cb.Round, cb.Hash)) &&
+			!bytes.Equal(cb.ValueHash, GenerateCommitValueHash(cb.Height, cb.Round, cb.Hash)) {
+			return errors.New("value hash does not match block hash")
+		}
+	}
+	if cb.AggregateSignature == nil {
+		return errors.New("nil aggregate signature")
+	}
+	return nil
+}
+
+// VerifyCommit 验证提交证明确实确认了给定的区块：证明里的区块哈希与区块的变色龙哈希一致，参与签名的验证者的投票权
+// 之和超过了2/3，并且聚合签名是合法的。
+func (cb *CommitBlock) VerifyCommit(block *Block, validators *ValidatorSet) error {
+	if err := cb.ValidateBasic(); err != nil {
+		return err
+	}
+	if block == nil || block.Header == nil || block.ChameleonHash == nil {
+		return errors.New("incomplete block")
+	}
+	if cb.Height != block.Header.Height {
+		return fmt.Errorf("commit is for height %d, but block height is %d", cb.Height, block.Header.Height)
+	}
+	if !bytes.Equal(cb.Hash, block.ChameleonHash.Hash) {
+		return fmt.Errorf("commit is for block %x, but got block %x", cb.Hash, block.ChameleonHash.Hash)
+	}
+	if err := validators.VerifyAggregateSignature(cb.AggregateSignature, cb.ValueHash); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+	return nil
+}
+
+func (cb *CommitBlock) ToProto() *pbtypes.CommitBlock {
+	if cb == nil {
+		return nil
+	}
+	return &pbtypes.CommitBlock{
+		Height:             cb.Height,
+		Hash:               cb.Hash,
+		Round:              int32(cb.Round),
+		View:               cb.View,
+		ValueHash:          cb.ValueHash,
+		AggregateSignature: cb.AggregateSignature.ToProto(),
+	}
+}
+
+func CommitBlockFromProto(pb *pbtypes.CommitBlock) *CommitBlock {
+	if pb == nil {
+		return nil
+	}
+	return &CommitBlock{
+		Height:             pb.Height,
+		Hash:               pb.Hash,
+		Round:              int16(pb.Round),
+		View:               pb.View,
+		ValueHash:          pb.ValueHash,
+		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
+	}
+}
+
 /*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
 
 // 区块头
@@ -186,6 +380,16 @@ type Header struct {
 	Height            int64     `json:"height"`
 	Timestamp         time.Time `json:"timestamp"`
 	Proposer          crypto.ID `json:"proposer"`
+	// AppHash 执行完AppHashHeight()高度的区块后应用状态的哈希值，副本节点只为与自己的应用状态一致的区块投票
+	AppHash []byte `json:"app_hash"`
+	// AppHashLag AppHash所对应的区块比前一个区块低了多少个高度。四阶段和两阶段共识里总是0，即AppHash是执行完前一个
+	// 区块后的状态哈希；链式HotStuff里父区块可能还没有被提交，AppHash是主节点最后提交的区块执行完之后的状态哈希
+	AppHashLag int64 `json:"app_hash_lag"`
+}
+
+// AppHashHeight 返回AppHash所对应的区块高度，即执行完这个高度的区块后应用状态的哈希值是AppHash。
+func (h *Header) AppHashHeight() int64 {
+	return h.Height - 1 - h.AppHashLag
 }
 
 func (h *Header) ToProto() *pbtypes.Header {
@@ -198,6 +402,8 @@ func (h *Header) ToProto() *pbtypes.Header {
 		Height:            h.Height,
 		Timestamp:         h.Timestamp,
 		Proposer:          string(h.Proposer),
+		AppHash:           h.AppHash,
+		AppHashLag:        h.AppHashLag,
 	}
 }
 
@@ -211,6 +417,8 @@ func HeaderFromProto(pb *pbtypes.Header) *Header {
 		Height:            pb.Height,
 		Timestamp:         pb.Timestamp.Local(),
 		Proposer:          crypto.ID(pb.Proposer),
+		AppHash:           pb.AppHash,
+		AppHashLag:        pb.AppHashLag,
 	}
 }
 
@@ -251,16 +459,21 @@ func DataFromProto(pb *pbtypes.Data) *Data {
 	}
 }
 
+// ComputeRootHash 计算区块体里所有交易数据的默克尔根。
+func (d *Data) ComputeRootHash() []byte {
+	_txs := make([][]byte, len(d.Txs))
+	for i, tx := range d.Txs {
+		_txs[i] = tx
+	}
+	return merkle.ComputeMerkleRoot(_txs)
+}
+
 // ValidateBasic ♏ | 作者 ⇨ 吴翔宇 | (｡･∀･)ﾉﾞ嗨
 //
-// ValidateBasic 方法验证区块体部分的交易数据大小不能超过1MB。
+// ValidateBasic 方法验证区块体部分的交易数据大小不能超过MaxBlockSizeBytes，共识参数里更严格的限制由区块执行器检查。
 func (d *Data) ValidateBasic() error {
-	size := 0
-	for _, tx := range d.Txs {
-		size += len(tx)
-	}
-	if size > 1024*1024 {
-		return fmt.Errorf("exceed data limit: %d > %d", size, 1024*1024)
+	if size := d.Txs.ByteSize(); size > MaxBlockSizeBytes {
+		return fmt.Errorf("exceed data limit: %d > %d", size, MaxBlockSizeBytes)
 	}
 	return nil
 }
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"errors"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+)
+
+// 副本节点错过了主节点的Prepare消息，却收到了认证该区块的PreCommit、Commit或者Decide消息时，按照消息里的变色龙哈希
+// 向其他节点索要区块，拿到区块后继续当前高度的共识，而不必等到重启后再由同步模块追赶。
+
+// BlockRequest 索要Height高度变色龙哈希为BlockHash的区块。
+type BlockRequest struct {
+	Height    int64  `json:"height"`
+	BlockHash []byte `json:"block_hash"`
+}
+
+func (req *BlockRequest) ValidateBasic() error {
+	if req.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	if len(req.BlockHash) == 0 {
+		return errors.New("empty block hash")
+	}
+	return nil
+}
+
+func (req *BlockRequest) ToProto() *pbtypes.BlockRequest {
+	return &pbtypes.BlockRequest{
+		Height:    req.Height,
+		BlockHash: req.BlockHash,
+	}
+}
+
+func BlockRequestFromProto(pb *pbtypes.BlockRequest) *BlockRequest {
+	if pb == nil {
+		return nil
+	}
+	return &BlockRequest{
+		Height:    pb.Height,
+		BlockHash: pb.BlockHash,
+	}
+}
+
+// BlockResponse 对BlockRequest的回复，区块是否就是被索要的区块由收到回复的节点根据QC判断。
+type BlockResponse struct {
+	Block *Block `json:"block"`
+}
+
+func (resp *BlockResponse) ValidateBasic() error {
+	if resp.Block == nil || resp.Block.ChameleonHash == nil {
+		return errors.New("incomplete block")
+	}
+	return resp.Block.ValidateBasic()
+}
+
+func (resp *BlockResponse) ToProto() *pbtypes.BlockResponse {
+	return &pbtypes.BlockResponse{Block: resp.Block.ToProto()}
+}
+
+func BlockResponseFromProto(pb *pbtypes.BlockResponse) *BlockResponse {
+	if pb == nil {
+		return nil
+	}
+	return &BlockResponse{Block: BlockFromProto(pb.Block)}
+}
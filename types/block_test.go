@@ -2,6 +2,9 @@ package types
 
 import (
 	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/stretchr/testify/assert"
 	"io"
 	"testing"
 )
@@ -26,3 +29,94 @@ func TestName(t *testing.T) {
 func TestEof(t *testing.T) {
 	t.Log(io.EOF)
 }
+
+func TestCommitBlock_VerifyCommit(t *testing.T) {
+	privateKeys := make([]*bls12.PrivateKey, 4)
+	validators := make([]*Validator, 4)
+	for i := 0; i < 4; i++ {
+		privateKeys[i], _ = bls12.GeneratePrivateKey()
+		validators[i] = NewValidator(privateKeys[i].PublicKey(), 10)
+	}
+	set := NewValidatorSet(validators)
+
+	block := &Block{
+		Header:        &Header{Height: 3},
+		Body:          &Data{},
+		ChameleonHash: &ChameleonHash{Hash: []byte("block hash")},
+	}
+	valueHash := GenerateDecideValueHash(block.Header.Height, 1, block.ChameleonHash.Hash)
+	sign := func(keys []*bls12.PrivateKey) *bls12.AggregateSignature {
+		sigs := make([]*bls12.Signature, 0)
+		for _, key := range keys {
+			sig, err := key.Sign(valueHash)
+			assert.Nil(t, err)
+			sigs = append(sigs, sig)
+		}
+		agg, err := bls12.NewCryptoBLS12().CreateThresholdSignature(sigs)
+		assert.Nil(t, err)
+		return agg
+	}
+
+	commit := NewCommitBlock(block, NewDecide(sign(privateKeys[:3]), valueHash, block.ChameleonHash.Hash, validators[0].ID, 3, 1))
+	assert.Nil(t, commit.VerifyCommit(block, set))
+	assert.Equal(t, 3, commit.Signers().Size())
+
+	// 经过序列化之后依然可以验证
+	pb := commit.ToProto()
+	assert.Nil(t, CommitBlockFromProto(pb).VerifyCommit(block, set))
+
+	// 投票权不足2/3
+	weak := NewCommitBlock(block, NewDecide(sign(privateKeys[:2]), valueHash, block.ChameleonHash.Hash, validators[0].ID, 3, 1))
+	assert.NotNil(t, weak.VerifyCommit(block, set))
+
+	// 证明的不是这个区块
+	other := &Block{Header: &Header{Height: 3}, Body: &Data{}, ChameleonHash: &ChameleonHash{Hash: []byte("other hash")}}
+	assert.NotNil(t, commit.VerifyCommit(other, set))
+}
+
+func TestCommitBlock_VerifyChainedCommit(t *testing.T) {
+	privateKeys := make([]*bls12.PrivateKey, 4)
+	validators := make([]*Validator, 4)
+	for i := 0; i < 4; i++ {
+		privateKeys[i], _ = bls12.GeneratePrivateKey()
+		validators[i] = NewValidator(privateKeys[i].PublicKey(), 10)
+	}
+	set := NewValidatorSet(validators)
+
+	block := &Block{
+		Header:        &Header{Height: 3},
+		Body:          &Data{},
+		ChameleonHash: &ChameleonHash{Hash: []byte("block hash")},
+	}
+	sigs := make([]*bls12.Signature, 0)
+	for _, key := range privateKeys[:3] {
+		vote, err := NewChainedVote(7, 3, block.ChameleonHash.Hash, NewPrivateKeySigner(key))
+		assert.Nil(t, err)
+		sigs = append(sigs, vote.Signature)
+	}
+	agg, err := bls12.NewCryptoBLS12().CreateThresholdSignature(sigs)
+	assert.Nil(t, err)
+	qc := NewQuorumCert(7, 3, block.ChameleonHash.Hash, agg)
+	assert.Nil(t, qc.Verify(set))
+
+	commit := NewChainedCommitBlock(block, qc)
+	assert.Nil(t, CommitBlockFromProto(commit.ToProto()).VerifyCommit(block, set))
+
+	// QC所在的视图被篡改
+	commit.View = 8
+	assert.NotNil(t, commit.VerifyCommit(block, set))
+}
+
+func TestBlock_ComputeBlockDataHashFieldBoundaries(t *testing.T) {
+	newBlock := func(proposer string, appHash []byte, lag int64) *Block {
+		return &Block{
+			Header: &Header{PreviousBlockHash: []byte("prev"), Height: 5, Proposer: crypto.ID(proposer), AppHash: appHash, AppHashLag: lag},
+			Body:   &Data{Txs: Txs{Tx("tx")}},
+		}
+	}
+	// 字段之间的边界不同，拼接起来的字节却相同
+	assert.NotEqual(t, newBlock("ab", nil, 0).ComputeBlockDataHash(), newBlock("a", []byte("b"), 0).ComputeBlockDataHash())
+	// 应用状态的哈希值为空、落后的高度数为0时也参与哈希
+	assert.NotEqual(t, newBlock("a", nil, 0).ComputeBlockDataHash(), newBlock("a", []byte{}, 1).ComputeBlockDataHash())
+	assert.NotEqual(t, newBlock("a", []byte("1"), 0).ComputeBlockDataHash(), newBlock("a", nil, 1).ComputeBlockDataHash())
+}
@@ -0,0 +1,342 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"time"
+)
+
+// 链式（流水线）HotStuff用到的消息。与四阶段共识按照高度和轮次推进不同，链式HotStuff按照单调递增的视图推进，
+// 每个视图只有一轮投票：主节点提出的区块携带父区块的QC，验证者把投票发送给下一个视图的主节点，下一个视图的主节点
+// 用收集到的投票组成QC，并在它的基础上提出新的区块。一个QC同时推动了父区块、祖父区块和曾祖父区块的共识进程。
+
+// QuorumCert 2/3以上验证者对View视图里Height高度的区块的投票所聚合成的QC。
+type QuorumCert struct {
+	View               int64                     `json:"view"`
+	Height             int64                     `json:"height"`
+	BlockHash          []byte                    `json:"block_hash"`
+	AggregateSignature *bls12.AggregateSignature `json:"aggregate_signature"`
+}
+
+func NewQuorumCert(view, height int64, blockHash []byte, agg *bls12.AggregateSignature) *QuorumCert {
+	return &QuorumCert{
+		View:               view,
+		Height:             height,
+		BlockHash:          blockHash,
+		AggregateSignature: agg,
+	}
+}
+
+// ValueHash 返回QC里的聚合签名所签署的内容，即验证者为投票签名的内容。
+func (qc *QuorumCert) ValueHash() []byte {
+	return GenerateChainedVoteValueHash(qc.View, qc.Height, qc.BlockHash)
+}
+
+func (qc *QuorumCert) ValidateBasic() error {
+	if qc.View < 1 {
+		return errors.New("view must be greater than 0")
+	}
+	if qc.Height < 0 {
+		return errors.New("negative height")
+	}
+	if len(qc.BlockHash) == 0 {
+		return errors.New("empty block hash")
+	}
+	if qc.AggregateSignature == nil {
+		return errors.New("nil aggregate signature")
+	}
+	return nil
+}
+
+// Verify 检查参与签名的验证者的投票权之和超过了2/3，并且聚合签名是合法的。
+func (qc *QuorumCert) Verify(validators *ValidatorSet) error {
+	if err := qc.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := validators.VerifyAggregateSignature(qc.AggregateSignature, qc.ValueHash()); err != nil {
+		return fmt.Errorf("invalid QC: %w", err)
+	}
+	return nil
+}
+
+func (qc *QuorumCert) ToProto() *pbtypes.QuorumCert {
+	if qc == nil {
+		return nil
+	}
+	return &pbtypes.QuorumCert{
+		View:               qc.View,
+		Height:             qc.Height,
+		BlockHash:          qc.BlockHash,
+		AggregateSignature: qc.AggregateSignature.ToProto(),
+	}
+}
+
+func QuorumCertFromProto(pb *pbtypes.QuorumCert) *QuorumCert {
+	if pb == nil {
+		return nil
+	}
+	return &QuorumCert{
+		View:               pb.View,
+		Height:             pb.Height,
+		BlockHash:          pb.BlockHash,
+		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
+	}
+}
+
+/**********************************************************************************************************************/
+
+// ChainedProposal 主节点在View视图提出的区块，Justify是父区块的QC，它为nil时，区块直接接在已经提交的最后一个区块之后。
+type ChainedProposal struct {
+	ID        crypto.ID        `json:"ID"`
+	View      int64            `json:"view"`
+	Block     *Block           `json:"block"`
+	Justify   *QuorumCert      `json:"justify"`
+	Timestamp time.Time        `json:"timestamp"`
+	Signature *bls12.Signature `json:"signature"`
+}
+
+func NewChainedProposal(view int64, block *Block, justify *QuorumCert, signer Signer) (*ChainedProposal, error) {
+	p := &ChainedProposal{
+		ID:        signer.PublicKey().ToID(),
+		View:      view,
+		Block:     block,
+		Justify:   justify,
+		Timestamp: time.Now(),
+	}
+	if err := signer.SignChainedProposal(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *ChainedProposal) ValidateBasic() error {
+	if p.View < 1 {
+		return errors.New("view must be greater than 0")
+	}
+	if p.Block == nil || p.Block.Header == nil || p.Block.ChameleonHash == nil || len(p.Block.ChameleonHash.Hash) == 0 {
+		return errors.New("incomplete block")
+	}
+	if p.Signature == nil {
+		return errors.New("proposal is not signed")
+	}
+	if p.Justify != nil {
+		if err := p.Justify.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid justify QC: %w", err)
+		}
+		if p.Justify.View >= p.View {
+			return fmt.Errorf("justify QC is from view %d, but proposal is at view %d", p.Justify.View, p.View)
+		}
+		if p.Justify.Height+1 != p.Block.Header.Height {
+			return fmt.Errorf("justify QC is for height %d, but block height is %d", p.Justify.Height, p.Block.Header.Height)
+		}
+		if !bytes.Equal(p.Justify.BlockHash, p.Block.Header.PreviousBlockHash) {
+			return errors.New("justify QC does not certify the parent of the proposed block")
+		}
+	}
+	return nil
+}
+
+func (p *ChainedProposal) ToProto() *pbtypes.ChainedProposal {
+	if p == nil {
+		return nil
+	}
+	return &pbtypes.ChainedProposal{
+		ID:        string(p.ID),
+		View:      p.View,
+		Block:     p.Block.ToProto(),
+		Justify:   p.Justify.ToProto(),
+		Timestamp: p.Timestamp,
+		Signature: p.Signature.ToProto(),
+	}
+}
+
+func ChainedProposalFromProto(pb *pbtypes.ChainedProposal) *ChainedProposal {
+	if pb == nil {
+		return nil
+	}
+	p := &ChainedProposal{
+		ID:        crypto.ID(pb.ID),
+		View:      pb.View,
+		Block:     BlockFromProto(pb.Block),
+		Justify:   QuorumCertFromProto(pb.Justify),
+		Timestamp: pb.Timestamp,
+	}
+	if pb.Signature != nil {
+		p.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return p
+}
+
+/**********************************************************************************************************************/
+
+// ChainedVote 验证者对View视图里Height高度的区块的投票。
+type ChainedVote struct {
+	View      int64            `json:"view"`
+	Height    int64            `json:"height"`
+	BlockHash []byte           `json:"block_hash"`
+	ValueHash []byte           `json:"value_hash"`
+	Timestamp time.Time        `json:"timestamp"`
+	Signature *bls12.Signature `json:"signature"`
+}
+
+func NewChainedVote(view, height int64, blockHash []byte, signer Signer) (*ChainedVote, error) {
+	v := &ChainedVote{
+		View:      view,
+		Height:    height,
+		BlockHash: blockHash,
+		ValueHash: GenerateChainedVoteValueHash(view, height, blockHash),
+		Timestamp: time.Now(),
+	}
+	if err := signer.SignChainedVote(v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+func (v *ChainedVote) ValidateBasic() error {
+	if v.View < 1 {
+		return errors.New("view must be greater than 0")
+	}
+	if v.Height < 0 {
+		return errors.New("negative height")
+	}
+	if v.Signature == nil {
+		return errors.New("vote is not signed")
+	}
+	if !bytes.Equal(v.ValueHash, GenerateChainedVoteValueHash(v.View, v.Height, v.BlockHash)) {
+		return errors.New("value hash does not match the vote's view, height and block hash")
+	}
+	return nil
+}
+
+func (v *ChainedVote) ToProto() *pbtypes.ChainedVote {
+	if v == nil {
+		return nil
+	}
+	return &pbtypes.ChainedVote{
+		View:      v.View,
+		Height:    v.Height,
+		BlockHash: v.BlockHash,
+		ValueHash: v.ValueHash,
+		Timestamp: v.Timestamp,
+		Signature: v.Signature.ToProto(),
+	}
+}
+
+func ChainedVoteFromProto(pb *pbtypes.ChainedVote) *ChainedVote {
+	if pb == nil {
+		return nil
+	}
+	v := &ChainedVote{
+		View:      pb.View,
+		Height:    pb.Height,
+		BlockHash: pb.BlockHash,
+		ValueHash: pb.ValueHash,
+		Timestamp: pb.Timestamp,
+	}
+	if pb.Signature != nil {
+		v.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return v
+}
+
+/**********************************************************************************************************************/
+
+// ChainedNewView 验证者在视图超时后发送给View视图主节点的消息，携带自己所知道的最高QC，主节点收集到2/3以上的
+// ChainedNewView消息后，基于其中最高的QC提出区块。
+type ChainedNewView struct {
+	ID        crypto.ID        `json:"ID"`
+	View      int64            `json:"view"` // 想要进入的新视图
+	HighQC    *QuorumCert      `json:"high_qc"`
+	Timestamp time.Time        `json:"timestamp"`
+	Signature *bls12.Signature `json:"signature"`
+}
+
+func NewChainedNewView(view int64, highQC *QuorumCert, signer Signer) (*ChainedNewView, error) {
+	nv := &ChainedNewView{
+		ID:        signer.PublicKey().ToID(),
+		View:      view,
+		HighQC:    highQC,
+		Timestamp: time.Now(),
+	}
+	if err := signer.SignChainedNewView(nv); err != nil {
+		return nil, err
+	}
+	return nv, nil
+}
+
+// ValueHash 计算ChainedNewView消息中需要被签名的部分的哈希值。
+func (nv *ChainedNewView) ValueHash() []byte {
+	value := []byte(fmt.Sprintf("ChainedNewView-%s-%d-", nv.ID, nv.View))
+	if nv.HighQC != nil {
+		value = append(value, nv.HighQC.ValueHash()...)
+	}
+	h := sha256.Sum(value)
+	return h[:]
+}
+
+func (nv *ChainedNewView) ValidateBasic() error {
+	if nv.View < 1 {
+		return errors.New("view must be greater than 0")
+	}
+	if nv.HighQC != nil {
+		if err := nv.HighQC.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid high QC: %w", err)
+		}
+		if nv.HighQC.View >= nv.View {
+			return errors.New("the view of high QC must be less than the new view")
+		}
+	}
+	if nv.Signature == nil {
+		return errors.New("ChainedNewView is not signed")
+	}
+	return nil
+}
+
+func (nv *ChainedNewView) ToProto() *pbtypes.ChainedNewView {
+	if nv == nil {
+		return nil
+	}
+	return &pbtypes.ChainedNewView{
+		ID:        string(nv.ID),
+		View:      nv.View,
+		HighQC:    nv.HighQC.ToProto(),
+		Timestamp: nv.Timestamp,
+		Signature: nv.Signature.ToProto(),
+	}
+}
+
+func ChainedNewViewFromProto(pb *pbtypes.ChainedNewView) *ChainedNewView {
+	if pb == nil {
+		return nil
+	}
+	nv := &ChainedNewView{
+		ID:        crypto.ID(pb.ID),
+		View:      pb.View,
+		HighQC:    QuorumCertFromProto(pb.HighQC),
+		Timestamp: pb.Timestamp,
+	}
+	if pb.Signature != nil {
+		nv.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return nv
+}
+
+// GenerateChainedProposalSignBytes 主节点为链式HotStuff的提案签名的内容，签名绑定了视图、区块高度和区块哈希。
+func GenerateChainedProposalSignBytes(view, height int64, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("ChainedProposal-%d-%d-", view, height)), blockHash...)
+	h := sha256.Sum(value)
+	return h[:]
+}
+
+// GenerateChainedVoteValueHash 计算链式HotStuff里投票需要签名的值，也是QC的聚合签名所签署的值。
+func GenerateChainedVoteValueHash(view, height int64, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("ChainedVote-%d-%d-", view, height)), blockHash...)
+	h := sha256.Sum(value)
+	return h[:]
+}
@@ -0,0 +1,333 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"time"
+)
+
+// 紧凑区块转发。主节点的Prepare消息里不再携带完整的交易数据，而是只携带区块头、变色龙哈希以及每笔交易的哈希值，
+// 副本节点用自己交易池里的交易还原出完整的区块，交易池里没有的交易再通过BlockTxsRequest向主节点索要，还原出的
+// 区块必须与区块体的默克尔根相符，才会被交给共识模块。
+
+// CompactBlock 用交易哈希代替交易数据的区块。
+type CompactBlock struct {
+	Header        *Header        `json:"header"`
+	RootHash      []byte         `json:"root_hash"`
+	TxHashes      [][]byte       `json:"tx_hashes"`
+	ChameleonHash *ChameleonHash `json:"chameleon_hash"`
+	Evidence      EvidenceList   `json:"evidence"`
+}
+
+func NewCompactBlock(block *Block) *CompactBlock {
+	hashes := make([][]byte, len(block.Body.Txs))
+	for i, tx := range block.Body.Txs {
+		hashes[i] = tx.Hash()
+	}
+	return &CompactBlock{
+		Header:        block.Header,
+		RootHash:      block.Body.RootHash,
+		TxHashes:      hashes,
+		ChameleonHash: block.ChameleonHash,
+		Evidence:      block.Evidence,
+	}
+}
+
+func (cb *CompactBlock) ValidateBasic() error {
+	if cb == nil {
+		return errors.New("nil compact block")
+	}
+	if cb.Header == nil {
+		return errors.New("nil block header")
+	}
+	if cb.ChameleonHash == nil {
+		return errors.New("nil chameleon hash")
+	}
+	for i, hash := range cb.TxHashes {
+		if len(hash) != sha256.Size32 {
+			return fmt.Errorf("wrong size of tx hash #%d: %d", i, len(hash))
+		}
+	}
+	return nil
+}
+
+// Reconstruct 用与TxHashes一一对应的交易还原出完整的区块，每笔交易的哈希值以及所有交易的默克尔根都必须与紧凑区块相符。
+func (cb *CompactBlock) Reconstruct(txs Txs) (*Block, error) {
+	if len(txs) != len(cb.TxHashes) {
+		return nil, fmt.Errorf("compact block has %d txs, but got %d", len(cb.TxHashes), len(txs))
+	}
+	for i, tx := range txs {
+		if !bytes.Equal(tx.Hash(), cb.TxHashes[i]) {
+			return nil, fmt.Errorf("tx #%d does not match its hash %X", i, cb.TxHashes[i])
+		}
+	}
+	body := &Data{RootHash: cb.RootHash, Txs: txs}
+	if rootHash := body.ComputeRootHash(); !bytes.Equal(rootHash, cb.RootHash) {
+		return nil, fmt.Errorf("wrong root hash, expected %X, got %X", cb.RootHash, rootHash)
+	}
+	return &Block{
+		Header:        cb.Header,
+		Body:          body,
+		ChameleonHash: cb.ChameleonHash,
+		Evidence:      cb.Evidence,
+	}, nil
+}
+
+func (cb *CompactBlock) ToProto() *pbtypes.CompactBlock {
+	if cb == nil {
+		return nil
+	}
+	return &pbtypes.CompactBlock{
+		Header:        cb.Header.ToProto(),
+		RootHash:      cb.RootHash,
+		TxHashes:      cb.TxHashes,
+		ChameleonHash: cb.ChameleonHash.ToProto(),
+		Evidence:      cb.Evidence.ToProto(),
+	}
+}
+
+func CompactBlockFromProto(pb *pbtypes.CompactBlock) *CompactBlock {
+	if pb == nil {
+		return nil
+	}
+	// 与BlockFromProto一样，无法解析的证据会被丢弃，还原出的区块会因为哈希值对不上而被拒绝
+	evidence, _ := EvidenceListFromProto(pb.Evidence)
+	return &CompactBlock{
+		Header:        HeaderFromProto(pb.Header),
+		RootHash:      pb.RootHash,
+		TxHashes:      pb.TxHashes,
+		ChameleonHash: ChameleonHashFromProto(pb.ChameleonHash),
+		Evidence:      evidence,
+	}
+}
+
+// CompactPrepare 紧凑模式下在网络上传输的Prepare消息，主节点的签名只覆盖高度、轮次和区块的变色龙哈希，因此还原出
+// 区块后，可以直接得到与主节点签名的Prepare消息相同的消息。
+type CompactPrepare struct {
+	Type      pbtypes.ConsensusMessageType `json:"type"`
+	ID        crypto.ID                    `json:"ID"`
+	Height    int64                        `json:"height"`
+	Round     int16                        `json:"round"`
+	Block     *CompactBlock                `json:"block"`
+	Timestamp time.Time                    `json:"timestamp"`
+	Signature *bls12.Signature             `json:"signature"`
+	HighQC    *PreCommit                   `json:"high_qc"`
+	TC        *TimeoutCert                 `json:"tc"`
+}
+
+func NewCompactPrepare(prepare *Prepare) *CompactPrepare {
+	return &CompactPrepare{
+		Type:      prepare.Type,
+		ID:        prepare.ID,
+		Height:    prepare.Height,
+		Round:     prepare.Round,
+		Block:     NewCompactBlock(prepare.Block),
+		Timestamp: prepare.Timestamp,
+		Signature: prepare.Signature,
+		HighQC:    prepare.HighQC,
+		TC:        prepare.TC,
+	}
+}
+
+// ToPrepare 用还原出的完整区块组装出原本的Prepare消息。
+func (cp *CompactPrepare) ToPrepare(block *Block) *Prepare {
+	return &Prepare{
+		Type:      cp.Type,
+		ID:        cp.ID,
+		Height:    cp.Height,
+		Round:     cp.Round,
+		Block:     block,
+		Timestamp: cp.Timestamp,
+		Signature: cp.Signature,
+		HighQC:    cp.HighQC,
+		TC:        cp.TC,
+	}
+}
+
+func (cp *CompactPrepare) ValidateBasic() error {
+	if cp.Height < 0 {
+		return errors.New("negative height")
+	}
+	if cp.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if cp.Signature == nil {
+		return errors.New("CompactPrepare is not signed")
+	}
+	return cp.Block.ValidateBasic()
+}
+
+func (cp *CompactPrepare) ToProto() *pbtypes.CompactPrepare {
+	return &pbtypes.CompactPrepare{
+		Type:      cp.Type,
+		ID:        string(cp.ID),
+		Height:    cp.Height,
+		Round:     int32(cp.Round),
+		Block:     cp.Block.ToProto(),
+		Timestamp: cp.Timestamp,
+		Signature: cp.Signature.ToProto(),
+		HighQC:    cp.HighQC.ToProto(),
+		TC:        cp.TC.ToProto(),
+	}
+}
+
+func CompactPrepareFromProto(pb *pbtypes.CompactPrepare) *CompactPrepare {
+	if pb == nil {
+		return nil
+	}
+	return &CompactPrepare{
+		Type:      pb.Type,
+		ID:        crypto.ID(pb.ID),
+		Height:    pb.Height,
+		Round:     int16(pb.Round),
+		Block:     CompactBlockFromProto(pb.Block),
+		Timestamp: pb.Timestamp,
+		Signature: bls12.SignatureFromProto(pb.Signature),
+		HighQC:    PreCommitFromProto(pb.HighQC),
+		TC:        TimeoutCertFromProto(pb.TC),
+	}
+}
+
+// BlockTxsRequest 副本节点向主节点索要Height高度第Round轮提案里的部分交易，Indexes是这些交易在区块里的位置。
+type BlockTxsRequest struct {
+	Height    int64  `json:"height"`
+	Round     int16  `json:"round"`
+	BlockHash []byte `json:"block_hash"`
+	Indexes   []int  `json:"indexes"`
+}
+
+func (req *BlockTxsRequest) ValidateBasic() error {
+	if req.Height < 0 {
+		return errors.New("negative height")
+	}
+	if len(req.BlockHash) == 0 {
+		return errors.New("empty block hash")
+	}
+	if len(req.Indexes) == 0 {
+		return errors.New("no tx is requested")
+	}
+	for _, index := range req.Indexes {
+		if index < 0 {
+			return fmt.Errorf("negative tx index: %d", index)
+		}
+	}
+	return nil
+}
+
+func (req *BlockTxsRequest) ToProto() *pbtypes.BlockTxsRequest {
+	indexes := make([]uint32, len(req.Indexes))
+	for i, index := range req.Indexes {
+		indexes[i] = uint32(index)
+	}
+	return &pbtypes.BlockTxsRequest{
+		Height:    req.Height,
+		Round:     int32(req.Round),
+		BlockHash: req.BlockHash,
+		Indexes:   indexes,
+	}
+}
+
+func BlockTxsRequestFromProto(pb *pbtypes.BlockTxsRequest) *BlockTxsRequest {
+	if pb == nil {
+		return nil
+	}
+	indexes := make([]int, len(pb.Indexes))
+	for i, index := range pb.Indexes {
+		indexes[i] = int(index)
+	}
+	return &BlockTxsRequest{
+		Height:    pb.Height,
+		Round:     int16(pb.Round),
+		BlockHash: pb.BlockHash,
+		Indexes:   indexes,
+	}
+}
+
+// BlockTxsResponse 主节点对BlockTxsRequest的回复，Txs与Indexes一一对应。
+type BlockTxsResponse struct {
+	Height    int64  `json:"height"`
+	Round     int16  `json:"round"`
+	BlockHash []byte `json:"block_hash"`
+	Indexes   []int  `json:"indexes"`
+	Txs       Txs    `json:"txs"`
+}
+
+// NewBlockTxsResponse 从区块里取出请求所索要的交易，请求的位置超出了区块的交易数量时返回错误。
+func NewBlockTxsResponse(block *Block, req *BlockTxsRequest) (*BlockTxsResponse, error) {
+	txs := make(Txs, len(req.Indexes))
+	for i, index := range req.Indexes {
+		if index >= len(block.Body.Txs) {
+			return nil, fmt.Errorf("tx index %d is out of range, block has %d txs", index, len(block.Body.Txs))
+		}
+		txs[i] = block.Body.Txs[index]
+	}
+	return &BlockTxsResponse{
+		Height:    req.Height,
+		Round:     req.Round,
+		BlockHash: req.BlockHash,
+		Indexes:   req.Indexes,
+		Txs:       txs,
+	}, nil
+}
+
+func (resp *BlockTxsResponse) ValidateBasic() error {
+	if resp.Height < 0 {
+		return errors.New("negative height")
+	}
+	if len(resp.BlockHash) == 0 {
+		return errors.New("empty block hash")
+	}
+	if len(resp.Indexes) != len(resp.Txs) {
+		return fmt.Errorf("%d indexes do not match %d txs", len(resp.Indexes), len(resp.Txs))
+	}
+	for _, index := range resp.Indexes {
+		if index < 0 {
+			return fmt.Errorf("negative tx index: %d", index)
+		}
+	}
+	return nil
+}
+
+func (resp *BlockTxsResponse) ToProto() *pbtypes.BlockTxsResponse {
+	indexes := make([]uint32, len(resp.Indexes))
+	for i, index := range resp.Indexes {
+		indexes[i] = uint32(index)
+	}
+	txs := make([][]byte, len(resp.Txs))
+	for i, tx := range resp.Txs {
+		txs[i] = tx
+	}
+	return &pbtypes.BlockTxsResponse{
+		Height:    resp.Height,
+		Round:     int32(resp.Round),
+		BlockHash: resp.BlockHash,
+		Indexes:   indexes,
+		Txs:       txs,
+	}
+}
+
+func BlockTxsResponseFromProto(pb *pbtypes.BlockTxsResponse) *BlockTxsResponse {
+	if pb == nil {
+		return nil
+	}
+	indexes := make([]int, len(pb.Indexes))
+	for i, index := range pb.Indexes {
+		indexes[i] = int(index)
+	}
+	txs := make(Txs, len(pb.Txs))
+	for i, tx := range pb.Txs {
+		txs[i] = tx
+	}
+	return &BlockTxsResponse{
+		Height:    pb.Height,
+		Round:     int16(pb.Round),
+		BlockHash: pb.BlockHash,
+		Indexes:   indexes,
+		Txs:       txs,
+	}
+}
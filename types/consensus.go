@@ -2,6 +2,7 @@ package types
 
 import (
 	"errors"
+	"fmt"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12"
 	"github.com/232425wxy/meta--/crypto/sha256"
@@ -44,49 +45,182 @@ func (nv *NextView) ValidateBasic() error {
 
 /**********************************************************************************************************************/
 
+// NewView 当副本节点在规定时间内没有等到当前高度的Decide消息时，会进入下一轮，并广播NewView消息，NewView消息里携带了
+// 该节点所知道的最高的QC（即PreCommit消息）以及该QC所认证的区块，新一轮的主节点会基于收集到的最高QC继续推进共识。
+type NewView struct {
+	Type        pbtypes.ConsensusMessageType `json:"type"`
+	ID          crypto.ID                    `json:"ID"`
+	Height      int64                        `json:"height"`
+	Round       int16                        `json:"round"` // 想要进入的新一轮
+	HighQCRound int16                        `json:"high_qc_round"`
+	HighQCBlock *Block                       `json:"high_qc_block"`
+	HighQC      *PreCommit                   `json:"high_qc"`
+	Timestamp   time.Time                    `json:"timestamp"`
+	Signature   *bls12.Signature             `json:"signature"`
+}
+
+func NewNewView(height int64, round int16, highQCRound int16, highQCBlock *Block, highQC *PreCommit, signer Signer) (*NewView, error) {
+	nv := &NewView{
+		Type:        pbtypes.NewViewType,
+		ID:          signer.PublicKey().ToID(),
+		Height:      height,
+		Round:       round,
+		HighQCRound: highQCRound,
+		HighQCBlock: highQCBlock,
+		HighQC:      highQC,
+		Timestamp:   time.Now(),
+	}
+	if err := signer.SignNewView(nv); err != nil {
+		return nil, err
+	}
+	return nv, nil
+}
+
+// ValueHash 计算NewView消息中需要被签名的部分的哈希值。
+func (nv *NewView) ValueHash() []byte {
+	value := []byte(fmt.Sprintf("NewView-%s-%d-%d-%d-", nv.ID, nv.Height, nv.Round, nv.HighQCRound))
+	if nv.HighQC != nil {
+		value = append(value, nv.HighQC.ValueHash...)
+	}
+	h := sha256.Sum(value)
+	return h[:]
+}
+
+func (nv *NewView) ValidateBasic() error {
+	if nv.Height < 0 {
+		return errors.New("negative height")
+	}
+	if nv.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if nv.HighQCRound >= nv.Round {
+		return errors.New("the round of high QC must be less than the new round")
+	}
+	if (nv.HighQC == nil) != (nv.HighQCBlock == nil) {
+		return errors.New("high QC and its block must be provided together")
+	}
+	if nv.HighQCBlock != nil && nv.HighQCBlock.Header == nil {
+		return errors.New("the block of high QC has no header")
+	}
+	if nv.HighQC != nil && (nv.HighQC.Height != nv.Height || nv.HighQCBlock.Header.Height != nv.Height) {
+		return errors.New("the height of high QC does not match the height of NewView")
+	}
+	if nv.HighQC != nil && nv.HighQC.Round != nv.HighQCRound {
+		return errors.New("the round of high QC does not match the high QC round of NewView")
+	}
+	if nv.Signature == nil {
+		return errors.New("NewView is not signed")
+	}
+	return nil
+}
+
+func (nv *NewView) ToProto() *pbtypes.NewView {
+	if nv == nil {
+		return nil
+	}
+	return &pbtypes.NewView{
+		Type:        nv.Type,
+		ID:          string(nv.ID),
+		Height:      nv.Height,
+		Round:       int32(nv.Round),
+		HighQCRound: int32(nv.HighQCRound),
+		HighQCBlock: nv.HighQCBlock.ToProto(),
+		HighQC:      nv.HighQC.ToProto(),
+		Timestamp:   nv.Timestamp,
+		Signature:   nv.Signature.ToProto(),
+	}
+}
+
+func NewViewFromProto(pb *pbtypes.NewView) *NewView {
+	if pb == nil {
+		return nil
+	}
+	nv := &NewView{
+		Type:        pb.Type,
+		ID:          crypto.ID(pb.ID),
+		Height:      pb.Height,
+		Round:       int16(pb.Round),
+		HighQCRound: int16(pb.HighQCRound),
+		HighQCBlock: BlockFromProto(pb.HighQCBlock),
+		HighQC:      PreCommitFromProto(pb.HighQC),
+		Timestamp:   pb.Timestamp,
+	}
+	if pb.Signature != nil {
+		nv.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return nv
+}
+
+/**********************************************************************************************************************/
+
 type Prepare struct {
 	Type      pbtypes.ConsensusMessageType `json:"type"`
 	ID        crypto.ID                    `json:"ID"`
 	Height    int64                        `json:"height"`
+	Round     int16                        `json:"round"`
 	Block     *Block                       `json:"block"`
 	Timestamp time.Time                    `json:"timestamp"`
 	Signature *bls12.Signature             `json:"signature"`
+	HighQC    *PreCommit                   `json:"high_qc"` // 认证Block的QC，被锁定的副本节点只有在该QC比自己锁定的QC更新时，才会为其他区块投票
+	TC        *TimeoutCert                 `json:"tc"`      // 两阶段共识里上一轮的超时证书，它本身携带了聚合签名，因此不在主节点的签名范围内
 }
 
-func NewPrepare(height int64, block *Block, id crypto.ID, privateKey *bls12.PrivateKey) *Prepare {
+func NewPrepare(height int64, round int16, block *Block, highQC *PreCommit, signer Signer) (*Prepare, error) {
 	p := &Prepare{
 		Type:      pbtypes.PrepareType,
-		ID:        id,
+		ID:        signer.PublicKey().ToID(),
 		Height:    height,
+		Round:     round,
 		Block:     block,
 		Timestamp: time.Now(),
+		HighQC:    highQC,
 	}
-	if len(block.ChameleonHash.Hash) == 0 {
-		panic("block's hash is empty")
+	if err := signer.SignPrepare(p); err != nil {
+		return nil, err
 	}
-	hash := make([]byte, len(block.ChameleonHash.Hash))
-	copy(hash[:], block.ChameleonHash.Hash)
-	var err error
-	p.Signature, err = privateKey.Sign(hash)
-	if err != nil {
-		panic(err)
-	}
-	return p
+	return p, nil
 }
 
 func (p *Prepare) ValidateBasic() error {
 	if p.Height < 0 {
 		return errors.New("negative height")
 	}
+	if p.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if p.HighQC != nil {
+		if p.HighQC.Height != p.Height {
+			return fmt.Errorf("high QC is from height %d, but Prepare is at height %d", p.HighQC.Height, p.Height)
+		}
+		if p.HighQC.Round >= p.Round {
+			return fmt.Errorf("high QC round %d must be less than Prepare round %d", p.HighQC.Round, p.Round)
+		}
+		if p.HighQC.AggregateSignature == nil {
+			return errors.New("high QC has no aggregate signature")
+		}
+	}
+	if p.TC != nil {
+		if err := p.TC.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid timeout certificate: %w", err)
+		}
+		if p.TC.Height != p.Height || p.TC.Round+1 != p.Round {
+			return fmt.Errorf("timeout certificate is for %d/%d, but Prepare is at %d/%d", p.TC.Height, p.TC.Round, p.Height, p.Round)
+		}
+	}
 	return nil
 }
 
 func (p *Prepare) ToProto() *pbtypes.Prepare {
 	return &pbtypes.Prepare{
+		Type:      p.Type,
+		ID:        string(p.ID),
 		Height:    p.Height,
+		Round:     int32(p.Round),
 		Block:     p.Block.ToProto(),
 		Timestamp: p.Timestamp,
 		Signature: p.Signature.ToProto(),
+		HighQC:    p.HighQC.ToProto(),
+		TC:        p.TC.ToProto(),
 	}
 }
 
@@ -98,9 +232,12 @@ func PrepareFromProto(pb *pbtypes.Prepare) *Prepare {
 		Type:      pb.Type,
 		ID:        crypto.ID(pb.ID),
 		Height:    pb.Height,
+		Round:     int16(pb.Round),
 		Block:     BlockFromProto(pb.Block),
 		Timestamp: pb.Timestamp,
 		Signature: bls12.SignatureFromProto(pb.Signature),
+		HighQC:    PreCommitFromProto(pb.HighQC),
+		TC:        TimeoutCertFromProto(pb.TC),
 	}
 }
 
@@ -117,19 +254,12 @@ type PrepareVote struct {
 	Vote *Vote `json:"vote"`
 }
 
-func NewPrepareVote(height int64, hash []byte, privateKey *bls12.PrivateKey) *PrepareVote {
-	vote := &PrepareVote{Vote: &Vote{
-		VoteType:  pbtypes.PrepareVoteType,
-		Height:    height,
-		ValueHash: hash,
-		Timestamp: time.Now(),
-	}}
-	var err error
-	vote.Vote.Signature, err = privateKey.Sign(vote.Vote.ValueHash)
+func NewPrepareVote(height int64, round int16, blockHash []byte, signer Signer) (*PrepareVote, error) {
+	vote, err := NewVote(pbtypes.PrepareVoteType, height, round, blockHash, signer)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return vote
+	return &PrepareVote{Vote: vote}, nil
 }
 
 func (pv *PrepareVote) ToProto() *pbtypes.PrepareVote {
@@ -156,17 +286,21 @@ type PreCommit struct {
 	Type               pbtypes.ConsensusMessageType `json:"type"`
 	ID                 crypto.ID                    `json:"ID"`
 	Height             int64                        `json:"height"`
+	Round              int16                        `json:"round"`
 	ValueHash          []byte                       `json:"value_hash"`
+	BlockHash          []byte                       `json:"block_hash"` // 被认证的区块的变色龙哈希，副本节点通过重新计算ValueHash来确认它
 	Timestamp          time.Time                    `json:"timestamp"`
 	AggregateSignature *bls12.AggregateSignature    `json:"aggregate_signature"` // 这个签名是对PrepareVote消息的聚合签名
 }
 
-func NewPreCommit(agg *bls12.AggregateSignature, hash []byte, id crypto.ID, height int64) *PreCommit {
+func NewPreCommit(agg *bls12.AggregateSignature, hash, blockHash []byte, id crypto.ID, height int64, round int16) *PreCommit {
 	return &PreCommit{
 		Type:               pbtypes.PreCommitType,
 		ID:                 id,
 		Height:             height,
+		Round:              round,
 		ValueHash:          hash,
+		BlockHash:          blockHash,
 		Timestamp:          time.Now(),
 		AggregateSignature: agg,
 	}
@@ -180,7 +314,9 @@ func (pc *PreCommit) ToProto() *pbtypes.PreCommit {
 		Type:               pc.Type,
 		ID:                 string(pc.ID),
 		Height:             pc.Height,
+		Round:              int32(pc.Round),
 		ValueHash:          pc.ValueHash[:],
+		BlockHash:          pc.BlockHash,
 		Timestamp:          pc.Timestamp,
 		AggregateSignature: pc.AggregateSignature.ToProto(),
 	}
@@ -196,7 +332,9 @@ func PreCommitFromProto(pb *pbtypes.PreCommit) *PreCommit {
 		Type:               pb.Type,
 		ID:                 crypto.ID(pb.ID),
 		Height:             pb.Height,
+		Round:              int16(pb.Round),
 		ValueHash:          hash,
+		BlockHash:          pb.BlockHash,
 		Timestamp:          pb.Timestamp,
 		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
 	}
@@ -210,19 +348,12 @@ type PreCommitVote struct {
 	Vote *Vote `json:"vote"`
 }
 
-func NewPreCommitVote(height int64, hash []byte, privateKey *bls12.PrivateKey) *PreCommitVote {
-	vote := &PreCommitVote{Vote: &Vote{
-		VoteType:  pbtypes.PreCommitVoteType,
-		Height:    height,
-		ValueHash: hash,
-		Timestamp: time.Now(),
-	}}
-	var err error
-	vote.Vote.Signature, err = privateKey.Sign(vote.Vote.ValueHash)
+func NewPreCommitVote(height int64, round int16, blockHash []byte, signer Signer) (*PreCommitVote, error) {
+	vote, err := NewVote(pbtypes.PreCommitVoteType, height, round, blockHash, signer)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return vote
+	return &PreCommitVote{Vote: vote}, nil
 }
 
 func (pcv *PreCommitVote) ToProto() *pbtypes.PreCommitVote {
@@ -249,17 +380,21 @@ type Commit struct {
 	Type               pbtypes.ConsensusMessageType `json:"type"`
 	ID                 crypto.ID                    `json:"ID"`
 	Height             int64                        `json:"height"`
+	Round              int16                        `json:"round"`
 	ValueHash          []byte                       `json:"value_hash"`
+	BlockHash          []byte                       `json:"block_hash"` // 被认证的区块的变色龙哈希，副本节点通过重新计算ValueHash来确认它
 	Timestamp          time.Time                    `json:"timestamp"`
 	AggregateSignature *bls12.AggregateSignature    `json:"aggregate_signature"`
 }
 
-func NewCommit(agg *bls12.AggregateSignature, hash []byte, id crypto.ID, height int64) *Commit {
+func NewCommit(agg *bls12.AggregateSignature, hash, blockHash []byte, id crypto.ID, height int64, round int16) *Commit {
 	return &Commit{
 		Type:               pbtypes.CommitType,
 		ID:                 id,
 		Height:             height,
+		Round:              round,
 		ValueHash:          hash,
+		BlockHash:          blockHash,
 		Timestamp:          time.Now(),
 		AggregateSignature: agg,
 	}
@@ -273,7 +408,9 @@ func (c *Commit) ToProto() *pbtypes.Commit {
 		Type:               c.Type,
 		ID:                 string(c.ID),
 		Height:             c.Height,
+		Round:              int32(c.Round),
 		ValueHash:          c.ValueHash[:],
+		BlockHash:          c.BlockHash,
 		Timestamp:          c.Timestamp,
 		AggregateSignature: c.AggregateSignature.ToProto(),
 	}
@@ -289,7 +426,9 @@ func CommitFromProto(pb *pbtypes.Commit) *Commit {
 		Type:               pb.Type,
 		ID:                 crypto.ID(pb.ID),
 		Height:             pb.Height,
+		Round:              int16(pb.Round),
 		ValueHash:          hash,
+		BlockHash:          pb.BlockHash,
 		Timestamp:          pb.Timestamp,
 		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
 	}
@@ -303,19 +442,12 @@ type CommitVote struct {
 	Vote *Vote `json:"vote"`
 }
 
-func NewCommitVote(height int64, hash []byte, privateKey *bls12.PrivateKey) *CommitVote {
-	vote := &CommitVote{Vote: &Vote{
-		VoteType:  pbtypes.CommitVoteType,
-		Height:    height,
-		ValueHash: hash,
-		Timestamp: time.Now(),
-	}}
-	var err error
-	vote.Vote.Signature, err = privateKey.Sign(vote.Vote.ValueHash)
+func NewCommitVote(height int64, round int16, blockHash []byte, signer Signer) (*CommitVote, error) {
+	vote, err := NewVote(pbtypes.CommitVoteType, height, round, blockHash, signer)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
-	return vote
+	return &CommitVote{Vote: vote}, nil
 }
 
 func (cv *CommitVote) ToProto() *pbtypes.CommitVote {
@@ -342,17 +474,21 @@ type Decide struct {
 	Type               pbtypes.ConsensusMessageType `json:"type"`
 	ID                 crypto.ID                    `json:"ID"`
 	Height             int64                        `json:"height"`
+	Round              int16                        `json:"round"`
 	ValueHash          []byte                       `json:"value_hash"`
+	BlockHash          []byte                       `json:"block_hash"` // 被认证的区块的变色龙哈希，副本节点通过重新计算ValueHash来确认它
 	Timestamp          time.Time                    `json:"timestamp"`
 	AggregateSignature *bls12.AggregateSignature
 }
 
-func NewDecide(agg *bls12.AggregateSignature, hash []byte, id crypto.ID, height int64) *Decide {
+func NewDecide(agg *bls12.AggregateSignature, hash, blockHash []byte, id crypto.ID, height int64, round int16) *Decide {
 	return &Decide{
 		Type:               pbtypes.DecideType,
 		ID:                 id,
 		Height:             height,
+		Round:              round,
 		ValueHash:          hash,
+		BlockHash:          blockHash,
 		Timestamp:          time.Now(),
 		AggregateSignature: agg,
 	}
@@ -366,7 +502,9 @@ func (d *Decide) ToProto() *pbtypes.Decide {
 		Type:               d.Type,
 		ID:                 string(d.ID),
 		Height:             d.Height,
+		Round:              int32(d.Round),
 		ValueHash:          d.ValueHash[:],
+		BlockHash:          d.BlockHash,
 		Timestamp:          d.Timestamp,
 		AggregateSignature: d.AggregateSignature.ToProto(),
 	}
@@ -382,7 +520,9 @@ func DecideFromProto(pb *pbtypes.Decide) *Decide {
 		Type:               pb.Type,
 		ID:                 crypto.ID(pb.ID),
 		Height:             pb.Height,
+		Round:              int16(pb.Round),
 		ValueHash:          hash,
+		BlockHash:          pb.BlockHash,
 		Timestamp:          pb.Timestamp,
 		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
 	}
@@ -392,32 +532,54 @@ func (d *Decide) ValidateBasic() error {
 	return nil
 }
 
-func GeneratePrepareVoteValueHash(blockHash []byte) []byte {
-	value := append([]byte("PrepareVote-"), blockHash...)
+// GeneratePrepareSignBytes 主节点为Prepare消息签名的内容，签名同时绑定了区块高度、轮次和区块哈希，
+// 这样主节点在同一高度、同一轮次提出两个不同区块的行为就可以被证明。
+func GeneratePrepareSignBytes(height int64, round int16, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("Prepare-%d-%d-", height, round)), blockHash...)
+	h := sha256.Sum(value)
+	return h[:]
+}
+
+// GenerateVoteValueHash 根据投票类型计算投票需要签名的值。
+func GenerateVoteValueHash(typ pbtypes.VoteType, height int64, round int16, blockHash []byte) []byte {
+	switch typ {
+	case pbtypes.PrepareVoteType:
+		return GeneratePrepareVoteValueHash(height, round, blockHash)
+	case pbtypes.PreCommitVoteType:
+		return GeneratePreCommitVoteValueHash(height, round, blockHash)
+	case pbtypes.CommitVoteType:
+		return GenerateCommitVoteValueHash(height, round, blockHash)
+	default:
+		panic(fmt.Sprintf("unknown vote type: %v", typ))
+	}
+}
+
+func GeneratePrepareVoteValueHash(height int64, round int16, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("PrepareVote-%d-%d-", height, round)), blockHash...)
 	h := sha256.Sum(value)
 	return h[:]
 }
 
-func GeneratePreCommitValueHash(blockHash []byte) []byte {
-	return GeneratePrepareVoteValueHash(blockHash)
+func GeneratePreCommitValueHash(height int64, round int16, blockHash []byte) []byte {
+	return GeneratePrepareVoteValueHash(height, round, blockHash)
 }
 
-func GeneratePreCommitVoteValueHash(blockHash []byte) []byte {
-	value := append([]byte("PreCommitVote-"), blockHash...)
+func GeneratePreCommitVoteValueHash(height int64, round int16, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("PreCommitVote-%d-%d-", height, round)), blockHash...)
 	h := sha256.Sum(value)
 	return h[:]
 }
 
-func GenerateCommitValueHash(blockHash []byte) []byte {
-	return GeneratePreCommitVoteValueHash(blockHash)
+func GenerateCommitValueHash(height int64, round int16, blockHash []byte) []byte {
+	return GeneratePreCommitVoteValueHash(height, round, blockHash)
 }
 
-func GenerateCommitVoteValueHash(blockHash []byte) []byte {
-	value := append([]byte("CommitVote-"), blockHash...)
+func GenerateCommitVoteValueHash(height int64, round int16, blockHash []byte) []byte {
+	value := append([]byte(fmt.Sprintf("CommitVote-%d-%d-", height, round)), blockHash...)
 	h := sha256.Sum(value)
 	return h[:]
 }
 
-func GenerateDecideValueHash(blockHash []byte) []byte {
-	return GenerateCommitVoteValueHash(blockHash)
+func GenerateDecideValueHash(height int64, round int16, blockHash []byte) []byte {
+	return GenerateCommitVoteValueHash(height, round, blockHash)
 }
@@ -0,0 +1,385 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/merkle"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/proto/pbabci"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"time"
+)
+
+// Evidence 验证者作恶的证据，证据里包含了作恶者自己的签名，因此任何节点都可以独立地验证证据的真伪。
+type Evidence interface {
+	Height() int64                         // 作恶行为发生的区块高度
+	Offender() crypto.ID                   // 作恶的验证者
+	Hash() []byte                          // 证据的哈希值，用来唯一标识一份证据
+	ValidateBasic() error                  // 不依赖状态的基本检查
+	Verify(validators *ValidatorSet) error // 验证作恶者是给定验证者集合里的成员，并且证据里的签名都是合法的
+	String() string
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// DuplicateVoteEvidence 同一个验证者在同一高度、同一轮次为两个不同的区块投了同一类型的票。
+type DuplicateVoteEvidence struct {
+	VoteA     *Vote     `json:"vote_a"`
+	VoteB     *Vote     `json:"vote_b"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// NewDuplicateVoteEvidence 两张投票按照ValueHash排序，保证不同节点对同一对投票构造出的证据是一样的。
+func NewDuplicateVoteEvidence(vote1, vote2 *Vote, timestamp time.Time) *DuplicateVoteEvidence {
+	if vote1 == nil || vote2 == nil {
+		return nil
+	}
+	voteA, voteB := vote1, vote2
+	if bytes.Compare(vote1.ValueHash, vote2.ValueHash) > 0 {
+		voteA, voteB = vote2, vote1
+	}
+	return &DuplicateVoteEvidence{VoteA: voteA, VoteB: voteB, Timestamp: timestamp}
+}
+
+func (dve *DuplicateVoteEvidence) Height() int64 {
+	return dve.VoteA.Height
+}
+
+func (dve *DuplicateVoteEvidence) Offender() crypto.ID {
+	return dve.VoteA.Signature.Signer()
+}
+
+// Hash 投票里的时间戳没有被签名，可以被转发者随意修改，所以证据的哈希值只由作恶者、投票类型、高度、轮次和两个区块
+// 哈希决定，这样同一次作恶行为只会被记录一次。
+func (dve *DuplicateVoteEvidence) Hash() []byte {
+	return evidenceHash(fmt.Sprintf("DuplicateVote-%s-%d-%d-%d-", dve.Offender(), dve.VoteA.VoteType, dve.VoteA.Height, dve.VoteA.Round), dve.VoteA.BlockHash, dve.VoteB.BlockHash)
+}
+
+func (dve *DuplicateVoteEvidence) ValidateBasic() error {
+	if dve == nil {
+		return errors.New("nil duplicate vote evidence")
+	}
+	if dve.VoteA == nil || dve.VoteB == nil {
+		return errors.New("one or both of the votes are empty")
+	}
+	if err := dve.VoteA.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid VoteA: %w", err)
+	}
+	if err := dve.VoteB.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid VoteB: %w", err)
+	}
+	if dve.VoteA.VoteType != dve.VoteB.VoteType || dve.VoteA.Height != dve.VoteB.Height || dve.VoteA.Round != dve.VoteB.Round {
+		return errors.New("votes are not for the same type, height and round")
+	}
+	if dve.VoteA.Signature.Signer() != dve.VoteB.Signature.Signer() {
+		return errors.New("votes are signed by different validators")
+	}
+	if bytes.Equal(dve.VoteA.BlockHash, dve.VoteB.BlockHash) {
+		return errors.New("votes are for the same block")
+	}
+	if bytes.Compare(dve.VoteA.ValueHash, dve.VoteB.ValueHash) >= 0 {
+		return errors.New("votes are not in order")
+	}
+	return nil
+}
+
+func (dve *DuplicateVoteEvidence) Verify(validators *ValidatorSet) error {
+	if err := dve.ValidateBasic(); err != nil {
+		return err
+	}
+	validator := validators.GetValidatorByID(dve.Offender())
+	if validator == nil {
+		return fmt.Errorf("offender %s is not a validator", dve.Offender())
+	}
+	if !validator.PublicKey.Verify(dve.VoteA.Signature, dve.VoteA.ValueHash) {
+		return errors.New("invalid signature of VoteA")
+	}
+	if !validator.PublicKey.Verify(dve.VoteB.Signature, dve.VoteB.ValueHash) {
+		return errors.New("invalid signature of VoteB")
+	}
+	return nil
+}
+
+func (dve *DuplicateVoteEvidence) String() string {
+	return fmt.Sprintf("DuplicateVoteEvidence{Offender: %s, Type: %s, Height: %d, Round: %d, BlockHashA: %x, BlockHashB: %x}",
+		dve.Offender(), dve.VoteA.VoteType, dve.VoteA.Height, dve.VoteA.Round, dve.VoteA.BlockHash, dve.VoteB.BlockHash)
+}
+
+func (dve *DuplicateVoteEvidence) ToProto() *pbtypes.DuplicateVoteEvidence {
+	if dve == nil {
+		return nil
+	}
+	return &pbtypes.DuplicateVoteEvidence{
+		VoteA:     dve.VoteA.ToProto(),
+		VoteB:     dve.VoteB.ToProto(),
+		Timestamp: dve.Timestamp,
+	}
+}
+
+func DuplicateVoteEvidenceFromProto(pb *pbtypes.DuplicateVoteEvidence) *DuplicateVoteEvidence {
+	if pb == nil {
+		return nil
+	}
+	return &DuplicateVoteEvidence{
+		VoteA:     VoteFromProto(pb.VoteA),
+		VoteB:     VoteFromProto(pb.VoteB),
+		Timestamp: pb.Timestamp,
+	}
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// ConflictingPrepareEvidence 主节点在同一高度、同一轮次为两个不同的区块签发了Prepare消息。
+type ConflictingPrepareEvidence struct {
+	Proposer      crypto.ID        `json:"proposer"`
+	PrepareHeight int64            `json:"height"`
+	PrepareRound  int16            `json:"round"`
+	BlockHashA    []byte           `json:"block_hash_a"`
+	SignatureA    *bls12.Signature `json:"signature_a"`
+	BlockHashB    []byte           `json:"block_hash_b"`
+	SignatureB    *bls12.Signature `json:"signature_b"`
+	Timestamp     time.Time        `json:"timestamp"`
+}
+
+// NewConflictingPrepareEvidence 两个Prepare消息按照区块哈希排序，保证不同节点构造出的证据是一样的。
+func NewConflictingPrepareEvidence(prepare1, prepare2 *Prepare, timestamp time.Time) *ConflictingPrepareEvidence {
+	if prepare1 == nil || prepare2 == nil || prepare1.Block == nil || prepare2.Block == nil {
+		return nil
+	}
+	a, b := prepare1, prepare2
+	if bytes.Compare(prepare1.Block.ChameleonHash.Hash, prepare2.Block.ChameleonHash.Hash) > 0 {
+		a, b = prepare2, prepare1
+	}
+	return &ConflictingPrepareEvidence{
+		Proposer:      a.ID,
+		PrepareHeight: a.Height,
+		PrepareRound:  a.Round,
+		BlockHashA:    a.Block.ChameleonHash.Hash,
+		SignatureA:    a.Signature,
+		BlockHashB:    b.Block.ChameleonHash.Hash,
+		SignatureB:    b.Signature,
+		Timestamp:     timestamp,
+	}
+}
+
+func (cpe *ConflictingPrepareEvidence) Height() int64 {
+	return cpe.PrepareHeight
+}
+
+func (cpe *ConflictingPrepareEvidence) Offender() crypto.ID {
+	return cpe.Proposer
+}
+
+func (cpe *ConflictingPrepareEvidence) Hash() []byte {
+	return evidenceHash(fmt.Sprintf("ConflictingPrepare-%s-%d-%d-", cpe.Proposer, cpe.PrepareHeight, cpe.PrepareRound), cpe.BlockHashA, cpe.BlockHashB)
+}
+
+func (cpe *ConflictingPrepareEvidence) ValidateBasic() error {
+	if cpe == nil {
+		return errors.New("nil conflicting prepare evidence")
+	}
+	if cpe.PrepareHeight < 0 {
+		return errors.New("negative height")
+	}
+	if cpe.PrepareRound < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if len(cpe.BlockHashA) == 0 || len(cpe.BlockHashB) == 0 {
+		return errors.New("empty block hash")
+	}
+	if bytes.Compare(cpe.BlockHashA, cpe.BlockHashB) >= 0 {
+		return errors.New("block hashes are equal or not in order")
+	}
+	if cpe.SignatureA == nil || cpe.SignatureB == nil {
+		return errors.New("one or both of the signatures are empty")
+	}
+	if cpe.SignatureA.Signer() != cpe.Proposer || cpe.SignatureB.Signer() != cpe.Proposer {
+		return errors.New("signatures are not signed by the proposer")
+	}
+	return nil
+}
+
+func (cpe *ConflictingPrepareEvidence) Verify(validators *ValidatorSet) error {
+	if err := cpe.ValidateBasic(); err != nil {
+		return err
+	}
+	validator := validators.GetValidatorByID(cpe.Proposer)
+	if validator == nil {
+		return fmt.Errorf("proposer %s is not a validator", cpe.Proposer)
+	}
+	if !validator.PublicKey.Verify(cpe.SignatureA, GeneratePrepareSignBytes(cpe.PrepareHeight, cpe.PrepareRound, cpe.BlockHashA)) {
+		return errors.New("invalid signature of the first Prepare")
+	}
+	if !validator.PublicKey.Verify(cpe.SignatureB, GeneratePrepareSignBytes(cpe.PrepareHeight, cpe.PrepareRound, cpe.BlockHashB)) {
+		return errors.New("invalid signature of the second Prepare")
+	}
+	return nil
+}
+
+func (cpe *ConflictingPrepareEvidence) String() string {
+	return fmt.Sprintf("ConflictingPrepareEvidence{Proposer: %s, Height: %d, Round: %d, BlockHashA: %x, BlockHashB: %x}",
+		cpe.Proposer, cpe.PrepareHeight, cpe.PrepareRound, cpe.BlockHashA, cpe.BlockHashB)
+}
+
+func (cpe *ConflictingPrepareEvidence) ToProto() *pbtypes.ConflictingPrepareEvidence {
+	if cpe == nil {
+		return nil
+	}
+	pb := &pbtypes.ConflictingPrepareEvidence{
+		Proposer:   string(cpe.Proposer),
+		Height:     cpe.PrepareHeight,
+		Round:      int32(cpe.PrepareRound),
+		BlockHashA: cpe.BlockHashA,
+		BlockHashB: cpe.BlockHashB,
+		Timestamp:  cpe.Timestamp,
+	}
+	if cpe.SignatureA != nil {
+		pb.SignatureA = cpe.SignatureA.ToProto()
+	}
+	if cpe.SignatureB != nil {
+		pb.SignatureB = cpe.SignatureB.ToProto()
+	}
+	return pb
+}
+
+func ConflictingPrepareEvidenceFromProto(pb *pbtypes.ConflictingPrepareEvidence) *ConflictingPrepareEvidence {
+	if pb == nil {
+		return nil
+	}
+	cpe := &ConflictingPrepareEvidence{
+		Proposer:      crypto.ID(pb.Proposer),
+		PrepareHeight: pb.Height,
+		PrepareRound:  int16(pb.Round),
+		BlockHashA:    pb.BlockHashA,
+		BlockHashB:    pb.BlockHashB,
+		Timestamp:     pb.Timestamp,
+	}
+	if pb.SignatureA != nil {
+		cpe.SignatureA = bls12.SignatureFromProto(pb.SignatureA)
+	}
+	if pb.SignatureB != nil {
+		cpe.SignatureB = bls12.SignatureFromProto(pb.SignatureB)
+	}
+	return cpe
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// EvidenceList 区块里携带的证据列表。
+type EvidenceList []Evidence
+
+// Hash 计算证据列表的默克尔根。
+func (evl EvidenceList) Hash() []byte {
+	hashes := make([][]byte, len(evl))
+	for i, ev := range evl {
+		hashes[i] = ev.Hash()
+	}
+	return merkle.ComputeMerkleRoot(hashes)
+}
+
+// ByteSize 返回证据列表序列化后的总字节数。
+func (evl EvidenceList) ByteSize() int64 {
+	var size int64
+	for _, ev := range evl {
+		size += int64(EvidenceToProto(ev).Size())
+	}
+	return size
+}
+
+// Has 判断列表里是否已经包含了给定的证据。
+func (evl EvidenceList) Has(evidence Evidence) bool {
+	for _, ev := range evl {
+		if bytes.Equal(ev.Hash(), evidence.Hash()) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToABCI 将证据转换为应用层能够理解的形式，应用层根据作恶者的公钥对其进行惩罚。作恶者的投票权取自validatorsAt返回的
+// 作恶高度上的验证者集合，而不是当前的验证者集合。
+func (evl EvidenceList) ToABCI(validatorsAt func(height int64) *ValidatorSet) []pbabci.Evidence {
+	res := make([]pbabci.Evidence, 0, len(evl))
+	for _, ev := range evl {
+		validator := validatorsAt(ev.Height()).GetValidatorByID(ev.Offender())
+		if validator == nil {
+			continue
+		}
+		res = append(res, pbabci.Evidence{
+			Validator: pbabci.ValidatorUpdate{
+				BLS12PublicKey: validator.PublicKey.ToProto(),
+				Power:          validator.VotingPower,
+			},
+			Height: ev.Height(),
+		})
+	}
+	return res
+}
+
+func (evl EvidenceList) ToProto() *pbtypes.EvidenceList {
+	if len(evl) == 0 {
+		return nil
+	}
+	pb := &pbtypes.EvidenceList{Evidence: make([]*pbtypes.Evidence, len(evl))}
+	for i, ev := range evl {
+		pb.Evidence[i] = EvidenceToProto(ev)
+	}
+	return pb
+}
+
+func EvidenceListFromProto(pb *pbtypes.EvidenceList) (EvidenceList, error) {
+	if pb == nil || len(pb.Evidence) == 0 {
+		return nil, nil
+	}
+	evl := make(EvidenceList, len(pb.Evidence))
+	for i, ev := range pb.Evidence {
+		evidence, err := EvidenceFromProto(ev)
+		if err != nil {
+			return nil, err
+		}
+		evl[i] = evidence
+	}
+	return evl, nil
+}
+
+func EvidenceToProto(evidence Evidence) *pbtypes.Evidence {
+	switch ev := evidence.(type) {
+	case *DuplicateVoteEvidence:
+		return &pbtypes.Evidence{Sum: &pbtypes.Evidence_DuplicateVoteEvidence{DuplicateVoteEvidence: ev.ToProto()}}
+	case *ConflictingPrepareEvidence:
+		return &pbtypes.Evidence{Sum: &pbtypes.Evidence_ConflictingPrepareEvidence{ConflictingPrepareEvidence: ev.ToProto()}}
+	default:
+		panic(fmt.Sprintf("unknown evidence type: %T", evidence))
+	}
+}
+
+func EvidenceFromProto(pb *pbtypes.Evidence) (Evidence, error) {
+	if pb == nil {
+		return nil, errors.New("nil evidence")
+	}
+	switch ev := pb.Sum.(type) {
+	case *pbtypes.Evidence_DuplicateVoteEvidence:
+		if ev.DuplicateVoteEvidence == nil {
+			return nil, errors.New("nil duplicate vote evidence")
+		}
+		return DuplicateVoteEvidenceFromProto(ev.DuplicateVoteEvidence), nil
+	case *pbtypes.Evidence_ConflictingPrepareEvidence:
+		if ev.ConflictingPrepareEvidence == nil {
+			return nil, errors.New("nil conflicting prepare evidence")
+		}
+		return ConflictingPrepareEvidenceFromProto(ev.ConflictingPrepareEvidence), nil
+	default:
+		return nil, fmt.Errorf("unknown evidence type: %T", pb.Sum)
+	}
+}
+
+func evidenceHash(prefix string, hashA, hashB []byte) []byte {
+	value := append([]byte(prefix), hashA...)
+	value = append(value, '-')
+	value = append(value, hashB...)
+	h := sha256.Sum(value)
+	return h[:]
+}
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDuplicateVoteEvidence(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	set := NewValidatorSet([]*Validator{NewValidator(privateKey.PublicKey(), 10)})
+
+	voteA := mustNewVote(t, pbtypes.PrepareVoteType, 3, 1, []byte("block a"), privateKey)
+	voteB := mustNewVote(t, pbtypes.PrepareVoteType, 3, 1, []byte("block b"), privateKey)
+	ev := NewDuplicateVoteEvidence(voteA, voteB, time.Now())
+	assert.Nil(t, ev.Verify(set))
+	assert.Equal(t, privateKey.PublicKey().ToID(), ev.Offender())
+	assert.Equal(t, int64(3), ev.Height())
+
+	// 两张投票的顺序不影响证据
+	assert.Equal(t, ev.Hash(), NewDuplicateVoteEvidence(voteB, voteA, time.Now()).Hash())
+
+	// 经过序列化之后依然可以验证
+	evl, err := EvidenceListFromProto(EvidenceList{ev}.ToProto())
+	assert.Nil(t, err)
+	assert.Nil(t, evl[0].Verify(set))
+	assert.Equal(t, ev.Hash(), evl[0].Hash())
+
+	// 不同轮次的投票不是作恶
+	voteC := mustNewVote(t, pbtypes.PrepareVoteType, 3, 2, []byte("block b"), privateKey)
+	assert.NotNil(t, NewDuplicateVoteEvidence(voteA, voteC, time.Now()).ValidateBasic())
+
+	// 不同验证者的投票不是作恶
+	other, _ := bls12.GeneratePrivateKey()
+	voteD := mustNewVote(t, pbtypes.PrepareVoteType, 3, 1, []byte("block b"), other)
+	assert.NotNil(t, NewDuplicateVoteEvidence(voteA, voteD, time.Now()).ValidateBasic())
+
+	// 篡改轮次后签名不再合法
+	voteE := mustNewVote(t, pbtypes.PrepareVoteType, 3, 2, []byte("block c"), privateKey)
+	voteE.Round = 1
+	assert.NotNil(t, NewDuplicateVoteEvidence(voteA, voteE, time.Now()).Verify(set))
+
+	abci := EvidenceList{ev}.ToABCI(func(int64) *ValidatorSet { return set })
+	assert.Equal(t, 1, len(abci))
+	assert.Equal(t, int64(10), abci[0].Validator.Power)
+	assert.Equal(t, int64(3), abci[0].Height)
+}
+
+func TestConflictingPrepareEvidence(t *testing.T) {
+	privateKey, _ := bls12.GeneratePrivateKey()
+	set := NewValidatorSet([]*Validator{NewValidator(privateKey.PublicKey(), 10)})
+	id := privateKey.PublicKey().ToID()
+	newBlock := func(hash string) *Block {
+		return &Block{Header: &Header{Height: 5}, Body: &Data{}, ChameleonHash: &ChameleonHash{Hash: []byte(hash)}}
+	}
+
+	prepareA, _ := NewPrepare(5, 2, newBlock("block a"), nil, NewPrivateKeySigner(privateKey))
+	prepareB, _ := NewPrepare(5, 2, newBlock("block b"), nil, NewPrivateKeySigner(privateKey))
+	ev := NewConflictingPrepareEvidence(prepareB, prepareA, time.Now())
+	assert.Nil(t, ev.Verify(set))
+	assert.Equal(t, id, ev.Offender())
+
+	evl, err := EvidenceListFromProto(EvidenceList{ev}.ToProto())
+	assert.Nil(t, err)
+	assert.Nil(t, evl[0].Verify(set))
+
+	// 不同轮次的Prepare消息签名不能被挪用
+	prepareC, _ := NewPrepare(5, 3, newBlock("block c"), nil, NewPrivateKeySigner(privateKey))
+	forged := NewConflictingPrepareEvidence(prepareA, prepareC, time.Now())
+	forged.PrepareRound = 2
+	assert.NotNil(t, forged.Verify(set))
+
+	// 相同的区块不是作恶
+	assert.NotNil(t, NewConflictingPrepareEvidence(prepareA, prepareA, time.Now()).ValidateBasic())
+}
+
+func mustNewVote(t *testing.T, typ pbtypes.VoteType, height int64, round int16, blockHash []byte, privateKey *bls12.PrivateKey) *Vote {
+	vote, err := NewVote(typ, height, round, blockHash, NewPrivateKeySigner(privateKey))
+	assert.Nil(t, err)
+	return vote
+}
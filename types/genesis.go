@@ -1,15 +1,28 @@
 package types
 
 import (
+	"fmt"
 	mos "github.com/232425wxy/meta--/common/os"
 	mjson "github.com/232425wxy/meta--/json"
 	"time"
 )
 
 type Genesis struct {
-	GenesisTime   time.Time    `json:"genesis_time"`
-	InitialHeight int64        `json:"initial_height"`
-	Validators    []*Validator `json:"validators"`
+	GenesisTime     time.Time        `json:"genesis_time"`
+	InitialHeight   int64            `json:"initial_height"`
+	Validators      []*Validator     `json:"validators"`
+	ConsensusParams *ConsensusParams `json:"consensus_params"`
+}
+
+// ValidateAndComplete 检查创世文件里的共识参数，没有设置共识参数时使用默认的共识参数。
+func (gen *Genesis) ValidateAndComplete() error {
+	if gen.ConsensusParams == nil {
+		gen.ConsensusParams = DefaultConsensusParams()
+	}
+	if err := gen.ConsensusParams.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid consensus params in genesis: %w", err)
+	}
+	return nil
 }
 
 func (gen *Genesis) SaveAs(file string) error {
@@ -29,5 +42,8 @@ func GenesisReadFromFile(file string) (*Genesis, error) {
 	if err = mjson.Decode(bz, gen); err != nil {
 		return nil, err
 	}
+	if err = gen.ValidateAndComplete(); err != nil {
+		return nil, err
+	}
 	return gen, nil
 }
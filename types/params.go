@@ -0,0 +1,155 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"time"
+)
+
+const (
+	// MaxBlockSizeBytes 区块里交易数据总大小的上限，共识参数里的BlockParams.MaxBytes不能超过它。
+	MaxBlockSizeBytes = 100 * 1024 * 1024
+
+	// BLS12PublicKeyType 验证者BLS12-381公钥的类型名称。
+	BLS12PublicKeyType = "bls12-381"
+)
+
+// ConsensusParams 所有节点必须保持一致的共识参数，包括区块大小的限制、证据大小的限制、验证者允许使用的公钥类型以及
+// 共识各阶段之间的等待时间。初始的共识参数写在创世文件里，之后可以由应用在EndBlock里更新。
+type ConsensusParams struct {
+	Block     BlockParams     `json:"block"`
+	Evidence  EvidenceParams  `json:"evidence"`
+	Validator ValidatorParams `json:"validator"`
+	Timeout   TimeoutParams   `json:"timeout"`
+}
+
+// BlockParams 区块里交易数据的总字节数以及交易数量的上限。
+type BlockParams struct {
+	MaxBytes int64 `json:"max_bytes"`
+	MaxTxs   int64 `json:"max_txs"`
+}
+
+// EvidenceParams 一个区块里最多可以携带的证据的字节数。
+type EvidenceParams struct {
+	MaxBytes int64 `json:"max_bytes"`
+}
+
+// ValidatorParams 验证者允许使用的公钥类型。
+type ValidatorParams struct {
+	PublicKeyTypes []string `json:"public_key_types"`
+}
+
+// TimeoutParams 主节点收集齐投票后进入下一个阶段之前的等待时间，以及确认一个区块后进入下一个高度之前的等待时间。
+type TimeoutParams struct {
+	StepDelay      time.Duration `json:"step_delay"`
+	NewHeightDelay time.Duration `json:"new_height_delay"`
+}
+
+func DefaultConsensusParams() *ConsensusParams {
+	return &ConsensusParams{
+		Block: BlockParams{
+			MaxBytes: 1024 * 1024, // 1MB
+			MaxTxs:   2000,
+		},
+		Evidence: EvidenceParams{
+			MaxBytes: 1024 * 64, // 64KB
+		},
+		Validator: ValidatorParams{
+			PublicKeyTypes: []string{BLS12PublicKeyType},
+		},
+		Timeout: TimeoutParams{
+			StepDelay:      100 * time.Millisecond,
+			NewHeightDelay: time.Second,
+		},
+	}
+}
+
+func (params *ConsensusParams) ValidateBasic() error {
+	if params.Block.MaxBytes <= 0 {
+		return fmt.Errorf("block.max_bytes must be greater than 0, got %d", params.Block.MaxBytes)
+	}
+	if params.Block.MaxBytes > MaxBlockSizeBytes {
+		return fmt.Errorf("block.max_bytes is too big: %d > %d", params.Block.MaxBytes, MaxBlockSizeBytes)
+	}
+	if params.Block.MaxTxs <= 0 {
+		return fmt.Errorf("block.max_txs must be greater than 0, got %d", params.Block.MaxTxs)
+	}
+	if params.Evidence.MaxBytes < 0 {
+		return fmt.Errorf("evidence.max_bytes must be non-negative, got %d", params.Evidence.MaxBytes)
+	}
+	if len(params.Validator.PublicKeyTypes) == 0 {
+		return errors.New("validator.public_key_types must not be empty")
+	}
+	for _, typ := range params.Validator.PublicKeyTypes {
+		if typ != BLS12PublicKeyType {
+			return fmt.Errorf("unknown public key type: %s", typ)
+		}
+	}
+	if params.Timeout.StepDelay < 0 || params.Timeout.NewHeightDelay < 0 {
+		return errors.New("timeout delays must be non-negative")
+	}
+	return nil
+}
+
+// IsValidPublicKeyType 判断验证者是否可以使用给定类型的公钥。
+func (params *ConsensusParams) IsValidPublicKeyType(typ string) bool {
+	for _, t := range params.Validator.PublicKeyTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Update 用应用在EndBlock里返回的共识参数更新当前的共识参数，返回一份新的共识参数，updates里为空的部分保持不变。
+func (params *ConsensusParams) Update(updates *pbtypes.ConsensusParams) *ConsensusParams {
+	res := params.Copy()
+	if updates == nil {
+		return res
+	}
+	if updates.Block != nil {
+		res.Block.MaxBytes = updates.Block.MaxBytes
+		res.Block.MaxTxs = updates.Block.MaxTxs
+	}
+	if updates.Evidence != nil {
+		res.Evidence.MaxBytes = updates.Evidence.MaxBytes
+	}
+	if updates.Validator != nil {
+		res.Validator.PublicKeyTypes = append([]string{}, updates.Validator.PublicKeyTypes...)
+	}
+	if updates.Timeout != nil {
+		res.Timeout.StepDelay = updates.Timeout.StepDelay
+		res.Timeout.NewHeightDelay = updates.Timeout.NewHeightDelay
+	}
+	return res
+}
+
+func (params *ConsensusParams) Copy() *ConsensusParams {
+	if params == nil {
+		return nil
+	}
+	cp := *params
+	cp.Validator.PublicKeyTypes = append([]string{}, params.Validator.PublicKeyTypes...)
+	return &cp
+}
+
+func (params *ConsensusParams) ToProto() *pbtypes.ConsensusParams {
+	if params == nil {
+		return nil
+	}
+	return &pbtypes.ConsensusParams{
+		Block:     &pbtypes.BlockParams{MaxBytes: params.Block.MaxBytes, MaxTxs: params.Block.MaxTxs},
+		Evidence:  &pbtypes.EvidenceParams{MaxBytes: params.Evidence.MaxBytes},
+		Validator: &pbtypes.ValidatorParams{PublicKeyTypes: params.Validator.PublicKeyTypes},
+		Timeout:   &pbtypes.TimeoutParams{StepDelay: params.Timeout.StepDelay, NewHeightDelay: params.Timeout.NewHeightDelay},
+	}
+}
+
+// ConsensusParamsFromProto 将proto形式的共识参数转换为ConsensusParams，proto里为空的部分使用默认值。
+func ConsensusParamsFromProto(pb *pbtypes.ConsensusParams) *ConsensusParams {
+	if pb == nil {
+		return nil
+	}
+	return DefaultConsensusParams().Update(pb)
+}
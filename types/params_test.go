@@ -0,0 +1,51 @@
+package types
+
+import (
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestConsensusParamsValidateBasic(t *testing.T) {
+	params := DefaultConsensusParams()
+	assert.Nil(t, params.ValidateBasic())
+
+	params.Block.MaxBytes = MaxBlockSizeBytes + 1
+	assert.NotNil(t, params.ValidateBasic())
+
+	params = DefaultConsensusParams()
+	params.Validator.PublicKeyTypes = []string{"ed25519"}
+	assert.NotNil(t, params.ValidateBasic())
+}
+
+func TestConsensusParamsUpdate(t *testing.T) {
+	params := DefaultConsensusParams()
+	updated := params.Update(&pbtypes.ConsensusParams{
+		Timeout: &pbtypes.TimeoutParams{StepDelay: 50 * time.Millisecond, NewHeightDelay: 2 * time.Second},
+	})
+	assert.Equal(t, 50*time.Millisecond, updated.Timeout.StepDelay)
+	assert.Equal(t, params.Block, updated.Block)
+	// 原来的共识参数不受影响
+	assert.Equal(t, 100*time.Millisecond, params.Timeout.StepDelay)
+
+	assert.Equal(t, updated, ConsensusParamsFromProto(updated.ToProto()))
+}
+
+func TestGenesisConsensusParams(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "genesis.json")
+	gen := &Genesis{GenesisTime: time.Now(), InitialHeight: 1}
+	assert.Nil(t, gen.SaveAs(file))
+	loaded, err := GenesisReadFromFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultConsensusParams(), loaded.ConsensusParams)
+
+	gen.ConsensusParams = DefaultConsensusParams()
+	gen.ConsensusParams.Block.MaxTxs = 100
+	gen.ConsensusParams.Timeout.NewHeightDelay = 3 * time.Second
+	assert.Nil(t, gen.SaveAs(file))
+	loaded, err = GenesisReadFromFile(file)
+	assert.Nil(t, err)
+	assert.Equal(t, gen.ConsensusParams, loaded.ConsensusParams)
+}
@@ -0,0 +1,352 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto/merkle"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"sync"
+)
+
+// 区块分片。主节点把编码后的Prepare消息切分成固定大小的分片，所有分片组成一棵默克尔树，每个分片都携带自己在树里的
+// 证明，收到分片的节点可以只凭分片集合的头部验证分片，并把验证过的分片转发给其他节点，这样就不再受单条消息大小的限制，
+// 主节点也不必把完整的区块发送给每一个节点。
+
+// BlockPartSizeBytes 区块分片的大小。
+const BlockPartSizeBytes uint32 = 64 * 1024
+
+// Part 区块分片。
+type Part struct {
+	Index uint32       `json:"index"`
+	Bytes []byte       `json:"bytes"`
+	Proof merkle.Proof `json:"proof"`
+}
+
+func (part *Part) ValidateBasic() error {
+	if part == nil {
+		return errors.New("nil part")
+	}
+	if len(part.Bytes) > int(BlockPartSizeBytes) {
+		return fmt.Errorf("too big part: %d > %d", len(part.Bytes), BlockPartSizeBytes)
+	}
+	if uint64(part.Index) != part.Proof.Index {
+		return fmt.Errorf("part index %d does not match proof index %d", part.Index, part.Proof.Index)
+	}
+	return part.Proof.ValidateBasic()
+}
+
+func (part *Part) ToProto() *pbtypes.Part {
+	if part == nil {
+		return nil
+	}
+	return &pbtypes.Part{
+		Index: part.Index,
+		Bytes: part.Bytes,
+		Proof: part.Proof.ToProto(),
+	}
+}
+
+func PartFromProto(pb *pbtypes.Part) (*Part, error) {
+	if pb == nil {
+		return nil, errors.New("nil part")
+	}
+	proof, err := merkle.ProofFromProto(pb.Proof)
+	if err != nil {
+		return nil, err
+	}
+	return &Part{
+		Index: pb.Index,
+		Bytes: pb.Bytes,
+		Proof: *proof,
+	}, nil
+}
+
+// PartSetHeader 分片集合的头部，Hash是所有分片组成的默克尔树的根。
+type PartSetHeader struct {
+	Total uint32 `json:"total"`
+	Hash  []byte `json:"hash"`
+}
+
+func (psh PartSetHeader) Equals(other PartSetHeader) bool {
+	return psh.Total == other.Total && bytes.Equal(psh.Hash, other.Hash)
+}
+
+func (psh PartSetHeader) ValidateBasic() error {
+	if psh.Total == 0 {
+		return errors.New("empty part set")
+	}
+	if len(psh.Hash) == 0 {
+		return errors.New("empty part set hash")
+	}
+	return nil
+}
+
+func (psh PartSetHeader) ToProto() pbtypes.PartSetHeader {
+	return pbtypes.PartSetHeader{
+		Total: psh.Total,
+		Hash:  psh.Hash,
+	}
+}
+
+func PartSetHeaderFromProto(pb pbtypes.PartSetHeader) PartSetHeader {
+	return PartSetHeader{
+		Total: pb.Total,
+		Hash:  pb.Hash,
+	}
+}
+
+// PartSet 分片集合，主节点用完整的数据创建它，其他节点用主节点发来的头部创建它，然后逐个添加收到的分片。
+type PartSet struct {
+	mu       sync.Mutex
+	total    uint32
+	hash     []byte
+	parts    []*Part
+	count    uint32
+	byteSize int
+}
+
+// NewPartSetFromData 把data切分成大小为partSize的分片，并为每个分片生成默克尔证明。
+func NewPartSetFromData(data []byte, partSize uint32) *PartSet {
+	total := (uint32(len(data)) + partSize - 1) / partSize
+	if total == 0 {
+		total = 1
+	}
+	parts := make([]*Part, total)
+	chunks := make([][]byte, total)
+	for i := uint32(0); i < total; i++ {
+		end := (i + 1) * partSize
+		if end > uint32(len(data)) {
+			end = uint32(len(data))
+		}
+		chunks[i] = data[i*partSize : end]
+		parts[i] = &Part{Index: i, Bytes: chunks[i]}
+	}
+	root, proofs := merkle.ProofsFromByteSlices(chunks)
+	for i := range parts {
+		parts[i].Proof = *proofs[i]
+	}
+	return &PartSet{
+		total:    total,
+		hash:     root,
+		parts:    parts,
+		count:    total,
+		byteSize: len(data),
+	}
+}
+
+// NewPartSetFromHeader 创建一个空的分片集合，等待添加分片。
+func NewPartSetFromHeader(header PartSetHeader) *PartSet {
+	return &PartSet{
+		total: header.Total,
+		hash:  header.Hash,
+		parts: make([]*Part, header.Total),
+	}
+}
+
+func (ps *PartSet) Header() PartSetHeader {
+	return PartSetHeader{Total: ps.total, Hash: ps.hash}
+}
+
+func (ps *PartSet) Hash() []byte {
+	return ps.hash
+}
+
+func (ps *PartSet) Total() uint32 {
+	return ps.total
+}
+
+func (ps *PartSet) Count() uint32 {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.count
+}
+
+func (ps *PartSet) IsComplete() bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	return ps.count == ps.total
+}
+
+// AddPart 验证分片的默克尔证明后把它加入集合，分片已经存在时返回false。
+func (ps *PartSet) AddPart(part *Part) (bool, error) {
+	if err := part.ValidateBasic(); err != nil {
+		return false, err
+	}
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if part.Index >= ps.total {
+		return false, fmt.Errorf("part index %d is out of range, part set has %d parts", part.Index, ps.total)
+	}
+	if ps.parts[part.Index] != nil {
+		return false, nil
+	}
+	if part.Proof.Total != uint64(ps.total) {
+		return false, fmt.Errorf("proof is for %d parts, but part set has %d parts", part.Proof.Total, ps.total)
+	}
+	if err := part.Proof.Verify(ps.hash, part.Bytes); err != nil {
+		return false, fmt.Errorf("invalid part #%d: %w", part.Index, err)
+	}
+	ps.parts[part.Index] = part
+	ps.count++
+	ps.byteSize += len(part.Bytes)
+	return true, nil
+}
+
+// GetPart 返回第index个分片，还没有收到该分片时返回nil。
+func (ps *PartSet) GetPart(index uint32) *Part {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if index >= ps.total {
+		return nil
+	}
+	return ps.parts[index]
+}
+
+// HasParts 返回集合里已经有的分片。
+func (ps *PartSet) HasParts() []bool {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	has := make([]bool, ps.total)
+	for i, part := range ps.parts {
+		has[i] = part != nil
+	}
+	return has
+}
+
+// Bytes 把所有分片拼接成原来的数据，集合还不完整时返回nil。
+func (ps *PartSet) Bytes() []byte {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.count != ps.total {
+		return nil
+	}
+	data := make([]byte, 0, ps.byteSize)
+	for _, part := range ps.parts {
+		data = append(data, part.Bytes...)
+	}
+	return data
+}
+
+/*⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓⛓*/
+
+// 在网络上传输的区块分片消息
+
+// BlockPartSetHeader 主节点在Height高度第Round轮提出的Prepare消息的分片集合的头部。
+type BlockPartSetHeader struct {
+	Height int64         `json:"height"`
+	Round  int16         `json:"round"`
+	Header PartSetHeader `json:"header"`
+}
+
+func (h *BlockPartSetHeader) ValidateBasic() error {
+	if h.Height < 0 {
+		return errors.New("negative height")
+	}
+	if h.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	return h.Header.ValidateBasic()
+}
+
+func (h *BlockPartSetHeader) ToProto() *pbtypes.BlockPartSetHeader {
+	return &pbtypes.BlockPartSetHeader{
+		Height: h.Height,
+		Round:  int32(h.Round),
+		Header: h.Header.ToProto(),
+	}
+}
+
+func BlockPartSetHeaderFromProto(pb *pbtypes.BlockPartSetHeader) *BlockPartSetHeader {
+	if pb == nil {
+		return nil
+	}
+	return &BlockPartSetHeader{
+		Height: pb.Height,
+		Round:  int16(pb.Round),
+		Header: PartSetHeaderFromProto(pb.Header),
+	}
+}
+
+// BlockPart 属于分片集合Hash的一个分片。
+type BlockPart struct {
+	Height int64  `json:"height"`
+	Round  int16  `json:"round"`
+	Hash   []byte `json:"hash"`
+	Part   *Part  `json:"part"`
+}
+
+func (bp *BlockPart) ValidateBasic() error {
+	if bp.Height < 0 {
+		return errors.New("negative height")
+	}
+	if bp.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if len(bp.Hash) == 0 {
+		return errors.New("empty part set hash")
+	}
+	return bp.Part.ValidateBasic()
+}
+
+func (bp *BlockPart) ToProto() *pbtypes.BlockPart {
+	return &pbtypes.BlockPart{
+		Height: bp.Height,
+		Round:  int32(bp.Round),
+		Hash:   bp.Hash,
+		Part:   bp.Part.ToProto(),
+	}
+}
+
+// BlockPartFromProto 分片的证明无法解析时，Part为nil，消息会在ValidateBasic时被拒绝。
+func BlockPartFromProto(pb *pbtypes.BlockPart) *BlockPart {
+	if pb == nil {
+		return nil
+	}
+	part, _ := PartFromProto(pb.Part)
+	return &BlockPart{
+		Height: pb.Height,
+		Round:  int16(pb.Round),
+		Hash:   pb.Hash,
+		Part:   part,
+	}
+}
+
+// HasBlockPart 节点告诉其他节点自己已经有了分片集合Hash里的第Index个分片。
+type HasBlockPart struct {
+	Height int64  `json:"height"`
+	Round  int16  `json:"round"`
+	Hash   []byte `json:"hash"`
+	Index  uint32 `json:"index"`
+}
+
+func (hbp *HasBlockPart) ValidateBasic() error {
+	if hbp.Height < 0 {
+		return errors.New("negative height")
+	}
+	if len(hbp.Hash) == 0 {
+		return errors.New("empty part set hash")
+	}
+	return nil
+}
+
+func (hbp *HasBlockPart) ToProto() *pbtypes.HasBlockPart {
+	return &pbtypes.HasBlockPart{
+		Height: hbp.Height,
+		Round:  int32(hbp.Round),
+		Hash:   hbp.Hash,
+		Index:  hbp.Index,
+	}
+}
+
+func HasBlockPartFromProto(pb *pbtypes.HasBlockPart) *HasBlockPart {
+	if pb == nil {
+		return nil
+	}
+	return &HasBlockPart{
+		Height: pb.Height,
+		Round:  int16(pb.Round),
+		Hash:   pb.Hash,
+		Index:  pb.Index,
+	}
+}
@@ -0,0 +1,69 @@
+package types
+
+import (
+	"bytes"
+	"crypto/rand"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPartSet(t *testing.T) {
+	data := make([]byte, 5*BlockPartSizeBytes+100)
+	_, err := rand.Read(data)
+	assert.Nil(t, err)
+
+	leader := NewPartSetFromData(data, BlockPartSizeBytes)
+	assert.Equal(t, uint32(6), leader.Total())
+	assert.True(t, leader.IsComplete())
+	assert.True(t, bytes.Equal(data, leader.Bytes()))
+
+	replica := NewPartSetFromHeader(leader.Header())
+	assert.False(t, replica.IsComplete())
+	assert.Nil(t, replica.Bytes())
+	// 分片可以按照任意顺序到达
+	for _, i := range []uint32{3, 0, 5, 1, 4} {
+		added, err := replica.AddPart(leader.GetPart(i))
+		assert.Nil(t, err)
+		assert.True(t, added)
+	}
+	assert.Equal(t, []bool{true, true, false, true, true, true}, replica.HasParts())
+	added, err := replica.AddPart(leader.GetPart(3))
+	assert.Nil(t, err)
+	assert.False(t, added)
+
+	// 篡改过内容的分片无法通过默克尔证明的验证
+	forged := *leader.GetPart(2)
+	forged.Bytes = append([]byte{}, forged.Bytes...)
+	forged.Bytes[0] ^= 0xff
+	_, err = replica.AddPart(&forged)
+	assert.NotNil(t, err)
+
+	// 把一个分片的内容和证明当成另一个位置上的分片也无法通过验证
+	moved := *leader.GetPart(1)
+	moved.Index, moved.Proof.Index = 2, 2
+	_, err = replica.AddPart(&moved)
+	assert.NotNil(t, err)
+
+	added, err = replica.AddPart(leader.GetPart(2))
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.True(t, replica.IsComplete())
+	assert.True(t, bytes.Equal(data, replica.Bytes()))
+
+	// 不属于该集合的分片会被拒绝
+	other := NewPartSetFromData(data[:5*BlockPartSizeBytes+50], BlockPartSizeBytes)
+	_, err = NewPartSetFromHeader(other.Header()).AddPart(leader.GetPart(0))
+	assert.NotNil(t, err)
+}
+
+func TestPartSetSmallData(t *testing.T) {
+	data := []byte("small prepare message")
+	leader := NewPartSetFromData(data, BlockPartSizeBytes)
+	assert.Equal(t, uint32(1), leader.Total())
+
+	replica := NewPartSetFromHeader(leader.Header())
+	added, err := replica.AddPart(leader.GetPart(0))
+	assert.Nil(t, err)
+	assert.True(t, added)
+	assert.Equal(t, data, replica.Bytes())
+}
@@ -0,0 +1,165 @@
+package types
+
+import (
+	"errors"
+	"github.com/232425wxy/meta--/crypto/bls12"
+)
+
+// Signer 为共识消息签名的接口，验证者的私钥可以保存在本地进程里，也可以保存在独立的签名进程里，共识模块只通过
+// Signer为Prepare、投票和NewView消息签名，不直接接触私钥。
+type Signer interface {
+	// PublicKey 返回签名者的公钥。
+	PublicKey() *bls12.PublicKey
+	// SignVote 为投票签名，签名成功后将签名填入vote.Signature。
+	SignVote(vote *Vote) error
+	// SignPrepare 为Prepare消息签名，签名成功后将签名填入prepare.Signature。
+	SignPrepare(prepare *Prepare) error
+	// SignNewView 为NewView消息签名，签名成功后将签名填入view.Signature。
+	SignNewView(view *NewView) error
+	// SignChainedProposal 为链式HotStuff的提案签名，签名成功后将签名填入proposal.Signature。
+	SignChainedProposal(proposal *ChainedProposal) error
+	// SignChainedVote 为链式HotStuff的投票签名，签名成功后将签名填入vote.Signature。
+	SignChainedVote(vote *ChainedVote) error
+	// SignChainedNewView 为链式HotStuff的ChainedNewView消息签名，签名成功后将签名填入view.Signature。
+	SignChainedNewView(view *ChainedNewView) error
+	// SignTimeout 为两阶段共识的Timeout消息签名，签名成功后将签名填入timeout.Signature。
+	SignTimeout(timeout *Timeout) error
+}
+
+// PrivateKeySigner 直接用私钥为消息签名，不记录签名状态，因此无法防止重复签名，只适合在测试里使用，
+// 或者作为其他Signer的底层实现。
+type PrivateKeySigner struct {
+	privateKey *bls12.PrivateKey
+}
+
+var _ Signer = (*PrivateKeySigner)(nil)
+
+func NewPrivateKeySigner(privateKey *bls12.PrivateKey) *PrivateKeySigner {
+	return &PrivateKeySigner{privateKey: privateKey}
+}
+
+func (s *PrivateKeySigner) PublicKey() *bls12.PublicKey {
+	return s.privateKey.PublicKey()
+}
+
+func (s *PrivateKeySigner) SignVote(vote *Vote) error {
+	if len(vote.ValueHash) == 0 {
+		return errors.New("cannot sign vote with empty value hash")
+	}
+	sig, err := s.privateKey.Sign(vote.ValueHash)
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignPrepare(prepare *Prepare) error {
+	if prepare.Block == nil || prepare.Block.ChameleonHash == nil || len(prepare.Block.ChameleonHash.Hash) == 0 {
+		return errors.New("cannot sign Prepare with empty block hash")
+	}
+	sig, err := s.privateKey.Sign(GeneratePrepareSignBytes(prepare.Height, prepare.Round, prepare.Block.ChameleonHash.Hash))
+	if err != nil {
+		return err
+	}
+	prepare.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignNewView(view *NewView) error {
+	sig, err := s.privateKey.Sign(view.ValueHash())
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignChainedProposal(proposal *ChainedProposal) error {
+	if proposal.Block == nil || proposal.Block.Header == nil || proposal.Block.ChameleonHash == nil || len(proposal.Block.ChameleonHash.Hash) == 0 {
+		return errors.New("cannot sign ChainedProposal with empty block hash")
+	}
+	sig, err := s.privateKey.Sign(GenerateChainedProposalSignBytes(proposal.View, proposal.Block.Header.Height, proposal.Block.ChameleonHash.Hash))
+	if err != nil {
+		return err
+	}
+	proposal.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignChainedVote(vote *ChainedVote) error {
+	if len(vote.ValueHash) == 0 {
+		return errors.New("cannot sign vote with empty value hash")
+	}
+	sig, err := s.privateKey.Sign(vote.ValueHash)
+	if err != nil {
+		return err
+	}
+	vote.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignChainedNewView(view *ChainedNewView) error {
+	sig, err := s.privateKey.Sign(view.ValueHash())
+	if err != nil {
+		return err
+	}
+	view.Signature = sig
+	return nil
+}
+
+func (s *PrivateKeySigner) SignTimeout(timeout *Timeout) error {
+	sig, err := s.privateKey.Sign(timeout.ValueHash())
+	if err != nil {
+		return err
+	}
+	timeout.Signature = sig
+	return nil
+}
+
+// ErrObserverSigner 观察者节点不为任何共识消息签名。
+var ErrObserverSigner = errors.New("observer node does not sign consensus messages")
+
+// ObserverSigner 观察者节点使用的Signer，它只提供节点的公钥，拒绝所有的签名请求，因此节点即使在验证者集合里也不会
+// 投票或者提出区块。
+type ObserverSigner struct {
+	publicKey *bls12.PublicKey
+}
+
+var _ Signer = (*ObserverSigner)(nil)
+
+func NewObserverSigner(publicKey *bls12.PublicKey) *ObserverSigner {
+	return &ObserverSigner{publicKey: publicKey}
+}
+
+func (s *ObserverSigner) PublicKey() *bls12.PublicKey {
+	return s.publicKey
+}
+
+func (s *ObserverSigner) SignVote(*Vote) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignPrepare(*Prepare) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignNewView(*NewView) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignChainedProposal(*ChainedProposal) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignChainedVote(*ChainedVote) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignChainedNewView(*ChainedNewView) error {
+	return ErrObserverSigner
+}
+
+func (s *ObserverSigner) SignTimeout(*Timeout) error {
+	return ErrObserverSigner
+}
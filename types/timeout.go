@@ -0,0 +1,236 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/sha256"
+	"github.com/232425wxy/meta--/proto/pbtypes"
+	"sort"
+	"time"
+)
+
+// 两阶段共识的视图切换消息。副本节点在某一轮超时后广播Timeout消息，消息里携带它所知道的最高的QC，签名只覆盖
+// 高度、轮次和最高QC的轮次。收集到2/3以上验证者的Timeout消息后，它们的签名被聚合成超时证书（TimeoutCert），
+// 下一轮的主节点在Prepare消息里附上超时证书，证明上一轮已经超时，并证明它所携带的最高QC不低于证书里任何一个QC。
+
+// Timeout 验证者在Height高度的第Round轮超时后发出的消息。
+type Timeout struct {
+	ID          crypto.ID        `json:"ID"`
+	Height      int64            `json:"height"`
+	Round       int16            `json:"round"` // 超时的轮次
+	HighQC      *PreCommit       `json:"high_qc"`
+	HighQCBlock *Block           `json:"high_qc_block"`
+	Timestamp   time.Time        `json:"timestamp"`
+	Signature   *bls12.Signature `json:"signature"`
+}
+
+func NewTimeout(height int64, round int16, highQC *PreCommit, highQCBlock *Block, signer Signer) (*Timeout, error) {
+	t := &Timeout{
+		ID:          signer.PublicKey().ToID(),
+		Height:      height,
+		Round:       round,
+		HighQC:      highQC,
+		HighQCBlock: highQCBlock,
+		Timestamp:   time.Now(),
+	}
+	if err := signer.SignTimeout(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// HighQCRound 返回Timeout消息携带的最高QC的轮次，没有QC时返回0。
+func (t *Timeout) HighQCRound() int16 {
+	if t.HighQC == nil {
+		return 0
+	}
+	return t.HighQC.Round
+}
+
+// ValueHash 返回验证者为Timeout消息签名的内容。
+func (t *Timeout) ValueHash() []byte {
+	return GenerateTimeoutValueHash(t.Height, t.Round, t.HighQCRound())
+}
+
+func (t *Timeout) ValidateBasic() error {
+	if t.Height < 0 {
+		return errors.New("negative height")
+	}
+	if t.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if (t.HighQC == nil) != (t.HighQCBlock == nil) {
+		return errors.New("high QC and its block must be provided together")
+	}
+	if t.HighQC != nil {
+		if t.HighQC.Height != t.Height || t.HighQCBlock.Header == nil || t.HighQCBlock.Header.Height != t.Height {
+			return errors.New("the height of high QC does not match the height of Timeout")
+		}
+		if t.HighQC.Round > t.Round {
+			return fmt.Errorf("high QC round %d is greater than timeout round %d", t.HighQC.Round, t.Round)
+		}
+		if t.HighQC.AggregateSignature == nil {
+			return errors.New("high QC has no aggregate signature")
+		}
+	}
+	if t.Signature == nil {
+		return errors.New("Timeout is not signed")
+	}
+	return nil
+}
+
+func (t *Timeout) ToProto() *pbtypes.Timeout {
+	if t == nil {
+		return nil
+	}
+	return &pbtypes.Timeout{
+		ID:          string(t.ID),
+		Height:      t.Height,
+		Round:       int32(t.Round),
+		HighQC:      t.HighQC.ToProto(),
+		HighQCBlock: t.HighQCBlock.ToProto(),
+		Timestamp:   t.Timestamp,
+		Signature:   t.Signature.ToProto(),
+	}
+}
+
+func TimeoutFromProto(pb *pbtypes.Timeout) *Timeout {
+	if pb == nil {
+		return nil
+	}
+	t := &Timeout{
+		ID:          crypto.ID(pb.ID),
+		Height:      pb.Height,
+		Round:       int16(pb.Round),
+		HighQC:      PreCommitFromProto(pb.HighQC),
+		HighQCBlock: BlockFromProto(pb.HighQCBlock),
+		Timestamp:   pb.Timestamp,
+	}
+	if pb.Signature != nil {
+		t.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return t
+}
+
+/**********************************************************************************************************************/
+
+// TimeoutCert 2/3以上验证者在Height高度的第Round轮发出的Timeout消息所聚合成的超时证书。每个验证者签署的最高QC轮次
+// 可能各不相同，所以聚合签名是对一组不同消息的签名，HighQCRounds记录了每个签名者所签署的最高QC轮次。
+type TimeoutCert struct {
+	Height             int64                     `json:"height"`
+	Round              int16                     `json:"round"`
+	HighQCRounds       map[crypto.ID]int16       `json:"high_qc_rounds"`
+	AggregateSignature *bls12.AggregateSignature `json:"aggregate_signature"`
+}
+
+func NewTimeoutCert(height int64, round int16, highQCRounds map[crypto.ID]int16, agg *bls12.AggregateSignature) *TimeoutCert {
+	return &TimeoutCert{
+		Height:             height,
+		Round:              round,
+		HighQCRounds:       highQCRounds,
+		AggregateSignature: agg,
+	}
+}
+
+// MaxHighQCRound 返回超时证书里最高的QC轮次，下一轮的主节点所携带的QC不能低于它。
+func (tc *TimeoutCert) MaxHighQCRound() int16 {
+	var max int16 = 0
+	for _, round := range tc.HighQCRounds {
+		if round > max {
+			max = round
+		}
+	}
+	return max
+}
+
+func (tc *TimeoutCert) ValidateBasic() error {
+	if tc.Height < 0 {
+		return errors.New("negative height")
+	}
+	if tc.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if len(tc.HighQCRounds) == 0 {
+		return errors.New("empty timeout certificate")
+	}
+	for id, round := range tc.HighQCRounds {
+		if round < 0 || round > tc.Round {
+			return fmt.Errorf("invalid high QC round %d from %s", round, id)
+		}
+	}
+	if tc.AggregateSignature == nil {
+		return errors.New("nil aggregate signature")
+	}
+	return nil
+}
+
+// Verify 检查超时证书的签名者都是验证者，他们的投票权之和超过了2/3，并且聚合签名是合法的。
+func (tc *TimeoutCert) Verify(validators *ValidatorSet) error {
+	if err := tc.ValidateBasic(); err != nil {
+		return err
+	}
+	participants := tc.AggregateSignature.Participants()
+	if participants.Size() != len(tc.HighQCRounds) {
+		return fmt.Errorf("timeout certificate has %d signers, but %d high QC rounds", participants.Size(), len(tc.HighQCRounds))
+	}
+	hashes := make(map[crypto.ID]sha256.Hash, len(tc.HighQCRounds))
+	for _, id := range participants.IDs {
+		round, ok := tc.HighQCRounds[id]
+		if !ok {
+			return fmt.Errorf("missing high QC round of signer %s", id)
+		}
+		var hash sha256.Hash
+		copy(hash[:], GenerateTimeoutValueHash(tc.Height, tc.Round, round))
+		hashes[id] = hash
+	}
+	if err := validators.VerifyAggregateSignatureForMessageSet(tc.AggregateSignature, hashes); err != nil {
+		return fmt.Errorf("invalid timeout certificate: %w", err)
+	}
+	return nil
+}
+
+func (tc *TimeoutCert) ToProto() *pbtypes.TimeoutCert {
+	if tc == nil {
+		return nil
+	}
+	// 按照ID排序，保证序列化的结果是确定的
+	entries := make([]*pbtypes.TimeoutCert_Entry, 0, len(tc.HighQCRounds))
+	for id, round := range tc.HighQCRounds {
+		entries = append(entries, &pbtypes.TimeoutCert_Entry{ID: string(id), HighQCRound: int32(round)})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return &pbtypes.TimeoutCert{
+		Height:             tc.Height,
+		Round:              int32(tc.Round),
+		Entries:            entries,
+		AggregateSignature: tc.AggregateSignature.ToProto(),
+	}
+}
+
+func TimeoutCertFromProto(pb *pbtypes.TimeoutCert) *TimeoutCert {
+	if pb == nil {
+		return nil
+	}
+	rounds := make(map[crypto.ID]int16, len(pb.Entries))
+	for _, entry := range pb.Entries {
+		rounds[crypto.ID(entry.ID)] = int16(entry.HighQCRound)
+	}
+	return &TimeoutCert{
+		Height:             pb.Height,
+		Round:              int16(pb.Round),
+		HighQCRounds:       rounds,
+		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
+	}
+}
+
+// GenerateTimeoutValueHash 验证者为Timeout消息签名的内容，签名只绑定高度、轮次和最高QC的轮次，
+// 这样同一轮里携带不同QC轮次的Timeout消息只需要少量不同的消息哈希，就能聚合成超时证书。
+func GenerateTimeoutValueHash(height int64, round int16, highQCRound int16) []byte {
+	value := []byte(fmt.Sprintf("Timeout-%d-%d-%d-", height, round, highQCRound))
+	h := sha256.Sum(value)
+	return h[:]
+}
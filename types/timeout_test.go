@@ -0,0 +1,56 @@
+package types
+
+import (
+	"github.com/232425wxy/meta--/crypto"
+	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTimeoutCert_Verify(t *testing.T) {
+	privateKeys := make([]*bls12.PrivateKey, 4)
+	validators := make([]*Validator, 4)
+	for i := 0; i < 4; i++ {
+		privateKeys[i], _ = bls12.GeneratePrivateKey()
+		validators[i] = NewValidator(privateKeys[i].PublicKey(), 10)
+	}
+	set := NewValidatorSet(validators)
+
+	// 每个验证者携带的最高QC轮次各不相同，其中两个验证者相同
+	highQCRounds := []int16{0, 2, 2, 1}
+	certify := func(n int) *TimeoutCert {
+		rounds := make(map[crypto.ID]int16)
+		sigs := make([]*bls12.Signature, 0)
+		for i := 0; i < n; i++ {
+			sig, err := privateKeys[i].Sign(GenerateTimeoutValueHash(5, 3, highQCRounds[i]))
+			assert.Nil(t, err)
+			sigs = append(sigs, sig)
+			rounds[privateKeys[i].PublicKey().ToID()] = highQCRounds[i]
+		}
+		agg, err := bls12.NewCryptoBLS12().CreateThresholdSignatureForMessageSet(sigs)
+		assert.Nil(t, err)
+		return NewTimeoutCert(5, 3, rounds, agg)
+	}
+
+	tc := certify(4)
+	assert.Nil(t, tc.Verify(set))
+	assert.Equal(t, int16(2), tc.MaxHighQCRound())
+
+	// 经过序列化之后依然可以验证，并且序列化的结果是确定的
+	pb := tc.ToProto()
+	assert.Nil(t, TimeoutCertFromProto(pb).Verify(set))
+	assert.Equal(t, pb, TimeoutCertFromProto(pb).ToProto())
+
+	// 投票权不足2/3
+	assert.NotNil(t, certify(2).Verify(set))
+
+	// 篡改某个验证者的最高QC轮次
+	forged := certify(3)
+	forged.HighQCRounds[privateKeys[0].PublicKey().ToID()] = 2
+	assert.NotNil(t, forged.Verify(set))
+
+	// 证书里的轮次与签名的内容不一致
+	forged = certify(3)
+	forged.Round = 2
+	assert.NotNil(t, forged.Verify(set))
+}
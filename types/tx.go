@@ -58,6 +58,15 @@ func (txs Txs) MerkleRootHash() []byte {
 	return merkle.ComputeMerkleRoot(hashes)
 }
 
+// ByteSize 返回所有交易数据的总字节数。
+func (txs Txs) ByteSize() int64 {
+	var size int64
+	for _, tx := range txs {
+		size += int64(len(tx))
+	}
+	return size
+}
+
 func (txs Txs) ToProto() pbtypes.Txs {
 	pb := pbtypes.Txs{Txs: make([][]byte, len(txs))}
 	for i := 0; i < len(txs); i++ {
@@ -1,8 +1,11 @@
 package types
 
 import (
+	"errors"
+	"fmt"
 	"github.com/232425wxy/meta--/crypto"
 	"github.com/232425wxy/meta--/crypto/bls12"
+	"github.com/232425wxy/meta--/crypto/sha256"
 	"github.com/232425wxy/meta--/proto/pbabci"
 	"github.com/232425wxy/meta--/proto/pbtypes"
 	"sort"
@@ -66,37 +69,136 @@ func (vals Validators) Swap(i, j int) {
 
 // validator.proto 集合
 
+// PriorityWindowSizeFactor 验证者之间主节点优先级的最大差距不能超过 PriorityWindowSizeFactor * TotalVotingPower，
+// 否则会对所有验证者的优先级进行等比例缩小，避免某个验证者的优先级无限增长或减小。
+const PriorityWindowSizeFactor = 2
+
 type ValidatorSet struct {
 	Validators       []*Validator `json:"validators"`
-	Leader           *Validator   `json:"leader"`
+	Leader           *Validator   `json:"leader"` // 当前高度第1轮的主节点
 	TotalVotingPower int64        `json:"total_voting_power"`
 }
 
+// GetLeader 返回当前高度第round轮的主节点。主节点的选取采用加权轮询算法：每进入一个新的高度，验证者集合都会累加
+// 一次优先级，选出第1轮的主节点 set.Leader；同一高度下，第round轮的主节点是在验证者集合的副本上再累加 round-1
+// 次优先级后选出的主节点。因此主节点由高度和轮次共同决定，并且投票权越大的验证者当选主节点的次数越多。第1轮的主节点在
+// 创建验证者集合时就已经选出，GetLeader不会修改验证者集合，空的验证者集合没有主节点，返回nil。
 func (set *ValidatorSet) GetLeader(round int16) *Validator {
-	index := int(round) % len(set.Validators)
-	set.Leader = set.Validators[index]
-	return set.Leader
+	if round <= 1 || set.Leader == nil {
+		return set.Leader
+	}
+	cpy := set.Copy()
+	cpy.IncrementLeaderPriority(int(round - 1))
+	return cpy.Leader
 }
 
+// Copy 深度拷贝验证者集合，修改副本里验证者的优先级不会影响原来的集合。
 func (set *ValidatorSet) Copy() *ValidatorSet {
 	cpy := &ValidatorSet{
 		Validators:       make([]*Validator, len(set.Validators)),
-		Leader:           set.Leader,
 		TotalVotingPower: set.TotalVotingPower,
 	}
-	copy(cpy.Validators, set.Validators)
+	for i, val := range set.Validators {
+		v := *val
+		cpy.Validators[i] = &v
+		if set.Leader != nil && set.Leader.ID == val.ID {
+			cpy.Leader = cpy.Validators[i]
+		}
+	}
 	return cpy
 }
 
 func NewValidatorSet(validators []*Validator) *ValidatorSet {
 	sort.Sort(Validators(validators))
 	set := &ValidatorSet{Validators: validators}
-	for _, validator := range validators {
-		set.TotalVotingPower += validator.VotingPower
+	set.updateTotalVotingPower()
+	if len(validators) > 0 {
+		set.IncrementLeaderPriority(1)
 	}
 	return set
 }
 
+// IncrementLeaderPriority 累加times次验证者的优先级，每次累加时，每个验证者的优先级增加其投票权，然后优先级最高
+// 的验证者当选主节点，并且其优先级减去总投票权，最后一次选出的主节点被记录在 set.Leader 里。
+func (set *ValidatorSet) IncrementLeaderPriority(times int) {
+	if len(set.Validators) == 0 {
+		panic("empty validator set")
+	}
+	if times <= 0 {
+		panic("cannot call IncrementLeaderPriority with non-positive times")
+	}
+	set.updateTotalVotingPower()
+	set.RescalePriorities(PriorityWindowSizeFactor * set.TotalVotingPower)
+	set.shiftByAvgLeaderPriority()
+
+	var leader *Validator
+	for i := 0; i < times; i++ {
+		for _, val := range set.Validators {
+			val.LeaderPriority += val.VotingPower
+		}
+		leader = set.getValWithMostPriority()
+		leader.LeaderPriority -= set.TotalVotingPower
+	}
+	set.Leader = leader
+}
+
+// RescalePriorities 如果验证者之间优先级的最大差距超过了diffMax，则将所有验证者的优先级等比例缩小。
+func (set *ValidatorSet) RescalePriorities(diffMax int64) {
+	if len(set.Validators) == 0 || diffMax <= 0 {
+		return
+	}
+	diff := set.computeMaxMinPriorityDiff()
+	ratio := (diff + diffMax - 1) / diffMax
+	if diff > diffMax {
+		for _, val := range set.Validators {
+			val.LeaderPriority /= ratio
+		}
+	}
+}
+
+func (set *ValidatorSet) computeMaxMinPriorityDiff() int64 {
+	max, min := set.Validators[0].LeaderPriority, set.Validators[0].LeaderPriority
+	for _, val := range set.Validators[1:] {
+		if val.LeaderPriority > max {
+			max = val.LeaderPriority
+		}
+		if val.LeaderPriority < min {
+			min = val.LeaderPriority
+		}
+	}
+	return max - min
+}
+
+// shiftByAvgLeaderPriority 将所有验证者的优先级减去平均值，使优先级围绕0上下波动。
+func (set *ValidatorSet) shiftByAvgLeaderPriority() {
+	var sum int64
+	for _, val := range set.Validators {
+		sum += val.LeaderPriority
+	}
+	avg := sum / int64(len(set.Validators))
+	for _, val := range set.Validators {
+		val.LeaderPriority -= avg
+	}
+}
+
+// getValWithMostPriority 返回优先级最高的验证者，优先级相同时，选择ID较小的验证者，保证所有节点的选择结果一致。
+func (set *ValidatorSet) getValWithMostPriority() *Validator {
+	var res *Validator
+	for _, val := range set.Validators {
+		if res == nil || val.LeaderPriority > res.LeaderPriority || (val.LeaderPriority == res.LeaderPriority && val.ID < res.ID) {
+			res = val
+		}
+	}
+	return res
+}
+
+func (set *ValidatorSet) updateTotalVotingPower() {
+	set.TotalVotingPower = 0
+	for _, val := range set.Validators {
+		set.TotalVotingPower += val.VotingPower
+	}
+}
+
 func (set *ValidatorSet) GetValidatorByID(id crypto.ID) *Validator {
 	for _, val := range set.Validators {
 		if val.ID == id {
@@ -106,49 +208,138 @@ func (set *ValidatorSet) GetValidatorByID(id crypto.ID) *Validator {
 	return nil
 }
 
+// PowerMajor23 返回严格超过总投票权2/3所需的最小投票权，所有法定人数的判断都与它比较：power >= PowerMajor23()
+// 等价于 power*3 > TotalVotingPower*2。
 func (set *ValidatorSet) PowerMajor23() int64 {
-	set.TotalVotingPower = 0
-	for _, val := range set.Validators {
-		set.TotalVotingPower += val.VotingPower
-	}
+	set.updateTotalVotingPower()
 	major23 := set.TotalVotingPower * 2
 	major23 /= 3
-	return major23
+	return major23 + 1
 }
 
 func (set *ValidatorSet) PowerMajorFull() int64 {
-	set.TotalVotingPower = 0
-	for _, val := range set.Validators {
-		set.TotalVotingPower += val.VotingPower
-	}
+	set.updateTotalVotingPower()
 	return set.TotalVotingPower
 }
 
+// VerifyAggregateSignature 检查聚合签名的参与者都是集合里的验证者，他们的投票权之和超过了2/3，并且聚合签名对valueHash
+// 是合法的。观察者节点不一定与签名者直接相连，签名者的公钥直接从验证者集合里获取。
+func (set *ValidatorSet) VerifyAggregateSignature(agg *bls12.AggregateSignature, valueHash []byte) error {
+	power, err := set.VerifyPartialAggregateSignature(agg, valueHash)
+	if err != nil {
+		return err
+	}
+	if power < set.PowerMajor23() {
+		return fmt.Errorf("insufficient voting power in aggregate signature, got %d, need %d", power, set.PowerMajor23())
+	}
+	return nil
+}
+
+// VerifyPartialAggregateSignature 检查聚合签名的参与者都是集合里的验证者，并且聚合签名对valueHash是合法的，返回参与者的
+// 投票权之和。投票聚合树里的节点用它验证子树发来的部分聚合签名，这时参与者的投票权不必超过2/3。
+func (set *ValidatorSet) VerifyPartialAggregateSignature(agg *bls12.AggregateSignature, valueHash []byte) (int64, error) {
+	if agg == nil {
+		return 0, errors.New("nil aggregate signature")
+	}
+	power, err := set.signersVotingPower(agg.Participants().IDs)
+	if err != nil {
+		return 0, err
+	}
+	if !bls12.NewCryptoBLS12().VerifyThresholdSignature(agg, valueHash) {
+		return 0, errors.New("invalid aggregate signature")
+	}
+	return power, nil
+}
+
+// VerifyAggregateSignatureForMessageSet 与 VerifyAggregateSignature 相同，只是每个签名者签署的内容可以不同，hashes
+// 记录了每个签名者所签署的消息哈希值，它必须与聚合签名的参与者完全一致。
+func (set *ValidatorSet) VerifyAggregateSignatureForMessageSet(agg *bls12.AggregateSignature, hashes map[crypto.ID]sha256.Hash) error {
+	if agg == nil {
+		return errors.New("nil aggregate signature")
+	}
+	power, err := set.signersVotingPower(agg.Participants().IDs)
+	if err != nil {
+		return err
+	}
+	if power < set.PowerMajor23() {
+		return fmt.Errorf("insufficient voting power in aggregate signature, got %d, need %d", power, set.PowerMajor23())
+	}
+	if !bls12.NewCryptoBLS12().VerifyThresholdSignatureForMessageSet(agg, hashes, len(hashes)) {
+		return errors.New("invalid aggregate signature")
+	}
+	return nil
+}
+
+// signersVotingPower 检查签名者都是集合里的验证者并且没有重复，返回他们的投票权之和。签名者不一定与自己直接相连，
+// 验证聚合签名时需要的公钥直接从验证者集合里获取。
+func (set *ValidatorSet) signersVotingPower(ids []crypto.ID) (int64, error) {
+	var power int64 = 0
+	seen := make(map[crypto.ID]struct{})
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			return 0, fmt.Errorf("duplicate signer %s in aggregate signature", id)
+		}
+		seen[id] = struct{}{}
+		validator := set.GetValidatorByID(id)
+		if validator == nil {
+			return 0, fmt.Errorf("unknown signer %s in aggregate signature", id)
+		}
+		if bls12.GetBLSPublicKeyFromLib(id) == nil {
+			if err := bls12.AddBLSPublicKey(validator.PublicKey.ToBytes()); err != nil {
+				return 0, err
+			}
+		}
+		power += validator.VotingPower
+	}
+	return power, nil
+}
+
+// Update 根据应用层返回的验证者更新信息修改验证者集合：投票权小于等于0的验证者会被移除，已存在的验证者更新投票权，
+// 新加入的验证者的优先级被设置为 -1.125 * TotalVotingPower，避免其通过反复退出和加入来获得更高的优先级。
 func (set *ValidatorSet) Update(validatorUpdates []*pbabci.ValidatorUpdate) {
+	added := make(map[crypto.ID]bool)
 	for _, update := range validatorUpdates {
-		var exists bool = false
 		publicKey := bls12.PublicKeyFromProto(update.BLS12PublicKey)
+		id := publicKey.ToID()
+		index := -1
 		for i, validator := range set.Validators {
-			if publicKey.ToID() == validator.ID {
-				exists = true
-				validator.VotingPower = update.Power
-				if update.Power <= 0 {
-					set.Validators = append(set.Validators[:i], set.Validators[i+1:]...)
-					if publicKey.ToID() == set.Leader.ID {
-						set.Leader = set.Validators[0]
-					}
-				}
+			if validator.ID == id {
+				index = i
+				break
 			}
 		}
-		if !exists {
+		switch {
+		case index >= 0 && update.Power <= 0:
+			set.Validators = append(set.Validators[:index], set.Validators[index+1:]...)
+		case index >= 0:
+			set.Validators[index].VotingPower = update.Power
+		case update.Power > 0:
 			set.Validators = append(set.Validators, &Validator{
-				ID:             publicKey.ToID(),
-				PublicKey:      publicKey,
-				VotingPower:    update.Power,
-				LeaderPriority: 10,
+				ID:          id,
+				PublicKey:   publicKey,
+				VotingPower: update.Power,
 			})
+			added[id] = true
 		}
 	}
+	sort.Sort(Validators(set.Validators))
+	set.updateTotalVotingPower()
+	for _, val := range set.Validators {
+		if added[val.ID] {
+			val.LeaderPriority = -(set.TotalVotingPower + (set.TotalVotingPower >> 3))
+		}
+	}
+	if len(set.Validators) == 0 {
+		set.Leader = nil
+		return
+	}
+	set.RescalePriorities(PriorityWindowSizeFactor * set.TotalVotingPower)
+	set.shiftByAvgLeaderPriority()
+	if set.Leader == nil || set.GetValidatorByID(set.Leader.ID) == nil {
+		set.Leader = set.getValWithMostPriority()
+	} else {
+		set.Leader = set.GetValidatorByID(set.Leader.ID)
+	}
 }
 
 func (set *ValidatorSet) ToProto() *pbtypes.ValidatorSet {
@@ -174,9 +365,16 @@ func ValidatorSetFromProto(pb *pbtypes.ValidatorSet) *ValidatorSet {
 	for _, validator := range pb.Validators {
 		validators = append(validators, ValidatorFromProto(validator))
 	}
-	return &ValidatorSet{
+	set := &ValidatorSet{
 		Validators:       validators,
-		Leader:           ValidatorFromProto(pb.Leader),
 		TotalVotingPower: pb.TotalVotingPower,
 	}
+	if pb.Leader != nil {
+		set.Leader = set.GetValidatorByID(crypto.ID(pb.Leader.ID))
+	}
+	if set.Leader == nil && len(set.Validators) > 0 {
+		// 没有记录主节点的验证者集合与新创建的验证者集合一样，累加一次优先级选出第1轮的主节点
+		set.IncrementLeaderPriority(1)
+	}
+	return set
 }
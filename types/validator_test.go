@@ -40,6 +40,110 @@ func TestValidatorSet_Update(t *testing.T) {
 	}
 }
 
+func newTestValidatorSet(powers ...int64) *ValidatorSet {
+	validators := make([]*Validator, len(powers))
+	for i, power := range powers {
+		privateKey, _ := bls12.GeneratePrivateKey()
+		validators[i] = NewValidator(privateKey.PublicKey(), power)
+	}
+	return NewValidatorSet(validators)
+}
+
+func TestValidatorSet_LeaderRotation(t *testing.T) {
+	// 投票权相同的验证者轮流当选主节点
+	set := newTestValidatorSet(10, 10, 10, 10)
+	leaders := make(map[crypto.ID]int)
+	for h := 0; h < 8; h++ {
+		leaders[set.GetLeader(1).ID]++
+		set.IncrementLeaderPriority(1)
+	}
+	assert.Equal(t, 4, len(leaders))
+	for _, times := range leaders {
+		assert.Equal(t, 2, times)
+	}
+
+	// 当选主节点的次数与投票权成正比
+	set = newTestValidatorSet(1, 1, 2)
+	var big crypto.ID
+	for _, val := range set.Validators {
+		if val.VotingPower == 2 {
+			big = val.ID
+		}
+	}
+	leaders = make(map[crypto.ID]int)
+	for h := 0; h < 40; h++ {
+		leaders[set.GetLeader(1).ID]++
+		set.IncrementLeaderPriority(1)
+	}
+	assert.Equal(t, 20, leaders[big])
+}
+
+func TestValidatorSet_GetLeaderByRound(t *testing.T) {
+	set := newTestValidatorSet(10, 10, 10, 10)
+	before := set.Copy()
+
+	seen := make(map[crypto.ID]bool)
+	for round := int16(1); round <= 4; round++ {
+		leader := set.GetLeader(round)
+		assert.Equal(t, leader.ID, set.GetLeader(round).ID)
+		seen[leader.ID] = true
+	}
+	assert.Equal(t, 4, len(seen))
+	// 计算后续轮次的主节点不会修改验证者集合里的优先级
+	for i, val := range set.Validators {
+		assert.Equal(t, before.Validators[i].LeaderPriority, val.LeaderPriority)
+	}
+	assert.Equal(t, before.Leader.ID, set.Leader.ID)
+
+	// 下一个高度第1轮的主节点就是当前高度第2轮的主节点
+	round2 := set.GetLeader(2).ID
+	set.IncrementLeaderPriority(1)
+	assert.Equal(t, round2, set.GetLeader(1).ID)
+
+	// 序列化之后主节点和优先级保持不变
+	restored := ValidatorSetFromProto(set.ToProto())
+	for round := int16(1); round <= 4; round++ {
+		assert.Equal(t, set.GetLeader(round).ID, restored.GetLeader(round).ID)
+	}
+
+	// 没有记录主节点的验证者集合在反序列化时就选出第1轮的主节点，之后GetLeader只读取验证者集合
+	pb := newTestValidatorSet(10, 20, 30).ToProto()
+	pb.Leader = nil
+	restored = ValidatorSetFromProto(pb)
+	assert.NotNil(t, restored.Leader)
+	before = restored.Copy()
+	for round := int16(1); round <= 4; round++ {
+		restored.GetLeader(round)
+	}
+	for i, val := range restored.Validators {
+		assert.Equal(t, before.Validators[i].LeaderPriority, val.LeaderPriority)
+	}
+	assert.Equal(t, before.Leader.ID, restored.Leader.ID)
+}
+
+func TestValidatorSet_UpdateLeaderPriority(t *testing.T) {
+	set := newTestValidatorSet(10, 10, 10)
+	leader := set.GetLeader(1)
+
+	privateKey, _ := bls12.GeneratePrivateKey()
+	set.Update([]*pbabci.ValidatorUpdate{
+		{BLS12PublicKey: leader.PublicKey.ToProto(), Power: 0},
+		{BLS12PublicKey: privateKey.PublicKey().ToProto(), Power: 10},
+	})
+	assert.Equal(t, 3, len(set.Validators))
+	assert.Nil(t, set.GetValidatorByID(leader.ID))
+	assert.NotNil(t, set.GetValidatorByID(set.Leader.ID))
+	assert.Equal(t, int64(30), set.TotalVotingPower)
+
+	// 新加入的验证者优先级最低，不会立刻当选主节点
+	added := set.GetValidatorByID(privateKey.PublicKey().ToID())
+	for _, val := range set.Validators {
+		assert.True(t, added.LeaderPriority <= val.LeaderPriority)
+	}
+	set.IncrementLeaderPriority(1)
+	assert.NotEqual(t, added.ID, set.Leader.ID)
+}
+
 func del(arr *[]int, size int) {
 	*arr = append((*arr)[:size], (*arr)[size+1:]...)
 }
@@ -69,3 +173,11 @@ func TestSortVals(t *testing.T) {
 		t.Log(i, ":", vals[i].ID)
 	}
 }
+
+func TestValidatorSet_PowerMajor23(t *testing.T) {
+	// 法定人数必须严格超过总投票权的2/3
+	assert.Equal(t, int64(3), newTestValidatorSet(1, 1, 1, 1).PowerMajor23())
+	assert.Equal(t, int64(3), newTestValidatorSet(1, 1, 1).PowerMajor23())
+	assert.Equal(t, int64(27), newTestValidatorSet(10, 10, 10, 10).PowerMajor23())
+	assert.Equal(t, int64(5), newTestValidatorSet(1, 1, 1, 1, 1, 1).PowerMajor23())
+}
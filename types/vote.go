@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"github.com/232425wxy/meta--/crypto/bls12"
@@ -9,23 +10,31 @@ import (
 )
 
 // Vote 对不同共识阶段里的消息进行投票，投票需要节点的签名，对投票中的ValueHash进行签名。
+// ValueHash由投票类型、区块高度、轮次和区块哈希共同计算得到，因此同一验证者在同一高度、同一轮次对不同区块的两张同类型
+// 投票可以作为其作恶的证据。
 type Vote struct {
 	VoteType  pbtypes.VoteType
 	Height    int64
+	Round     int16
+	BlockHash []byte
 	ValueHash []byte
 	Timestamp time.Time
 	Signature *bls12.Signature
 }
 
-func NewVote(typ pbtypes.VoteType, height int64, valueHash []byte, privateKey *bls12.PrivateKey) *Vote {
+func NewVote(typ pbtypes.VoteType, height int64, round int16, blockHash []byte, signer Signer) (*Vote, error) {
 	v := &Vote{
 		VoteType:  typ,
 		Height:    height,
-		ValueHash: valueHash,
+		Round:     round,
+		BlockHash: blockHash,
+		ValueHash: GenerateVoteValueHash(typ, height, round, blockHash),
 		Timestamp: time.Now(),
 	}
-	v.sign(privateKey)
-	return v
+	if err := signer.SignVote(v); err != nil {
+		return nil, err
+	}
+	return v, nil
 }
 
 func (v *Vote) Verify() error {
@@ -43,21 +52,22 @@ func (v *Vote) Verify() error {
 	return fmt.Errorf("invalid signature for %s", v.VoteType)
 }
 
-func (v *Vote) sign(privateKey *bls12.PrivateKey) {
-	if len(v.ValueHash[:]) == 0 {
-		panic("cannot sign nil message")
-	}
-	sig, err := privateKey.Sign(v.ValueHash)
-	if err != nil {
-		panic(err)
-	}
-	v.Signature = sig
-}
-
 func (v *Vote) ValidateBasic() error {
 	if v.Height < 0 {
 		return errors.New("negative height")
 	}
+	if v.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if _, ok := pbtypes.VoteType_name[int32(v.VoteType)]; !ok {
+		return fmt.Errorf("unknown vote type: %d", v.VoteType)
+	}
+	if v.Signature == nil {
+		return errors.New("vote is not signed")
+	}
+	if !bytes.Equal(v.ValueHash, GenerateVoteValueHash(v.VoteType, v.Height, v.Round, v.BlockHash)) {
+		return errors.New("value hash does not match the vote's height, round and block hash")
+	}
 	return nil
 }
 
@@ -71,6 +81,8 @@ func (v *Vote) ToProto() *pbtypes.Vote {
 		ValueHash: v.ValueHash[:],
 		Timestamp: v.Timestamp,
 		Signature: v.Signature.ToProto(),
+		Round:     int32(v.Round),
+		BlockHash: v.BlockHash,
 	}
 }
 
@@ -80,11 +92,74 @@ func VoteFromProto(pb *pbtypes.Vote) *Vote {
 	}
 	hash := make([]byte, len(pb.ValueHash))
 	copy(hash[:], pb.ValueHash)
-	return &Vote{
+	vote := &Vote{
 		VoteType:  pb.VoteType,
 		Height:    pb.Height,
+		Round:     int16(pb.Round),
+		BlockHash: pb.BlockHash,
 		ValueHash: hash,
 		Timestamp: pb.Timestamp,
-		Signature: bls12.SignatureFromProto(pb.Signature),
+	}
+	if pb.Signature != nil {
+		vote.Signature = bls12.SignatureFromProto(pb.Signature)
+	}
+	return vote
+}
+
+// AggregateVote 投票聚合树里的内部节点把自己和子树里的验证者对同一个ValueHash的投票聚合成的部分聚合签名，参与聚合的
+// 验证者记录在聚合签名的参与者集合里。主节点收到它后只需要验证一次聚合签名，而不是逐个验证子树里每个验证者的签名。
+type AggregateVote struct {
+	VoteType           pbtypes.VoteType
+	Height             int64
+	Round              int16
+	BlockHash          []byte
+	ValueHash          []byte
+	AggregateSignature *bls12.AggregateSignature
+}
+
+func (av *AggregateVote) ValidateBasic() error {
+	if av.Height < 0 {
+		return errors.New("negative height")
+	}
+	if av.Round < 1 {
+		return errors.New("round must be greater than 0")
+	}
+	if _, ok := pbtypes.VoteType_name[int32(av.VoteType)]; !ok {
+		return fmt.Errorf("unknown vote type: %d", av.VoteType)
+	}
+	if av.AggregateSignature == nil || av.AggregateSignature.Participants().Size() == 0 {
+		return errors.New("empty aggregate signature")
+	}
+	if !bytes.Equal(av.ValueHash, GenerateVoteValueHash(av.VoteType, av.Height, av.Round, av.BlockHash)) {
+		return errors.New("value hash does not match the vote's height, round and block hash")
+	}
+	return nil
+}
+
+func (av *AggregateVote) ToProto() *pbtypes.AggregateVote {
+	if av == nil {
+		return nil
+	}
+	return &pbtypes.AggregateVote{
+		VoteType:           av.VoteType,
+		Height:             av.Height,
+		Round:              int32(av.Round),
+		BlockHash:          av.BlockHash,
+		ValueHash:          av.ValueHash,
+		AggregateSignature: av.AggregateSignature.ToProto(),
+	}
+}
+
+func AggregateVoteFromProto(pb *pbtypes.AggregateVote) *AggregateVote {
+	if pb == nil {
+		return nil
+	}
+	return &AggregateVote{
+		VoteType:           pb.VoteType,
+		Height:             pb.Height,
+		Round:              int16(pb.Round),
+		BlockHash:          pb.BlockHash,
+		ValueHash:          pb.ValueHash,
+		AggregateSignature: bls12.AggregateSignatureFromProto(pb.AggregateSignature),
 	}
 }